@@ -0,0 +1,161 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Command iso checks Secrets against the internal-secrets-operator's rules.
+//
+//	iso validate [-n namespace] [--config path] [--kubeconfig path] <secret>
+//
+// validate loads a Secret from the cluster and reports the problems of its secret generator
+// annotations, the same the validating webhook denies. It exits with 0 if the annotations are
+// valid, 1 if they have problems, and 2 if the check couldn't run.
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/clientcmd"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/guided-traffic/internal-secrets-operator/internal/controller"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+)
+
+// Exit codes
+const (
+	exitValid   = 0
+	exitInvalid = 1
+	exitError   = 2
+)
+
+const usage = `Usage: iso validate [-n namespace] [--config path] [--kubeconfig path] <secret>
+
+Reports the problems of the secret generator annotations of a Secret.
+Exits with 0 if they are valid, 1 if they have problems, and 2 on errors.
+`
+
+// clientFactory returns a client for the cluster of a kubeconfig file, or the default kubeconfig
+// if the path is empty, and the namespace of its current context
+type clientFactory func(kubeconfig string) (client.Client, string, error)
+
+func main() {
+	os.Exit(run(context.Background(), os.Args[1:], os.Stdout, os.Stderr, newClient))
+}
+
+// run executes the command with args and returns its exit code
+func run(ctx context.Context, args []string, stdout, stderr io.Writer, newClient clientFactory) int {
+	if len(args) == 0 || args[0] != "validate" {
+		_, _ = fmt.Fprint(stderr, usage)
+		return exitError
+	}
+	return validate(ctx, args[1:], stdout, stderr, newClient)
+}
+
+// validate runs the validate subcommand
+func validate(ctx context.Context, args []string, stdout, stderr io.Writer, newClient clientFactory) int {
+	fs := flag.NewFlagSet("validate", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	fs.Usage = func() {
+		_, _ = fmt.Fprint(stderr, usage)
+		fs.PrintDefaults()
+	}
+	var namespace, configPath, kubeconfig string
+	fs.StringVar(&namespace, "n", "", "Namespace of the Secret (defaults to the namespace of the current context).")
+	fs.StringVar(&namespace, "namespace", "", "Namespace of the Secret (defaults to the namespace of the current context).")
+	fs.StringVar(&configPath, "config", config.DefaultConfigPath,
+		"Path to the operator's configuration file. The defaults are used if it doesn't exist.")
+	fs.StringVar(&kubeconfig, "kubeconfig", "", "Path to the kubeconfig file (defaults to the standard locations).")
+	if err := fs.Parse(args); err != nil {
+		return exitError
+	}
+	if fs.NArg() != 1 {
+		fs.Usage()
+		return exitError
+	}
+
+	cfg, err := config.LoadConfig(configPath)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+	c, defaultNamespace, err := newClient(kubeconfig)
+	if err != nil {
+		_, _ = fmt.Fprintf(stderr, "error: %v\n", err)
+		return exitError
+	}
+	if namespace == "" {
+		namespace = defaultNamespace
+	}
+
+	var secret corev1.Secret
+	key := types.NamespacedName{Namespace: namespace, Name: fs.Arg(0)}
+	if err := c.Get(ctx, key, &secret); err != nil {
+		_, _ = fmt.Fprintf(stderr, "error: failed to get Secret %s: %v\n", key, err)
+		return exitError
+	}
+
+	validator := &controller.SecretValidator{Config: cfg}
+	if !validator.HasAutogenerateAnnotation(&secret) {
+		_, _ = fmt.Fprintf(stdout, "%s has no autogenerate annotation, nothing to validate\n", key)
+		return exitValid
+	}
+	// The annotations are checked anyway, but the operator won't act on them
+	if !cfg.Namespaces.Allows(namespace) {
+		_, _ = fmt.Fprintf(stdout, "note: namespace %s is excluded by the configuration, the operator ignores %s\n", namespace, key)
+	}
+	if !cfg.Selector().Matches(labels.Set(secret.Labels)) {
+		_, _ = fmt.Fprintf(stdout, "note: %s doesn't match the label selector %q, the operator ignores it\n", key, cfg.LabelSelector)
+	}
+
+	problems := validator.Validate(&secret)
+	if len(problems) == 0 {
+		_, _ = fmt.Fprintf(stdout, "%s: annotations are valid\n", key)
+		return exitValid
+	}
+	_, _ = fmt.Fprintf(stdout, "%s: %d problem(s)\n", key, len(problems))
+	for _, problem := range problems {
+		_, _ = fmt.Fprintf(stdout, "  - %s\n", problem)
+	}
+	return exitInvalid
+}
+
+// newClient returns a client for the cluster of kubeconfig and the namespace of its current context
+func newClient(kubeconfig string) (client.Client, string, error) {
+	rules := clientcmd.NewDefaultClientConfigLoadingRules()
+	rules.ExplicitPath = kubeconfig
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(rules, &clientcmd.ConfigOverrides{})
+
+	restConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	namespace, _, err := clientConfig.Namespace()
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to load kubeconfig: %w", err)
+	}
+	c, err := client.New(restConfig, client.Options{Scheme: scheme.Scheme})
+	if err != nil {
+		return nil, "", fmt.Errorf("failed to create client: %w", err)
+	}
+	return c, namespace, nil
+}
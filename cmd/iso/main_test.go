@@ -0,0 +1,199 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+)
+
+// fakeClientFactory returns a clientFactory serving secrets from a fake client, with the
+// current context's namespace "default"
+func fakeClientFactory(secrets ...*corev1.Secret) clientFactory {
+	return func(string) (client.Client, string, error) {
+		builder := fake.NewClientBuilder().WithScheme(scheme.Scheme)
+		for _, secret := range secrets {
+			builder = builder.WithObjects(secret)
+		}
+		return builder.Build(), "default", nil
+	}
+}
+
+// testSecret returns a Secret in namespace with annotations
+func testSecret(namespace string, annotations map[string]string) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "app", Namespace: namespace, Annotations: annotations},
+		Data:       map[string][]byte{"username": []byte("app")},
+	}
+}
+
+// runValidate runs iso validate with args and a configuration file holding cfg
+func runValidate(t *testing.T, cfg string, newClient clientFactory, args ...string) (int, string, string) {
+	t.Helper()
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(configPath, []byte(cfg), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	var stdout, stderr bytes.Buffer
+	args = append([]string{"validate", "--config", configPath}, args...)
+	code := run(context.Background(), args, &stdout, &stderr, newClient)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestValidate(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantCode    int
+		// wantOutput lists substrings of the output
+		wantOutput []string
+	}{
+		{
+			name: "valid annotations",
+			annotations: map[string]string{
+				"iso.gtrfc.com/autogenerate":     "password,dsn",
+				"iso.gtrfc.com/length":           "24",
+				"iso.gtrfc.com/rotate":           "24h",
+				"iso.gtrfc.com/template.dsn-url": "postgres://{{ .username }}:{{ .password }}@db",
+			},
+			wantCode:   exitValid,
+			wantOutput: []string{"default/app: annotations are valid"},
+		},
+		{
+			name:        "no autogenerate",
+			annotations: map[string]string{"iso.gtrfc.com/length": "abc"},
+			wantCode:    exitValid,
+			wantOutput:  []string{"has no autogenerate annotation"},
+		},
+		{
+			name: "problems",
+			annotations: map[string]string{
+				"iso.gtrfc.com/autogenerate":     "password,token",
+				"iso.gtrfc.com/length.password":  "abc",
+				"iso.gtrfc.com/type.token":       "strnig",
+				"iso.gtrfc.com/rotate":           "1m",
+				"iso.gtrfc.com/template.dsn-url": "{{ .missing }}",
+			},
+			wantCode: exitInvalid,
+			wantOutput: []string{
+				"default/app: 5 problem(s)",
+				`iso.gtrfc.com/length.password: invalid length "abc"`,
+				`iso.gtrfc.com/type.token: unknown type "strnig"`,
+				`iso.gtrfc.com/rotate: rotation interval 1m0s for field "password" is below minimum 5m0s`,
+				`iso.gtrfc.com/template.dsn-url: references nonexistent field "missing"`,
+			},
+		},
+		{
+			name: "template syntax error",
+			annotations: map[string]string{
+				"iso.gtrfc.com/autogenerate":     "password",
+				"iso.gtrfc.com/template.dsn-url": "{{ .password",
+			},
+			wantCode:   exitInvalid,
+			wantOutput: []string{"iso.gtrfc.com/template.dsn-url: invalid template"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			code, stdout, stderr := runValidate(t, "", fakeClientFactory(testSecret("default", tt.annotations)), "app")
+			if code != tt.wantCode {
+				t.Errorf("expected exit code %d, got %d (stdout %q, stderr %q)", tt.wantCode, code, stdout, stderr)
+			}
+			for _, want := range tt.wantOutput {
+				if !strings.Contains(stdout, want) {
+					t.Errorf("expected output to contain %q, got %q", want, stdout)
+				}
+			}
+		})
+	}
+}
+
+func TestValidateNamespace(t *testing.T) {
+	secret := testSecret("team-a", map[string]string{"iso.gtrfc.com/autogenerate": "password"})
+
+	code, stdout, _ := runValidate(t, "namespaces:\n  exclude: [team-a]\n", fakeClientFactory(secret), "-n", "team-a", "app")
+	if code != exitValid {
+		t.Errorf("expected exit code %d, got %d", exitValid, code)
+	}
+	if !strings.Contains(stdout, "team-a/app: annotations are valid") || !strings.Contains(stdout, "namespace team-a is excluded") {
+		t.Errorf("expected a valid Secret in an excluded namespace, got %q", stdout)
+	}
+
+	// Without -n, the namespace of the current context is used
+	code, _, stderr := runValidate(t, "", fakeClientFactory(secret), "app")
+	if code != exitError || !strings.Contains(stderr, "default/app") {
+		t.Errorf("expected the Secret not to be found in default, got exit code %d and %q", code, stderr)
+	}
+}
+
+func TestValidateAnnotationPrefix(t *testing.T) {
+	secret := testSecret("default", map[string]string{
+		"secrets.example.com/autogenerate": "password",
+		"secrets.example.com/length":       "-1",
+	})
+
+	code, stdout, _ := runValidate(t, "annotationPrefix: secrets.example.com/\n", fakeClientFactory(secret), "app")
+	if code != exitInvalid || !strings.Contains(stdout, `secrets.example.com/length: invalid length "-1"`) {
+		t.Errorf("expected the custom prefix's length to be reported, got exit code %d and %q", code, stdout)
+	}
+}
+
+func TestValidateErrors(t *testing.T) {
+	failingClient := func(string) (client.Client, string, error) {
+		return nil, "", errors.New("no kubeconfig")
+	}
+	tests := []struct {
+		name      string
+		args      []string
+		newClient clientFactory
+		wantErr   string
+	}{
+		{"no subcommand", nil, fakeClientFactory(), "Usage: iso validate"},
+		{"unknown subcommand", []string{"check", "app"}, fakeClientFactory(), "Usage: iso validate"},
+		{"no secret", []string{"validate"}, fakeClientFactory(), "Usage: iso validate"},
+		{"unknown flag", []string{"validate", "--verbose", "app"}, fakeClientFactory(), "flag provided but not defined"},
+		{"client error", []string{"validate", "app"}, failingClient, "no kubeconfig"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			if code := run(context.Background(), tt.args, &stdout, &stderr, tt.newClient); code != exitError {
+				t.Errorf("expected exit code %d, got %d", exitError, code)
+			}
+			if !strings.Contains(stderr.String(), tt.wantErr) {
+				t.Errorf("expected error output to contain %q, got %q", tt.wantErr, stderr.String())
+			}
+		})
+	}
+
+	code, _, stderr := runValidate(t, "defaults:\n  type: unknown\n", fakeClientFactory(), "app")
+	if code != exitError || !strings.Contains(stderr, "unknown") {
+		t.Errorf("expected an invalid configuration to be reported, got exit code %d and %q", code, stderr)
+	}
+}
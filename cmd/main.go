@@ -18,7 +18,9 @@ limitations under the License.
 package main
 
 import (
+	"errors"
 	"flag"
+	"io/fs"
 	"os"
 
 	corev1 "k8s.io/api/core/v1"
@@ -29,6 +31,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/healthz"
 	"sigs.k8s.io/controller-runtime/pkg/log/zap"
 	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
 
 	"github.com/guided-traffic/internal-secrets-operator/internal/controller"
 	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
@@ -49,14 +52,19 @@ func main() {
 	var metricsAddr string
 	var enableLeaderElection bool
 	var probeAddr string
+	var webhookCertDir string
 	var configPath string
+	var configFlags config.Flags
 
 	flag.StringVar(&metricsAddr, "metrics-bind-address", ":8080", "The address the metric endpoint binds to.")
 	flag.StringVar(&probeAddr, "health-probe-bind-address", ":8081", "The address the probe endpoint binds to.")
 	flag.BoolVar(&enableLeaderElection, "leader-elect", false,
 		"Enable leader election for controller manager. "+
 			"Enabling this will ensure there is only one active controller manager.")
+	flag.StringVar(&webhookCertDir, "webhook-cert-dir", "/tmp/k8s-webhook-server/serving-certs",
+		"The directory with the webhook server's tls.crt and tls.key, used if a webhook feature is enabled.")
 	flag.StringVar(&configPath, "config", config.DefaultConfigPath, "Path to the configuration file.")
+	configFlags.BindFlags(flag.CommandLine)
 
 	opts := zap.Options{
 		Development: false,
@@ -67,22 +75,45 @@ func main() {
 	ctrl.SetLogger(zap.New(zap.UseFlagOptions(&opts)))
 
 	// Load configuration
-	cfg, err := config.LoadConfig(configPath)
+	cfg, err := loadConfig(configPath)
 	if err != nil {
 		setupLog.Error(err, "unable to load configuration")
 		os.Exit(1)
 	}
+	applyFlags := func(cfg *config.Config) error {
+		// The --leader-elect flag enables leader election with the configured settings
+		if enableLeaderElection {
+			cfg.LeaderElection.Enabled = true
+		}
+		return configFlags.Apply(cfg)
+	}
+	if err := applyFlags(cfg); err != nil {
+		setupLog.Error(err, "invalid configuration flags")
+		os.Exit(1)
+	}
 	setupLog.Info("Configuration loaded", "path", configPath, "defaults", cfg.Defaults)
 
-	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), ctrl.Options{
-		Scheme: scheme,
-		Metrics: metricsserver.Options{
-			BindAddress: metricsAddr,
-		},
-		HealthProbeBindAddress: probeAddr,
-		LeaderElection:         enableLeaderElection,
-		LeaderElectionID:       "secret-operator.guided-traffic.com",
-	})
+	integrityKey, err := cfg.Integrity.LoadKey()
+	if err != nil {
+		setupLog.Error(err, "unable to load integrity key")
+		os.Exit(1)
+	}
+	if integrityKey != nil {
+		setupLog.Info("Tamper detection enabled")
+	}
+	if cfg.Rotation.NotifyOnRotation {
+		setupLog.Info("Rotation notifications enabled")
+	}
+	if cfg.Notifications.Slack.Enabled() {
+		setupLog.Info("Slack notifications enabled", "reasons", cfg.Notifications.Slack.Reasons)
+	}
+
+	if cfg.LeaderElection.Enabled {
+		setupLog.Info("Leader election enabled", "lease", cfg.LeaderElection.ResourceName,
+			"leaseDuration", cfg.LeaderElection.LeaseDuration.Duration())
+	}
+
+	mgr, err := ctrl.NewManager(ctrl.GetConfigOrDie(), managerOptions(cfg, metricsAddr, probeAddr, webhookCertDir))
 	if err != nil {
 		setupLog.Error(err, "unable to start manager")
 		os.Exit(1)
@@ -92,23 +123,103 @@ func main() {
 	charset := cfg.Defaults.String.BuildCharset()
 	gen := generator.NewSecretGeneratorWithCharset(charset)
 
+	// drain lets in-flight reconciles finish on shutdown, so no Secret is left half-written
+	drain := controller.NewReconcileDrain(cfg.ShutdownGracePeriod.Duration())
+	if err := mgr.Add(drain); err != nil {
+		setupLog.Error(err, "unable to set up reconcile draining")
+		os.Exit(1)
+	}
+
+	// reloadable holds the configuration reloaded from the configuration file, shared by the
+	// generators and webhooks
+	reloadable := controller.NewReloadableConfig(cfg)
+
+	// configCheck reports the configuration as not ready if it is invalid or was rejected on reload
+	var configCheck healthz.Checker
+
 	// Set up the Secret Generator controller (if enabled)
 	if cfg.Features.SecretGenerator {
-		if err = (&controller.SecretReconciler{
+		secretReconciler := &controller.SecretReconciler{
 			Client:        mgr.GetClient(),
 			Scheme:        mgr.GetScheme(),
 			Generator:     gen,
 			Config:        cfg,
+			Reloadable:    reloadable,
 			EventRecorder: mgr.GetEventRecorder("secret-operator"),
-		}).SetupWithManager(mgr); err != nil {
+			IntegrityKey:  integrityKey,
+			Notifiers:     controller.NewNotifiers(cfg, mgr.GetClient()),
+			// Audit records are always JSON, so they stay parseable with --zap-devel
+			AuditLogger: zap.New(zap.UseFlagOptions(&opts), zap.JSONEncoder()).WithName(controller.AuditLoggerName),
+			Drain:       drain,
+		}
+		if err = secretReconciler.SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "SecretGenerator")
 			os.Exit(1)
 		}
+		configCheck = secretReconciler.ConfigReadyzCheck
 		setupLog.Info("Secret Generator controller enabled")
 	} else {
 		setupLog.Info("Secret Generator controller disabled")
 	}
 
+	// Set up the ConfigMap Generator controller (if enabled)
+	if cfg.Features.ConfigMapGenerator {
+		if err = (&controller.ConfigMapGeneratorReconciler{
+			Client:        mgr.GetClient(),
+			Scheme:        mgr.GetScheme(),
+			Generator:     gen,
+			Config:        cfg,
+			Reloadable:    reloadable,
+			EventRecorder: mgr.GetEventRecorder("configmap-generator"),
+			Drain:         drain,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create controller", "controller", "ConfigMapGenerator")
+			os.Exit(1)
+		}
+		setupLog.Info("ConfigMap Generator controller enabled")
+	} else {
+		setupLog.Info("ConfigMap Generator controller disabled")
+	}
+
+	// Set up the validating webhook for Secrets (if enabled)
+	if cfg.Features.ValidatingWebhook {
+		if err = (&controller.SecretValidator{
+			Config:     cfg,
+			Reloadable: reloadable,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "SecretValidator")
+			os.Exit(1)
+		}
+		setupLog.Info("Secret validating webhook enabled")
+	} else {
+		setupLog.Info("Secret validating webhook disabled")
+	}
+
+	// Set up the defaulting webhook for Secrets (if enabled)
+	if cfg.Features.DefaultingWebhook {
+		if err = (&controller.SecretDefaulter{
+			Config:     cfg,
+			Reloadable: reloadable,
+		}).SetupWithManager(mgr); err != nil {
+			setupLog.Error(err, "unable to create webhook", "webhook", "SecretDefaulter")
+			os.Exit(1)
+		}
+		setupLog.Info("Secret defaulting webhook enabled")
+	} else {
+		setupLog.Info("Secret defaulting webhook disabled")
+	}
+
+	// Reload the configuration file on changes, if there is one
+	if _, err := os.Stat(configPath); err == nil {
+		watcher := controller.NewConfigWatcher(configPath, reloadable)
+		watcher.Overrides = applyFlags
+		if err := mgr.Add(watcher); err != nil {
+			setupLog.Error(err, "unable to watch the configuration file")
+			os.Exit(1)
+		}
+		configCheck = watcher.ReadyzCheck
+	}
+
 	// Set up the Secret Replicator controller (if enabled)
 	if cfg.Features.SecretReplicator {
 		if err = (&controller.SecretReplicatorReconciler{
@@ -116,6 +227,7 @@ func main() {
 			Scheme:        mgr.GetScheme(),
 			Config:        cfg,
 			EventRecorder: mgr.GetEventRecorder("secret-replicator"),
+			Drain:         drain,
 		}).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "SecretReplicator")
 			os.Exit(1)
@@ -132,6 +244,7 @@ func main() {
 			Scheme:        mgr.GetScheme(),
 			Config:        cfg,
 			EventRecorder: mgr.GetEventRecorder("configmap-replicator"),
+			Drain:         drain,
 		}).SetupWithManager(mgr); err != nil {
 			setupLog.Error(err, "unable to create controller", "controller", "ConfigMapReplicator")
 			os.Exit(1)
@@ -149,6 +262,12 @@ func main() {
 		setupLog.Error(err, "unable to set up ready check")
 		os.Exit(1)
 	}
+	if configCheck != nil {
+		if err := mgr.AddReadyzCheck("config", configCheck); err != nil {
+			setupLog.Error(err, "unable to set up config ready check")
+			os.Exit(1)
+		}
+	}
 
 	setupLog.Info("starting manager")
 	if err := mgr.Start(ctrl.SetupSignalHandler()); err != nil {
@@ -156,3 +275,36 @@ func main() {
 		os.Exit(1)
 	}
 }
+
+// loadConfig loads the configuration file at path strictly, see config.LoadFromFile. Without a
+// file, the defaults are used; the environment overrides apply either way.
+func loadConfig(path string) (*config.Config, error) {
+	if _, err := os.Stat(path); errors.Is(err, fs.ErrNotExist) {
+		return config.LoadFromEnv()
+	}
+	return config.LoadFromFile(path)
+}
+
+// managerOptions returns the options of the controller manager for the configuration and the
+// addresses and certificate directory given on the command line
+func managerOptions(cfg *config.Config, metricsAddr, probeAddr, webhookCertDir string) ctrl.Options {
+	leaseDuration := cfg.LeaderElection.LeaseDuration.Duration()
+	renewDeadline := cfg.LeaderElection.RenewDeadline.Duration()
+	retryPeriod := cfg.LeaderElection.RetryPeriod.Duration()
+	shutdownGracePeriod := cfg.ShutdownGracePeriod.Duration()
+	return ctrl.Options{
+		Scheme: scheme,
+		Metrics: metricsserver.Options{
+			BindAddress: metricsAddr,
+		},
+		HealthProbeBindAddress: probeAddr,
+		WebhookServer:          webhook.NewServer(webhook.Options{CertDir: webhookCertDir}),
+		LeaderElection:         cfg.LeaderElection.Enabled,
+		LeaderElectionID:       cfg.LeaderElection.ResourceName,
+		LeaseDuration:          &leaseDuration,
+		RenewDeadline:          &renewDeadline,
+		RetryPeriod:            &retryPeriod,
+		// In-flight reconciles get the same time to finish, see controller.ReconcileDrain
+		GracefulShutdownTimeout: &shutdownGracePeriod,
+	}
+}
@@ -0,0 +1,102 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+)
+
+func TestManagerOptions(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	cfg.LeaderElection = config.LeaderElectionConfig{
+		Enabled:       true,
+		LeaseDuration: config.Duration(30 * time.Second),
+		RenewDeadline: config.Duration(20 * time.Second),
+		RetryPeriod:   config.Duration(5 * time.Second),
+		ResourceName:  "iso-leader",
+	}
+
+	opts := managerOptions(cfg, ":9090", ":9091", "/certs")
+
+	if !opts.LeaderElection || opts.LeaderElectionID != "iso-leader" {
+		t.Errorf("expected leader election with lease iso-leader, got %v and %q", opts.LeaderElection, opts.LeaderElectionID)
+	}
+	for name, got := range map[string]*time.Duration{
+		"leaseDuration": opts.LeaseDuration,
+		"renewDeadline": opts.RenewDeadline,
+		"retryPeriod":   opts.RetryPeriod,
+	} {
+		if got == nil {
+			t.Fatalf("expected %s to be set", name)
+		}
+	}
+	if *opts.LeaseDuration != 30*time.Second || *opts.RenewDeadline != 20*time.Second || *opts.RetryPeriod != 5*time.Second {
+		t.Errorf("expected durations 30s/20s/5s, got %s/%s/%s", *opts.LeaseDuration, *opts.RenewDeadline, *opts.RetryPeriod)
+	}
+	if opts.Metrics.BindAddress != ":9090" || opts.HealthProbeBindAddress != ":9091" {
+		t.Errorf("expected bind addresses :9090 and :9091, got %q and %q", opts.Metrics.BindAddress, opts.HealthProbeBindAddress)
+	}
+
+	// Leader election stays off unless enabled, with the default lease name
+	opts = managerOptions(config.NewDefaultConfig(), ":8080", ":8081", "/certs")
+	if opts.LeaderElection || opts.LeaderElectionID != config.DefaultLeaderElectionResourceName {
+		t.Errorf("expected leader election disabled with lease %s, got %v and %q", config.DefaultLeaderElectionResourceName, opts.LeaderElection, opts.LeaderElectionID)
+	}
+	if opts.GracefulShutdownTimeout == nil || *opts.GracefulShutdownTimeout != config.DefaultShutdownGracePeriod {
+		t.Errorf("expected graceful shutdown timeout %s, got %v", config.DefaultShutdownGracePeriod, opts.GracefulShutdownTimeout)
+	}
+}
+
+func TestLoadConfig(t *testing.T) {
+	dir := t.TempDir()
+
+	// A typo in the configuration file fails the startup
+	path := filepath.Join(dir, "config.yaml")
+	if err := os.WriteFile(path, []byte("defaults:\n  lenght: 24\n"), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	if _, err := loadConfig(path); err == nil {
+		t.Error("expected an error for an unknown key")
+	}
+
+	// The environment overrides the file
+	t.Setenv("ISO_DEFAULTS_LENGTH", "48")
+	if err := os.WriteFile(path, []byte("defaults:\n  length: 24\n"), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	cfg, err := loadConfig(path)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Defaults.Length != 48 {
+		t.Errorf("expected length 48 from the environment, got %d", cfg.Defaults.Length)
+	}
+
+	// Without a file, the defaults and the environment are used
+	cfg, err = loadConfig(filepath.Join(dir, "missing.yaml"))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Defaults.Length != 48 || cfg.Defaults.Type != config.DefaultType {
+		t.Errorf("expected the defaults with length 48, got type %q and length %d", cfg.Defaults.Type, cfg.Defaults.Length)
+	}
+}
@@ -60,3 +60,10 @@ Create the name of the service account to use
 {{- default "default" .Values.serviceAccount.name }}
 {{- end }}
 {{- end }}
+
+{{/*
+Whether an admission webhook is enabled, which needs the webhook Service and certificate
+*/}}
+{{- define "internal-secrets-operator.webhookEnabled" -}}
+{{- if or .Values.config.features.validatingWebhook .Values.config.features.defaultingWebhook }}true{{- end }}
+{{- end }}
@@ -0,0 +1,214 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import "strings"
+
+// annotationKeys holds the secret generator's annotation keys for an annotation prefix. The
+// Annotation* constants are the keys with the built-in AnnotationPrefix; reconcilers build their
+// keys once from the configured prefix (see SecretReconciler.keys).
+type annotationKeys struct {
+	Autogenerate               string
+	Type                       string
+	Length                     string
+	TypePrefix                 string
+	LengthPrefix               string
+	Curve                      string
+	CurvePrefix                string
+	Param                      string
+	ParamPrefix                string
+	KeyEncoding                string
+	KeyEncodingPrefix          string
+	PublicKeyFieldPrefix       string
+	JWKFieldPrefix             string
+	VersionFieldPrefix         string
+	KeyFormatPrefix            string
+	KeyPassphraseFieldPrefix   string
+	ValuePrefixPrefix          string
+	ValueSuffixPrefix          string
+	Encoding                   string
+	EncodingPrefix             string
+	CompanionEncodingsPrefix   string
+	OutputLengthPrefix         string
+	CountPrefix                string
+	RotateStrategyPrefix       string
+	ImmutableValuePrefix       string
+	TemplatePrefix             string
+	WordsPrefix                string
+	SeparatorPrefix            string
+	BcryptSourcePrefix         string
+	BcryptCostPrefix           string
+	HtpasswdUserPrefix         string
+	GeneratedAt                string
+	ExistingValues             string
+	Rotate                     string
+	RotatePrefix               string
+	Consumer                   string
+	RotateOffsetPrefix         string
+	RotationAnchorPrefix       string
+	GracePeriod                string
+	GracePeriodPrefix          string
+	PreviousValueExpiresPrefix string
+	LastRotationWindow         string
+	Status                     string
+	LastError                  string
+	FailureCount               string
+	LastRotationTime           string
+	RotationHistory            string
+	NextRotationTime           string
+	Paused                     string
+	RotateNow                  string
+	RotateNowForce             string
+	Compromised                string
+	Prune                      string
+	ManagedFields              string
+	ForceRotationTokens        string
+	UniqueWithinLabel          string
+	ValueHashPrefix            string
+	ValueMACPrefix             string
+	FieldMetadata              string
+	MetadataVersion            string
+	StringUppercase            string
+	StringLowercase            string
+	StringNumbers              string
+	StringSpecialChars         string
+	StringAllowedSpecialChars  string
+	CharsetPreset              string
+	ExcludeAmbiguous           string
+	SafeFor                    string
+	MinUppercase               string
+	MinLowercase               string
+	MinDigits                  string
+	MinSymbols                 string
+	JWTIssuer                  string
+	JWTSubject                 string
+	JWTAudience                string
+	JWTTTL                     string
+	JWTSigningKey              string
+	CertCommonName             string
+	CertDNS                    string
+	CertIP                     string
+	CertURI                    string
+	CertValidity               string
+	CertKeyUsage               string
+	CertUsage                  string
+	CertMode                   string
+	CertCASecret               string
+	CertKeyFieldPrefix         string
+}
+
+// defaultAnnotationKeys are the annotation keys with the built-in AnnotationPrefix
+var defaultAnnotationKeys = newAnnotationKeys(AnnotationPrefix)
+
+// newAnnotationKeys returns the annotation keys for prefix. An empty prefix stands for AnnotationPrefix.
+func newAnnotationKeys(prefix string) *annotationKeys {
+	if prefix == "" {
+		prefix = AnnotationPrefix
+	}
+	key := func(builtIn string) string {
+		return prefix + strings.TrimPrefix(builtIn, AnnotationPrefix)
+	}
+	return &annotationKeys{
+		Autogenerate:               key(AnnotationAutogenerate),
+		Type:                       key(AnnotationType),
+		Length:                     key(AnnotationLength),
+		TypePrefix:                 key(AnnotationTypePrefix),
+		LengthPrefix:               key(AnnotationLengthPrefix),
+		Curve:                      key(AnnotationCurve),
+		CurvePrefix:                key(AnnotationCurvePrefix),
+		Param:                      key(AnnotationParam),
+		ParamPrefix:                key(AnnotationParamPrefix),
+		KeyEncoding:                key(AnnotationKeyEncoding),
+		KeyEncodingPrefix:          key(AnnotationKeyEncodingPrefix),
+		PublicKeyFieldPrefix:       key(AnnotationPublicKeyFieldPrefix),
+		JWKFieldPrefix:             key(AnnotationJWKFieldPrefix),
+		VersionFieldPrefix:         key(AnnotationVersionFieldPrefix),
+		KeyFormatPrefix:            key(AnnotationKeyFormatPrefix),
+		KeyPassphraseFieldPrefix:   key(AnnotationKeyPassphraseFieldPrefix),
+		ValuePrefixPrefix:          key(AnnotationValuePrefixPrefix),
+		ValueSuffixPrefix:          key(AnnotationValueSuffixPrefix),
+		Encoding:                   key(AnnotationEncoding),
+		EncodingPrefix:             key(AnnotationEncodingPrefix),
+		CompanionEncodingsPrefix:   key(AnnotationCompanionEncodingsPrefix),
+		OutputLengthPrefix:         key(AnnotationOutputLengthPrefix),
+		CountPrefix:                key(AnnotationCountPrefix),
+		RotateStrategyPrefix:       key(AnnotationRotateStrategyPrefix),
+		ImmutableValuePrefix:       key(AnnotationImmutableValuePrefix),
+		TemplatePrefix:             key(AnnotationTemplatePrefix),
+		WordsPrefix:                key(AnnotationWordsPrefix),
+		SeparatorPrefix:            key(AnnotationSeparatorPrefix),
+		BcryptSourcePrefix:         key(AnnotationBcryptSourcePrefix),
+		BcryptCostPrefix:           key(AnnotationBcryptCostPrefix),
+		HtpasswdUserPrefix:         key(AnnotationHtpasswdUserPrefix),
+		GeneratedAt:                key(AnnotationGeneratedAt),
+		ExistingValues:             key(AnnotationExistingValues),
+		Rotate:                     key(AnnotationRotate),
+		RotatePrefix:               key(AnnotationRotatePrefix),
+		Consumer:                   key(AnnotationConsumer),
+		RotateOffsetPrefix:         key(AnnotationRotateOffsetPrefix),
+		RotationAnchorPrefix:       key(AnnotationRotationAnchorPrefix),
+		GracePeriod:                key(AnnotationGracePeriod),
+		GracePeriodPrefix:          key(AnnotationGracePeriodPrefix),
+		PreviousValueExpiresPrefix: key(AnnotationPreviousValueExpiresPrefix),
+		LastRotationWindow:         key(AnnotationLastRotationWindow),
+		Status:                     key(AnnotationStatus),
+		LastError:                  key(AnnotationLastError),
+		FailureCount:               key(AnnotationFailureCount),
+		LastRotationTime:           key(AnnotationLastRotationTime),
+		RotationHistory:            key(AnnotationRotationHistory),
+		NextRotationTime:           key(AnnotationNextRotationTime),
+		Paused:                     key(AnnotationPaused),
+		RotateNow:                  key(AnnotationRotateNow),
+		RotateNowForce:             key(AnnotationRotateNowForce),
+		Compromised:                key(AnnotationCompromised),
+		Prune:                      key(AnnotationPrune),
+		ManagedFields:              key(AnnotationManagedFields),
+		ForceRotationTokens:        key(AnnotationForceRotationTokens),
+		UniqueWithinLabel:          key(AnnotationUniqueWithinLabel),
+		ValueHashPrefix:            key(AnnotationValueHashPrefix),
+		ValueMACPrefix:             key(AnnotationValueMACPrefix),
+		FieldMetadata:              key(AnnotationFieldMetadata),
+		MetadataVersion:            key(AnnotationMetadataVersion),
+		StringUppercase:            key(AnnotationStringUppercase),
+		StringLowercase:            key(AnnotationStringLowercase),
+		StringNumbers:              key(AnnotationStringNumbers),
+		StringSpecialChars:         key(AnnotationStringSpecialChars),
+		StringAllowedSpecialChars:  key(AnnotationStringAllowedSpecialChars),
+		CharsetPreset:              key(AnnotationCharsetPreset),
+		ExcludeAmbiguous:           key(AnnotationExcludeAmbiguous),
+		SafeFor:                    key(AnnotationSafeFor),
+		MinUppercase:               key(AnnotationMinUppercase),
+		MinLowercase:               key(AnnotationMinLowercase),
+		MinDigits:                  key(AnnotationMinDigits),
+		MinSymbols:                 key(AnnotationMinSymbols),
+		JWTIssuer:                  key(AnnotationJWTIssuer),
+		JWTSubject:                 key(AnnotationJWTSubject),
+		JWTAudience:                key(AnnotationJWTAudience),
+		JWTTTL:                     key(AnnotationJWTTTL),
+		JWTSigningKey:              key(AnnotationJWTSigningKey),
+		CertCommonName:             key(AnnotationCertCommonName),
+		CertDNS:                    key(AnnotationCertDNS),
+		CertIP:                     key(AnnotationCertIP),
+		CertURI:                    key(AnnotationCertURI),
+		CertValidity:               key(AnnotationCertValidity),
+		CertKeyUsage:               key(AnnotationCertKeyUsage),
+		CertUsage:                  key(AnnotationCertUsage),
+		CertMode:                   key(AnnotationCertMode),
+		CertCASecret:               key(AnnotationCertCASecret),
+		CertKeyFieldPrefix:         key(AnnotationCertKeyFieldPrefix),
+	}
+}
@@ -0,0 +1,120 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/generator"
+)
+
+func TestReconcileCustomAnnotationPrefix(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	const prefix = "secrets.acme.internal/"
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "custom-prefix",
+			Namespace: "default",
+			Annotations: map[string]string{
+				prefix + "autogenerate":    "password,token",
+				prefix + "length.password": "20",
+				prefix + "type.token":      "uuid",
+				// Annotations with the built-in prefix belong to someone else and are kept as they are
+				AnnotationPrefix + "autogenerate": "ignored",
+			},
+		},
+	}
+
+	cfg := config.NewDefaultConfig()
+	cfg.AnnotationPrefix = prefix
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	reconciler := &SecretReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Generator:     generator.NewSecretGenerator(),
+		Config:        cfg,
+		EventRecorder: NewTestEventRecorder(10),
+		Clock:         &MockClock{currentTime: time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)},
+	}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "custom-prefix", Namespace: "default"}
+
+	if _, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var updated corev1.Secret
+	if err := fakeClient.Get(ctx, key, &updated); err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+	if len(updated.Data["password"]) != 20 {
+		t.Errorf("expected password of length 20, got %q", updated.Data["password"])
+	}
+	if len(updated.Data["token"]) != 36 {
+		t.Errorf("expected uuid token, got %q", updated.Data["token"])
+	}
+	if _, ok := updated.Data["ignored"]; ok {
+		t.Error("expected annotations with the built-in prefix to be ignored")
+	}
+
+	if updated.Annotations[prefix+"generated-at"] != "2026-02-02T12:00:00Z" {
+		t.Errorf("expected generated-at with the custom prefix, got annotations %v", updated.Annotations)
+	}
+	if updated.Annotations[prefix+"status"] != StatusReady {
+		t.Errorf("expected status with the custom prefix, got annotations %v", updated.Annotations)
+	}
+	for key, value := range updated.Annotations {
+		if strings.HasPrefix(key, AnnotationPrefix) && key != AnnotationPrefix+"autogenerate" {
+			t.Errorf("unexpected annotation with the built-in prefix: %s=%s", key, value)
+		}
+	}
+	if updated.Annotations[AnnotationPrefix+"autogenerate"] != "ignored" {
+		t.Errorf("expected foreign annotation to be kept, got annotations %v", updated.Annotations)
+	}
+}
+
+func TestNewAnnotationKeys(t *testing.T) {
+	keys := newAnnotationKeys("secrets.acme.internal/")
+	if keys.Autogenerate != "secrets.acme.internal/autogenerate" {
+		t.Errorf("expected autogenerate with the custom prefix, got %q", keys.Autogenerate)
+	}
+	if keys.LengthPrefix != "secrets.acme.internal/length." {
+		t.Errorf("expected length prefix with the custom prefix, got %q", keys.LengthPrefix)
+	}
+
+	if *newAnnotationKeys("") != *defaultAnnotationKeys {
+		t.Error("expected an empty prefix to use the built-in one")
+	}
+	if defaultAnnotationKeys.Autogenerate != AnnotationAutogenerate || defaultAnnotationKeys.Status != AnnotationStatus {
+		t.Errorf("expected the default keys to match the constants, got %+v", defaultAnnotationKeys)
+	}
+}
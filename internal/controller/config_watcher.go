@@ -0,0 +1,189 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"sync/atomic"
+
+	"github.com/fsnotify/fsnotify"
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+)
+
+// ReloadableConfig holds the configuration in use, which the ConfigWatcher replaces when the
+// configuration file changes. One ReloadableConfig is shared by the reconcilers and webhooks, so
+// a reload reaches all of them. It is safe for concurrent use.
+type ReloadableConfig struct {
+	current atomic.Pointer[config.Config]
+}
+
+// NewReloadableConfig returns a ReloadableConfig holding the startup configuration cfg
+func NewReloadableConfig(cfg *config.Config) *ReloadableConfig {
+	c := &ReloadableConfig{}
+	c.current.Store(cfg)
+	return c
+}
+
+// Load returns the configuration in use, or nil if c is nil
+func (c *ReloadableConfig) Load() *config.Config {
+	if c == nil {
+		return nil
+	}
+	return c.current.Load()
+}
+
+// Store replaces the configuration in use, e.g. after the configuration file changed. cfg must be
+// validated. The annotation prefix can't change while running, since the annotation keys are built
+// from it once. Settings read at startup, like the features, leader election,
+// maxConcurrentReconciles, and the metrics interval, keep their startup values until the operator
+// restarts.
+func (c *ReloadableConfig) Store(cfg *config.Config) error {
+	if current := c.Load(); current != nil && cfg.AnnotationPrefix != current.AnnotationPrefix {
+		return fmt.Errorf("annotationPrefix can't change from %q to %q without a restart", current.AnnotationPrefix, cfg.AnnotationPrefix)
+	}
+	c.current.Store(cfg)
+	return nil
+}
+
+// ConfigWatcher reloads the configuration file when it changes and stores valid configurations in
+// a ReloadableConfig, so e.g. maintenance windows and rotation defaults change without a restart.
+// Invalid configurations are logged and rejected, keeping the previous one.
+type ConfigWatcher struct {
+	// Path is the configuration file
+	Path string
+	// Config receives the reloaded configurations
+	Config *ReloadableConfig
+	// Overrides is applied to each reloaded configuration, e.g. the command-line flags, and validates it.
+	// It may be nil.
+	Overrides func(*config.Config) error
+
+	// content is the file content last loaded
+	content []byte
+
+	// mu guards reloadErr, which is read by readiness probes
+	mu sync.Mutex
+	// reloadErr is why the last changed content was rejected, or nil if it was loaded
+	reloadErr error
+}
+
+// NewConfigWatcher returns a watcher of the configuration file at path. It is created right after
+// the configuration was loaded, so changes from then on are picked up.
+func NewConfigWatcher(path string, cfg *ReloadableConfig) *ConfigWatcher {
+	content, _ := os.ReadFile(filepath.Clean(path))
+	return &ConfigWatcher{Path: filepath.Clean(path), Config: cfg, content: content}
+}
+
+// NeedLeaderElection implements manager.LeaderElectionRunnable: every replica reloads
+func (w *ConfigWatcher) NeedLeaderElection() bool {
+	return false
+}
+
+// Start implements manager.Runnable and watches the configuration file until ctx is done
+func (w *ConfigWatcher) Start(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("config-watcher")
+
+	watcher, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("failed to watch the configuration file: %w", err)
+	}
+	defer func() { _ = watcher.Close() }()
+
+	// The directory is watched, since ConfigMap volumes replace files by swapping a symlink
+	if err := watcher.Add(filepath.Dir(w.Path)); err != nil {
+		return fmt.Errorf("failed to watch the configuration file: %w", err)
+	}
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case _, ok := <-watcher.Events:
+			if !ok {
+				return nil
+			}
+			w.reload(logger)
+		case err, ok := <-watcher.Errors:
+			if !ok {
+				return nil
+			}
+			logger.Error(err, "Failed to watch the configuration file", "path", w.Path)
+		}
+	}
+}
+
+// reload loads the configuration file if its content changed
+func (w *ConfigWatcher) reload(logger logr.Logger) {
+	data, err := os.ReadFile(w.Path)
+	if err != nil {
+		// A ConfigMap update briefly removes the file; the event of its return reloads it
+		if !os.IsNotExist(err) {
+			logger.Error(err, "Failed to read the configuration file", "path", w.Path)
+		}
+		return
+	}
+	if bytes.Equal(data, w.content) {
+		return
+	}
+	// Rejected content is remembered, too, so it is reported only once
+	w.content = data
+
+	cfg, err := config.ParseStrict(data)
+	if err == nil && w.Overrides != nil {
+		err = w.Overrides(cfg)
+	}
+	if err == nil {
+		err = w.Config.Store(cfg)
+	}
+	w.setReloadErr(err)
+	if err != nil {
+		logger.Error(err, "Rejected invalid configuration, keeping the previous one", "path", w.Path)
+		return
+	}
+	logger.Info("Reloaded configuration", "path", w.Path)
+}
+
+// setReloadErr records the result of the last reload
+func (w *ConfigWatcher) setReloadErr(err error) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	w.reloadErr = err
+}
+
+// ReadyzCheck is a healthz.Checker that fails while the configuration file holds a rejected
+// configuration, so a bad rollout is noticed although the previous configuration stays in use.
+// It also fails if the configuration in use is invalid, like SecretReconciler.ConfigReadyzCheck.
+func (w *ConfigWatcher) ReadyzCheck(_ *http.Request) error {
+	w.mu.Lock()
+	reloadErr := w.reloadErr
+	w.mu.Unlock()
+	if reloadErr != nil {
+		return fmt.Errorf("configuration file %s was rejected: %w", w.Path, reloadErr)
+	}
+	if err := w.Config.Load().Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,312 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr"
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/generator"
+)
+
+// saturdayWindowConfig enables a maintenance window on Saturday nights only
+const saturdayWindowConfig = `
+rotation:
+  maintenanceWindows:
+    enabled: true
+    windows:
+      - name: saturday-night
+        days: [saturday]
+        startTime: "03:00"
+        endTime: "05:00"
+        timezone: UTC
+`
+
+func TestConfigWatcherReload(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	// Monday 12:00, outside the window of the reloaded configuration
+	now := time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "reload-secret",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationAutogenerate: "password",
+				AnnotationRotate:       "1h",
+				AnnotationGeneratedAt:  now.Add(-2 * time.Hour).Format(time.RFC3339),
+			},
+		},
+		Data: map[string][]byte{"password": []byte("old-password")},
+	}
+
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte("rotation:\n  createEvents: false\n"), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	cfg, err := config.LoadConfig(path)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	reconciler := &SecretReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Generator:     generator.NewSecretGenerator(),
+		Config:        cfg,
+		Reloadable:    NewReloadableConfig(cfg),
+		EventRecorder: NewTestEventRecorder(10),
+		Clock:         &MockClock{currentTime: now},
+	}
+	watcher := NewConfigWatcher(path, reconciler.Reloadable)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error)
+	go func() { done <- watcher.Start(ctx) }()
+
+	// Write the new configuration until the watcher is set up and picks it up
+	deadline := time.Now().Add(5 * time.Second)
+	for !reconciler.currentConfig().Rotation.MaintenanceWindows.Enabled && time.Now().Before(deadline) {
+		if err := os.WriteFile(path, []byte(saturdayWindowConfig+"defaults:\n  length: 48\n"), 0644); err != nil {
+			t.Fatalf("failed to write config file: %v", err)
+		}
+		time.Sleep(50 * time.Millisecond)
+	}
+	cancel()
+	if err := <-done; err != nil {
+		t.Fatalf("unexpected watcher error: %v", err)
+	}
+	if !reconciler.currentConfig().Rotation.MaintenanceWindows.Enabled {
+		t.Fatal("expected the reconciler to pick up the reloaded configuration")
+	}
+	if reconciler.Config != cfg {
+		t.Error("expected the startup configuration to be kept in Config")
+	}
+
+	// The due rotation now waits for the maintenance window
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}}
+	if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var updatedSecret corev1.Secret
+	if err := fakeClient.Get(context.Background(), req.NamespacedName, &updatedSecret); err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+	if got := string(updatedSecret.Data["password"]); got != "old-password" {
+		t.Errorf("expected rotation to be deferred to the maintenance window, got password %q", got)
+	}
+
+	// The ConfigMap generator and the webhooks sharing the ReloadableConfig use it, too
+	reloaded := reconciler.Reloadable.Load()
+	cmReconciler := &ConfigMapGeneratorReconciler{Config: cfg, Reloadable: reconciler.Reloadable}
+	if cmReconciler.secretReconciler().currentConfig() != reloaded {
+		t.Error("expected the ConfigMap generator to use the reloaded configuration")
+	}
+	validator := &SecretValidator{Config: cfg, Reloadable: reconciler.Reloadable}
+	if validator.currentConfig() != reloaded {
+		t.Error("expected the validating webhook to use the reloaded configuration")
+	}
+	defaulter := &SecretDefaulter{Config: cfg, Reloadable: reconciler.Reloadable, Decoder: admission.NewDecoder(scheme)}
+	resp := defaulter.Handle(context.Background(), secretAdmissionRequest(t, admissionv1.Create, map[string]string{
+		AnnotationAutogenerate: "password",
+	}, nil))
+	var length string
+	for _, op := range resp.Patches {
+		if op.Path == "/metadata/annotations/iso.gtrfc.com~1length" {
+			length, _ = op.Value.(string)
+		}
+	}
+	if length != "48" {
+		t.Errorf("expected the defaulting webhook to write the reloaded default length 48, got %v", resp.Patches)
+	}
+}
+
+func TestConfigWatcherRejectsInvalidConfig(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(saturdayWindowConfig), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	cfg, err := config.LoadConfig(path)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	reconciler := &SecretReconciler{Config: cfg, Reloadable: NewReloadableConfig(cfg)}
+	watcher := NewConfigWatcher(path, reconciler.Reloadable)
+
+	for name, content := range map[string]string{
+		"invalid window":    "rotation:\n  maintenanceWindows:\n    enabled: true\n    windows:\n      - name: w\n        days: [someday]\n        startTime: \"03:00\"\n        endTime: \"05:00\"\n",
+		"unparsable":        "rotation: [",
+		"unknown key":       "rotation:\n  minIntervall: 1h\n",
+		"annotation prefix": "annotationPrefix: example.com/\n",
+	} {
+		if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write config file: %v", err)
+		}
+		watcher.reload(logr.Discard())
+		if reconciler.currentConfig() != cfg {
+			t.Errorf("%s: expected the previous configuration to be kept", name)
+		}
+	}
+
+	// Overrides apply to reloaded configurations
+	watcher.Overrides = func(c *config.Config) error {
+		c.DryRun = true
+		return nil
+	}
+	if err := os.WriteFile(path, []byte(saturdayWindowConfig+"dryRun: false\n"), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	watcher.reload(logr.Discard())
+	if !reconciler.currentConfig().DryRun {
+		t.Error("expected the overrides to apply to the reloaded configuration")
+	}
+}
+
+func TestReconcileKeepsConfigDuringReload(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "reload-secret",
+			Namespace:   "default",
+			Annotations: map[string]string{AnnotationAutogenerate: "password"},
+		},
+	}
+
+	// The configuration is reloaded while the reconcile reads the Secret
+	reloadable := NewReloadableConfig(config.NewDefaultConfig())
+	reloaded := config.NewDefaultConfig()
+	reloaded.DryRun = true
+	reloaded.Defaults.Length = 48
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(secret).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Get: func(ctx context.Context, c client.WithWatch, key client.ObjectKey, obj client.Object, opts ...client.GetOption) error {
+				if err := reloadable.Store(reloaded); err != nil {
+					t.Errorf("failed to reload: %v", err)
+				}
+				return c.Get(ctx, key, obj, opts...)
+			},
+		}).
+		Build()
+
+	reconciler := &SecretReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Generator:     generator.NewSecretGenerator(),
+		Reloadable:    reloadable,
+		EventRecorder: NewTestEventRecorder(10),
+	}
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}}
+	if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// The whole reconcile used the configuration it started with: not dry-run, default length 32
+	var updatedSecret corev1.Secret
+	if err := fakeClient.Get(context.Background(), req.NamespacedName, &updatedSecret); err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+	if len(updatedSecret.Data["password"]) != config.DefaultLength {
+		t.Errorf("expected password generated with the configuration at the start, got %q", updatedSecret.Data["password"])
+	}
+
+	// The next reconcile uses the reloaded configuration
+	if reconciler.currentConfig() != reloaded {
+		t.Error("expected the reloaded configuration to be used afterwards")
+	}
+}
+
+func TestConfigWatcherReadyzCheck(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(saturdayWindowConfig), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	cfg, err := config.LoadConfig(path)
+	if err != nil {
+		t.Fatalf("failed to load config: %v", err)
+	}
+	reconciler := &SecretReconciler{Config: cfg, Reloadable: NewReloadableConfig(cfg)}
+	watcher := NewConfigWatcher(path, reconciler.Reloadable)
+
+	if err := watcher.ReadyzCheck(nil); err != nil {
+		t.Errorf("expected ready with a valid configuration, got %v", err)
+	}
+
+	// A rejected reload makes the operator not ready, although the previous configuration stays in use
+	invalid := strings.Replace(saturdayWindowConfig, "[saturday]", "[someday]", 1)
+	if err := os.WriteFile(path, []byte(invalid), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	watcher.reload(logr.Discard())
+	if err := watcher.ReadyzCheck(nil); err == nil || !strings.Contains(err.Error(), "invalid day") {
+		t.Errorf("expected not ready after a rejected reload, got %v", err)
+	}
+
+	// Fixing the file makes it ready again
+	if err := os.WriteFile(path, []byte(saturdayWindowConfig+"dryRun: true\n"), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	watcher.reload(logr.Discard())
+	if err := watcher.ReadyzCheck(nil); err != nil {
+		t.Errorf("expected ready after a successful reload, got %v", err)
+	}
+}
+
+func TestSecretReconcilerConfigReadyzCheck(t *testing.T) {
+	reconciler := &SecretReconciler{Config: config.NewDefaultConfig()}
+	if err := reconciler.ConfigReadyzCheck(nil); err != nil {
+		t.Errorf("expected ready with the default configuration, got %v", err)
+	}
+
+	// Maintenance windows enabled without any window
+	invalid := config.NewDefaultConfig()
+	invalid.Rotation.MaintenanceWindows.Enabled = true
+	reconciler.Config = invalid
+	if err := reconciler.ConfigReadyzCheck(nil); err == nil || !strings.Contains(err.Error(), "maintenance windows configuration error") {
+		t.Errorf("expected not ready with invalid maintenance windows, got %v", err)
+	}
+
+	invalid = config.NewDefaultConfig()
+	invalid.Rotation.MinInterval = config.Duration(-time.Minute)
+	reconciler.Config = invalid
+	if err := reconciler.ConfigReadyzCheck(nil); err == nil || !strings.Contains(err.Error(), "minInterval") {
+		t.Errorf("expected not ready with an invalid rotation configuration, got %v", err)
+	}
+}
@@ -0,0 +1,277 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"time"
+	"unicode/utf8"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/generator"
+)
+
+// configMapImmutableMessage is the last-error of an immutable ConfigMap with values to generate
+const configMapImmutableMessage = "ConfigMap is immutable, its values cannot be generated or rotated; recreate it without immutable: true to let the operator manage it"
+
+// ConfigMapGeneratorReconciler generates values into ConfigMaps, using the same annotations as
+// for Secrets. Generation and rotation are shared with the Secret generator: processFields works
+// on the values of the ConfigMap, with data and binaryData merged.
+type ConfigMapGeneratorReconciler struct {
+	client.Client
+	Scheme        *runtime.Scheme
+	Generator     generator.Generator
+	Config        *config.Config
+	EventRecorder events.EventRecorder
+	// Reloadable holds the configuration after reloads of the configuration file, see
+	// SecretReconciler.Reloadable. If nil, Config is used.
+	Reloadable *ReloadableConfig
+	// Clock is used to get the current time. If nil, time.Now() is used.
+	Clock Clock
+	// Drain lets in-flight reconciles finish on shutdown. If nil, they are canceled right away.
+	Drain *ReconcileDrain
+
+	once    sync.Once
+	secrets *SecretReconciler
+}
+
+// +kubebuilder:rbac:groups="",resources=configmaps,verbs=get;list;watch;update;patch
+
+// secretReconciler returns the SecretReconciler whose field generation and rotation the ConfigMap
+// generator shares. It is created once, so that state like the rotation throttle is kept across reconciles.
+func (r *ConfigMapGeneratorReconciler) secretReconciler() *SecretReconciler {
+	r.once.Do(func() {
+		r.secrets = &SecretReconciler{
+			Client:        r.Client,
+			Scheme:        r.Scheme,
+			Generator:     r.Generator,
+			Config:        r.Config,
+			Reloadable:    r.Reloadable,
+			EventRecorder: r.EventRecorder,
+			Clock:         r.Clock,
+			Drain:         r.Drain,
+		}
+	})
+	return r.secrets
+}
+
+// Reconcile handles the reconciliation of ConfigMaps with autogenerate annotations
+func (r *ConfigMapGeneratorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, done := r.Drain.Track(ctx)
+	defer done()
+	logger := log.FromContext(ctx)
+	// The configuration is read once, so a reload takes effect with the next reconcile
+	s := r.secretReconciler()
+	s = s.withConfig(s.currentConfig())
+
+	// ConfigMaps outside the configured namespaces are never touched
+	if !s.currentConfig().Namespaces.Allows(req.Namespace) {
+		return ctrl.Result{}, nil
+	}
+
+	var cm corev1.ConfigMap
+	if err := r.Get(ctx, req.NamespacedName, &cm); err != nil {
+		return ctrl.Result{}, client.IgnoreNotFound(err)
+	}
+
+	fields := s.keys().parseSecretAnnotations(cm.Annotations)
+	if len(fields) == 0 || !s.currentConfig().Selector().Matches(labels.Set(cm.Labels)) {
+		return ctrl.Result{}, nil
+	}
+	if paused, _ := parseBoolAnnotation(cm.Annotations, s.keys().Paused); paused {
+		logger.V(1).Info("ConfigMap is paused, skipping", "name", cm.Name, "namespace", cm.Namespace)
+		return ctrl.Result{}, nil
+	}
+	if !cm.DeletionTimestamp.IsZero() {
+		logger.V(1).Info("ConfigMap is being deleted, skipping", "name", cm.Name, "namespace", cm.Namespace)
+		return ctrl.Result{}, nil
+	}
+
+	logger.Info("Reconciling ConfigMap", "name", cm.Name, "namespace", cm.Namespace)
+
+	values := configMapValues(&cm)
+	generatedAt := s.getGeneratedAtTime(cm.Annotations)
+
+	// Check for pending force rotation triggers, rotate-now requests, and compromised ConfigMaps
+	forceRotation, forceDeferral := s.checkForceRotation(&cm, logger)
+	trigger := rotationScheduled
+	if forceRotation {
+		trigger = s.keys().forcedRotationTrigger(cm.Annotations)
+	}
+
+	// Check whether due rotations have to wait for the rotation throttle
+	rotationThrottled, throttleDeferral, throttleSlot := s.checkRotationThrottle(&cm, values, s.keys().rotatableFields(cm.Annotations, values, fields), generatedAt, forceRotation, logger)
+	defer throttleSlot.release()
+
+	// Remove previous values whose grace period is over
+	expired := s.clearExpiredPreviousValues(cm.Annotations, values, logger)
+
+	// Remove the values of fields no longer listed in autogenerate
+	pruned := s.pruneFields(cm.Annotations, values, fields, logger)
+
+	updateResult := s.processFields(ctx, &cm, values, fields, generatedAt, forceRotation, rotationThrottled, nil, logger)
+	if updateResult.skipRest {
+		// The failure was logged and reported by processFields
+		if err := s.updateStatus(ctx, &cm, nil, updateResult.errMsg, logger); err != nil {
+			return ctrl.Result{}, err
+		}
+		if retryAfter := s.failureBackoff(s.keys().getFailureCount(cm.Annotations)); retryAfter != nil {
+			return ctrl.Result{RequeueAfter: *retryAfter}, nil
+		}
+		return ctrl.Result{}, nil
+	}
+	// Immutable ConfigMaps reject changes to their data, so retrying would only fail again
+	if updateResult.changed && cm.Immutable != nil && *cm.Immutable {
+		logger.Info("ConfigMap is immutable, skipping", "name", cm.Name, "namespace", cm.Namespace)
+		if cm.Annotations[s.keys().LastError] == configMapImmutableMessage {
+			return ctrl.Result{}, nil
+		}
+		s.eventf(&cm, nil, corev1.EventTypeWarning, EventReasonGenerationFailed, "Generate", "%s", configMapImmutableMessage)
+		return ctrl.Result{}, s.updateStatus(ctx, &cm, nil, configMapImmutableMessage, logger)
+	}
+	// In dry-run mode, nothing is written
+	if (updateResult.changed || len(expired) > 0 || len(pruned) > 0) && s.currentConfig().DryRun {
+		logger.Info("Dry run, not updating ConfigMap", "fields", updateResult.updated, "expired", expired)
+		if len(pruned) > 0 {
+			s.recordPrunedFields(&cm, pruned)
+		}
+		return ctrl.Result{}, nil
+	}
+	s.recordGenerationRecovery(&cm)
+
+	if updateResult.changed {
+		if forceRotation {
+			meta := s.readMetadata(cm.Annotations)
+			meta.ForceRotationTokens = s.matchingForceRotationTokens(cm.Labels)
+			meta.writeTo(cm.Annotations)
+			// rotate-now and compromised fire only once
+			delete(cm.Annotations, s.keys().RotateNow)
+			delete(cm.Annotations, s.keys().RotateNowForce)
+			delete(cm.Annotations, s.keys().Compromised)
+		}
+		setConfigMapValues(&cm, values)
+		if err := s.updateSecretAndEmitEvents(ctx, &cm, updateResult.rotated, trigger, logger); err != nil {
+			return ctrl.Result{}, err
+		}
+		if updateResult.rotated {
+			throttleSlot.keep()
+		}
+		generatedAt = s.getGeneratedAtTime(cm.Annotations)
+		if len(pruned) > 0 {
+			s.recordPrunedFields(&cm, pruned)
+		}
+	} else if (len(expired) > 0 || len(pruned) > 0) && (cm.Immutable == nil || !*cm.Immutable) {
+		setConfigMapValues(&cm, values)
+		if err := r.Update(ctx, &cm); err != nil {
+			logger.Error(err, "Failed to remove expired previous values or pruned fields")
+			return ctrl.Result{}, err
+		}
+		if len(pruned) > 0 {
+			s.recordPrunedFields(&cm, pruned)
+		}
+	}
+
+	// Schedule the next rotation
+	nextRotation := s.calculateNextRotation(secretKey(&cm), cm.Annotations, s.keys().rotatableFields(cm.Annotations, values, fields), generatedAt)
+	for _, deferral := range []*time.Duration{forceDeferral, throttleDeferral} {
+		if deferral != nil && (nextRotation == nil || *deferral < *nextRotation) {
+			nextRotation = deferral
+		}
+	}
+	if err := s.updateStatus(ctx, &cm, nextRotation, "", logger); err != nil {
+		return ctrl.Result{}, err
+	}
+
+	requeueAfter := nextRotation
+	if expiry := s.nextPreviousValueExpiry(cm.Annotations); expiry != nil && (requeueAfter == nil || *expiry < *requeueAfter) {
+		requeueAfter = expiry
+	}
+	if requeueAfter != nil {
+		logger.Info("Scheduling next reconciliation", "requeueAfter", *requeueAfter)
+		return ctrl.Result{RequeueAfter: *requeueAfter}, nil
+	}
+	return ctrl.Result{}, nil
+}
+
+// SetupWithManager sets up the controller with the Manager
+func (r *ConfigMapGeneratorReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	return r.SetupWithManagerAndName(mgr, "configmap-generator")
+}
+
+// SetupWithManagerAndName sets up the controller with the Manager using a custom name.
+// This is useful for testing where multiple controllers may run in the same process.
+func (r *ConfigMapGeneratorReconciler) SetupWithManagerAndName(mgr ctrl.Manager, name string) error {
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named(name).
+		For(&corev1.ConfigMap{}).
+		WithOptions(r.secretReconciler().controllerOptions())
+	for _, filter := range r.secretReconciler().eventFilters() {
+		b = b.WithEventFilter(filter)
+	}
+	return b.Complete(r)
+}
+
+// configMapValues returns the values of a ConfigMap by key, from both data and binaryData
+func configMapValues(cm *corev1.ConfigMap) map[string][]byte {
+	values := make(map[string][]byte, len(cm.Data)+len(cm.BinaryData))
+	for key, value := range cm.Data {
+		values[key] = []byte(value)
+	}
+	for key, value := range cm.BinaryData {
+		values[key] = value
+	}
+	return values
+}
+
+// setConfigMapValues writes values back to the ConfigMap. Existing keys stay in data or
+// binaryData; new keys go to data if they are valid UTF-8, and to binaryData otherwise. A value
+// in data that is no longer valid UTF-8 moves to binaryData. Keys missing from values are removed.
+func setConfigMapValues(cm *corev1.ConfigMap, values map[string][]byte) {
+	for key, value := range values {
+		if _, binary := cm.BinaryData[key]; binary || !utf8.Valid(value) {
+			if cm.BinaryData == nil {
+				cm.BinaryData = make(map[string][]byte)
+			}
+			cm.BinaryData[key] = value
+			delete(cm.Data, key)
+			continue
+		}
+		if cm.Data == nil {
+			cm.Data = make(map[string]string)
+		}
+		cm.Data[key] = string(value)
+	}
+	for key := range cm.Data {
+		if _, ok := values[key]; !ok {
+			delete(cm.Data, key)
+		}
+	}
+	for key := range cm.BinaryData {
+		if _, ok := values[key]; !ok {
+			delete(cm.BinaryData, key)
+		}
+	}
+}
@@ -0,0 +1,196 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+	"sigs.k8s.io/controller-runtime/pkg/event"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/generator"
+)
+
+func TestConfigMapGeneratorReconcile(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	cm := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "instance",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationAutogenerate:            "suffix,seed",
+				AnnotationLengthPrefix + "suffix": "8",
+				AnnotationTypePrefix + "seed":     "bytes",
+				AnnotationRotate:                  "24h",
+			},
+		},
+		Data: map[string]string{"region": "eu-west-1"},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(cm).Build()
+	mockClock := &MockClock{currentTime: time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)}
+	fakeRecorder := NewTestEventRecorder(10)
+	reconciler := &ConfigMapGeneratorReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Generator:     generator.NewSecretGenerator(),
+		Config:        config.NewDefaultConfig(),
+		EventRecorder: fakeRecorder,
+		Clock:         mockClock,
+	}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "instance", Namespace: "default"}
+
+	result, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.RequeueAfter != 24*time.Hour {
+		t.Errorf("expected requeue after 24h, got %s", result.RequeueAfter)
+	}
+
+	var generated corev1.ConfigMap
+	if err := fakeClient.Get(ctx, key, &generated); err != nil {
+		t.Fatalf("failed to get configmap: %v", err)
+	}
+	suffix := generated.Data["suffix"]
+	if len(suffix) != 8 {
+		t.Errorf("expected suffix of length 8, got %q", suffix)
+	}
+	if generated.Data["region"] != "eu-west-1" {
+		t.Errorf("expected other data to be kept, got %q", generated.Data["region"])
+	}
+	if _, ok := generated.Data["seed"]; ok {
+		if len(generated.BinaryData["seed"]) != 0 {
+			t.Error("expected seed in either data or binaryData")
+		}
+	} else if len(generated.BinaryData["seed"]) != 32 {
+		t.Errorf("expected 32 random bytes in binaryData, got %d", len(generated.BinaryData["seed"]))
+	}
+	if generated.Annotations[AnnotationGeneratedAt] != "2026-02-02T12:00:00Z" {
+		t.Errorf("expected generated-at to be set, got %q", generated.Annotations[AnnotationGeneratedAt])
+	}
+	if generated.Annotations[AnnotationStatus] != StatusReady {
+		t.Errorf("expected status %s, got %q", StatusReady, generated.Annotations[AnnotationStatus])
+	}
+	select {
+	case event := <-fakeRecorder.Events:
+		if !strings.Contains(event, EventReasonGenerationSucceeded) {
+			t.Errorf("expected generation succeeded event, got: %s", event)
+		}
+	default:
+		t.Error("expected generation succeeded event to be recorded")
+	}
+
+	// Once due, the fields are rotated
+	mockClock.currentTime = mockClock.currentTime.Add(25 * time.Hour)
+	if _, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var rotated corev1.ConfigMap
+	if err := fakeClient.Get(ctx, key, &rotated); err != nil {
+		t.Fatalf("failed to get configmap: %v", err)
+	}
+	if rotated.Data["suffix"] == suffix || len(rotated.Data["suffix"]) != 8 {
+		t.Errorf("expected suffix to be rotated, got %q (was %q)", rotated.Data["suffix"], suffix)
+	}
+	if rotated.Annotations[AnnotationLastRotationTime] != "2026-02-03T13:00:00Z" {
+		t.Errorf("expected last-rotation-time to be set, got %q", rotated.Annotations[AnnotationLastRotationTime])
+	}
+}
+
+func TestConfigMapValues(t *testing.T) {
+	cm := &corev1.ConfigMap{
+		Data: map[string]string{"suffix": "abc", "region": "eu-west-1"},
+		// Valid UTF-8 in binaryData must stay there
+		BinaryData: map[string][]byte{"seed": {0xff, 0xfe}, "text": []byte("plain")},
+	}
+
+	values := configMapValues(cm)
+	if string(values["suffix"]) != "abc" || string(values["seed"]) != "\xff\xfe" || string(values["text"]) != "plain" {
+		t.Errorf("expected data and binaryData in the values, got %v", values)
+	}
+
+	values["suffix"] = []byte("def")
+	values["text"] = []byte("rotated")
+	values["token"] = []byte("new")
+	values["key"] = []byte{0x00, 0xff}
+	delete(values, "region")
+	setConfigMapValues(cm, values)
+
+	wantData := map[string]string{"suffix": "def", "token": "new"}
+	if !reflect.DeepEqual(cm.Data, wantData) {
+		t.Errorf("expected data %v, got %v", wantData, cm.Data)
+	}
+	wantBinary := map[string][]byte{"seed": {0xff, 0xfe}, "text": []byte("rotated"), "key": {0x00, 0xff}}
+	if !reflect.DeepEqual(cm.BinaryData, wantBinary) {
+		t.Errorf("expected binaryData %v, got %v", wantBinary, cm.BinaryData)
+	}
+}
+
+func TestConfigMapEventFilters(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	cfg.Namespaces.Exclude = []string{"kube-system"}
+	filters := (&SecretReconciler{Config: cfg}).eventFilters()
+
+	allowed := func(cm *corev1.ConfigMap) bool {
+		for _, filter := range filters {
+			if !filter.Create(event.CreateEvent{Object: cm}) {
+				return false
+			}
+		}
+		return true
+	}
+
+	annotated := &corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{
+		Namespace:   "default",
+		Annotations: map[string]string{AnnotationAutogenerate: "suffix"},
+	}}
+	if !allowed(annotated) {
+		t.Error("expected annotated ConfigMap to be reconciled")
+	}
+	if allowed(&corev1.ConfigMap{ObjectMeta: metav1.ObjectMeta{Namespace: "default"}}) {
+		t.Error("expected ConfigMap without autogenerate annotation to be filtered")
+	}
+	excluded := annotated.DeepCopy()
+	excluded.Namespace = "kube-system"
+	if allowed(excluded) {
+		t.Error("expected ConfigMap in excluded namespace to be filtered")
+	}
+
+	statusOnly := annotated.DeepCopy()
+	statusOnly.Annotations[AnnotationStatus] = StatusReady
+	for _, filter := range filters {
+		if !filter.Update(event.UpdateEvent{ObjectOld: annotated, ObjectNew: statusOnly}) {
+			return
+		}
+	}
+	t.Error("expected a status-only update of a ConfigMap to be filtered")
+}
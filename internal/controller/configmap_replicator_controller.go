@@ -18,7 +18,9 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -44,10 +46,27 @@ type ConfigMapReplicatorReconciler struct {
 	Scheme        *runtime.Scheme
 	Config        *config.Config
 	EventRecorder events.EventRecorder
+	// Drain lets in-flight reconciles finish on shutdown. If nil, they are canceled right away.
+	Drain *ReconcileDrain
+
+	// annotationKeys are the replication annotation keys for the configured prefix, see keys
+	annotationKeys     *replicator.Annotations
+	annotationKeysOnce sync.Once
+}
+
+// keys returns the replication annotation keys for the configured annotation prefix.
+// They are built on first use; the prefix can't change while running.
+func (r *ConfigMapReplicatorReconciler) keys() *replicator.Annotations {
+	r.annotationKeysOnce.Do(func() {
+		r.annotationKeys = replicator.NewAnnotations(r.Config.AnnotationPrefix)
+	})
+	return r.annotationKeys
 }
 
 // Reconcile handles ConfigMap replication (both pull and push)
 func (r *ConfigMapReplicatorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, done := r.Drain.Track(ctx)
+	defer done()
 	log := log.FromContext(ctx)
 
 	// Fetch the ConfigMap
@@ -67,12 +86,12 @@ func (r *ConfigMapReplicatorReconciler) Reconcile(ctx context.Context, req ctrl.
 	}
 
 	// Handle pull-based replication
-	if cm.Annotations[replicator.AnnotationReplicateFrom] != "" {
+	if cm.Annotations[r.keys().ReplicateFrom] != "" {
 		return r.handlePullReplication(ctx, cm)
 	}
 
 	// Handle push-based replication
-	if cm.Annotations[replicator.AnnotationReplicateTo] != "" {
+	if cm.Annotations[r.keys().ReplicateTo] != "" {
 		return r.handlePushReplication(ctx, cm)
 	}
 
@@ -84,11 +103,11 @@ func (r *ConfigMapReplicatorReconciler) handlePullReplication(ctx context.Contex
 	log := log.FromContext(ctx)
 
 	// Parse source reference
-	sourceRef := targetCM.Annotations[replicator.AnnotationReplicateFrom]
+	sourceRef := targetCM.Annotations[r.keys().ReplicateFrom]
 	sourceNamespace, sourceName, err := replicator.ParseSourceReference(sourceRef)
 	if err != nil {
 		r.EventRecorder.Eventf(targetCM, nil, corev1.EventTypeWarning, EventReasonReplicationFailed, "Pull",
-			fmt.Sprintf("Invalid source reference: %v", err))
+			"Invalid source reference: %v", err)
 		log.Error(err, "invalid source reference", "sourceRef", sourceRef)
 		return ctrl.Result{}, nil // Don't requeue - user needs to fix annotation
 	}
@@ -99,7 +118,7 @@ func (r *ConfigMapReplicatorReconciler) handlePullReplication(ctx context.Contex
 	if err := r.Get(ctx, sourceKey, sourceCM); err != nil {
 		if apierrors.IsNotFound(err) {
 			r.EventRecorder.Eventf(targetCM, nil, corev1.EventTypeWarning, EventReasonReplicationFailed, "Pull",
-				fmt.Sprintf("Source ConfigMap %s not found", sourceRef))
+				"Source ConfigMap %s not found", sourceRef)
 			log.Info("Source ConfigMap not found", "source", sourceRef)
 			return ctrl.Result{}, nil
 		}
@@ -110,35 +129,35 @@ func (r *ConfigMapReplicatorReconciler) handlePullReplication(ctx context.Contex
 	// Check if source ConfigMap was deleted
 	if replicator.IsBeingDeleted(sourceCM) {
 		r.EventRecorder.Eventf(targetCM, nil, corev1.EventTypeWarning, EventReasonSourceDeleted, "Pull",
-			fmt.Sprintf("Source ConfigMap %s is being deleted. Target will keep last known data.", sourceRef))
+			"Source ConfigMap %s is being deleted. Target will keep last known data.", sourceRef)
 		log.Info("Source ConfigMap being deleted - keeping snapshot", "source", sourceRef)
 		return ctrl.Result{}, nil
 	}
 
 	// Validate replication is allowed (mutual consent or global pull-based permission)
-	sourceAllowlist := sourceCM.Annotations[replicator.AnnotationReplicatableFromNamespaces]
+	sourceAllowlist := sourceCM.Annotations[r.keys().ReplicatableFromNamespaces]
 	allowed, denyReason := replicator.ValidatePullConsent(r.Config.GlobalPullBasedPermissions, replicator.KindConfigMap,
 		sourceNamespace, sourceName, sourceAllowlist, targetCM.Namespace)
 	if !allowed {
 		r.EventRecorder.Eventf(targetCM, nil, corev1.EventTypeWarning, EventReasonReplicationFailed, "Pull",
-			fmt.Sprintf("Replication not allowed: %s", denyReason))
+			"Replication not allowed: %s", denyReason)
 		log.Info("Replication not allowed", "source", sourceRef, "reason", denyReason)
 		return ctrl.Result{}, nil // Don't requeue - consent required
 	}
 
 	// Replicate data from source to target
-	replicator.ReplicateConfigMap(sourceCM, targetCM)
+	r.keys().ReplicateConfigMap(sourceCM, targetCM)
 
 	// Update target ConfigMap
 	if err := r.Update(ctx, targetCM); err != nil {
 		r.EventRecorder.Eventf(targetCM, nil, corev1.EventTypeWarning, EventReasonReplicationFailed, "Pull",
-			fmt.Sprintf("Failed to update target ConfigMap: %v", err))
+			"Failed to update target ConfigMap: %v", err)
 		log.Error(err, "failed to update target ConfigMap")
 		return ctrl.Result{}, err
 	}
 
 	r.EventRecorder.Eventf(targetCM, nil, corev1.EventTypeNormal, EventReasonReplicationSucceeded, "Pull",
-		fmt.Sprintf("Successfully replicated from %s", sourceRef))
+		"Successfully replicated from %s", sourceRef)
 	log.Info("Pull replication succeeded", "target", fmt.Sprintf("%s/%s", targetCM.Namespace, targetCM.Name), "source", sourceRef)
 
 	return ctrl.Result{}, nil
@@ -149,7 +168,7 @@ func (r *ConfigMapReplicatorReconciler) handlePushReplication(ctx context.Contex
 	log := log.FromContext(ctx)
 
 	// Parse target namespaces
-	targetNSList := sourceCM.Annotations[replicator.AnnotationReplicateTo]
+	targetNSList := sourceCM.Annotations[r.keys().ReplicateTo]
 	targetNamespaces := replicator.ParseTargetNamespaces(targetNSList)
 
 	if len(targetNamespaces) == 0 {
@@ -190,11 +209,11 @@ func (r *ConfigMapReplicatorReconciler) pushToNamespace(ctx context.Context, sou
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			// Target doesn't exist - create it
-			targetCM = replicator.CreateReplicatedConfigMap(sourceCM, targetNS)
+			targetCM = r.keys().CreateReplicatedConfigMap(sourceCM, targetNS)
 			if err := r.Create(ctx, targetCM); err != nil {
 				reasonMsg := humanReadableErrorReason(err)
 				r.EventRecorder.Eventf(sourceCM, nil, corev1.EventTypeWarning, EventReasonPushFailed, "Push",
-					fmt.Sprintf("Could not replicate to namespace %s: %s", targetNS, reasonMsg))
+					"Could not replicate to namespace %s: %s", targetNS, reasonMsg)
 				log.V(1).Info("Could not replicate to namespace", "targetNamespace", targetNS, "reason", reasonMsg)
 				return
 			}
@@ -205,25 +224,25 @@ func (r *ConfigMapReplicatorReconciler) pushToNamespace(ctx context.Context, sou
 		// Unexpected error reading target
 		reasonMsg := humanReadableErrorReason(err)
 		r.EventRecorder.Eventf(sourceCM, nil, corev1.EventTypeWarning, EventReasonPushFailed, "Push",
-			fmt.Sprintf("Could not access namespace %s: %s", targetNS, reasonMsg))
+			"Could not access namespace %s: %s", targetNS, reasonMsg)
 		log.V(1).Info("Could not access namespace", "targetNamespace", targetNS, "reason", reasonMsg)
 		return
 	}
 
 	// Target exists - check if we own it
-	if !replicator.IsOwnedByUs(targetCM, sourceRef) {
+	if !r.keys().IsOwnedByUs(targetCM, sourceRef) {
 		r.EventRecorder.Eventf(sourceCM, nil, corev1.EventTypeWarning, EventReasonPushFailed, "Push",
-			fmt.Sprintf("ConfigMap already exists in namespace %s and is not managed by this replication", targetNS))
+			"ConfigMap already exists in namespace %s and is not managed by this replication", targetNS)
 		log.V(1).Info("Target ConfigMap exists but is not owned by us", "targetNamespace", targetNS, "name", sourceCM.Name)
 		return
 	}
 
 	// We own it - update it
-	replicator.ReplicateConfigMap(sourceCM, targetCM)
+	r.keys().ReplicateConfigMap(sourceCM, targetCM)
 	if err := r.Update(ctx, targetCM); err != nil {
 		reasonMsg := humanReadableErrorReason(err)
 		r.EventRecorder.Eventf(sourceCM, nil, corev1.EventTypeWarning, EventReasonPushFailed, "Push",
-			fmt.Sprintf("Could not update ConfigMap in namespace %s: %s", targetNS, reasonMsg))
+			"Could not update ConfigMap in namespace %s: %s", targetNS, reasonMsg)
 		log.V(1).Info("Could not update ConfigMap in namespace", "targetNamespace", targetNS, "reason", reasonMsg)
 		return
 	}
@@ -241,7 +260,7 @@ func (r *ConfigMapReplicatorReconciler) handleDeletion(ctx context.Context, sour
 	}
 
 	// Only handle deletion for ConfigMaps with replicate-to annotation
-	if sourceCM.Annotations[replicator.AnnotationReplicateTo] == "" {
+	if sourceCM.Annotations[r.keys().ReplicateTo] == "" {
 		// Remove finalizer and let it be deleted
 		replicator.RemoveFinalizer(sourceCM)
 		if err := r.Update(ctx, sourceCM); err != nil {
@@ -260,16 +279,29 @@ func (r *ConfigMapReplicatorReconciler) handleDeletion(ctx context.Context, sour
 		return ctrl.Result{}, err
 	}
 
-	// Delete all pushed ConfigMaps
+	// Delete all pushed ConfigMaps. Failures don't stop the cleanup of the others; the finalizer is
+	// kept and the deletion retried until all replicas are gone.
+	var deleteErrs []error
 	for i := range cmList.Items {
 		cm := &cmList.Items[i]
-		if replicator.GetReplicatedFromAnnotation(cm) == sourceRef {
-			if err := r.Delete(ctx, cm); err != nil && !apierrors.IsNotFound(err) {
-				log.Error(err, "failed to delete replicated ConfigMap", "namespace", cm.Namespace, "name", cm.Name)
-				return ctrl.Result{}, err
-			}
-			log.Info("Deleted replicated ConfigMap", "namespace", cm.Namespace, "name", cm.Name)
+		if r.keys().GetReplicatedFromAnnotation(cm) != sourceRef {
+			continue
 		}
+		if r.keys().IsRetargeted(cm, sourceRef) {
+			log.Info("Keeping retargeted replicated ConfigMap", "namespace", cm.Namespace, "name", cm.Name)
+			continue
+		}
+		if err := r.Delete(ctx, cm); err != nil && !apierrors.IsNotFound(err) {
+			log.Error(err, "failed to delete replicated ConfigMap", "namespace", cm.Namespace, "name", cm.Name)
+			deleteErrs = append(deleteErrs, fmt.Errorf("%s/%s: %w", cm.Namespace, cm.Name, err))
+			continue
+		}
+		log.Info("Deleted replicated ConfigMap", "namespace", cm.Namespace, "name", cm.Name)
+	}
+	if len(deleteErrs) > 0 {
+		r.EventRecorder.Eventf(sourceCM, nil, corev1.EventTypeWarning, EventReasonCleanupFailed, "Cleanup",
+			"Could not delete %d replicated ConfigMap(s), retrying", len(deleteErrs))
+		return ctrl.Result{}, fmt.Errorf("failed to delete replicated ConfigMaps: %w", errors.Join(deleteErrs...))
 	}
 
 	// Remove finalizer from source ConfigMap
@@ -300,8 +332,8 @@ func (r *ConfigMapReplicatorReconciler) SetupWithManagerAndName(mgr ctrl.Manager
 		if cm.Annotations == nil {
 			return false
 		}
-		hasReplicateFrom := cm.Annotations[replicator.AnnotationReplicateFrom] != ""
-		hasReplicateTo := cm.Annotations[replicator.AnnotationReplicateTo] != ""
+		hasReplicateFrom := cm.Annotations[r.keys().ReplicateFrom] != ""
+		hasReplicateTo := cm.Annotations[r.keys().ReplicateTo] != ""
 		return hasReplicateFrom || hasReplicateTo
 	})
 
@@ -313,7 +345,7 @@ func (r *ConfigMapReplicatorReconciler) SetupWithManagerAndName(mgr ctrl.Manager
 		}
 		// ConfigMaps with replicatable-from-namespaces can be sources
 		if cm.Annotations != nil &&
-			cm.Annotations[replicator.AnnotationReplicatableFromNamespaces] != "" {
+			cm.Annotations[r.keys().ReplicatableFromNamespaces] != "" {
 			return true
 		}
 		// ConfigMaps covered by a global pull-based permission can be sources too
@@ -337,6 +369,12 @@ func (r *ConfigMapReplicatorReconciler) SetupWithManagerAndName(mgr ctrl.Manager
 			&corev1.ConfigMap{},
 			handler.EnqueueRequestsFromMapFunc(r.findPushSourcesForTarget),
 		).
+		// Watch Namespace creation to push ConfigMaps into target namespaces that didn't exist yet
+		Watches(
+			&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.findPushSourcesForNamespace),
+			builder.WithPredicates(namespaceCreatedPredicate),
+		).
 		Complete(r)
 }
 
@@ -365,7 +403,7 @@ func (r *ConfigMapReplicatorReconciler) findPushSourcesForTarget(ctx context.Con
 		}
 
 		// Check if this source pushes to the namespace where the ConfigMap changed
-		replicateTo := source.Annotations[replicator.AnnotationReplicateTo]
+		replicateTo := source.Annotations[r.keys().ReplicateTo]
 		if replicateTo == "" {
 			continue
 		}
@@ -424,7 +462,7 @@ func (r *ConfigMapReplicatorReconciler) findTargetsForSource(ctx context.Context
 		}
 
 		// Check if this target pulls from our source
-		if target.Annotations[replicator.AnnotationReplicateFrom] == sourceRef {
+		if target.Annotations[r.keys().ReplicateFrom] == sourceRef {
 			requests = append(requests, reconcile.Request{
 				NamespacedName: types.NamespacedName{
 					Namespace: target.Namespace,
@@ -441,3 +479,46 @@ func (r *ConfigMapReplicatorReconciler) findTargetsForSource(ctx context.Context
 
 	return requests
 }
+
+// findPushSourcesForNamespace finds all source ConfigMaps that push to a newly created namespace.
+// This pre-provisions the replicas instead of waiting for the next change of the source.
+func (r *ConfigMapReplicatorReconciler) findPushSourcesForNamespace(ctx context.Context, obj client.Object) []reconcile.Request {
+	namespace, ok := obj.(*corev1.Namespace)
+	if !ok {
+		return nil
+	}
+
+	log := log.FromContext(ctx)
+
+	cmList := &corev1.ConfigMapList{}
+	if err := r.List(ctx, cmList); err != nil {
+		log.Error(err, "failed to list ConfigMaps for namespace mapping", "namespace", namespace.Name)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range cmList.Items {
+		source := &cmList.Items[i]
+		if source.Annotations == nil {
+			continue
+		}
+
+		for _, targetNS := range replicator.ParseTargetNamespaces(source.Annotations[r.keys().ReplicateTo]) {
+			if targetNS == namespace.Name {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Namespace: source.Namespace,
+						Name:      source.Name,
+					},
+				})
+				break
+			}
+		}
+	}
+
+	if len(requests) > 0 {
+		log.Info("Triggering reconciliation of push sources for new namespace", "namespace", namespace.Name, "sourceCount", len(requests))
+	}
+
+	return requests
+}
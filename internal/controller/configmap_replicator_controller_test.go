@@ -927,6 +927,50 @@ func TestConfigMapReplicatorReconciler_FindPushSourcesForTarget(t *testing.T) {
 	}
 }
 
+func TestConfigMapReplicatorReconciler_FindPushSourcesForNamespace(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	pushSource := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "app-config",
+			Namespace: "production",
+			Annotations: map[string]string{
+				replicator.AnnotationReplicateTo: "staging,preview-42",
+			},
+		},
+	}
+
+	otherSource := &corev1.ConfigMap{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "other-config",
+			Namespace: "production",
+			Annotations: map[string]string{
+				replicator.AnnotationReplicateTo: "staging",
+			},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(pushSource, otherSource).Build()
+	reconciler := newConfigMapReconciler(fakeClient, scheme, config.NewDefaultConfig(), NewTestEventRecorder(10))
+
+	// The new namespace is a target of app-config only
+	created := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "preview-42"}}
+	requests := reconciler.findPushSourcesForNamespace(context.Background(), created)
+	if len(requests) != 1 {
+		t.Fatalf("Expected 1 reconcile request, got %d", len(requests))
+	}
+	if requests[0].Namespace != "production" || requests[0].Name != "app-config" {
+		t.Errorf("Unexpected request: %+v", requests[0])
+	}
+
+	// A namespace nobody pushes to must not trigger anything
+	unrelated := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "qa"}}
+	if reqs := reconciler.findPushSourcesForNamespace(context.Background(), unrelated); len(reqs) != 0 {
+		t.Errorf("Expected 0 requests, got %d", len(reqs))
+	}
+}
+
 func TestConfigMapReplicatorReconciler_FindTargetsForSource(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = corev1.AddToScheme(scheme)
@@ -0,0 +1,100 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+
+	"sigs.k8s.io/controller-runtime/pkg/log"
+)
+
+// drainPollInterval is how often ReconcileDrain checks whether in-flight reconciles finished
+const drainPollInterval = 50 * time.Millisecond
+
+// ReconcileDrain lets in-flight reconciles finish when the operator shuts down. controller-runtime
+// cancels the context of running reconciles on shutdown, which would fail their remaining API
+// calls and could leave a Secret half-written, e.g. rotated but without its previous value.
+// Reconciles tracked by a ReconcileDrain keep a context that is canceled only GracePeriod after
+// the shutdown began. A nil *ReconcileDrain tracks nothing and passes contexts through.
+type ReconcileDrain struct {
+	// GracePeriod is how long in-flight reconciles may continue after shutdown began. It should
+	// match the manager's GracefulShutdownTimeout.
+	GracePeriod time.Duration
+
+	inFlight atomic.Int64
+}
+
+// NewReconcileDrain returns a ReconcileDrain giving in-flight reconciles gracePeriod to finish
+func NewReconcileDrain(gracePeriod time.Duration) *ReconcileDrain {
+	return &ReconcileDrain{GracePeriod: gracePeriod}
+}
+
+// Track registers a reconcile running with ctx. It returns the context the reconcile uses instead,
+// which outlives the cancellation of ctx by the grace period, and a function to call once the
+// reconcile returned.
+func (d *ReconcileDrain) Track(ctx context.Context) (context.Context, func()) {
+	if d == nil {
+		return ctx, func() {}
+	}
+	d.inFlight.Add(1)
+	drainCtx, cancel := context.WithCancel(context.WithoutCancel(ctx))
+	stop := context.AfterFunc(ctx, func() {
+		timer := time.NewTimer(d.GracePeriod)
+		defer timer.Stop()
+		select {
+		case <-timer.C:
+			cancel()
+		case <-drainCtx.Done():
+		}
+	})
+	return drainCtx, func() {
+		stop()
+		cancel()
+		d.inFlight.Add(-1)
+	}
+}
+
+// InFlight returns the number of reconciles currently running
+func (d *ReconcileDrain) InFlight() int64 {
+	return d.inFlight.Load()
+}
+
+// Start implements manager.Runnable. Once ctx is done, it waits for the in-flight reconciles and
+// logs how many were drained. ReconcileDrain doesn't implement manager.LeaderElectionRunnable:
+// as a leader election runnable, it is stopped together with the controllers. The manager stops
+// the other runnables first and waits for them, so waiting there would block the shutdown of
+// the controllers until the grace period ends.
+func (d *ReconcileDrain) Start(ctx context.Context) error {
+	<-ctx.Done()
+	logger := log.FromContext(ctx).WithName("reconcile-drain")
+
+	inFlight := d.InFlight()
+	if inFlight == 0 {
+		return nil
+	}
+	logger.Info("Shutting down, waiting for in-flight reconciles", "inFlight", inFlight, "gracePeriod", d.GracePeriod)
+
+	ticker := time.NewTicker(drainPollInterval)
+	defer ticker.Stop()
+	for d.InFlight() > 0 {
+		<-ticker.C
+	}
+	logger.Info("Drained in-flight reconciles", "drained", inFlight)
+	return nil
+}
@@ -0,0 +1,183 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/generator"
+)
+
+// blockingUpdateClient blocks Updates until release is closed and fails them if their context
+// is done by then, like a client talking to the API server
+type blockingUpdateClient struct {
+	client.Client
+	updating chan struct{}
+	release  chan struct{}
+	once     sync.Once
+}
+
+func (c *blockingUpdateClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	c.once.Do(func() { close(c.updating) })
+	<-c.release
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+func TestReconcileDrainTrack(t *testing.T) {
+	drain := NewReconcileDrain(50 * time.Millisecond)
+	ctx, cancel := context.WithCancel(context.Background())
+
+	drainCtx, done := drain.Track(ctx)
+	if drain.InFlight() != 1 {
+		t.Errorf("expected 1 in-flight reconcile, got %d", drain.InFlight())
+	}
+
+	// The reconcile's context outlives the shutdown by the grace period
+	cancel()
+	time.Sleep(10 * time.Millisecond)
+	if err := drainCtx.Err(); err != nil {
+		t.Fatalf("expected the context to stay valid within the grace period, got %v", err)
+	}
+	select {
+	case <-drainCtx.Done():
+	case <-time.After(time.Second):
+		t.Fatal("expected the context to be canceled after the grace period")
+	}
+
+	done()
+	if drain.InFlight() != 0 {
+		t.Errorf("expected no in-flight reconciles, got %d", drain.InFlight())
+	}
+
+	// A finished reconcile's context is canceled right away
+	drainCtx, done = drain.Track(context.Background())
+	done()
+	if drainCtx.Err() == nil {
+		t.Error("expected the context to be canceled once the reconcile is done")
+	}
+}
+
+func TestReconcileDrainNil(t *testing.T) {
+	var drain *ReconcileDrain
+	ctx := context.Background()
+	drainCtx, done := drain.Track(ctx)
+	defer done()
+	if drainCtx != ctx {
+		t.Error("expected a nil drain to pass the context through")
+	}
+}
+
+func TestReconcileDrainStartWaitsForReconciles(t *testing.T) {
+	drain := NewReconcileDrain(time.Minute)
+	_, done := drain.Track(context.Background())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	stopped := make(chan struct{})
+	go func() {
+		_ = drain.Start(ctx)
+		close(stopped)
+	}()
+
+	cancel()
+	select {
+	case <-stopped:
+		t.Fatal("expected Start to wait for the in-flight reconcile")
+	case <-time.After(100 * time.Millisecond):
+	}
+
+	done()
+	select {
+	case <-stopped:
+	case <-time.After(time.Second):
+		t.Fatal("expected Start to return once the reconcile finished")
+	}
+}
+
+func TestReconcileDrainCompletesUpdate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "draining",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationAutogenerate: "password",
+			},
+		},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+
+	for _, tt := range []struct {
+		name        string
+		drain       *ReconcileDrain
+		expectValue bool
+	}{
+		{"without drain", nil, false},
+		{"with drain", NewReconcileDrain(time.Minute), true},
+	} {
+		t.Run(tt.name, func(t *testing.T) {
+			blocking := &blockingUpdateClient{Client: fakeClient, updating: make(chan struct{}), release: make(chan struct{})}
+			reconciler := &SecretReconciler{
+				Client:        blocking,
+				Scheme:        scheme,
+				Generator:     generator.NewSecretGenerator(),
+				Config:        config.NewDefaultConfig(),
+				EventRecorder: NewTestEventRecorder(10),
+				Drain:         tt.drain,
+			}
+
+			ctx, cancel := context.WithCancel(context.Background())
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}}
+			reconciled := make(chan struct{})
+			go func() {
+				_, _ = reconciler.Reconcile(ctx, req)
+				close(reconciled)
+			}()
+
+			// Shut down while the reconcile is updating the Secret
+			<-blocking.updating
+			cancel()
+			close(blocking.release)
+			<-reconciled
+
+			var updated corev1.Secret
+			if err := fakeClient.Get(context.Background(), req.NamespacedName, &updated); err != nil {
+				t.Fatalf("failed to get secret: %v", err)
+			}
+			if _, ok := updated.Data["password"]; ok != tt.expectValue {
+				t.Errorf("expected value written: %v, got %v", tt.expectValue, ok)
+			}
+		})
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"os"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	ctrl "sigs.k8s.io/controller-runtime"
+)
+
+// AuditLoggerName is the name of the logger audit records are written to, so they can be
+// filtered from the controller logs
+const AuditLoggerName = "audit"
+
+// Actions of audit records
+const (
+	// AuditActionGenerate records the initial generation of fields
+	AuditActionGenerate = "generate"
+	// AuditActionRotate records the rotation of fields
+	AuditActionRotate = "rotate"
+	// AuditActionPrune records the removal of fields no longer listed in autogenerate
+	AuditActionPrune = "prune"
+	// AuditActionExpire records the removal of previous values after their grace period
+	AuditActionExpire = "expire"
+	// AuditActionAdopt records the adoption of existing values
+	AuditActionAdopt = "adopt"
+)
+
+// auditLogger returns the logger audit records are written to: AuditLogger if set, otherwise
+// the global logger named AuditLoggerName
+func (r *SecretReconciler) auditLogger() logr.Logger {
+	if r.AuditLogger.GetSink() != nil {
+		return r.AuditLogger
+	}
+	return ctrl.Log.WithName(AuditLoggerName)
+}
+
+// auditActor returns the operator instance changing Secrets: Instance if set, otherwise the
+// hostname, which is the Pod name in a cluster
+func (r *SecretReconciler) auditActor() string {
+	if r.Instance != "" {
+		return r.Instance
+	}
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "unknown"
+	}
+	return hostname
+}
+
+// audit writes an audit record of an update of secret that changed the data keys fields.
+// Records name the keys only, never their values.
+func (r *SecretReconciler) audit(secret *corev1.Secret, action string, fields []string) {
+	if len(fields) == 0 {
+		return
+	}
+	r.auditLogger().Info("Secret updated",
+		"namespace", secret.Namespace,
+		"name", secret.Name,
+		"action", action,
+		"fields", fields,
+		"actor", r.auditActor())
+}
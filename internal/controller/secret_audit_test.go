@@ -0,0 +1,144 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-logr/logr/funcr"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/generator"
+)
+
+// auditRecord is the shape of an audit log record
+type auditRecord struct {
+	Logger    string   `json:"logger"`
+	Msg       string   `json:"msg"`
+	Namespace string   `json:"namespace"`
+	Name      string   `json:"name"`
+	Action    string   `json:"action"`
+	Fields    []string `json:"fields"`
+	Actor     string   `json:"actor"`
+}
+
+func TestReconcileAuditRecords(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "audited-secret",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationAutogenerate: "password,token",
+				AnnotationRotate:       "24h",
+			},
+		},
+	}
+
+	var lines []string
+	auditLogger := funcr.NewJSON(func(obj string) { lines = append(lines, obj) }, funcr.Options{}).WithName(AuditLoggerName)
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	mockClock := &MockClock{currentTime: time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)}
+	reconciler := &SecretReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Generator:     generator.NewSecretGenerator(),
+		Config:        config.NewDefaultConfig(),
+		EventRecorder: NewTestEventRecorder(10),
+		Clock:         mockClock,
+		AuditLogger:   auditLogger,
+		Instance:      "operator-0",
+	}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}}
+	ctx := context.Background()
+	reconcile := func() auditRecord {
+		t.Helper()
+		lines = nil
+		if _, err := reconciler.Reconcile(ctx, req); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if len(lines) != 1 {
+			t.Fatalf("expected 1 audit record, got %d: %v", len(lines), lines)
+		}
+
+		var updatedSecret corev1.Secret
+		if err := fakeClient.Get(ctx, req.NamespacedName, &updatedSecret); err != nil {
+			t.Fatalf("failed to get secret: %v", err)
+		}
+		for field, value := range updatedSecret.Data {
+			if strings.Contains(lines[0], string(value)) {
+				t.Errorf("expected the audit record not to contain the value of %s", field)
+			}
+		}
+
+		var record auditRecord
+		if err := json.Unmarshal([]byte(lines[0]), &record); err != nil {
+			t.Fatalf("expected a JSON audit record, got %q: %v", lines[0], err)
+		}
+		return record
+	}
+	check := func(record auditRecord, action string) {
+		t.Helper()
+		want := auditRecord{
+			Logger:    AuditLoggerName,
+			Msg:       "Secret updated",
+			Namespace: "default",
+			Name:      "audited-secret",
+			Action:    action,
+			Fields:    []string{"password", "token"},
+			Actor:     "operator-0",
+		}
+		slices.Sort(record.Fields)
+		if record.Logger != want.Logger || record.Msg != want.Msg || record.Namespace != want.Namespace ||
+			record.Name != want.Name || record.Action != want.Action || record.Actor != want.Actor ||
+			!slices.Equal(record.Fields, want.Fields) {
+			t.Errorf("expected audit record %+v, got %+v", want, record)
+		}
+	}
+
+	// Initial generation
+	check(reconcile(), AuditActionGenerate)
+
+	// Rotation
+	mockClock.currentTime = mockClock.currentTime.Add(25 * time.Hour)
+	check(reconcile(), AuditActionRotate)
+
+	// Nothing changed, nothing audited
+	lines = nil
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(lines) != 0 {
+		t.Errorf("expected no audit record without changes, got %v", lines)
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strconv"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// getFailureCount returns the number of consecutive failed reconciles of a Secret, or 0 if the
+// failure-count annotation is missing or invalid
+func (k *annotationKeys) getFailureCount(annotations map[string]string) int {
+	count, err := strconv.Atoi(annotations[k.FailureCount])
+	if err != nil || count < 0 {
+		return 0
+	}
+	return count
+}
+
+// failureBackoff returns the delay before retrying a Secret after its failures-th consecutive
+// failure. The delay doubles with every failure up to the configured maximum. It returns nil
+// once the Secret failed maxFailures times: it is retried when it changes, not on a schedule.
+func (r *SecretReconciler) failureBackoff(failures int) *time.Duration {
+	cfg := r.currentConfig().FailureBackoff
+	if failures <= 0 || failures >= cfg.MaxFailures {
+		return nil
+	}
+	delay := cfg.InitialDelay.Duration()
+	maxDelay := cfg.MaxDelay.Duration()
+	for i := 1; i < failures && delay < maxDelay; i++ {
+		delay *= 2
+	}
+	if delay > maxDelay {
+		delay = maxDelay
+	}
+	return &delay
+}
+
+// recordGenerationFailure creates the GenerationFailed event of a failed reconcile. Only the first
+// of consecutive failures creates an event and a notification; later ones update the last-error
+// annotation only.
+func (r *SecretReconciler) recordGenerationFailure(ctx context.Context, obj client.Object, msg string) {
+	if r.keys().getFailureCount(obj.GetAnnotations()) > 0 {
+		return
+	}
+	r.eventf(obj, nil, corev1.EventTypeWarning, EventReasonGenerationFailed, "Generate", "%s", msg)
+	r.notify(ctx, obj, Notification{Reason: EventReasonGenerationFailed, Message: msg})
+}
+
+// recordGenerationRecovery creates a GenerationRecovered event if the Secret failed before
+func (r *SecretReconciler) recordGenerationRecovery(obj client.Object) {
+	if failures := r.keys().getFailureCount(obj.GetAnnotations()); failures > 0 {
+		r.eventf(obj, nil, corev1.EventTypeNormal, EventReasonGenerationRecovered, "Generate",
+			"Generation succeeded after %d failed attempt(s)", failures)
+	}
+}
@@ -0,0 +1,197 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/generator"
+)
+
+// failingGenerator fails the given number of string generations, then generates random values
+type failingGenerator struct {
+	*generator.SecretGenerator
+	failures int
+}
+
+func (g *failingGenerator) GenerateWithPrefix(prefix, genType string, length int, charset string) (string, error) {
+	if g.failures > 0 {
+		g.failures--
+		return "", errors.New("entropy source unavailable")
+	}
+	return g.SecretGenerator.GenerateWithPrefix(prefix, genType, length, charset)
+}
+
+func TestFailureBackoff(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	cfg.FailureBackoff = config.FailureBackoffConfig{
+		InitialDelay: config.Duration(30 * time.Second),
+		MaxDelay:     config.Duration(5 * time.Minute),
+		MaxFailures:  6,
+	}
+	reconciler := &SecretReconciler{Config: cfg}
+
+	expected := map[int]time.Duration{
+		1: 30 * time.Second,
+		2: time.Minute,
+		3: 2 * time.Minute,
+		4: 4 * time.Minute,
+		5: 5 * time.Minute,
+	}
+	for failures, want := range expected {
+		got := reconciler.failureBackoff(failures)
+		if got == nil || *got != want {
+			t.Errorf("expected backoff %s after %d failures, got %v", want, failures, got)
+		}
+	}
+	for _, failures := range []int{0, 6, 7} {
+		if got := reconciler.failureBackoff(failures); got != nil {
+			t.Errorf("expected no retry after %d failures, got %s", failures, *got)
+		}
+	}
+}
+
+func TestReconcileFailureBackoff(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name             string
+		failures         int
+		maxFailures      int
+		expectedRequeues []time.Duration
+		expectRecovery   bool
+	}{
+		{
+			name:             "recovers after backing off",
+			failures:         3,
+			maxFailures:      10,
+			expectedRequeues: []time.Duration{30 * time.Second, time.Minute, 2 * time.Minute},
+			expectRecovery:   true,
+		},
+		{
+			name:             "stops retrying after max failures",
+			failures:         3,
+			maxFailures:      2,
+			expectedRequeues: []time.Duration{30 * time.Second, 0},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "flaky",
+					Namespace: "default",
+					Annotations: map[string]string{
+						AnnotationAutogenerate: "password",
+					},
+				},
+			}
+
+			cfg := config.NewDefaultConfig()
+			cfg.FailureBackoff.MaxFailures = tt.maxFailures
+
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+			fakeRecorder := NewTestEventRecorder(20)
+			reconciler := &SecretReconciler{
+				Client:        fakeClient,
+				Scheme:        scheme,
+				Generator:     &failingGenerator{SecretGenerator: generator.NewSecretGenerator(), failures: tt.failures},
+				Config:        cfg,
+				EventRecorder: fakeRecorder,
+				Clock:         &MockClock{currentTime: time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)},
+			}
+			ctx := context.Background()
+			key := types.NamespacedName{Name: "flaky", Namespace: "default"}
+
+			for i, want := range tt.expectedRequeues {
+				result, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if result.RequeueAfter != want {
+					t.Errorf("failure %d: expected requeue after %s, got %s", i+1, want, result.RequeueAfter)
+				}
+
+				var updated corev1.Secret
+				if err := fakeClient.Get(ctx, key, &updated); err != nil {
+					t.Fatalf("failed to get secret: %v", err)
+				}
+				if got := defaultAnnotationKeys.getFailureCount(updated.Annotations); got != i+1 {
+					t.Errorf("failure %d: expected failure count %d, got %d", i+1, i+1, got)
+				}
+				if updated.Annotations[AnnotationStatus] != StatusError {
+					t.Errorf("failure %d: expected status %s, got %q", i+1, StatusError, updated.Annotations[AnnotationStatus])
+				}
+			}
+
+			// Only the first failure creates an event
+			failedEvents := 0
+			for len(fakeRecorder.Events) > 0 {
+				if strings.Contains(<-fakeRecorder.Events, EventReasonGenerationFailed) {
+					failedEvents++
+				}
+			}
+			if failedEvents != 1 {
+				t.Errorf("expected 1 GenerationFailed event, got %d", failedEvents)
+			}
+
+			if !tt.expectRecovery {
+				return
+			}
+			if _, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var updated corev1.Secret
+			if err := fakeClient.Get(ctx, key, &updated); err != nil {
+				t.Fatalf("failed to get secret: %v", err)
+			}
+			if len(updated.Data["password"]) == 0 {
+				t.Error("expected password to be generated after recovery")
+			}
+			if _, ok := updated.Annotations[AnnotationFailureCount]; ok {
+				t.Error("expected failure count to be cleared after recovery")
+			}
+			if updated.Annotations[AnnotationStatus] != StatusReady {
+				t.Errorf("expected status %s, got %q", StatusReady, updated.Annotations[AnnotationStatus])
+			}
+			recovered := false
+			for len(fakeRecorder.Events) > 0 {
+				if strings.Contains(<-fakeRecorder.Events, EventReasonGenerationRecovered) {
+					recovered = true
+				}
+			}
+			if !recovered {
+				t.Error("expected GenerationRecovered event")
+			}
+		})
+	}
+}
@@ -0,0 +1,128 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"time"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+)
+
+// splitBcryptFields separates the bcrypt fields, which hash another field instead of being
+// generated, from the generated fields
+func (r *SecretReconciler) splitBcryptFields(annotations map[string]string, fields []string) (generated, hashed []string) {
+	for _, field := range fields {
+		if r.getFieldType(annotations, field) == config.TypeBcrypt {
+			hashed = append(hashed, field)
+		} else {
+			generated = append(generated, field)
+		}
+	}
+	return generated, hashed
+}
+
+// htpasswdPasswordField returns the field holding the plaintext password of an htpasswd field
+func htpasswdPasswordField(field string) string {
+	return field + ".password"
+}
+
+// generateHtpasswdValue generates the htpasswd line of an htpasswd field for the user named by
+// htpasswd-user.<field>, with the plaintext password as a companion field. A rotation generates
+// a new password and line.
+func (r *SecretReconciler) generateHtpasswdValue(annotations map[string]string, field string) valueGenerationResult {
+	username := annotations[r.keys().HtpasswdUserPrefix+field]
+	if username == "" {
+		return fieldConfigError(field, "htpasswd user", fmt.Errorf("htpasswd field has no %s annotation", r.keys().HtpasswdUserPrefix+field))
+	}
+	line, password, err := r.Generator.GenerateHtpasswd(username)
+	if err != nil {
+		return fieldConfigError(field, "htpasswd user", err)
+	}
+	return valueGenerationResult{
+		value:      []byte(line),
+		companions: map[string][]byte{htpasswdPasswordField(field): []byte(password)},
+	}
+}
+
+// getFieldBcryptCost returns the bcrypt cost of a field (bcrypt-cost.<field>, default 10)
+func (k *annotationKeys) getFieldBcryptCost(annotations map[string]string, field string) (int, error) {
+	value, ok := annotations[k.BcryptCostPrefix+field]
+	if !ok {
+		return config.DefaultBcryptCost, nil
+	}
+	cost, err := strconv.Atoi(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid bcrypt cost %q", value)
+	}
+	return cost, nil
+}
+
+// hashBcryptFields computes the bcrypt fields of the Secret from their source fields. A hash is
+// computed when it has no value yet or its source was written in this reconcile (updated), so it
+// follows every generation and rotation of the source. It returns the fields it hashed.
+// Nothing is written on error.
+func (r *SecretReconciler) hashBcryptFields(annotations map[string]string, data map[string][]byte, hashed, generated, updated []string) ([]string, error) {
+	hashes := make(map[string][]byte, len(hashed))
+	gracePeriods := make(map[string]time.Duration, len(hashed))
+	var changed []string
+	for _, field := range hashed {
+		source := annotations[r.keys().BcryptSourcePrefix+field]
+		switch {
+		case source == "":
+			return nil, fmt.Errorf("bcrypt field %q has no %s annotation", field, r.keys().BcryptSourcePrefix+field)
+		case slices.Contains(hashed, source):
+			return nil, fmt.Errorf("source field %q of bcrypt field %q must not be a bcrypt field", source, field)
+		}
+		if hasFieldValue(data, field) && !slices.Contains(updated, source) {
+			continue
+		}
+		if !hasFieldValue(data, source) {
+			// The source is generated later, e.g. once the initial generation gate opens
+			if slices.Contains(generated, source) {
+				continue
+			}
+			return nil, fmt.Errorf("source field %q of bcrypt field %q has no value", source, field)
+		}
+		cost, err := r.keys().getFieldBcryptCost(annotations, field)
+		if err != nil {
+			return nil, fmt.Errorf("bcrypt field %q: %w", field, err)
+		}
+		gracePeriod, err := r.getFieldGracePeriod(annotations, field)
+		if err != nil {
+			return nil, fmt.Errorf("bcrypt field %q: %w", field, err)
+		}
+		hash, err := r.Generator.GenerateBcryptHash(string(data[source]), cost)
+		if err != nil {
+			return nil, fmt.Errorf("bcrypt field %q: %w", field, err)
+		}
+		hashes[field] = []byte(hash)
+		gracePeriods[field] = gracePeriod
+		changed = append(changed, field)
+	}
+
+	// A replaced hash is kept during the grace period like any rotated value
+	for _, field := range changed {
+		if hasFieldValue(data, field) {
+			r.keepPreviousValue(annotations, data, field, gracePeriods[field])
+		}
+		data[field] = hashes[field]
+	}
+	return changed, nil
+}
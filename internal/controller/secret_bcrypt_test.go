@@ -0,0 +1,349 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"golang.org/x/crypto/bcrypt"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/generator"
+)
+
+func TestReconcileBcryptFields(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	oldHash, err := bcrypt.GenerateFromPassword([]byte("old-password"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+
+	tests := []struct {
+		name         string
+		annotations  map[string]string
+		data         map[string][]byte
+		expectedCost int
+		expectedErr  string
+	}{
+		{
+			name: "hashed on generation",
+			annotations: map[string]string{
+				AnnotationAutogenerate:                         "password,password-hash",
+				AnnotationTypePrefix + "password-hash":         "bcrypt",
+				AnnotationBcryptSourcePrefix + "password-hash": "password",
+				AnnotationBcryptCostPrefix + "password-hash":   "4",
+			},
+			expectedCost: 4,
+		},
+		{
+			name: "default cost",
+			annotations: map[string]string{
+				AnnotationAutogenerate:                         "password-hash,password",
+				AnnotationTypePrefix + "password-hash":         "bcrypt",
+				AnnotationBcryptSourcePrefix + "password-hash": "password",
+			},
+			expectedCost: config.DefaultBcryptCost,
+		},
+		{
+			name: "re-hashed on rotation",
+			annotations: map[string]string{
+				AnnotationAutogenerate:                         "password,password-hash",
+				AnnotationGeneratedAt:                          "2026-02-01T10:00:00Z",
+				AnnotationRotate:                               "24h",
+				AnnotationTypePrefix + "password-hash":         "bcrypt",
+				AnnotationBcryptSourcePrefix + "password-hash": "password",
+				AnnotationBcryptCostPrefix + "password-hash":   "4",
+			},
+			data: map[string][]byte{
+				"password":      []byte("old-password"),
+				"password-hash": oldHash,
+			},
+			expectedCost: 4,
+		},
+		{
+			name: "hashes a field that isn't autogenerated",
+			annotations: map[string]string{
+				AnnotationAutogenerate:                         "password-hash",
+				AnnotationTypePrefix + "password-hash":         "bcrypt",
+				AnnotationBcryptSourcePrefix + "password-hash": "password",
+				AnnotationBcryptCostPrefix + "password-hash":   "4",
+			},
+			data:         map[string][]byte{"password": []byte("chosen-by-user")},
+			expectedCost: 4,
+		},
+		{
+			name: "missing source annotation",
+			annotations: map[string]string{
+				AnnotationAutogenerate:                 "password,password-hash",
+				AnnotationTypePrefix + "password-hash": "bcrypt",
+			},
+			expectedErr: "has no " + AnnotationBcryptSourcePrefix + "password-hash annotation",
+		},
+		{
+			name: "source without value",
+			annotations: map[string]string{
+				AnnotationAutogenerate:                         "password-hash",
+				AnnotationTypePrefix + "password-hash":         "bcrypt",
+				AnnotationBcryptSourcePrefix + "password-hash": "password",
+			},
+			expectedErr: `source field "password" of bcrypt field "password-hash" has no value`,
+		},
+		{
+			name: "invalid cost",
+			annotations: map[string]string{
+				AnnotationAutogenerate:                         "password,password-hash",
+				AnnotationTypePrefix + "password-hash":         "bcrypt",
+				AnnotationBcryptSourcePrefix + "password-hash": "password",
+				AnnotationBcryptCostPrefix + "password-hash":   "40",
+			},
+			expectedErr: "bcrypt cost must be between 4 and 31, got 40",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "hashed",
+					Namespace:   "default",
+					Annotations: tt.annotations,
+				},
+				Data: tt.data,
+			}
+
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+			fakeRecorder := NewTestEventRecorder(10)
+			reconciler := &SecretReconciler{
+				Client:        fakeClient,
+				Scheme:        scheme,
+				Generator:     generator.NewSecretGenerator(),
+				Config:        config.NewDefaultConfig(),
+				EventRecorder: fakeRecorder,
+				Clock:         &MockClock{currentTime: time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)},
+			}
+			ctx := context.Background()
+			key := types.NamespacedName{Name: "hashed", Namespace: "default"}
+
+			if _, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var updated corev1.Secret
+			if err := fakeClient.Get(ctx, key, &updated); err != nil {
+				t.Fatalf("failed to get secret: %v", err)
+			}
+
+			if tt.expectedErr != "" {
+				if _, ok := updated.Data["password-hash"]; ok {
+					t.Error("expected bcrypt field not to be written")
+				}
+				select {
+				case event := <-fakeRecorder.Events:
+					if !strings.Contains(event, EventReasonGenerationFailed) || !strings.Contains(event, tt.expectedErr) {
+						t.Errorf("expected generation failed event containing %q, got: %s", tt.expectedErr, event)
+					}
+				default:
+					t.Error("expected generation failed event to be recorded")
+				}
+				return
+			}
+
+			password := updated.Data["password"]
+			if tt.annotations[AnnotationRotate] != "" && string(password) == "old-password" {
+				t.Fatal("expected password to be rotated")
+			}
+			hash := updated.Data["password-hash"]
+			if err := bcrypt.CompareHashAndPassword(hash, password); err != nil {
+				t.Errorf("expected hash to match the password %q: %v", password, err)
+			}
+			if cost, err := bcrypt.Cost(hash); err != nil || cost != tt.expectedCost {
+				t.Errorf("expected cost %d, got %d (%v)", tt.expectedCost, cost, err)
+			}
+		})
+	}
+}
+
+func TestReconcileBcryptFieldKeptWithSource(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	hash, err := bcrypt.GenerateFromPassword([]byte("password"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("failed to hash password: %v", err)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "hashed",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationAutogenerate:                         "password,password-hash",
+				AnnotationGeneratedAt:                          "2026-02-02T10:00:00Z",
+				AnnotationTypePrefix + "password-hash":         "bcrypt",
+				AnnotationBcryptSourcePrefix + "password-hash": "password",
+				// rotate annotations don't apply to bcrypt fields, they follow their source
+				AnnotationRotatePrefix + "password-hash": "1h",
+			},
+		},
+		Data: map[string][]byte{
+			"password":      []byte("password"),
+			"password-hash": hash,
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	reconciler := &SecretReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Generator:     generator.NewSecretGenerator(),
+		Config:        config.NewDefaultConfig(),
+		EventRecorder: NewTestEventRecorder(10),
+		Clock:         &MockClock{currentTime: time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)},
+	}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "hashed", Namespace: "default"}
+
+	result, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.RequeueAfter != 0 {
+		t.Errorf("expected no requeue, got %s", result.RequeueAfter)
+	}
+
+	var updated corev1.Secret
+	if err := fakeClient.Get(ctx, key, &updated); err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+	if string(updated.Data["password-hash"]) != string(hash) {
+		t.Error("expected hash of an unchanged source to be kept")
+	}
+}
+
+func TestReconcileHtpasswdField(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "basic-auth",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationAutogenerate:                "auth",
+				AnnotationTypePrefix + "auth":         "htpasswd",
+				AnnotationHtpasswdUserPrefix + "auth": "admin",
+				AnnotationRotatePrefix + "auth":       "1h",
+			},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	clock := &MockClock{currentTime: time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)}
+	reconciler := &SecretReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Generator:     generator.NewSecretGenerator(),
+		Config:        config.NewDefaultConfig(),
+		EventRecorder: NewTestEventRecorder(10),
+		Clock:         clock,
+	}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "basic-auth", Namespace: "default"}
+
+	// reconcileLine reconciles the Secret and returns its verified htpasswd line and password
+	reconcileLine := func() (string, string) {
+		t.Helper()
+		if _, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var updated corev1.Secret
+		if err := fakeClient.Get(ctx, key, &updated); err != nil {
+			t.Fatalf("failed to get secret: %v", err)
+		}
+		line, password := string(updated.Data["auth"]), string(updated.Data["auth.password"])
+		user, hash, ok := strings.Cut(line, ":")
+		if !ok || user != "admin" {
+			t.Fatalf("expected an htpasswd line for user admin, got %q", line)
+		}
+		if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(password)); err != nil {
+			t.Errorf("expected hash to match the stored password: %v", err)
+		}
+		return line, password
+	}
+
+	line, password := reconcileLine()
+
+	// A rotation generates a new password and regenerates the line
+	clock.currentTime = clock.currentTime.Add(2 * time.Hour)
+	rotatedLine, rotatedPassword := reconcileLine()
+	if rotatedPassword == password || rotatedLine == line {
+		t.Error("expected the password and line to change on rotation")
+	}
+}
+
+func TestReconcileHtpasswdFieldWithoutUser(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "basic-auth",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationAutogenerate:        "auth",
+				AnnotationTypePrefix + "auth": "htpasswd",
+			},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	recorder := NewTestEventRecorder(10)
+	reconciler := &SecretReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Generator:     generator.NewSecretGenerator(),
+		Config:        config.NewDefaultConfig(),
+		EventRecorder: recorder,
+	}
+	ctx := context.Background()
+	key := types.NamespacedName{Name: "basic-auth", Namespace: "default"}
+
+	if _, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: key}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var updated corev1.Secret
+	if err := fakeClient.Get(ctx, key, &updated); err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+	if _, ok := updated.Data["auth"]; ok {
+		t.Error("expected no htpasswd line without a user")
+	}
+	if !hasEventWithPrefix(drainEvents(recorder), "Warning "+EventReasonGenerationFailed) {
+		t.Error("expected a GenerationFailed event")
+	}
+}
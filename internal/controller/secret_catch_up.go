@@ -0,0 +1,55 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+	"time"
+)
+
+// catchUpThreshold is how many rotation intervals a rotation has to be overdue for to count as
+// missed during a downtime of the operator
+const catchUpThreshold = 2
+
+// catchUpStart remembers when the reconciler handled its first Secret
+type catchUpStart struct {
+	once sync.Once
+	at   time.Time
+}
+
+// startedAt returns now at the first call, which Reconcile makes, and that time afterwards
+func (s *catchUpStart) startedAt(now time.Time) time.Time {
+	s.once.Do(func() { s.at = now })
+	return s.at
+}
+
+// catchUpDelay returns how long a due rotation waits to catch up after a downtime, or nil if it
+// can happen now. Rotations more than catchUpThreshold intervals overdue are spread over the first
+// rotation.maxCatchUpDelay after the start, at a stable point per Secret (key is namespace/name),
+// so they don't all happen at once. Afterwards, overdue rotations happen right away.
+func (r *SecretReconciler) catchUpDelay(key string, now time.Time, sinceGeneration, interval time.Duration) *time.Duration {
+	maxDelay := r.currentConfig().Rotation.MaxCatchUpDelay.Duration()
+	if maxDelay <= 0 || sinceGeneration <= catchUpThreshold*interval {
+		return nil
+	}
+	rotateAt := r.state().catchUp.startedAt(now).Add(spreadOffset(key, maxDelay))
+	if !now.Before(rotateAt) {
+		return nil
+	}
+	delay := rotateAt.Sub(now)
+	return &delay
+}
@@ -0,0 +1,148 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/generator"
+)
+
+// newOverdueSecret returns a Secret with an hourly rotating password generated generatedAgo before now
+func newOverdueSecret(name string, now time.Time, generatedAgo time.Duration) *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationAutogenerate:  "password",
+				AnnotationRotate:        "1h",
+				AnnotationGeneratedAt:   now.Add(-generatedAgo).Format(time.RFC3339),
+				AnnotationManagedFields: "password",
+			},
+		},
+		Data: map[string][]byte{"password": []byte("old-password")},
+	}
+}
+
+func TestReconcileCatchUpOverdueRotation(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	// The operator was down for 10h, the password rotates hourly
+	now := time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)
+	overdue := newOverdueSecret("overdue", now, 10*time.Hour)
+	late := newOverdueSecret("late", now, 90*time.Minute)
+
+	cfg := config.NewDefaultConfig()
+	cfg.Rotation.MaxCatchUpDelay = config.Duration(30 * time.Minute)
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(overdue, late).Build()
+	clock := &MockClock{currentTime: now}
+	reconciler := &SecretReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Generator:     generator.NewSecretGenerator(),
+		Config:        cfg,
+		EventRecorder: NewTestEventRecorder(10),
+		Clock:         clock,
+	}
+	ctx := context.Background()
+	password := func(secret *corev1.Secret) string {
+		var current corev1.Secret
+		if err := fakeClient.Get(ctx, client.ObjectKeyFromObject(secret), &current); err != nil {
+			t.Fatalf("failed to get secret: %v", err)
+		}
+		return string(current.Data["password"])
+	}
+
+	// The long overdue rotation waits for its delay within the bound
+	result, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(overdue)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	want := spreadOffset(secretKey(overdue), 30*time.Minute)
+	if want == 0 {
+		t.Fatal("expected a test Secret with a catch-up delay")
+	}
+	if result.RequeueAfter != want {
+		t.Errorf("expected requeue after the catch-up delay %s, got %s", want, result.RequeueAfter)
+	}
+	if result.RequeueAfter > 30*time.Minute {
+		t.Errorf("expected the catch-up delay to be bounded by 30m, got %s", result.RequeueAfter)
+	}
+	if password(overdue) != "old-password" {
+		t.Error("expected the overdue rotation to wait for its catch-up delay")
+	}
+
+	// Rotations overdue by less than two intervals aren't delayed
+	if _, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(late)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if password(late) == "old-password" {
+		t.Error("expected a rotation overdue by less than two intervals to happen right away")
+	}
+
+	// At the requeue, the overdue rotation happens
+	clock.currentTime = clock.currentTime.Add(result.RequeueAfter)
+	result, err = reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: client.ObjectKeyFromObject(overdue)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if password(overdue) == "old-password" {
+		t.Error("expected the overdue rotation to happen after its catch-up delay")
+	}
+	// generated-at has second precision
+	if result.RequeueAfter <= time.Hour-time.Second || result.RequeueAfter > time.Hour {
+		t.Errorf("expected the next rotation in 1h, got %s", result.RequeueAfter)
+	}
+}
+
+func TestCatchUpDelay(t *testing.T) {
+	start := time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)
+	cfg := config.NewDefaultConfig()
+	cfg.Rotation.MaxCatchUpDelay = config.Duration(time.Hour)
+	reconciler := &SecretReconciler{Config: cfg}
+	reconciler.catchUp.startedAt(start)
+
+	key := "default/overdue"
+	offset := spreadOffset(key, time.Hour)
+	if got := reconciler.catchUpDelay(key, start, 3*time.Hour, time.Hour); got == nil || *got != offset {
+		t.Errorf("expected a catch-up delay of %s at the start, got %v", offset, got)
+	}
+	if got := reconciler.catchUpDelay(key, start, 2*time.Hour, time.Hour); got != nil {
+		t.Errorf("expected no delay for a rotation overdue by two intervals, got %s", *got)
+	}
+	if got := reconciler.catchUpDelay(key, start.Add(time.Hour), 3*time.Hour, time.Hour); got != nil {
+		t.Errorf("expected no delay after the catch-up period, got %s", *got)
+	}
+
+	cfg.Rotation.MaxCatchUpDelay = 0
+	if got := reconciler.catchUpDelay(key, start, 3*time.Hour, time.Hour); got != nil {
+		t.Errorf("expected no delay with maxCatchUpDelay 0, got %s", *got)
+	}
+}
@@ -0,0 +1,173 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/generator"
+)
+
+// getFieldCertValidity returns the validity period of a certificate field
+func (r *SecretReconciler) getFieldCertValidity(annotations map[string]string, field string) (time.Duration, error) {
+	value := getFieldAnnotation(annotations, r.keys().CertValidity, field)
+	if value == "" {
+		return config.DefaultCertificateValidity, nil
+	}
+	validity, err := config.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid cert-validity %q: %w", value, err)
+	}
+	if validity <= 0 {
+		return 0, fmt.Errorf("cert-validity must be positive, got %s", value)
+	}
+	return validity, nil
+}
+
+// certReissueInterval returns after how long a certificate with the given validity is reissued.
+// Certificates are reissued after two thirds of their validity, leaving consumers time to reload
+// them even if the reissue is deferred to a maintenance window.
+func certReissueInterval(validity time.Duration) time.Duration {
+	return validity * 2 / 3
+}
+
+// certKeyField returns the field receiving the private key of a certificate field.
+// Priority: cert-key-field.<field> annotation > <field>.key
+func (k *annotationKeys) certKeyField(annotations map[string]string, field string) string {
+	if v := annotations[k.CertKeyFieldPrefix+field]; v != "" {
+		return v
+	}
+	return field + ".key"
+}
+
+// getFieldCertCASecret returns the name of the Secret holding the CA that signs a certificate
+// field, or "" if the field is self-signed. ca-signed mode requires cert-ca-secret.
+func (r *SecretReconciler) getFieldCertCASecret(annotations map[string]string, field string) (string, error) {
+	mode := getFieldAnnotation(annotations, r.keys().CertMode, field)
+	if mode == "" {
+		mode = config.DefaultCertMode
+	}
+	switch mode {
+	case config.CertModeSelfSigned:
+		return "", nil
+	case config.CertModeCASigned:
+		caSecret := getFieldAnnotation(annotations, r.keys().CertCASecret, field)
+		if caSecret == "" {
+			return "", fmt.Errorf("cert-mode %s requires cert-ca-secret", mode)
+		}
+		return caSecret, nil
+	default:
+		return "", fmt.Errorf("unknown cert-mode %q (valid: %s, %s)", mode, config.CertModeSelfSigned, config.CertModeCASigned)
+	}
+}
+
+// certificateRequest builds the certificate of a certificate field from its cert-* annotations
+func (r *SecretReconciler) certificateRequest(annotations map[string]string, field string) (generator.CertificateRequest, error) {
+	validity, err := r.getFieldCertValidity(annotations, field)
+	if err != nil {
+		return generator.CertificateRequest{}, err
+	}
+	keyUsageNames := getFieldAnnotation(annotations, r.keys().CertKeyUsage, field)
+	if keyUsageNames == "" {
+		keyUsageNames = config.DefaultCertificateKeyUsage
+	}
+	keyUsage, err := generator.ParseKeyUsages(parseFields(keyUsageNames))
+	if err != nil {
+		return generator.CertificateRequest{}, err
+	}
+	usageNames := getFieldAnnotation(annotations, r.keys().CertUsage, field)
+	if usageNames == "" {
+		usageNames = config.DefaultCertificateUsage
+	}
+	extKeyUsage, err := generator.ParseExtKeyUsages(parseFields(usageNames))
+	if err != nil {
+		return generator.CertificateRequest{}, err
+	}
+	ipAddresses, err := generator.ParseIPAddresses(parseFields(getFieldAnnotation(annotations, r.keys().CertIP, field)))
+	if err != nil {
+		return generator.CertificateRequest{}, err
+	}
+	uris, err := generator.ParseURIs(parseFields(getFieldAnnotation(annotations, r.keys().CertURI, field)))
+	if err != nil {
+		return generator.CertificateRequest{}, err
+	}
+	return generator.CertificateRequest{
+		CommonName:  getFieldAnnotation(annotations, r.keys().CertCommonName, field),
+		DNSNames:    parseFields(getFieldAnnotation(annotations, r.keys().CertDNS, field)),
+		IPAddresses: ipAddresses,
+		URIs:        uris,
+		KeyUsage:    keyUsage,
+		ExtKeyUsage: extKeyUsage,
+		NotBefore:   r.now(),
+		Validity:    validity,
+	}, nil
+}
+
+// generateCertificateValue generates a certificate for a certificate field of an object in
+// namespace, with its private key as a companion field. The certificate is self-signed, or in
+// ca-signed mode signed by the CA in the tls.crt and tls.key fields of the cert-ca-secret Secret
+// in the same namespace. A rotation generates a new key and certificate.
+func (r *SecretReconciler) generateCertificateValue(ctx context.Context, namespace string, annotations map[string]string, field string) valueGenerationResult {
+	req, err := r.certificateRequest(annotations, field)
+	if err != nil {
+		return fieldConfigError(field, "certificate configuration", err)
+	}
+	keyField := r.keys().certKeyField(annotations, field)
+	if keyField == field {
+		return fieldConfigError(field, "certificate configuration", fmt.Errorf("key field must differ from the field itself"))
+	}
+	caSecretName, err := r.getFieldCertCASecret(annotations, field)
+	if err != nil {
+		return fieldConfigError(field, "certificate configuration", err)
+	}
+
+	if caSecretName != "" {
+		var caSecret corev1.Secret
+		if err := r.Get(ctx, client.ObjectKey{Namespace: namespace, Name: caSecretName}, &caSecret); err != nil {
+			return valueGenerationResult{
+				err:    fmt.Errorf("failed to get CA secret %s for field %s: %w", caSecretName, field, err),
+				errMsg: fmt.Sprintf("Failed to get CA secret %q for field %q: %v", caSecretName, field, err),
+			}
+		}
+		req.CACertificatePEM = string(caSecret.Data[corev1.TLSCertKey])
+		req.CAKeyPEM = string(caSecret.Data[corev1.TLSPrivateKeyKey])
+		if req.CACertificatePEM == "" || req.CAKeyPEM == "" {
+			return valueGenerationResult{
+				err:    fmt.Errorf("CA secret %s for field %s has no %s and %s", caSecretName, field, corev1.TLSCertKey, corev1.TLSPrivateKeyKey),
+				errMsg: fmt.Sprintf("CA secret %q for field %q has no %s and %s", caSecretName, field, corev1.TLSCertKey, corev1.TLSPrivateKeyKey),
+			}
+		}
+	}
+
+	certificatePEM, privateKeyPEM, err := r.Generator.GenerateCertificate(req)
+	if err != nil {
+		return valueGenerationResult{
+			err:    fmt.Errorf("failed to generate certificate for field %s: %w", field, err),
+			errMsg: fmt.Sprintf("Failed to generate certificate for field %q: %v", field, err),
+		}
+	}
+	return valueGenerationResult{
+		value:      []byte(certificatePEM),
+		companions: map[string][]byte{keyField: []byte(privateKeyPEM)},
+	}
+}
@@ -0,0 +1,405 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/generator"
+)
+
+// parseSecretCertificate parses the PEM certificate in field of data and checks that the private
+// key in keyField belongs to it
+func parseSecretCertificate(t *testing.T, data map[string][]byte, field, keyField string) *x509.Certificate {
+	t.Helper()
+	block, _ := pem.Decode(data[field])
+	if block == nil || block.Type != "CERTIFICATE" {
+		t.Fatalf("expected a PEM certificate in field %s, got %q", field, data[field])
+	}
+	cert, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		t.Fatalf("failed to parse certificate: %v", err)
+	}
+	block, _ = pem.Decode(data[keyField])
+	if block == nil {
+		t.Fatalf("expected a PEM private key in field %s, got %q", keyField, data[keyField])
+	}
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		t.Fatalf("failed to parse private key: %v", err)
+	}
+	if privateKey, ok := key.(*ecdsa.PrivateKey); !ok || !privateKey.PublicKey.Equal(cert.PublicKey) {
+		t.Fatalf("expected the private key in field %s to belong to the certificate", keyField)
+	}
+	return cert
+}
+
+func TestReconcileCertificate(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name            string
+		annotations     map[string]string
+		field, keyField string
+		wantKeyUsage    x509.KeyUsage
+		wantExtKeyUsage []x509.ExtKeyUsage
+	}{
+		{
+			name: "default usages",
+			annotations: map[string]string{
+				AnnotationAutogenerate: "cert",
+				AnnotationType:         "certificate",
+			},
+			field:           "cert",
+			keyField:        "cert.key",
+			wantKeyUsage:    x509.KeyUsageDigitalSignature,
+			wantExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		},
+		{
+			name: "server and client usages",
+			annotations: map[string]string{
+				AnnotationAutogenerate:                   "tls.crt",
+				AnnotationTypePrefix + "tls.crt":         "certificate",
+				AnnotationCertKeyFieldPrefix + "tls.crt": "tls.key",
+				AnnotationCertKeyUsage:                   "digital-signature,key-encipherment",
+				AnnotationCertUsage + ".tls.crt":         "server,client",
+			},
+			field:           "tls.crt",
+			keyField:        "tls.key",
+			wantKeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+			wantExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		},
+		{
+			name: "client usage only",
+			annotations: map[string]string{
+				AnnotationAutogenerate: "cert",
+				AnnotationType:         "certificate",
+				AnnotationCertUsage:    "client",
+			},
+			field:           "cert",
+			keyField:        "cert.key",
+			wantKeyUsage:    x509.KeyUsageDigitalSignature,
+			wantExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageClientAuth},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.annotations[AnnotationCertCommonName] = "api.example.com"
+			tt.annotations[AnnotationCertDNS] = "api.example.com,api"
+			tt.annotations[AnnotationCertIP] = "10.0.0.1, fd00::1"
+			tt.annotations[AnnotationCertURI] = "spiffe://cluster.local/ns/default/sa/api"
+			tt.annotations[AnnotationCertValidity] = "30d"
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "tls", Namespace: "default", Annotations: tt.annotations},
+			}
+
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+			now := time.Date(2026, 2, 2, 10, 0, 0, 0, time.UTC)
+			clock := &MockClock{currentTime: now}
+			reconciler := &SecretReconciler{
+				Client:        fakeClient,
+				Scheme:        scheme,
+				Generator:     generator.NewSecretGenerator(),
+				Config:        config.NewDefaultConfig(),
+				EventRecorder: NewTestEventRecorder(10),
+				Clock:         clock,
+			}
+			ctx := context.Background()
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}}
+
+			result, err := reconciler.Reconcile(ctx, req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			// The certificate is reissued after two thirds of its validity
+			if result.RequeueAfter != 20*24*time.Hour {
+				t.Errorf("expected requeue after 480h, got %s", result.RequeueAfter)
+			}
+
+			var updated corev1.Secret
+			if err := fakeClient.Get(ctx, req.NamespacedName, &updated); err != nil {
+				t.Fatalf("failed to get secret: %v", err)
+			}
+			cert := parseSecretCertificate(t, updated.Data, tt.field, tt.keyField)
+			if cert.KeyUsage != tt.wantKeyUsage {
+				t.Errorf("expected key usage %v, got %v", tt.wantKeyUsage, cert.KeyUsage)
+			}
+			if !slices.Equal(cert.ExtKeyUsage, tt.wantExtKeyUsage) {
+				t.Errorf("expected extended key usages %v, got %v", tt.wantExtKeyUsage, cert.ExtKeyUsage)
+			}
+			if cert.Subject.CommonName != "api.example.com" || !slices.Equal(cert.DNSNames, []string{"api.example.com", "api"}) {
+				t.Errorf("unexpected subject %q and DNS names %v", cert.Subject.CommonName, cert.DNSNames)
+			}
+			if len(cert.IPAddresses) != 2 || !cert.IPAddresses[0].Equal(net.ParseIP("10.0.0.1")) || !cert.IPAddresses[1].Equal(net.ParseIP("fd00::1")) {
+				t.Errorf("expected IP SANs 10.0.0.1 and fd00::1, got %v", cert.IPAddresses)
+			}
+			if len(cert.URIs) != 1 || cert.URIs[0].String() != "spiffe://cluster.local/ns/default/sa/api" {
+				t.Errorf("expected the SPIFFE ID as URI SAN, got %v", cert.URIs)
+			}
+			if !cert.NotAfter.Equal(now.Add(30 * 24 * time.Hour)) {
+				t.Errorf("expected the certificate to expire at %s, got %s", now.Add(30*24*time.Hour), cert.NotAfter)
+			}
+			if err := cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature); err != nil {
+				t.Errorf("expected a self-signed certificate: %v", err)
+			}
+			if managed := updated.Annotations[AnnotationManagedFields]; !strings.Contains(managed, tt.keyField) {
+				t.Errorf("expected key field %s to be managed, got %q", tt.keyField, managed)
+			}
+
+			// Reissue before expiry with a new key
+			clock.currentTime = now.Add(20 * 24 * time.Hour)
+			if _, err := reconciler.Reconcile(ctx, req); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var reissued corev1.Secret
+			if err := fakeClient.Get(ctx, req.NamespacedName, &reissued); err != nil {
+				t.Fatalf("failed to get secret: %v", err)
+			}
+			reissuedCert := parseSecretCertificate(t, reissued.Data, tt.field, tt.keyField)
+			if reissuedCert.SerialNumber.Cmp(cert.SerialNumber) == 0 || string(reissued.Data[tt.keyField]) == string(updated.Data[tt.keyField]) {
+				t.Error("expected a new certificate and key before expiry")
+			}
+		})
+	}
+}
+
+func TestReconcileCertificateMode(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	now := time.Date(2026, 2, 2, 10, 0, 0, 0, time.UTC)
+	caPEM, caKeyPEM, err := generator.NewSecretGenerator().GenerateCertificate(generator.CertificateRequest{
+		CommonName: "Internal CA",
+		KeyUsage:   x509.KeyUsageCertSign,
+		NotBefore:  now,
+		Validity:   365 * 24 * time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("failed to generate CA: %v", err)
+	}
+	caBlock, _ := pem.Decode([]byte(caPEM))
+	ca, err := x509.ParseCertificate(caBlock.Bytes)
+	if err != nil {
+		t.Fatalf("failed to parse CA: %v", err)
+	}
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantCA      bool
+	}{
+		{
+			name:        "self-signed by default",
+			annotations: map[string]string{AnnotationCertCASecret: "internal-ca"},
+		},
+		{
+			name:        "self-signed",
+			annotations: map[string]string{AnnotationCertMode: "self-signed"},
+		},
+		{
+			name:        "ca-signed",
+			annotations: map[string]string{AnnotationCertMode: "ca-signed", AnnotationCertCASecret: "internal-ca"},
+			wantCA:      true,
+		},
+		{
+			name: "ca-signed for the field",
+			annotations: map[string]string{
+				AnnotationCertMode:               "self-signed",
+				AnnotationCertMode + ".cert":     "ca-signed",
+				AnnotationCertCASecret + ".cert": "internal-ca",
+			},
+			wantCA: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			tt.annotations[AnnotationAutogenerate] = "cert"
+			tt.annotations[AnnotationType] = "certificate"
+			tt.annotations[AnnotationCertDNS] = "api.default.svc"
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "tls", Namespace: "default", Annotations: tt.annotations},
+			}
+			caSecret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "internal-ca", Namespace: "default"},
+				Data:       map[string][]byte{corev1.TLSCertKey: []byte(caPEM), corev1.TLSPrivateKeyKey: []byte(caKeyPEM)},
+			}
+
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret, caSecret).Build()
+			reconciler := &SecretReconciler{
+				Client:        fakeClient,
+				Scheme:        scheme,
+				Generator:     generator.NewSecretGenerator(),
+				Config:        config.NewDefaultConfig(),
+				EventRecorder: NewTestEventRecorder(10),
+				Clock:         &MockClock{currentTime: now},
+			}
+			ctx := context.Background()
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}}
+
+			if _, err := reconciler.Reconcile(ctx, req); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var updated corev1.Secret
+			if err := fakeClient.Get(ctx, req.NamespacedName, &updated); err != nil {
+				t.Fatalf("failed to get secret: %v", err)
+			}
+			cert := parseSecretCertificate(t, updated.Data, "cert", "cert.key")
+			selfSignatureErr := cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature)
+			if !tt.wantCA {
+				if selfSignatureErr != nil {
+					t.Errorf("expected a self-signed certificate: %v", selfSignatureErr)
+				}
+				return
+			}
+
+			if selfSignatureErr == nil {
+				t.Error("expected the certificate not to be self-signed")
+			}
+			roots := x509.NewCertPool()
+			roots.AddCert(ca)
+			opts := x509.VerifyOptions{Roots: roots, DNSName: "api.default.svc", CurrentTime: now.Add(time.Hour)}
+			if _, err := cert.Verify(opts); err != nil {
+				t.Errorf("expected a certificate signed by the CA: %v", err)
+			}
+		})
+	}
+}
+
+func TestReconcileCertificateInvalidConfiguration(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		wantMsg     string
+	}{
+		{
+			name:        "unknown key usage",
+			annotations: map[string]string{AnnotationCertKeyUsage: "digital-signature,server"},
+			wantMsg:     `unknown key usage "server"`,
+		},
+		{
+			name:        "unknown extended key usage",
+			annotations: map[string]string{AnnotationCertUsage: "server,ca"},
+			wantMsg:     `unknown extended key usage "ca"`,
+		},
+		{
+			name:        "invalid validity",
+			annotations: map[string]string{AnnotationCertValidity: "forever"},
+			wantMsg:     "invalid cert-validity",
+		},
+		{
+			name:        "invalid IP address",
+			annotations: map[string]string{AnnotationCertIP + ".cert": "10.0.0.1,10.0.0.256"},
+			wantMsg:     `invalid IP address "10.0.0.256"`,
+		},
+		{
+			name:        "relative URI",
+			annotations: map[string]string{AnnotationCertURI: "cluster.local/ns/default"},
+			wantMsg:     `invalid URI "cluster.local/ns/default": must be absolute`,
+		},
+		{
+			name:        "key field is the field itself",
+			annotations: map[string]string{AnnotationCertKeyFieldPrefix + "cert": "cert"},
+			wantMsg:     "key field must differ",
+		},
+		{
+			name:        "unknown mode",
+			annotations: map[string]string{AnnotationCertMode: "ca"},
+			wantMsg:     `unknown cert-mode "ca"`,
+		},
+		{
+			name:        "ca-signed without CA secret",
+			annotations: map[string]string{AnnotationCertMode + ".cert": "ca-signed"},
+			wantMsg:     "cert-mode ca-signed requires cert-ca-secret",
+		},
+		{
+			name:        "missing CA secret",
+			annotations: map[string]string{AnnotationCertMode: "ca-signed", AnnotationCertCASecret: "internal-ca"},
+			wantMsg:     `Failed to get CA secret "internal-ca"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotations := map[string]string{
+				AnnotationAutogenerate: "cert",
+				AnnotationType:         "certificate",
+			}
+			for k, v := range tt.annotations {
+				annotations[k] = v
+			}
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Name: "tls", Namespace: "default", Annotations: annotations},
+			}
+
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+			fakeRecorder := NewTestEventRecorder(10)
+			reconciler := &SecretReconciler{
+				Client:        fakeClient,
+				Scheme:        scheme,
+				Generator:     generator.NewSecretGenerator(),
+				Config:        config.NewDefaultConfig(),
+				EventRecorder: fakeRecorder,
+			}
+
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}}
+			if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			select {
+			case event := <-fakeRecorder.Events:
+				if !strings.Contains(event, EventReasonGenerationFailed) || !strings.Contains(event, tt.wantMsg) {
+					t.Errorf("expected generation failed event containing %q, got: %s", tt.wantMsg, event)
+				}
+			default:
+				t.Error("expected generation failed event to be recorded")
+			}
+
+			var updated corev1.Secret
+			if err := fakeClient.Get(context.Background(), req.NamespacedName, &updated); err != nil {
+				t.Fatalf("failed to get secret: %v", err)
+			}
+			if len(updated.Data) != 0 {
+				t.Errorf("expected no data to be written, got %v", updated.Data)
+			}
+		})
+	}
+}
@@ -19,18 +19,28 @@ package controller
 
 import (
 	"context"
+	"encoding/json"
 	"fmt"
+	"hash/fnv"
+	"net/http"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/go-logr/logr"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/tools/events"
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/controller"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/log"
+	"sigs.k8s.io/controller-runtime/pkg/manager"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
 
 	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
@@ -68,15 +78,198 @@ const (
 	// AnnotationParamPrefix is the prefix for field-specific param annotations (param.<field>)
 	AnnotationParamPrefix = AnnotationPrefix + "param."
 
+	// AnnotationKeyEncoding specifies the default output encoding (pem, der) for PEM keypair types
+	AnnotationKeyEncoding = AnnotationPrefix + "key-encoding"
+
+	// AnnotationKeyEncodingPrefix is the prefix for field-specific key encoding annotations (key-encoding.<field>)
+	AnnotationKeyEncodingPrefix = AnnotationPrefix + "key-encoding."
+
+	// AnnotationPublicKeyFieldPrefix is the prefix for annotations naming the field that receives
+	// the public key of a keypair field (public-key-field.<field>, default <field>.pub)
+	AnnotationPublicKeyFieldPrefix = AnnotationPrefix + "public-key-field."
+
+	// AnnotationJWKFieldPrefix is the prefix for annotations naming the field that receives the
+	// public key of an rsa, ecdsa, or ed25519 field as a JWK (jwk-field.<field>)
+	AnnotationJWKFieldPrefix = AnnotationPrefix + "jwk-field."
+
+	// AnnotationVersionFieldPrefix is the prefix for annotations naming the field that receives a
+	// counter incremented with each new value of a field (version-field.<field>), so consumers can
+	// order values
+	AnnotationVersionFieldPrefix = AnnotationPrefix + "version-field."
+
+	// AnnotationKeyFormatPrefix is the prefix for annotations selecting the private key format
+	// (pkcs1, pkcs8) of an rsa field (key-format.<field>)
+	AnnotationKeyFormatPrefix = AnnotationPrefix + "key-format."
+
+	// AnnotationKeyPassphraseFieldPrefix is the prefix for annotations naming the field holding the
+	// passphrase that encrypts the private key of an rsa or ecdsa field (key-passphrase-field.<field>)
+	AnnotationKeyPassphraseFieldPrefix = AnnotationPrefix + "key-passphrase-field."
+
+	// AnnotationValuePrefixPrefix is the prefix for annotations with a fixed string prepended to the
+	// generated value of a string or bytes field (prefix.<field>). It doesn't count towards the length.
+	AnnotationValuePrefixPrefix = AnnotationPrefix + "prefix."
+
+	// AnnotationValueSuffixPrefix is the prefix for annotations with a fixed string appended to the
+	// generated value of a string or bytes field (suffix.<field>). It doesn't count towards the length.
+	AnnotationValueSuffixPrefix = AnnotationPrefix + "suffix."
+
+	// AnnotationEncoding specifies the default output encoding (raw, hex, base64) for bytes fields
+	AnnotationEncoding = AnnotationPrefix + "encoding"
+
+	// AnnotationEncodingPrefix is the prefix for field-specific encoding annotations (encoding.<field>)
+	AnnotationEncodingPrefix = AnnotationPrefix + "encoding."
+
+	// AnnotationCompanionEncodingsPrefix is the prefix for annotations listing encodings of a bytes
+	// field to store in companion fields (companion-encodings.<field>), e.g. "base64" writes
+	// <field>.base64 from the same random bytes as the field itself
+	AnnotationCompanionEncodingsPrefix = AnnotationPrefix + "companion-encodings."
+
+	// AnnotationOutputLengthPrefix is the prefix for annotations with the exact number of characters
+	// of an encoded bytes field (output-length.<field>). The number of random bytes is derived from it.
+	AnnotationOutputLengthPrefix = AnnotationPrefix + "output-length."
+
+	// AnnotationCountPrefix is the prefix for annotations making a field hold a JSON array of
+	// independently generated values (count.<field>), e.g. several API keys
+	AnnotationCountPrefix = AnnotationPrefix + "count."
+
+	// AnnotationRotateStrategyPrefix is the prefix for annotations selecting how a list field is
+	// rotated (rotate-strategy.<field>): all values at once or, with round-robin, the oldest one
+	AnnotationRotateStrategyPrefix = AnnotationPrefix + "rotate-strategy."
+
+	// AnnotationImmutableValuePrefix is the prefix for annotations ("true") making a field
+	// generate-once (immutable-value.<field>): it is generated if missing but never rotated,
+	// whatever its rotate annotations say. Unrelated to immutable Secrets.
+	AnnotationImmutableValuePrefix = AnnotationPrefix + "immutable-value."
+
+	// AnnotationTemplatePrefix is the prefix for annotations with a Go text/template composing a
+	// field from other fields of the Secret (template.<field>), e.g. "{{ .username }}:{{ .password }}"
+	AnnotationTemplatePrefix = AnnotationPrefix + "template."
+
+	// AnnotationWordsPrefix is the prefix for annotations with the number of words of a passphrase
+	// field (words.<field>)
+	AnnotationWordsPrefix = AnnotationPrefix + "words."
+
+	// AnnotationSeparatorPrefix is the prefix for annotations with the separator between the words
+	// of a passphrase field (separator.<field>)
+	AnnotationSeparatorPrefix = AnnotationPrefix + "separator."
+
+	// AnnotationBcryptSourcePrefix is the prefix for annotations naming the field whose value a
+	// bcrypt field hashes (bcrypt-source.<field>). The hash follows the source on every generation.
+	AnnotationBcryptSourcePrefix = AnnotationPrefix + "bcrypt-source."
+
+	// AnnotationBcryptCostPrefix is the prefix for annotations with the cost factor of a bcrypt field
+	// (bcrypt-cost.<field>)
+	AnnotationBcryptCostPrefix = AnnotationPrefix + "bcrypt-cost."
+
+	// AnnotationHtpasswdUserPrefix is the prefix for annotations with the user name of an htpasswd
+	// field (htpasswd-user.<field>)
+	AnnotationHtpasswdUserPrefix = AnnotationPrefix + "htpasswd-user."
+
 	// AnnotationGeneratedAt indicates when the value was generated
 	AnnotationGeneratedAt = AnnotationPrefix + "generated-at"
 
+	// AnnotationExistingValues specifies how values already present before the first generation are
+	// handled (ignore, adopt). Adopted values get a generated-at baseline, so their rotation starts.
+	AnnotationExistingValues = AnnotationPrefix + "existing-values"
+
 	// AnnotationRotate specifies the default rotation interval for all fields
 	AnnotationRotate = AnnotationPrefix + "rotate"
 
 	// AnnotationRotatePrefix is the prefix for field-specific rotation annotations (rotate.<field>)
 	AnnotationRotatePrefix = AnnotationPrefix + "rotate."
 
+	// AnnotationConsumer names the workload ("<kind>/<name>", e.g. "deployment/my-app") consuming the
+	// Secret. Rotation events reference it as related object, so consumers can correlate them.
+	AnnotationConsumer = AnnotationPrefix + "consumer"
+
+	// AnnotationRotateOffsetPrefix is the prefix for field-specific rotation offset annotations (rotate-offset.<field>)
+	AnnotationRotateOffsetPrefix = AnnotationPrefix + "rotate-offset."
+
+	// AnnotationRotationAnchorPrefix is the prefix for field-specific rotation anchors (rotation-anchor.<field>).
+	// It is set by the operator for Secrets using rotate-offset and records the time a field's
+	// rotation interval is counted from.
+	AnnotationRotationAnchorPrefix = AnnotationPrefix + "rotation-anchor."
+
+	// AnnotationGracePeriod is the default time the previous value of a rotated field is kept in
+	// <field>-previous, so clients can switch over (e.g. "1h")
+	AnnotationGracePeriod = AnnotationPrefix + "grace-period"
+
+	// AnnotationGracePeriodPrefix is the prefix for field-specific grace periods (grace-period.<field>)
+	AnnotationGracePeriodPrefix = AnnotationPrefix + "grace-period."
+
+	// AnnotationPreviousValueExpiresPrefix is the prefix for annotations recording when the previous
+	// value of a field is removed (previous-value-expires.<field>), set by the operator
+	AnnotationPreviousValueExpiresPrefix = AnnotationPrefix + "previous-value-expires."
+
+	// AnnotationLastRotationWindow records the maintenance window in which the last rotation happened
+	AnnotationLastRotationWindow = AnnotationPrefix + "last-rotation-window"
+
+	// AnnotationStatus reports the outcome of the last reconcile (StatusReady or StatusError), set by the operator
+	AnnotationStatus = AnnotationPrefix + "status"
+
+	// AnnotationLastError holds the error of the last reconcile while the status is StatusError, set by the operator
+	AnnotationLastError = AnnotationPrefix + "last-error"
+
+	// AnnotationFailureCount counts the consecutive failed reconciles of the Secret, set by the operator
+	// while the status is StatusError. It drives the retry backoff.
+	AnnotationFailureCount = AnnotationPrefix + "failure-count"
+
+	// AnnotationLastRotationTime records when the Secret was last rotated, set by the operator
+	AnnotationLastRotationTime = AnnotationPrefix + "last-rotation-time"
+
+	// AnnotationRotationHistory holds the times of the last rotations as a JSON array of RFC3339
+	// timestamps, oldest first, set by the operator. Its length is bounded by the rotation historySize.
+	AnnotationRotationHistory = AnnotationPrefix + "rotation-history"
+
+	// AnnotationNextRotationTime records when the Secret is rotated next, set by the operator.
+	// It accounts for maintenance windows.
+	AnnotationNextRotationTime = AnnotationPrefix + "next-rotation-time"
+
+	// AnnotationPaused freezes a Secret ("true"): it is neither generated nor rotated until the
+	// annotation is removed, after which overdue rotations happen right away
+	AnnotationPaused = AnnotationPrefix + "paused"
+
+	// AnnotationRotateNow requests a one-time rotation of all fields on the next reconcile ("true").
+	// It is removed once the rotation happened and respects maintenance windows.
+	AnnotationRotateNow = AnnotationPrefix + "rotate-now"
+
+	// AnnotationRotateNowForce makes a rotate-now request ignore maintenance windows ("true")
+	AnnotationRotateNowForce = AnnotationPrefix + "rotate-now-force"
+
+	// AnnotationCompromised marks a Secret as compromised ("true"), e.g. by a secret scanner. All fields
+	// are rotated immediately, ignoring maintenance windows, and the annotation is removed afterwards.
+	AnnotationCompromised = AnnotationPrefix + "compromised"
+
+	// AnnotationPrune removes operator-created values of fields no longer listed in autogenerate ("true")
+	AnnotationPrune = AnnotationPrefix + "prune"
+
+	// AnnotationManagedFields records the data keys the operator created. Only these are rotated
+	// and pruned, so values provided by someone else are never replaced or removed.
+	AnnotationManagedFields = AnnotationPrefix + "managed-fields"
+
+	// AnnotationForceRotationTokens records the tokens of the force rotation triggers already applied to a Secret
+	AnnotationForceRotationTokens = AnnotationPrefix + "force-rotation-tokens"
+
+	// AnnotationUniqueWithinLabel names a label; generated values are kept distinct from the values of
+	// the same field in all Secrets sharing that label's value, compared by value hash
+	AnnotationUniqueWithinLabel = AnnotationPrefix + "unique-within-label"
+
+	// AnnotationValueHashPrefix is the prefix for the value hashes of Secrets using
+	// unique-within-label (value-hash.<field>, set by the operator)
+	AnnotationValueHashPrefix = AnnotationPrefix + "value-hash."
+
+	// AnnotationValueMACPrefix is the prefix for annotations holding the HMAC of a generated value
+	// (value-mac.<field>), set if tamper detection is enabled
+	AnnotationValueMACPrefix = AnnotationPrefix + "value-mac."
+
+	// AnnotationFieldMetadata holds the per-field metadata (rotation anchors, value hashes) of all fields
+	// as a JSON object keyed by field, instead of one annotation per field (defaults.fieldMetadata: json)
+	AnnotationFieldMetadata = AnnotationPrefix + "field-metadata"
+
+	// AnnotationMetadataVersion records the layout version of the annotations set by the operator,
+	// so that older layouts can be migrated (see managedMetadata)
+	AnnotationMetadataVersion = AnnotationPrefix + "metadata-version"
+
 	// AnnotationStringUppercase specifies whether to include uppercase letters
 	AnnotationStringUppercase = AnnotationPrefix + "string.uppercase"
 
@@ -92,28 +285,214 @@ const (
 	// AnnotationStringAllowedSpecialChars specifies which special characters to use
 	AnnotationStringAllowedSpecialChars = AnnotationPrefix + "string.allowedSpecialChars"
 
+	// AnnotationCharsetPreset selects a named charset for string fields instead of the
+	// string.* annotations (charset-preset.<field> overrides it)
+	AnnotationCharsetPreset = AnnotationPrefix + "charset-preset"
+
+	// AnnotationExcludeAmbiguous removes easily confused characters (e.g. 0/O, 1/l/I) from the
+	// charset of string fields (exclude-ambiguous.<field> overrides it)
+	AnnotationExcludeAmbiguous = AnnotationPrefix + "exclude-ambiguous"
+
+	// AnnotationSafeFor lists contexts generated values must be safe for, "shell" and/or "url"
+	// (safe-for.<field> overrides it). A charset, prefix, or suffix conflicting with them fails generation.
+	AnnotationSafeFor = AnnotationPrefix + "safe-for"
+
+	// AnnotationMinUppercase specifies the minimum number of uppercase letters in string fields
+	AnnotationMinUppercase = AnnotationPrefix + "min-uppercase"
+
+	// AnnotationMinLowercase specifies the minimum number of lowercase letters in string fields
+	AnnotationMinLowercase = AnnotationPrefix + "min-lowercase"
+
+	// AnnotationMinDigits specifies the minimum number of digits in string fields
+	AnnotationMinDigits = AnnotationPrefix + "min-digits"
+
+	// AnnotationMinSymbols specifies the minimum number of special characters in string fields
+	AnnotationMinSymbols = AnnotationPrefix + "min-symbols"
+
+	// AnnotationJWTIssuer specifies the issuer (iss) claim of jwt fields (jwt-issuer.<field> overrides it)
+	AnnotationJWTIssuer = AnnotationPrefix + "jwt-issuer"
+
+	// AnnotationJWTSubject specifies the subject (sub) claim of jwt fields (jwt-subject.<field> overrides it)
+	AnnotationJWTSubject = AnnotationPrefix + "jwt-subject"
+
+	// AnnotationJWTAudience specifies the comma-separated audience (aud) claim of jwt fields
+	// (jwt-audience.<field> overrides it)
+	AnnotationJWTAudience = AnnotationPrefix + "jwt-audience"
+
+	// AnnotationJWTTTL specifies the lifetime of jwt fields (jwt-ttl.<field> overrides it)
+	AnnotationJWTTTL = AnnotationPrefix + "jwt-ttl"
+
+	// AnnotationJWTSigningKey names the field holding the PEM private key used to sign jwt fields
+	// (jwt-signing-key.<field> overrides it). If unset, a new Ed25519 key is generated per token.
+	AnnotationJWTSigningKey = AnnotationPrefix + "jwt-signing-key"
+
+	// AnnotationCertCommonName specifies the subject common name of certificate fields
+	// (cert-common-name.<field> overrides it)
+	AnnotationCertCommonName = AnnotationPrefix + "cert-common-name"
+
+	// AnnotationCertDNS specifies the comma-separated DNS names of certificate fields
+	// (cert-dns.<field> overrides it)
+	AnnotationCertDNS = AnnotationPrefix + "cert-dns"
+
+	// AnnotationCertIP specifies the comma-separated IP address SANs of certificate fields
+	// (cert-ip.<field> overrides it)
+	AnnotationCertIP = AnnotationPrefix + "cert-ip"
+
+	// AnnotationCertURI specifies the comma-separated URI SANs of certificate fields, e.g. SPIFFE IDs
+	// (cert-uri.<field> overrides it)
+	AnnotationCertURI = AnnotationPrefix + "cert-uri"
+
+	// AnnotationCertValidity specifies the validity period of certificate fields (cert-validity.<field> overrides it)
+	AnnotationCertValidity = AnnotationPrefix + "cert-validity"
+
+	// AnnotationCertKeyUsage specifies the comma-separated key usages of certificate fields, e.g.
+	// "digital-signature,key-encipherment" (cert-key-usage.<field> overrides it)
+	AnnotationCertKeyUsage = AnnotationPrefix + "cert-key-usage"
+
+	// AnnotationCertUsage specifies the comma-separated extended key usages of certificate fields, e.g.
+	// "server,client" (cert-usage.<field> overrides it)
+	AnnotationCertUsage = AnnotationPrefix + "cert-usage"
+
+	// AnnotationCertMode specifies whether certificate fields are self-signed or signed by the CA
+	// of cert-ca-secret (self-signed, ca-signed; cert-mode.<field> overrides it)
+	AnnotationCertMode = AnnotationPrefix + "cert-mode"
+
+	// AnnotationCertCASecret names the Secret in the same namespace holding the CA (tls.crt, tls.key)
+	// signing ca-signed certificate fields (cert-ca-secret.<field> overrides it)
+	AnnotationCertCASecret = AnnotationPrefix + "cert-ca-secret"
+
+	// AnnotationCertKeyFieldPrefix is the prefix for annotations naming the field that receives the
+	// private key of a certificate field (cert-key-field.<field>, default <field>.key)
+	AnnotationCertKeyFieldPrefix = AnnotationPrefix + "cert-key-field."
+
 	// EventReasonGenerationFailed indicates that secret value generation failed.
 	EventReasonGenerationFailed = "GenerationFailed"
 	// EventReasonGenerationSucceeded indicates that secret value generation succeeded.
 	EventReasonGenerationSucceeded = "GenerationSucceeded"
+	// EventReasonGenerationRecovered indicates that generation succeeded again after failures.
+	EventReasonGenerationRecovered = "GenerationRecovered"
 	// EventReasonRotationSucceeded indicates that secret rotation succeeded.
 	EventReasonRotationSucceeded = "RotationSucceeded"
+	// EventReasonTamperDetected indicates that a generated value was changed outside the operator.
+	EventReasonTamperDetected = "TamperDetected"
+	// EventReasonCompromisedRotated indicates that a Secret marked as compromised was rotated.
+	EventReasonCompromisedRotated = "CompromisedRotated"
 	// EventReasonRotationFailed indicates that secret rotation failed.
 	EventReasonRotationFailed = "RotationFailed"
+	// EventReasonNotificationFailed indicates that a Notifier failed to send a notification.
+	EventReasonNotificationFailed = "NotificationFailed"
 	// EventReasonRotationDeferred indicates that secret rotation was deferred.
 	EventReasonRotationDeferred = "RotationDeferred"
+	// EventReasonGenerationDeferred indicates that initial generation was deferred.
+	EventReasonGenerationDeferred = "GenerationDeferred"
+	// EventReasonValuesAdopted indicates that pre-existing field values were adopted.
+	EventReasonValuesAdopted = "ValuesAdopted"
+	// EventReasonDryRunAction indicates a change that was not made because of dry-run mode.
+	EventReasonDryRunAction = "DryRunAction"
+	// EventReasonUserValuesKept indicates that listed fields with user-provided values were left alone.
+	EventReasonUserValuesKept = "UserValuesKept"
+	// EventReasonFieldsPruned indicates that values of fields removed from autogenerate were deleted.
+	EventReasonFieldsPruned = "FieldsPruned"
 )
 
 // SecretReconciler reconciles a Secret object
 type SecretReconciler struct {
 	client.Client
-	Scheme        *runtime.Scheme
-	Generator     generator.Generator
-	Config        *config.Config
+	Scheme    *runtime.Scheme
+	Generator generator.Generator
+	// Config is the configuration the reconciler starts with. Reloadable replaces it while running.
+	Config *config.Config
+	// Reloadable holds the configuration after reloads of the configuration file, shared with the
+	// other reconcilers and webhooks. If nil, Config is used.
+	Reloadable    *ReloadableConfig
 	EventRecorder events.EventRecorder
 	// Clock is used to get the current time. If nil, time.Now() is used.
 	// This allows for time mocking in tests.
 	Clock Clock
+	// IntegrityKey is the HMAC key used to detect values changed outside the operator.
+	// If nil, tamper detection is disabled.
+	IntegrityKey []byte
+	// Notifiers are told about rotations and failures, e.g. the rotation webhook and Slack
+	Notifiers []Notifier
+	// AuditLogger receives an audit record of every change to a Secret's data.
+	// If unset, the global logger named AuditLoggerName is used.
+	AuditLogger logr.Logger
+	// Instance identifies this operator replica in audit records. If empty, the hostname is used.
+	Instance string
+	// Drain lets in-flight reconciles finish on shutdown. If nil, they are canceled right away.
+	Drain *ReconcileDrain
+
+	// throttle counts rotations for the rotation.throttle setting
+	throttle rotationThrottle
+	// deferrals remembers why initial generation was last deferred, per Secret
+	deferrals generationDeferrals
+	// catchUp remembers when the reconciler started, to stagger rotations overdue after a downtime
+	catchUp catchUpStart
+	// shared is the reconciler whose state above a reconciler returned by withConfig uses, see state
+	shared *SecretReconciler
+
+	// annotationKeys are the annotation keys for the configured prefix, see keys
+	annotationKeys     *annotationKeys
+	annotationKeysOnce sync.Once
+}
+
+// keys returns the annotation keys for the configured annotation prefix. They are built once;
+// the prefix can't change while running. Without a configuration the built-in prefix is used.
+func (r *SecretReconciler) keys() *annotationKeys {
+	s := r.state()
+	s.annotationKeysOnce.Do(func() {
+		s.annotationKeys = defaultAnnotationKeys
+		if cfg := s.currentConfig(); cfg != nil {
+			s.annotationKeys = newAnnotationKeys(cfg.AnnotationPrefix)
+		}
+	})
+	return s.annotationKeys
+}
+
+// currentConfig returns the configuration reconciles use: the one held by Reloadable, or Config
+func (r *SecretReconciler) currentConfig() *config.Config {
+	if cfg := r.Reloadable.Load(); cfg != nil {
+		return cfg
+	}
+	return r.Config
+}
+
+// withConfig returns a reconciler that uses cfg until it is done, even if the configuration is
+// reloaded meanwhile, so a reconcile never mixes two configurations. It shares the state of r,
+// like the rotation throttle.
+func (r *SecretReconciler) withConfig(cfg *config.Config) *SecretReconciler {
+	return &SecretReconciler{
+		Client:        r.Client,
+		Scheme:        r.Scheme,
+		Generator:     r.Generator,
+		Config:        cfg,
+		EventRecorder: r.EventRecorder,
+		Clock:         r.Clock,
+		IntegrityKey:  r.IntegrityKey,
+		Notifiers:     r.Notifiers,
+		AuditLogger:   r.AuditLogger,
+		Instance:      r.Instance,
+		Drain:         r.Drain,
+		shared:        r.state(),
+	}
+}
+
+// state returns the reconciler holding the state kept across reconciles: r itself, or the
+// reconciler withConfig was called on
+func (r *SecretReconciler) state() *SecretReconciler {
+	if r.shared != nil {
+		return r.shared
+	}
+	return r
+}
+
+// ConfigReadyzCheck is a healthz.Checker that fails if the configuration in use is invalid,
+// e.g. its maintenance windows or rotation settings
+func (r *SecretReconciler) ConfigReadyzCheck(_ *http.Request) error {
+	if err := r.currentConfig().Validate(); err != nil {
+		return fmt.Errorf("invalid configuration: %w", err)
+	}
+	return nil
 }
 
 // Clock is an interface for getting the current time.
@@ -144,22 +523,76 @@ func (r *SecretReconciler) since(t time.Time) time.Duration {
 }
 
 // +kubebuilder:rbac:groups="",resources=secrets,verbs=get;list;watch;update;patch
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
 // +kubebuilder:rbac:groups="",resources=events,verbs=create;patch
 
-// Reconcile handles the reconciliation of Secrets with autogenerate annotations
+// Reconcile handles the reconciliation of Secrets with autogenerate annotations. The configuration
+// is read once, so a reload takes effect with the next reconcile.
 func (r *SecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	return r.withConfig(r.currentConfig()).reconcile(ctx, req)
+}
+
+// reconcile reconciles the Secret of req with the configuration of r
+func (r *SecretReconciler) reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, done := r.Drain.Track(ctx)
+	defer done()
 	logger := log.FromContext(ctx)
+	cfg := r.currentConfig()
+
+	// Secrets outside the configured namespaces are never touched. A reload may have excluded the
+	// namespace since the Secret's last reconcile, so its metrics are forgotten.
+	if !cfg.Namespaces.Allows(req.Namespace) {
+		forgetSecretMetrics(req.Namespace, req.Name)
+		return ctrl.Result{}, nil
+	}
+
+	// Rotations long overdue around the first reconcile were missed while the operator was down
+	r.state().catchUp.startedAt(r.now())
 
 	// Fetch the Secret
 	var secret corev1.Secret
 	if err := r.Get(ctx, req.NamespacedName, &secret); err != nil {
 		// Secret was deleted, nothing to do
+		if client.IgnoreNotFound(err) == nil {
+			r.state().deferrals.transition(req.String(), "")
+			forgetSecretMetrics(req.Namespace, req.Name)
+		}
 		return ctrl.Result{}, client.IgnoreNotFound(err)
 	}
 
 	// Parse the autogenerate annotation
-	fields := parseSecretAnnotations(secret.Annotations)
+	fields := r.keys().parseSecretAnnotations(secret.Annotations)
 	if len(fields) == 0 {
+		forgetSecretMetrics(secret.Namespace, secret.Name)
+		return ctrl.Result{}, nil
+	}
+
+	// Secrets not matching the label selector aren't managed, e.g. after the label was removed
+	if !cfg.Selector().Matches(labels.Set(secret.Labels)) {
+		logger.V(1).Info("Secret doesn't match the label selector, skipping", "name", secret.Name, "namespace", secret.Namespace)
+		forgetSecretMetrics(secret.Namespace, secret.Name)
+		return ctrl.Result{}, nil
+	}
+
+	// Paused Secrets are left alone; unpausing them triggers a new reconcile
+	if paused, _ := parseBoolAnnotation(secret.Annotations, r.keys().Paused); paused {
+		logger.V(1).Info("Secret is paused, skipping", "name", secret.Name, "namespace", secret.Namespace,
+			"missingFields", missingFields(secret.Data, fields))
+		return ctrl.Result{}, nil
+	}
+
+	// Secrets being deleted, or in a namespace being deleted, can't be updated anymore
+	if !secret.DeletionTimestamp.IsZero() {
+		logger.V(1).Info("Secret is being deleted, skipping", "name", secret.Name, "namespace", secret.Namespace)
+		forgetSecretMetrics(secret.Namespace, secret.Name)
+		return ctrl.Result{}, nil
+	}
+	terminating, err := r.isNamespaceTerminating(ctx, secret.Namespace)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+	if terminating {
+		logger.V(1).Info("Namespace is terminating, skipping", "name", secret.Name, "namespace", secret.Namespace)
 		return ctrl.Result{}, nil
 	}
 
@@ -169,37 +602,434 @@ func (r *SecretReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctr
 	if secret.Data == nil {
 		secret.Data = make(map[string][]byte)
 	}
+	mergeStringData(&secret)
+	r.keys().dropEmptyPlaceholders(secret.Annotations, secret.Data, fields)
 
 	// Get the generated-at timestamp for rotation checks
 	generatedAt := r.getGeneratedAtTime(secret.Annotations)
 
+	// Flag values changed outside the operator
+	r.verifyFieldIntegrity(&secret, fields, logger)
+
+	// Check for pending force rotation triggers, rotate-now requests, and compromised Secrets
+	forceRotation, forceDeferral := r.checkForceRotation(&secret, logger)
+	trigger := rotationScheduled
+	if forceRotation {
+		trigger = r.keys().forcedRotationTrigger(secret.Annotations)
+	}
+
+	// Check whether initial generation of missing fields has to wait for a maintenance window
+	missing := missingFields(secret.Data, fields)
+	generationDeferral, gateDeferral := r.checkInitialGenerationGate(&secret, missing, logger)
+
+	// Check whether due rotations have to wait for the rotation throttle
+	rotationThrottled, throttleDeferral, throttleSlot := r.checkRotationThrottle(&secret, secret.Data, r.keys().rotatableFields(secret.Annotations, secret.Data, fields), generatedAt, forceRotation, logger)
+	defer throttleSlot.release()
+
+	// Collect the value hashes generated values must not collide with
+	takenHashes, err := r.uniquenessSetHashes(ctx, &secret, logger)
+	if err != nil {
+		return ctrl.Result{}, err
+	}
+
+	// Remove previous values whose grace period is over
+	expired := r.clearExpiredPreviousValues(secret.Annotations, secret.Data, logger)
+
+	// Remove the values of fields no longer listed in autogenerate. In dry-run mode, they are only reported.
+	pruned := r.pruneFields(secret.Annotations, secret.Data, fields, logger)
+	if len(pruned) > 0 && cfg.DryRun {
+		r.recordPrunedFields(&secret, pruned)
+	}
+
 	// Process all fields
-	updateResult := r.processSecretFields(&secret, fields, generatedAt, logger)
+	updateResult := r.processFields(ctx, &secret, secret.Data, fields, generatedAt, forceRotation, rotationThrottled, takenHashes, logger)
 	if updateResult.skipRest {
 		// An error occurred during field processing. The error has already been logged
-		// and a Warning event has been created. We don't modify the secret and don't
-		// return an error (which would cause unnecessary retries).
+		// and a Warning event has been created for the first failure. We don't modify the
+		// secret's values and don't return an error, which would retry without backoff.
+		r.reportGenerationDeferral(&secret, r.lowEntropyDeferral(&secret, missing), logger)
+		if err := r.updateStatus(ctx, &secret, nil, updateResult.errMsg, logger); err != nil {
+			return ctrl.Result{}, err
+		}
+		if retryAfter := r.failureBackoff(r.keys().getFailureCount(secret.Annotations)); retryAfter != nil {
+			logger.Info("Scheduling retry after failed generation", "failures", r.keys().getFailureCount(secret.Annotations), "requeueAfter", *retryAfter)
+			return ctrl.Result{RequeueAfter: *retryAfter}, nil
+		}
+		logger.Info("Giving up on failed generation until the Secret changes", "failures", r.keys().getFailureCount(secret.Annotations))
+		return ctrl.Result{}, nil
+	}
+	// Immutable Secrets reject changes to their data, so retrying would only fail again
+	if updateResult.changed && isImmutable(&secret) {
+		return ctrl.Result{}, r.skipImmutableSecret(ctx, &secret, logger)
+	}
+
+	// In dry-run mode, the changes are only reported. The Secret isn't requeued: its values
+	// stay due, so every later reconcile reports them again.
+	if updateResult.changed && cfg.DryRun {
+		r.recordDryRun(&secret, updateResult, logger)
+		r.reportGenerationDeferral(&secret, &deferredGeneration{
+			reason:    deferralDryRun,
+			eventType: corev1.EventTypeNormal,
+			fields:    intersectFields(missing, updateResult.updated),
+		}, logger)
 		return ctrl.Result{}, nil
 	}
+	r.recordGenerationRecovery(&secret)
+	r.reportGenerationDeferral(&secret, gateDeferral, logger)
 
 	// If changes were made, update the secret
 	if updateResult.changed {
-		if err := r.updateSecretAndEmitEvents(ctx, &secret, updateResult.rotated, logger); err != nil {
+		if forceRotation {
+			meta := r.readMetadata(secret.Annotations)
+			meta.ForceRotationTokens = r.matchingForceRotationTokens(secret.Labels)
+			meta.writeTo(secret.Annotations)
+			// rotate-now and compromised fire only once
+			delete(secret.Annotations, r.keys().RotateNow)
+			delete(secret.Annotations, r.keys().RotateNowForce)
+			delete(secret.Annotations, r.keys().Compromised)
+		}
+		if err := r.updateSecretAndEmitEvents(ctx, &secret, updateResult.rotated, trigger, logger); err != nil {
 			return ctrl.Result{}, err
 		}
+		if updateResult.rotated {
+			throttleSlot.keep()
+			r.audit(&secret, AuditActionRotate, updateResult.updated)
+		} else {
+			r.audit(&secret, AuditActionGenerate, updateResult.updated)
+		}
+		r.audit(&secret, AuditActionExpire, expired)
+		r.audit(&secret, AuditActionPrune, pruned)
+		if len(pruned) > 0 {
+			r.recordPrunedFields(&secret, pruned)
+		}
+		r.recordUserProvidedFields(&secret, updateResult.userProvided, logger)
 		// Update generatedAt for next rotation calculation
 		generatedAt = r.getGeneratedAtTime(secret.Annotations)
+		if updateResult.rotated && generatedAt != nil {
+			notification := Notification{
+				Reason:  EventReasonRotationSucceeded,
+				Fields:  updateResult.updated,
+				Message: "Successfully rotated values for secret fields",
+				Time:    *generatedAt,
+			}
+			if trigger == rotationCompromised {
+				notification.Reason = EventReasonCompromisedRotated
+				notification.Message = "Rotated values for secret fields because the Secret was marked as compromised"
+			}
+			r.notify(ctx, &secret, notification)
+		}
+	} else if (len(expired) > 0 || len(pruned) > 0) && !cfg.DryRun && !isImmutable(&secret) {
+		if err := r.Update(ctx, &secret); err != nil {
+			logger.Error(err, "Failed to remove expired previous values or pruned fields")
+			return ctrl.Result{}, err
+		}
+		r.audit(&secret, AuditActionExpire, expired)
+		r.audit(&secret, AuditActionPrune, pruned)
+		if len(pruned) > 0 {
+			r.recordPrunedFields(&secret, pruned)
+		}
+	} else if generatedAt == nil {
+		// Nothing was generated yet, so all present values were provided by someone else
+		adopted, err := r.adoptExistingValues(ctx, &secret, fields, logger)
+		if err != nil {
+			return ctrl.Result{}, err
+		}
+		if adopted {
+			generatedAt = r.getGeneratedAtTime(secret.Annotations)
+		}
 	}
 
 	// Calculate next rotation time and schedule requeue if needed
-	if nextRotation := r.calculateNextRotation(secret.Annotations, fields, generatedAt); nextRotation != nil {
+	nextRotation := r.calculateNextRotation(secretKey(&secret), secret.Annotations, r.keys().rotatableFields(secret.Annotations, secret.Data, fields), generatedAt)
+	for _, deferral := range []*time.Duration{forceDeferral, generationDeferral, throttleDeferral} {
+		if deferral != nil && (nextRotation == nil || *deferral < *nextRotation) {
+			nextRotation = deferral
+		}
+	}
+	if err := r.updateStatus(ctx, &secret, nextRotation, "", logger); err != nil {
+		return ctrl.Result{}, err
+	}
+	r.recordNextRotation(&secret, nextRotation)
+	r.recordCertificateExpiry(&secret)
+	if nextRotation != nil {
 		logger.Info("Scheduling next reconciliation for rotation", "requeueAfter", *nextRotation)
-		return ctrl.Result{RequeueAfter: *nextRotation}, nil
+	}
+
+	// Previous values are removed on a later reconcile
+	requeueAfter := nextRotation
+	if expiry := r.nextPreviousValueExpiry(secret.Annotations); expiry != nil && (requeueAfter == nil || *expiry < *requeueAfter) {
+		logger.Info("Scheduling next reconciliation for previous value removal", "requeueAfter", *expiry)
+		requeueAfter = expiry
+	}
+	if requeueAfter != nil {
+		return ctrl.Result{RequeueAfter: *requeueAfter}, nil
 	}
 
 	return ctrl.Result{}, nil
 }
 
+// isNamespaceTerminating returns true if the namespace is being deleted. The namespace is read
+// from the cache; an unknown namespace is not considered terminating.
+func (r *SecretReconciler) isNamespaceTerminating(ctx context.Context, name string) (bool, error) {
+	var namespace corev1.Namespace
+	if err := r.Get(ctx, client.ObjectKey{Name: name}, &namespace); err != nil {
+		return false, client.IgnoreNotFound(err)
+	}
+	return namespace.Status.Phase == corev1.NamespaceTerminating || !namespace.DeletionTimestamp.IsZero(), nil
+}
+
+// isImmutable returns true if the Secret's data can't be changed
+func isImmutable(secret *corev1.Secret) bool {
+	return secret.Immutable != nil && *secret.Immutable
+}
+
+// skipImmutableSecret reports that the values of an immutable Secret can't be generated or rotated.
+// The Warning event is created once: the message is kept in last-error, and the Secret isn't requeued.
+func (r *SecretReconciler) skipImmutableSecret(ctx context.Context, secret *corev1.Secret, logger logr.Logger) error {
+	msg := "Secret is immutable, its values cannot be generated or rotated; recreate it without immutable: true to let the operator manage it"
+	logger.Info("Secret is immutable, skipping", "name", secret.Name, "namespace", secret.Namespace)
+	if secret.Annotations[r.keys().LastError] == msg {
+		return nil
+	}
+	r.eventf(secret, nil, corev1.EventTypeWarning, EventReasonGenerationFailed, "Generate", "%s", msg)
+	r.notify(ctx, secret, Notification{Reason: EventReasonGenerationFailed, Message: msg})
+	return r.updateStatus(ctx, secret, nil, msg, logger)
+}
+
+// secretKey returns the "namespace/name" key of a Secret
+func secretKey(obj client.Object) string {
+	return obj.GetNamespace() + "/" + obj.GetName()
+}
+
+// matchingForceRotationTokens returns the tokens of all force rotation triggers whose selector matches secretLabels
+func (r *SecretReconciler) matchingForceRotationTokens(secretLabels map[string]string) []string {
+	var tokens []string
+	for i := range r.currentConfig().Rotation.ForceRotationTriggers {
+		trigger := &r.currentConfig().Rotation.ForceRotationTriggers[i]
+		if trigger.Matches(secretLabels) {
+			tokens = append(tokens, trigger.Token)
+		}
+	}
+	return tokens
+}
+
+// rotationTrigger is what caused a rotation
+type rotationTrigger int
+
+const (
+	// rotationScheduled is a rotation due to the rotation interval or a force rotation trigger
+	rotationScheduled rotationTrigger = iota
+	// rotationManual is a rotation requested with the rotate-now annotation
+	rotationManual
+	// rotationCompromised is a rotation of a Secret marked as compromised
+	rotationCompromised
+)
+
+// forcedRotationTrigger returns what caused a forced rotation of a Secret
+func (k *annotationKeys) forcedRotationTrigger(annotations map[string]string) rotationTrigger {
+	switch {
+	case k.isMarkedCompromised(annotations):
+		return rotationCompromised
+	case k.isRotateNowRequested(annotations):
+		return rotationManual
+	default:
+		return rotationScheduled
+	}
+}
+
+// isRotateNowRequested returns true if the Secret carries a rotate-now request
+func (k *annotationKeys) isRotateNowRequested(annotations map[string]string) bool {
+	rotateNow, _ := parseBoolAnnotation(annotations, k.RotateNow)
+	return rotateNow
+}
+
+// isMarkedCompromised returns true if the Secret is marked as compromised
+func (k *annotationKeys) isMarkedCompromised(annotations map[string]string) bool {
+	compromised, _ := parseBoolAnnotation(annotations, k.Compromised)
+	return compromised
+}
+
+// checkForceRotation checks whether a force rotation trigger matching the Secret has not been applied yet,
+// or a rotation was requested with the rotate-now annotation.
+// It returns true if all existing fields should be rotated now. If the rotation has to wait for a
+// maintenance window, it returns false and the time until the rotation should be retried.
+// Secrets marked as compromised are always rotated right away.
+func (r *SecretReconciler) checkForceRotation(obj client.Object, logger logr.Logger) (bool, *time.Duration) {
+	annotations := obj.GetAnnotations()
+	if r.keys().isMarkedCompromised(annotations) {
+		logger.Info("Secret is marked as compromised, rotating immediately")
+		return true, nil
+	}
+
+	applied := make(map[string]bool)
+	for _, token := range r.keys().readManagedMetadata(annotations).ForceRotationTokens {
+		applied[token] = true
+	}
+
+	var pending []*config.ForceRotationTrigger
+	for i := range r.currentConfig().Rotation.ForceRotationTriggers {
+		trigger := &r.currentConfig().Rotation.ForceRotationTriggers[i]
+		if !applied[trigger.Token] && trigger.Matches(obj.GetLabels()) {
+			pending = append(pending, trigger)
+		}
+	}
+	rotateNow := r.keys().isRotateNowRequested(annotations)
+	if len(pending) == 0 && !rotateNow {
+		return false, nil
+	}
+
+	windows := &r.currentConfig().Rotation.MaintenanceWindows
+	now := r.now()
+	if windows.IsRotationAllowed(now) {
+		return true, nil
+	}
+	if force, _ := parseBoolAnnotation(annotations, r.keys().RotateNowForce); rotateNow && force {
+		return true, nil
+	}
+	for _, trigger := range pending {
+		if trigger.IgnoreMaintenanceWindows {
+			return true, nil
+		}
+	}
+
+	// Outside maintenance window or inside a blackout window - defer forced rotation
+	deferredUntil, windowName := r.nextDeferralTime(now, secretKey(obj))
+	if deferredUntil.IsZero() {
+		logger.Info("Forced rotation deferred - no upcoming maintenance window")
+		return false, nil
+	}
+	windowInfo := ""
+	if windowName != "" {
+		windowInfo = fmt.Sprintf(" (window: %s)", windowName)
+	}
+	msg := fmt.Sprintf("Forced rotation deferred until next maintenance window at %s%s%s",
+		deferredUntil.Format(time.RFC3339), windowInfo, r.blackoutInfo(now))
+	logger.Info(msg, "deferredUntil", deferredUntil)
+	r.eventf(obj, nil, corev1.EventTypeNormal, EventReasonRotationDeferred, "Rotate", "%s", msg)
+	timeUntilWindow := deferredUntil.Sub(now)
+	return false, &timeUntilWindow
+}
+
+// isInitialGenerationGated returns true if initial generation must wait for a maintenance window
+func (r *SecretReconciler) isInitialGenerationGated(now time.Time) bool {
+	return r.currentConfig().Rotation.GateInitialGeneration && !r.currentConfig().Rotation.MaintenanceWindows.IsRotationAllowed(now)
+}
+
+// checkInitialGenerationGate checks whether initial generation of the missing fields is deferred
+// to the next maintenance window. If so, it returns the time until the window and the deferral to report.
+func (r *SecretReconciler) checkInitialGenerationGate(secret *corev1.Secret, missing []string, logger logr.Logger) (*time.Duration, *deferredGeneration) {
+	now := r.now()
+	if !r.isInitialGenerationGated(now) || len(missing) == 0 {
+		return nil, nil
+	}
+
+	deferredUntil, windowName := r.nextDeferralTime(now, secretKey(secret))
+	if deferredUntil.IsZero() {
+		logger.Info("Initial generation deferred - no upcoming maintenance window", "fields", missing)
+		return nil, nil
+	}
+	windowInfo := ""
+	if windowName != "" {
+		windowInfo = fmt.Sprintf(" (window: %s)", windowName)
+	}
+	reason := deferralMaintenanceWindow
+	if r.currentConfig().Rotation.MaintenanceWindows.GetActiveBlackoutWindow(now) != nil {
+		reason = deferralBlackoutWindow
+	}
+	logger.V(1).Info("Initial generation deferred", "fields", missing, "deferredUntil", deferredUntil)
+	timeUntilWindow := deferredUntil.Sub(now)
+	return &timeUntilWindow, &deferredGeneration{
+		reason:    reason,
+		eventType: corev1.EventTypeNormal,
+		fields:    missing,
+		detail:    fmt.Sprintf(", until next maintenance window at %s%s", deferredUntil.Format(time.RFC3339), windowInfo),
+	}
+}
+
+// getExistingValuesMode returns how pre-existing field values are handled.
+// Priority: existing-values annotation > defaults.existingValues from config
+func (r *SecretReconciler) getExistingValuesMode(annotations map[string]string) (string, error) {
+	mode := r.getAnnotationOrDefault(annotations, r.keys().ExistingValues, r.currentConfig().Defaults.ExistingValues)
+	switch mode {
+	case "", config.ExistingValuesIgnore:
+		return config.ExistingValuesIgnore, nil
+	case config.ExistingValuesAdopt:
+		return mode, nil
+	default:
+		return "", fmt.Errorf("invalid existing-values %q, must be %s or %s", mode, config.ExistingValuesIgnore, config.ExistingValuesAdopt)
+	}
+}
+
+// adoptExistingValues adopts the values of fields that were present before the operator generated
+// anything for the Secret, if the existing-values mode is adopt. Adopting sets the generated-at
+// annotation, so the rotation schedule of these fields starts now. It returns whether the Secret
+// was updated.
+func (r *SecretReconciler) adoptExistingValues(ctx context.Context, secret *corev1.Secret, fields []string, logger logr.Logger) (bool, error) {
+	mode, err := r.getExistingValuesMode(secret.Annotations)
+	if err != nil {
+		logger.Error(err, "Ignoring existing values")
+		r.eventf(secret, nil, corev1.EventTypeWarning, EventReasonGenerationFailed, "Adopt",
+			"Invalid existing-values annotation %q, must be %s or %s", secret.Annotations[r.keys().ExistingValues],
+			config.ExistingValuesIgnore, config.ExistingValuesAdopt)
+		return false, nil
+	}
+	if mode != config.ExistingValuesAdopt {
+		return false, nil
+	}
+
+	var existing []string
+	for _, field := range fields {
+		if hasFieldValue(secret.Data, field) {
+			existing = append(existing, field)
+		}
+	}
+	if len(existing) == 0 {
+		return false, nil
+	}
+
+	if secret.Annotations == nil {
+		secret.Annotations = make(map[string]string)
+	}
+	if r.currentConfig().DryRun {
+		msg := fmt.Sprintf("Dry run: would adopt existing values of fields %s", strings.Join(existing, ", "))
+		logger.Info(msg)
+		r.eventf(secret, nil, corev1.EventTypeNormal, EventReasonDryRunAction, "Adopt", "%s", msg)
+		return false, nil
+	}
+
+	meta := r.readMetadata(secret.Annotations)
+	now := r.now()
+	meta.GeneratedAt = &now
+	meta.addManagedFields(existing...)
+	meta.writeTo(secret.Annotations)
+	if err := r.Update(ctx, secret); err != nil {
+		logger.Error(err, "Failed to update Secret")
+		return false, err
+	}
+
+	r.audit(secret, AuditActionAdopt, existing)
+	msg := fmt.Sprintf("Adopted existing values of fields %s", strings.Join(existing, ", "))
+	logger.Info(msg)
+	r.eventf(secret, nil, corev1.EventTypeNormal, EventReasonValuesAdopted, "Adopt", "%s", msg)
+	return true, nil
+}
+
+// hasFieldValue returns true if the field is set in data, even to an empty value
+func hasFieldValue(data map[string][]byte, field string) bool {
+	_, ok := data[field]
+	return ok
+}
+
+// mergeStringData moves stringData into data like the API server does on write, where stringData
+// takes precedence. Secrets read from the API server never have stringData, but clients that
+// return objects unchanged do, and the Secret is updated with data only.
+func mergeStringData(secret *corev1.Secret) {
+	for field, value := range secret.StringData {
+		secret.Data[field] = []byte(value)
+	}
+	secret.StringData = nil
+}
+
 // parseFields parses a comma-separated list of field names
 func parseFields(value string) []string {
 	var fields []string
@@ -222,50 +1052,91 @@ func (r *SecretReconciler) getAnnotationOrDefault(annotations map[string]string,
 
 // getLengthAnnotation returns the length annotation value or the default from config
 func (r *SecretReconciler) getLengthAnnotation(annotations map[string]string) int {
-	if value, ok := annotations[AnnotationLength]; ok && value != "" {
+	if value, ok := annotations[r.keys().Length]; ok && value != "" {
 		if length, err := strconv.Atoi(value); err == nil && length > 0 {
 			return length
 		}
 	}
-	return r.Config.Defaults.Length
+	return r.currentConfig().Defaults.Length
 }
 
 // getFieldType returns the type for a specific field.
 // Priority: type.<field> annotation > type annotation > default type from config
 func (r *SecretReconciler) getFieldType(annotations map[string]string, field string) string {
 	// Check for field-specific type annotation
-	fieldTypeKey := AnnotationTypePrefix + field
+	fieldTypeKey := r.keys().TypePrefix + field
 	if value, ok := annotations[fieldTypeKey]; ok && value != "" {
 		return value
 	}
 	// Fall back to default type annotation
-	return r.getAnnotationOrDefault(annotations, AnnotationType, r.Config.Defaults.Type)
+	return r.getAnnotationOrDefault(annotations, r.keys().Type, r.currentConfig().Defaults.Type)
 }
 
 // getFieldLength returns the length for a specific field.
-// Priority: length.<field> annotation > length annotation > default length
+// Priority: length.<field> annotation > length annotation > default length.
+// totp fields don't use the length annotation and default to 20 bytes instead.
 func (r *SecretReconciler) getFieldLength(annotations map[string]string, field string) int {
 	// Check for field-specific length annotation
-	fieldLengthKey := AnnotationLengthPrefix + field
+	fieldLengthKey := r.keys().LengthPrefix + field
 	if value, ok := annotations[fieldLengthKey]; ok && value != "" {
 		if length, err := strconv.Atoi(value); err == nil && length > 0 {
 			return length
 		}
 	}
+	// TOTP secrets are measured in bytes, so the default length for strings doesn't apply
+	if r.getFieldType(annotations, field) == config.TypeTOTP {
+		return config.DefaultTOTPSecretLength
+	}
 	// Fall back to default length annotation
 	return r.getLengthAnnotation(annotations)
 }
 
+// getFieldOutputLength returns the number of output characters of a bytes field
+// (output-length.<field>), or 0 if it isn't set
+func (k *annotationKeys) getFieldOutputLength(annotations map[string]string, field string) (int, error) {
+	value := annotations[k.OutputLengthPrefix+field]
+	if value == "" {
+		return 0, nil
+	}
+	outputLength, err := strconv.Atoi(value)
+	if err != nil || outputLength <= 0 {
+		return 0, fmt.Errorf("invalid output length %q, must be a positive integer", value)
+	}
+	return outputLength, nil
+}
+
+// getFieldWords returns the number of words of a passphrase field (words.<field>, default 7)
+func (k *annotationKeys) getFieldWords(annotations map[string]string, field string) (int, error) {
+	value, ok := annotations[k.WordsPrefix+field]
+	if !ok {
+		return config.DefaultPassphraseWords, nil
+	}
+	words, err := strconv.Atoi(value)
+	if err != nil || words <= 0 {
+		return 0, fmt.Errorf("invalid number of words %q, must be a positive integer", value)
+	}
+	return words, nil
+}
+
+// getFieldSeparator returns the separator between the words of a passphrase field
+// (separator.<field>, default "-"). An empty separator joins the words directly.
+func (k *annotationKeys) getFieldSeparator(annotations map[string]string, field string) string {
+	if value, ok := annotations[k.SeparatorPrefix+field]; ok {
+		return value
+	}
+	return config.DefaultPassphraseSeparator
+}
+
 // getFieldCurve returns the ECDSA curve for a specific field.
 // Priority: curve.<field> annotation > curve annotation > default curve (P-256)
 func (r *SecretReconciler) getFieldCurve(annotations map[string]string, field string) string {
 	// Check for field-specific curve annotation
-	fieldCurveKey := AnnotationCurvePrefix + field
+	fieldCurveKey := r.keys().CurvePrefix + field
 	if value, ok := annotations[fieldCurveKey]; ok && value != "" {
 		return value
 	}
 	// Fall back to default curve annotation
-	if value, ok := annotations[AnnotationCurve]; ok && value != "" {
+	if value, ok := annotations[r.keys().Curve]; ok && value != "" {
 		return value
 	}
 	// Default curve
@@ -275,43 +1146,307 @@ func (r *SecretReconciler) getFieldCurve(annotations map[string]string, field st
 // getFieldParam returns the parameter set for a specific field (used by post-quantum types).
 // Priority: param.<field> annotation > param annotation > defaultParam
 func (r *SecretReconciler) getFieldParam(annotations map[string]string, field string, defaultParam string) string {
-	if v, ok := annotations[AnnotationParamPrefix+field]; ok && v != "" {
+	if v, ok := annotations[r.keys().ParamPrefix+field]; ok && v != "" {
 		return v
 	}
-	if v, ok := annotations[AnnotationParam]; ok && v != "" {
+	if v, ok := annotations[r.keys().Param]; ok && v != "" {
 		return v
 	}
 	return defaultParam
 }
 
-// getFieldRotationInterval returns the rotation interval for a specific field.
-// Priority: rotate.<field> annotation > rotate annotation > 0 (no rotation)
-func (r *SecretReconciler) getFieldRotationInterval(annotations map[string]string, field string) time.Duration {
+// getFieldMinCount returns the minimum count for a character class of a field.
+// Priority: <annotation>.<field> > <annotation> > 0
+func getFieldMinCount(annotations map[string]string, annotation, field string) (int, error) {
+	value, ok := annotations[annotation+"."+field]
+	if !ok || value == "" {
+		value, ok = annotations[annotation]
+	}
+	if !ok || value == "" {
+		return 0, nil
+	}
+	count, err := strconv.Atoi(value)
+	if err != nil || count < 0 {
+		return 0, fmt.Errorf("invalid value %q for %s, must be a non-negative integer", value, annotation[strings.LastIndex(annotation, "/")+1:])
+	}
+	return count, nil
+}
+
+// getFieldComplexity returns the complexity requirements for a string field
+func (r *SecretReconciler) getFieldComplexity(annotations map[string]string, field string) (generator.ComplexityRequirements, error) {
+	var req generator.ComplexityRequirements
+	for _, c := range []struct {
+		annotation string
+		target     *int
+	}{
+		{r.keys().MinUppercase, &req.MinUppercase},
+		{r.keys().MinLowercase, &req.MinLowercase},
+		{r.keys().MinDigits, &req.MinDigits},
+		{r.keys().MinSymbols, &req.MinSymbols},
+	} {
+		count, err := getFieldMinCount(annotations, c.annotation, field)
+		if err != nil {
+			return req, err
+		}
+		*c.target = count
+	}
+	return req, nil
+}
+
+// getFieldExcludeAmbiguous returns whether ambiguous characters are excluded for a field.
+// Priority: exclude-ambiguous.<field> > exclude-ambiguous > false
+func (r *SecretReconciler) getFieldExcludeAmbiguous(annotations map[string]string, field string) bool {
+	if value, ok := parseBoolAnnotation(annotations, r.keys().ExcludeAmbiguous+"."+field); ok {
+		return value
+	}
+	value, _ := parseBoolAnnotation(annotations, r.keys().ExcludeAmbiguous)
+	return value
+}
+
+// checkFieldSafety checks that a field can only produce values safe for the contexts in its
+// safe-for annotation. Charset configuration errors are left to generateValue.
+func (r *SecretReconciler) checkFieldSafety(annotations map[string]string, field, genType string) error {
+	contexts := parseFields(getFieldAnnotation(annotations, r.keys().SafeFor, field))
+	if len(contexts) == 0 {
+		return nil
+	}
+
+	var charset string
+	switch genType {
+	case config.DefaultType, "":
+		var err error
+		if charset, err = r.getFieldCharset(annotations, field); err != nil {
+			return nil
+		}
+		if r.getFieldExcludeAmbiguous(annotations, field) {
+			if charset, err = generator.ExcludeAmbiguous(charset); err != nil {
+				return nil
+			}
+		}
+	case config.TypeURLSafePassword:
+		charset = generator.URLSafeCharset
+	case config.TypeNumeric:
+		charset = generator.NumericCharset
+	case config.TypePassphrase:
+		charset = generator.PassphraseCharset + r.keys().getFieldSeparator(annotations, field)
+	default:
+		return fmt.Errorf("safe-for is only supported for string, url-safe-password, numeric and passphrase fields, not %s", genType)
+	}
+
+	for _, context := range contexts {
+		unsafe, err := generator.UnsafeChars(charset, context)
+		if err != nil {
+			return err
+		}
+		if unsafe != "" {
+			return fmt.Errorf("charset contains characters %q that are not %s-safe", unsafe, context)
+		}
+		wrap := annotations[r.keys().ValuePrefixPrefix+field] + annotations[r.keys().ValueSuffixPrefix+field]
+		if unsafe, _ := generator.UnsafeChars(wrap, context); unsafe != "" {
+			return fmt.Errorf("prefix or suffix contains characters %q that are not %s-safe", unsafe, context)
+		}
+	}
+	return nil
+}
+
+// checkFieldEntropy rejects string, bytes, url-safe-password, numeric, passphrase and totp fields whose charset and length
+// give less entropy than defaults.minEntropyBits. Charset configuration errors are left to generateValue.
+func (r *SecretReconciler) checkFieldEntropy(annotations map[string]string, field, genType string) error {
+	minBits := r.currentConfig().Defaults.MinEntropyBits
+	if minBits <= 0 {
+		return nil
+	}
+	switch genType {
+	case config.DefaultType, config.TypeBytes, config.TypeURLSafePassword, config.TypeNumeric, config.TypePassphrase, config.TypeTOTP:
+	default:
+		return nil
+	}
+
+	bits, ok := r.fieldEntropyBits(annotations, field, genType)
+	if !ok || bits >= float64(minBits) {
+		return nil
+	}
+	return fmt.Errorf("estimated entropy of %.1f bits is below the minimum of %d bits, use a larger charset or length", bits, minBits)
+}
+
+// getFieldAnnotation returns the value of a field-specific annotation.
+// Priority: <annotation>.<field> > <annotation> > ""
+func getFieldAnnotation(annotations map[string]string, annotation, field string) string {
+	if v, ok := annotations[annotation+"."+field]; ok && v != "" {
+		return v
+	}
+	return annotations[annotation]
+}
+
+// getFieldJWTTTL returns the lifetime of a jwt field
+func (r *SecretReconciler) getFieldJWTTTL(annotations map[string]string, field string) (time.Duration, error) {
+	value := getFieldAnnotation(annotations, r.keys().JWTTTL, field)
+	if value == "" {
+		return config.DefaultJWTTTL, nil
+	}
+	ttl, err := config.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid jwt-ttl %q: %w", value, err)
+	}
+	if ttl <= 0 {
+		return 0, fmt.Errorf("jwt-ttl must be positive, got %s", value)
+	}
+	return ttl, nil
+}
+
+// jwtReissueInterval returns after how long a JWT with the given TTL is reissued.
+// Tokens are reissued after 80% of their lifetime, so consumers always see a valid token.
+func jwtReissueInterval(ttl time.Duration) time.Duration {
+	return ttl * 4 / 5
+}
+
+// getFieldEncoding returns the output encoding for a bytes field.
+// Priority: encoding.<field> annotation > encoding annotation > default (raw)
+func (r *SecretReconciler) getFieldEncoding(annotations map[string]string, field string) string {
+	if v, ok := annotations[r.keys().EncodingPrefix+field]; ok && v != "" {
+		return v
+	}
+	if v, ok := annotations[r.keys().Encoding]; ok && v != "" {
+		return v
+	}
+	return config.DefaultEncoding
+}
+
+// getFieldKeyEncoding returns the output encoding for a PEM keypair field.
+// Priority: key-encoding.<field> annotation > key-encoding annotation > pem
+func (r *SecretReconciler) getFieldKeyEncoding(annotations map[string]string, field string) string {
+	if v, ok := annotations[r.keys().KeyEncodingPrefix+field]; ok && v != "" {
+		return v
+	}
+	if v, ok := annotations[r.keys().KeyEncoding]; ok && v != "" {
+		return v
+	}
+	return config.DefaultKeyEncoding
+}
+
+// getFieldPublicKeyField returns the name of the field that receives the public key of a keypair field.
+// Priority: public-key-field.<field> annotation > <field>.pub
+func (r *SecretReconciler) getFieldPublicKeyField(annotations map[string]string, field string) string {
+	if v, ok := annotations[r.keys().PublicKeyFieldPrefix+field]; ok && v != "" {
+		return v
+	}
+	return field + ".pub"
+}
+
+// getFieldKeyFormat returns the private key format for an rsa field.
+// Priority: key-format.<field> annotation > pkcs1
+func (r *SecretReconciler) getFieldKeyFormat(annotations map[string]string, field string) string {
+	if v, ok := annotations[r.keys().KeyFormatPrefix+field]; ok && v != "" {
+		return v
+	}
+	return config.DefaultKeyFormat
+}
+
+// getFieldKeyPassphrase returns the passphrase encrypting the private key of a keypair field, read from
+// the field named by the key-passphrase-field.<field> annotation. It returns "" if the annotation is not set.
+func (r *SecretReconciler) getFieldKeyPassphrase(annotations map[string]string, data map[string][]byte, field string) (string, error) {
+	passphraseField := annotations[r.keys().KeyPassphraseFieldPrefix+field]
+	if passphraseField == "" {
+		return "", nil
+	}
+	if passphraseField == field {
+		return "", fmt.Errorf("passphrase field must differ from the field itself")
+	}
+	passphrase, ok := data[passphraseField]
+	if !ok || len(passphrase) == 0 {
+		return "", fmt.Errorf("passphrase field %s has no value", passphraseField)
+	}
+	return string(passphrase), nil
+}
+
+// getFieldRotationInterval returns the rotation interval for a specific field of a Secret in namespace.
+// Priority: rotate.<field> annotation > rotate annotation > namespace default > 0 (no rotation)
+// Fields with an immutable value never rotate.
+func (r *SecretReconciler) getFieldRotationInterval(namespace string, annotations map[string]string, field string) time.Duration {
+	if r.keys().isImmutableValueField(annotations, field) {
+		return 0
+	}
+	return r.configuredRotationInterval(namespace, annotations, field)
+}
+
+// configuredRotationInterval returns the rotation interval configured for field, ignoring immutable-value
+func (r *SecretReconciler) configuredRotationInterval(namespace string, annotations map[string]string, field string) time.Duration {
+	// JWTs are reissued based on their TTL, rotate annotations don't apply
+	if r.getFieldType(annotations, field) == config.TypeJWT {
+		ttl, err := r.getFieldJWTTTL(annotations, field)
+		if err != nil {
+			return 0
+		}
+		return jwtReissueInterval(ttl)
+	}
+
+	// Certificates are reissued based on their validity, rotate annotations don't apply
+	if r.getFieldType(annotations, field) == config.TypeCertificate {
+		validity, err := r.getFieldCertValidity(annotations, field)
+		if err != nil {
+			return 0
+		}
+		return certReissueInterval(validity)
+	}
+
+	// bcrypt fields are rehashed whenever their source is rotated
+	if r.getFieldType(annotations, field) == config.TypeBcrypt {
+		return 0
+	}
+
 	// Check for field-specific rotation annotation
-	fieldRotateKey := AnnotationRotatePrefix + field
+	fieldRotateKey := r.keys().RotatePrefix + field
 	if value, ok := annotations[fieldRotateKey]; ok && value != "" {
 		if duration, err := config.ParseDuration(value); err == nil {
 			return duration
 		}
 	}
 	// Check for default rotation annotation
-	if value, ok := annotations[AnnotationRotate]; ok && value != "" {
+	if value, ok := annotations[r.keys().Rotate]; ok && value != "" {
 		if duration, err := config.ParseDuration(value); err == nil {
 			return duration
 		}
 	}
-	// No rotation configured
+	// Check for the default of the namespace, 0 (no rotation) if there is none
+	return r.currentConfig().Rotation.NamespaceDefault(namespace)
+}
+
+// getFieldRotationOffset returns the rotation offset for a specific field, or 0 if none is configured
+func (r *SecretReconciler) getFieldRotationOffset(annotations map[string]string, field string) time.Duration {
+	if value, ok := annotations[r.keys().RotateOffsetPrefix+field]; ok && value != "" {
+		if duration, err := config.ParseDuration(value); err == nil && duration > 0 {
+			return duration
+		}
+	}
 	return 0
 }
 
-// getGeneratedAtTime parses the generated-at annotation and returns the time
-func (r *SecretReconciler) getGeneratedAtTime(annotations map[string]string) *time.Time {
-	if value, ok := annotations[AnnotationGeneratedAt]; ok && value != "" {
-		if t, err := time.Parse(time.RFC3339, value); err == nil {
-			return &t
+// hasRotationOffsets returns true if any field of the Secret has a rotation offset annotation
+func (k *annotationKeys) hasRotationOffsets(annotations map[string]string) bool {
+	for key := range annotations {
+		if strings.HasPrefix(key, k.RotateOffsetPrefix) {
+			return true
 		}
 	}
-	return nil
+	return false
+}
+
+// getFieldRotationBase returns the time a field's rotation interval is counted from.
+// This is the field's rotation anchor if set, otherwise generatedAt shifted by the field's offset.
+func (r *SecretReconciler) getFieldRotationBase(annotations map[string]string, field string, generatedAt *time.Time) *time.Time {
+	if anchor, ok := r.keys().readManagedMetadata(annotations).RotationAnchors[field]; ok {
+		return &anchor
+	}
+	if generatedAt == nil {
+		return nil
+	}
+	base := generatedAt.Add(r.getFieldRotationOffset(annotations, field))
+	return &base
+}
+
+// getGeneratedAtTime parses the generated-at annotation and returns the time
+func (r *SecretReconciler) getGeneratedAtTime(annotations map[string]string) *time.Time {
+	return r.keys().readManagedMetadata(annotations).GeneratedAt
 }
 
 // parseBoolAnnotation parses a boolean annotation value.
@@ -346,29 +1481,29 @@ type charsetOptions struct {
 // Priority: annotations > config defaults
 func (r *SecretReconciler) resolveCharsetOptions(annotations map[string]string) charsetOptions {
 	opts := charsetOptions{
-		uppercase:           r.Config.Defaults.String.Uppercase,
-		lowercase:           r.Config.Defaults.String.Lowercase,
-		numbers:             r.Config.Defaults.String.Numbers,
-		specialChars:        r.Config.Defaults.String.SpecialChars,
-		allowedSpecialChars: r.Config.Defaults.String.AllowedSpecialChars,
+		uppercase:           r.currentConfig().Defaults.String.Uppercase,
+		lowercase:           r.currentConfig().Defaults.String.Lowercase,
+		numbers:             r.currentConfig().Defaults.String.Numbers,
+		specialChars:        r.currentConfig().Defaults.String.SpecialChars,
+		allowedSpecialChars: r.currentConfig().Defaults.String.AllowedSpecialChars,
 	}
 
 	// Override with annotations if present
-	if val, ok := parseBoolAnnotation(annotations, AnnotationStringUppercase); ok {
+	if val, ok := parseBoolAnnotation(annotations, r.keys().StringUppercase); ok {
 		opts.uppercase = val
 	}
-	if val, ok := parseBoolAnnotation(annotations, AnnotationStringLowercase); ok {
+	if val, ok := parseBoolAnnotation(annotations, r.keys().StringLowercase); ok {
 		opts.lowercase = val
 	}
-	if val, ok := parseBoolAnnotation(annotations, AnnotationStringNumbers); ok {
+	if val, ok := parseBoolAnnotation(annotations, r.keys().StringNumbers); ok {
 		opts.numbers = val
 	}
-	if val, ok := parseBoolAnnotation(annotations, AnnotationStringSpecialChars); ok {
+	if val, ok := parseBoolAnnotation(annotations, r.keys().StringSpecialChars); ok {
 		opts.specialChars = val
 	}
 	// Note: We check for the annotation's existence, not just non-empty value
 	// This allows users to explicitly set it to empty if they want to override the config
-	if val, ok := annotations[AnnotationStringAllowedSpecialChars]; ok {
+	if val, ok := annotations[r.keys().StringAllowedSpecialChars]; ok {
 		opts.allowedSpecialChars = val
 	}
 
@@ -408,6 +1543,15 @@ func buildCharsetString(opts charsetOptions) string {
 	return charset
 }
 
+// getFieldCharset returns the charset of a string field.
+// Priority: charset-preset.<field> > charset-preset > string.* annotations > config defaults
+func (r *SecretReconciler) getFieldCharset(annotations map[string]string, field string) (string, error) {
+	if preset := getFieldAnnotation(annotations, r.keys().CharsetPreset, field); preset != "" {
+		return generator.CharsetForPreset(preset)
+	}
+	return r.getCharsetFromAnnotations(annotations)
+}
+
 // getCharsetFromAnnotations builds a charset based on annotations.
 // Priority: annotations > config defaults
 // Returns the charset and an error if the configuration is invalid.
@@ -423,124 +1567,407 @@ func (r *SecretReconciler) getCharsetFromAnnotations(annotations map[string]stri
 
 // secretUpdateResult contains the result of updating a secret
 type secretUpdateResult struct {
-	changed  bool
-	rotated  bool
-	err      error
-	skipRest bool
+	changed      bool
+	rotated      bool
+	updated      []string // fields that got a new value
+	userProvided []string // fields with values the operator didn't create, left alone
+	err          error
+	errMsg       string
+	skipRest     bool
 }
 
-// processSecretFields processes all fields that need generation or rotation.
-// It returns the update result indicating what changes were made.
-func (r *SecretReconciler) processSecretFields(
-	secret *corev1.Secret,
+// processFields processes all fields that need generation or rotation. data holds the values of
+// obj, a Secret or ConfigMap, and is updated in place along with obj's annotations; the Secret and
+// ConfigMap generators share it. takenHashes holds the value hashes of the Secret's uniqueness set by field, or nil if
+// values don't have to be unique. It returns the update result indicating what changes were made.
+func (r *SecretReconciler) processFields(
+	ctx context.Context,
+	obj client.Object,
+	data map[string][]byte,
 	fields []string,
 	generatedAt *time.Time,
+	forceRotation bool,
+	rotationThrottled bool,
+	takenHashes map[string]map[string]bool,
 	logger logr.Logger,
 ) secretUpdateResult {
+	annotations := obj.GetAnnotations()
 	result := secretUpdateResult{}
+	// A template cycle can never be rendered, so nothing is generated for it
+	if err := r.keys().checkTemplateCycles(annotations); err != nil {
+		result.err = err
+		result.errMsg = fmt.Sprintf("Invalid template: %v", err)
+		result.skipRest = true
+		logger.Error(err, "Templated fields reference each other in a cycle")
+		r.recordGenerationFailure(ctx, obj, result.errMsg)
+		return result
+	}
 
-	for _, field := range fields {
-		fieldResult := r.generateFieldValue(secret, field, generatedAt, logger)
+	trackAnchors := r.keys().hasRotationOffsets(annotations) || len(r.keys().readManagedMetadata(annotations).RotationAnchors) > 0
+	fieldResults := make(map[string]fieldGenerationResult, len(fields))
+	generatedFields, bcryptFields := r.splitBcryptFields(annotations, fields)
+	// written collects the data keys the operator created
+	var written []string
+
+	// With existing-values: adopt, values present at the first generation become the operator's
+	if generatedAt == nil && r.adoptsExistingValues(annotations) {
+		var existing []string
+		for _, field := range fields {
+			if hasFieldValue(data, field) {
+				existing = append(existing, field)
+			}
+		}
+		if len(existing) > 0 {
+			meta := r.readMetadata(annotations)
+			meta.addManagedFields(existing...)
+			meta.writeTo(annotations)
+		}
+	}
+	for _, field := range generatedFields {
+		if r.keys().isUserProvidedField(annotations, data, field) {
+			result.userProvided = append(result.userProvided, field)
+		}
+	}
+
+	for _, field := range generatedFields {
+		fieldResult := r.generateFieldValue(ctx, obj, data, field, generatedAt, forceRotation, rotationThrottled, takenHashes[field], logger)
 
 		if fieldResult.skipRest {
 			result.err = fieldResult.err
+			result.errMsg = fieldResult.errMsg
 			result.skipRest = true
 			return result
 		}
+		fieldResults[field] = fieldResult
+
+		// With rotation offsets, every rotating field keeps its own anchor so that
+		// rotating one field doesn't shift the schedule of the others
+		if trackAnchors && r.getFieldRotationInterval(obj.GetNamespace(), annotations, field) > 0 {
+			r.updateFieldRotationAnchor(annotations, field, fieldResult, generatedAt)
+		}
 
 		if fieldResult.value != nil {
-			secret.Data[field] = fieldResult.value
+			if fieldResult.rotated {
+				r.keepPreviousValue(annotations, data, field, fieldResult.gracePeriod)
+			}
+			data[field] = fieldResult.value
+			written = append(written, field)
 			// For keypair types, also store the public key
+			for companionField, companionValue := range fieldResult.companions {
+				data[companionField] = companionValue
+				written = append(written, companionField)
+			}
 			if fieldResult.publicKey != nil {
-				secret.Data[field+".pub"] = fieldResult.publicKey
+				publicKeyField := r.getFieldPublicKeyField(annotations, field)
+				data[publicKeyField] = fieldResult.publicKey
+				written = append(written, publicKeyField)
+			} else if takenHashes != nil {
+				meta := r.readMetadata(annotations)
+				meta.setValueHash(field, valueHash(fieldResult.value))
+				meta.writeTo(annotations)
+			}
+			if r.IntegrityKey != nil {
+				meta := r.readMetadata(annotations)
+				meta.setValueMAC(field, valueMAC(r.IntegrityKey, field, fieldResult.value))
+				meta.writeTo(annotations)
 			}
 			result.changed = true
+			result.updated = append(result.updated, field)
 			if fieldResult.rotated {
 				result.rotated = true
 			}
 		}
 	}
 
+	// The update moves generated-at. If some rotating fields keep their value, anchor every rotating
+	// field, so the kept ones still rotate at their current base instead of an interval from now.
+	if result.changed && !trackAnchors {
+		partial := false
+		for _, field := range generatedFields {
+			if fieldResults[field].value == nil && r.getFieldRotationInterval(obj.GetNamespace(), annotations, field) > 0 {
+				partial = true
+			}
+		}
+		if partial {
+			for _, field := range generatedFields {
+				if r.getFieldRotationInterval(obj.GetNamespace(), annotations, field) > 0 {
+					r.updateFieldRotationAnchor(annotations, field, fieldResults[field], generatedAt)
+				}
+			}
+		}
+	}
+
+	// bcrypt fields hash their source, so they follow its generation and rotation
+	hashedFields, err := r.hashBcryptFields(annotations, data, bcryptFields, generatedFields, result.updated)
+	if err != nil {
+		result.err = err
+		result.errMsg = fmt.Sprintf("Invalid bcrypt configuration: %v", err)
+		result.skipRest = true
+		logger.Error(err, "Failed to hash bcrypt fields")
+		r.recordGenerationFailure(ctx, obj, result.errMsg)
+		return result
+	}
+	for _, field := range hashedFields {
+		if r.IntegrityKey != nil {
+			meta := r.readMetadata(annotations)
+			meta.setValueMAC(field, valueMAC(r.IntegrityKey, field, data[field]))
+			meta.writeTo(annotations)
+		}
+		result.changed = true
+		result.updated = append(result.updated, field)
+		written = append(written, field)
+	}
+	if len(written) > 0 {
+		meta := r.readMetadata(annotations)
+		meta.addManagedFields(written...)
+		meta.writeTo(annotations)
+	}
+
+	// Templated fields are composed from the final values, so they follow every generation and rotation
+	templateChanged, err := r.renderTemplateFields(annotations, data, fields)
+	if err != nil {
+		result.err = err
+		result.errMsg = fmt.Sprintf("Invalid template: %v", err)
+		result.skipRest = true
+		logger.Error(err, "Failed to render templated fields")
+		r.recordGenerationFailure(ctx, obj, result.errMsg)
+		return result
+	}
+	if templateChanged {
+		result.changed = true
+	}
+
 	return result
 }
 
-// updateSecretAndEmitEvents updates the secret in Kubernetes and emits appropriate events.
+// updateFieldRotationAnchor records the rotation anchor of a field. A freshly generated field is
+// anchored at now plus its offset, a rotated field at now, and an unchanged field keeps its current base.
+func (r *SecretReconciler) updateFieldRotationAnchor(annotations map[string]string, field string, fieldResult fieldGenerationResult, generatedAt *time.Time) {
+	var anchor time.Time
+	switch {
+	case fieldResult.value != nil && fieldResult.rotated:
+		anchor = r.now()
+	case fieldResult.value != nil:
+		anchor = r.now().Add(r.getFieldRotationOffset(annotations, field))
+	default:
+		base := r.getFieldRotationBase(annotations, field, generatedAt)
+		if base == nil {
+			return
+		}
+		anchor = *base
+	}
+	meta := r.readMetadata(annotations)
+	if meta.RotationAnchors == nil {
+		meta.RotationAnchors = make(map[string]time.Time)
+	}
+	meta.RotationAnchors[field] = anchor
+	meta.writeTo(annotations)
+}
+
+// updateSecretAndEmitEvents updates obj, a Secret or ConfigMap, in Kubernetes and emits appropriate events.
 // It returns an error if the update fails.
 func (r *SecretReconciler) updateSecretAndEmitEvents(
 	ctx context.Context,
-	secret *corev1.Secret,
+	obj client.Object,
 	rotated bool,
+	trigger rotationTrigger,
 	logger logr.Logger,
 ) error {
 	// Update metadata annotations
-	if secret.Annotations == nil {
-		secret.Annotations = make(map[string]string)
+	if obj.GetAnnotations() == nil {
+		obj.SetAnnotations(make(map[string]string))
 	}
-	secret.Annotations[AnnotationGeneratedAt] = r.now().Format(time.RFC3339)
+	annotations := obj.GetAnnotations()
+	meta := r.readMetadata(annotations)
+	now := r.now()
+	meta.GeneratedAt = &now
 
-	// Update the secret
-	if err := r.Update(ctx, secret); err != nil {
-		logger.Error(err, "Failed to update Secret")
+	// Record the maintenance window the rotation happened in
+	windowName := ""
+	if rotated {
+		if window := r.currentConfig().Rotation.MaintenanceWindows.GetActiveWindow(now); window != nil {
+			windowName = window.Name
+		}
+		meta.LastRotationWindow = windowName
+		annotations[r.keys().LastRotationTime] = now.Format(time.RFC3339)
+		r.appendRotationHistory(annotations, now)
+	}
+	meta.writeTo(annotations)
+
+	// Update the object
+	if err := r.Update(ctx, obj); err != nil {
+		logger.Error(err, "Failed to update object")
 		return err
 	}
 
 	// Emit success event
-	r.emitSuccessEvent(secret, rotated, logger)
+	r.emitSuccessEvent(obj, rotated, trigger, windowName, logger)
 
 	return nil
 }
 
+// appendRotationHistory appends now to the rotation-history annotation, dropping the oldest
+// entries beyond the configured history size. An unparsable history is started over.
+// It is only written together with a rotation, so it never triggers a reconcile of its own.
+func (r *SecretReconciler) appendRotationHistory(annotations map[string]string, now time.Time) {
+	size := r.currentConfig().Rotation.HistorySize
+	if size <= 0 {
+		size = config.DefaultRotationHistorySize
+	}
+
+	var history []string
+	if err := json.Unmarshal([]byte(annotations[r.keys().RotationHistory]), &history); err != nil {
+		history = nil
+	}
+	history = append(history, now.Format(time.RFC3339))
+	if len(history) > size {
+		history = history[len(history)-size:]
+	}
+
+	data, err := json.Marshal(history)
+	if err != nil {
+		return
+	}
+	annotations[r.keys().RotationHistory] = string(data)
+}
+
 // emitSuccessEvent emits the appropriate success event based on whether rotation occurred.
-func (r *SecretReconciler) emitSuccessEvent(secret *corev1.Secret, rotated bool, logger logr.Logger) {
+// windowName is the maintenance window the rotation happened in, if any.
+// Rotation events are emitted if enabled in the config, if the rotation was requested with the
+// rotate-now annotation, or if the Secret names a consumer, which is then set as the event's
+// related object. Rotations of compromised Secrets always emit a Warning event.
+func (r *SecretReconciler) emitSuccessEvent(obj client.Object, rotated bool, trigger rotationTrigger, windowName string, logger logr.Logger) {
 	if rotated {
-		if r.Config.Rotation.CreateEvents {
-			r.EventRecorder.Eventf(secret, nil, corev1.EventTypeNormal, EventReasonRotationSucceeded, "Rotate",
-				"Successfully rotated values for secret fields")
+		consumer, err := r.keys().consumerReference(obj)
+		if err != nil {
+			logger.Error(err, "Ignoring invalid consumer annotation")
+		}
+		if trigger == rotationCompromised {
+			r.eventf(obj, consumer, corev1.EventTypeWarning, EventReasonCompromisedRotated, "Rotate",
+				"Rotated values for secret fields because the Secret was marked as compromised")
+			logger.Info("Rotated Secret values of compromised Secret")
+			return
+		}
+		if r.currentConfig().Rotation.CreateEvents || trigger == rotationManual || consumer != nil {
+			msg := "Successfully rotated values for secret fields"
+			if trigger == rotationManual {
+				msg += " (manually triggered)"
+			}
+			if windowName != "" {
+				msg = fmt.Sprintf("%s (window: %s)", msg, windowName)
+			}
+			r.eventf(obj, consumer, corev1.EventTypeNormal, EventReasonRotationSucceeded, "Rotate", "%s", msg)
 		}
-		logger.Info("Successfully rotated Secret values")
+		logger.Info("Successfully rotated Secret values", "window", windowName, "manual", trigger == rotationManual)
 	} else {
-		r.EventRecorder.Eventf(secret, nil, corev1.EventTypeNormal, EventReasonGenerationSucceeded, "Generate",
+		r.eventf(obj, nil, corev1.EventTypeNormal, EventReasonGenerationSucceeded, "Generate",
 			"Successfully generated values for secret fields")
 		logger.Info("Successfully updated Secret with generated values")
 	}
 }
 
+// consumerReference returns the workload named in the consumer annotation ("<kind>/<name>")
+// as an object reference in the Secret's namespace, or nil if the annotation is not set.
+func (k *annotationKeys) consumerReference(obj client.Object) (runtime.Object, error) {
+	value := strings.TrimSpace(obj.GetAnnotations()[k.Consumer])
+	if value == "" {
+		return nil, nil
+	}
+
+	kind, name, ok := strings.Cut(value, "/")
+	if !ok || name == "" {
+		return nil, fmt.Errorf("invalid consumer %q, must be <kind>/<name>", value)
+	}
+	meta := metav1.ObjectMeta{Name: name, Namespace: obj.GetNamespace()}
+
+	switch strings.ToLower(kind) {
+	case "deployment":
+		return &appsv1.Deployment{TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"}, ObjectMeta: meta}, nil
+	case "statefulset":
+		return &appsv1.StatefulSet{TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "StatefulSet"}, ObjectMeta: meta}, nil
+	case "daemonset":
+		return &appsv1.DaemonSet{TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "DaemonSet"}, ObjectMeta: meta}, nil
+	default:
+		return nil, fmt.Errorf("invalid consumer kind %q, must be deployment, statefulset, or daemonset", kind)
+	}
+}
+
 // fieldGenerationResult contains the result of processing a single field
 type fieldGenerationResult struct {
-	field     string
-	value     []byte
-	publicKey []byte // For keypair types: the public key value
-	rotated   bool
-	err       error
-	errMsg    string
-	skipRest  bool // if true, skip remaining fields and return error
+	field       string
+	value       []byte
+	publicKey   []byte            // For keypair types: the public key value
+	companions  map[string][]byte // Companion fields holding other encodings of the value
+	gracePeriod time.Duration     // How long the replaced value is kept in <field>-previous after a rotation
+	rotated     bool
+	err         error
+	errMsg      string
+	skipRest    bool // if true, skip remaining fields and return error
 }
 
 // valueGenerationResult contains the result of generating a value for a field.
 type valueGenerationResult struct {
-	value     []byte
-	publicKey []byte // For keypair types: the public key value
-	err       error
-	errMsg    string
+	value      []byte
+	publicKey  []byte            // For keypair types: the public key value
+	companions map[string][]byte // Companion fields holding other encodings of the value
+	err        error
+	errMsg     string
 }
 
-// generateValue generates the raw value for a field based on its type and length.
-// It returns the generated value (and public key for keypair types) or an error.
+// generateValue generates the raw value for a field of an object in namespace based on its type
+// and length. It returns the generated value (and public key for keypair types) or an error.
+// prefix is prepended to string and bytes values and doesn't count towards length.
 func (r *SecretReconciler) generateValue(
-	secret *corev1.Secret,
+	ctx context.Context,
+	namespace string,
+	annotations map[string]string, data map[string][]byte,
 	field string,
 	genType string,
 	length int,
+	prefix string,
 ) valueGenerationResult {
+	outputLength, err := r.keys().getFieldOutputLength(annotations, field)
+	if err != nil {
+		return fieldConfigError(field, "output length", err)
+	}
+	if outputLength > 0 && genType != config.TypeBytes {
+		return fieldConfigError(field, "output length", fmt.Errorf("output length is only supported for type %q, not %s", config.TypeBytes, genType))
+	}
+
 	switch genType {
 	case config.TypeRSA:
+		keyFormat := r.getFieldKeyFormat(annotations, field)
+		if keyFormat != config.KeyFormatPKCS1 && keyFormat != config.KeyFormatPKCS8 {
+			return fieldConfigError(field, "key format", fmt.Errorf("unsupported key format %q, must be 'pkcs1' or 'pkcs8'", keyFormat))
+		}
+		passphrase, err := r.getFieldKeyPassphrase(annotations, data, field)
+		if err != nil {
+			return fieldConfigError(field, "key passphrase", err)
+		}
+		if passphrase != "" && annotations[r.keys().KeyFormatPrefix+field] == config.KeyFormatPKCS1 {
+			return fieldConfigError(field, "key format", fmt.Errorf("encrypted private keys are always stored as pkcs8"))
+		}
 		return r.generateKeypairValue(field, genType, func() (string, string, error) {
-			return r.Generator.GenerateRSAKeypair(length)
+			switch {
+			case passphrase != "":
+				return r.Generator.GenerateEncryptedRSAKeypair(length, passphrase)
+			case keyFormat == config.KeyFormatPKCS8:
+				return r.Generator.GenerateRSAKeypairPKCS8(length)
+			default:
+				return r.Generator.GenerateRSAKeypair(length)
+			}
 		})
 
 	case config.TypeECDSA:
-		curveName := r.getFieldCurve(secret.Annotations, field)
+		curveName := r.getFieldCurve(annotations, field)
+		passphrase, err := r.getFieldKeyPassphrase(annotations, data, field)
+		if err != nil {
+			return fieldConfigError(field, "key passphrase", err)
+		}
 		return r.generateKeypairValue(field, genType, func() (string, string, error) {
+			if passphrase != "" {
+				return r.Generator.GenerateEncryptedECDSAKeypair(curveName, passphrase)
+			}
 			return r.Generator.GenerateECDSAKeypair(curveName)
 		})
 
@@ -548,32 +1975,86 @@ func (r *SecretReconciler) generateValue(
 		return r.generateKeypairValue(field, genType, r.Generator.GenerateEd25519Keypair)
 
 	case config.TypeMLKEM:
-		param := r.getFieldParam(secret.Annotations, field, config.DefaultMLKEMParam)
+		param := r.getFieldParam(annotations, field, config.DefaultMLKEMParam)
 		return r.generateKeypairValue(field, genType, func() (string, string, error) {
 			return r.Generator.GenerateMLKEMKeypair(param)
 		})
 
 	case config.TypeMLDSA:
-		param := r.getFieldParam(secret.Annotations, field, config.DefaultMLDSAParam)
+		param := r.getFieldParam(annotations, field, config.DefaultMLDSAParam)
 		return r.generateKeypairValue(field, genType, func() (string, string, error) {
 			return r.Generator.GenerateMLDSAKeypair(param)
 		})
 
 	case config.TypeSLHDSA:
-		param := r.getFieldParam(secret.Annotations, field, config.DefaultSLHDSAParam)
+		param := r.getFieldParam(annotations, field, config.DefaultSLHDSAParam)
 		return r.generateKeypairValue(field, genType, func() (string, string, error) {
 			return r.Generator.GenerateSLHDSAKeypair(param)
 		})
 
+	case config.TypeAge:
+		return r.generateKeypairValue(field, genType, r.Generator.GenerateAgeKeypair)
+
+	case config.TypeSSHRSA:
+		return r.generateKeypairValue(field, genType, func() (string, string, error) {
+			return r.Generator.GenerateSSHKeypair(generator.SSHKeyTypeRSA, length)
+		})
+
+	case config.TypeSSHEd25519:
+		return r.generateKeypairValue(field, genType, func() (string, string, error) {
+			return r.Generator.GenerateSSHKeypair(generator.SSHKeyTypeEd25519, 0)
+		})
+
+	case config.TypeJWT:
+		return r.generateJWTValue(annotations, data, field)
+
+	case config.TypeCertificate:
+		return r.generateCertificateValue(ctx, namespace, annotations, field)
+
+	case config.TypeHtpasswd:
+		return r.generateHtpasswdValue(annotations, field)
+
+	case config.TypePassphrase:
+		words, err := r.keys().getFieldWords(annotations, field)
+		if err != nil {
+			return fieldConfigError(field, "passphrase", err)
+		}
+		value, err := r.Generator.GeneratePassphrase(words, r.keys().getFieldSeparator(annotations, field))
+		if err != nil {
+			return valueGenerationResult{
+				err:    fmt.Errorf("failed to generate value for field %s: %w", field, err),
+				errMsg: fmt.Sprintf("Failed to generate value for field %q: %v", field, err),
+			}
+		}
+		return valueGenerationResult{value: []byte(value)}
+
 	case "string", "":
-		charset, charsetErr := r.getCharsetFromAnnotations(secret.Annotations)
+		charset, charsetErr := r.getFieldCharset(annotations, field)
+		if charsetErr == nil && r.getFieldExcludeAmbiguous(annotations, field) {
+			charset, charsetErr = generator.ExcludeAmbiguous(charset)
+		}
 		if charsetErr != nil {
 			return valueGenerationResult{
 				err:    fmt.Errorf("invalid charset configuration for field %s: %w", field, charsetErr),
 				errMsg: fmt.Sprintf("Invalid charset configuration for field %q: %v", field, charsetErr),
 			}
 		}
-		value, genErr := r.Generator.GenerateWithCharset(genType, length, charset)
+		complexity, complexityErr := r.getFieldComplexity(annotations, field)
+		if complexityErr != nil {
+			return valueGenerationResult{
+				err:    fmt.Errorf("invalid complexity requirements for field %s: %w", field, complexityErr),
+				errMsg: fmt.Sprintf("Invalid complexity requirements for field %q: %v", field, complexityErr),
+			}
+		}
+		var value string
+		var genErr error
+		if complexity.IsZero() {
+			value, genErr = r.Generator.GenerateWithPrefix(prefix, genType, length, charset)
+		} else {
+			complexity.Charset = charset
+			value, genErr = r.Generator.GenerateComplexString(length, complexity)
+			value = prefix + value
+		}
 		if genErr != nil {
 			return valueGenerationResult{
 				err:    fmt.Errorf("failed to generate value for field %s: %w", field, genErr),
@@ -583,16 +2064,217 @@ func (r *SecretReconciler) generateValue(
 		return valueGenerationResult{value: []byte(value)}
 
 	default:
-		// For bytes and any other type, use default Generate method
-		value, genErr := r.Generator.Generate(genType, length)
-		if genErr != nil {
+		result := r.generateEncodedValue(annotations, field, genType, length, outputLength)
+		if result.err == nil && prefix != "" {
+			result.value = append([]byte(prefix), result.value...)
+		}
+		return result
+	}
+}
+
+// generateEncodedValue generates a bytes field, or a field of any other type without dedicated
+// parameters, with the default Generate method. Output encodings only apply to the bytes type.
+func (r *SecretReconciler) generateEncodedValue(annotations map[string]string, field, genType string, length, outputLength int) valueGenerationResult {
+	encoding := config.EncodingRaw
+	if genType == config.TypeBytes {
+		encoding = r.getFieldEncoding(annotations, field)
+		if outputLength > 0 {
+			return r.generateBytesWithOutputLength(annotations, field, outputLength, encoding)
+		}
+		if companionEncodings := parseFields(annotations[r.keys().CompanionEncodingsPrefix+field]); len(companionEncodings) > 0 {
+			return r.generateBytesWithCompanions(field, length, encoding, companionEncodings)
+		}
+	}
+	value, genErr := r.Generator.GenerateEncoded(genType, length, encoding)
+	if genErr != nil {
+		return valueGenerationResult{
+			err:    fmt.Errorf("failed to generate value for field %s: %w", field, genErr),
+			errMsg: fmt.Sprintf("Failed to generate value for field %q: %v", field, genErr),
+		}
+	}
+	return valueGenerationResult{value: []byte(value)}
+}
+
+// generateBytesWithOutputLength generates a bytes field of exactly outputLength characters in
+// encoding. An explicit length.<field> must match the number of random bytes this takes.
+func (r *SecretReconciler) generateBytesWithOutputLength(annotations map[string]string, field string, outputLength int, encoding string) valueGenerationResult {
+	if len(parseFields(annotations[r.keys().CompanionEncodingsPrefix+field])) > 0 {
+		return fieldConfigError(field, "output length", fmt.Errorf("output length can't be combined with companion encodings"))
+	}
+	length, err := generator.BytesForOutputLength(outputLength, encoding)
+	if err != nil {
+		return fieldConfigError(field, "output length", err)
+	}
+	if value := annotations[r.keys().LengthPrefix+field]; value != "" && value != strconv.Itoa(length) {
+		return fieldConfigError(field, "output length", fmt.Errorf("length %s conflicts with output length %d, which takes %d random bytes in %s encoding", value, outputLength, length, encoding))
+	}
+
+	value, err := r.Generator.GenerateEncodedOutputLength(outputLength, encoding)
+	if err != nil {
+		return valueGenerationResult{
+			err:    fmt.Errorf("failed to generate value for field %s: %w", field, err),
+			errMsg: fmt.Sprintf("Failed to generate value for field %q: %v", field, err),
+		}
+	}
+	return valueGenerationResult{value: []byte(value)}
+}
+
+// generateBytesWithCompanions generates a bytes field in encoding and its companion fields
+// <field>.<encoding> in companionEncodings, all from a single random draw
+func (r *SecretReconciler) generateBytesWithCompanions(field string, length int, encoding string, companionEncodings []string) valueGenerationResult {
+	for _, companionEncoding := range companionEncodings {
+		if companionEncoding == encoding {
+			return fieldConfigError(field, "companion encodings", fmt.Errorf("companion encoding %q is the field's own encoding", companionEncoding))
+		}
+	}
+	_, encoded, err := r.Generator.GenerateBytesWithEncodings(length, append([]string{encoding}, companionEncodings...))
+	if err != nil {
+		return valueGenerationResult{
+			err:    fmt.Errorf("failed to generate value for field %s: %w", field, err),
+			errMsg: fmt.Sprintf("Failed to generate value for field %q: %v", field, err),
+		}
+	}
+	result := valueGenerationResult{
+		value:      []byte(encoded[encoding]),
+		companions: make(map[string][]byte, len(companionEncodings)),
+	}
+	for _, companionEncoding := range companionEncodings {
+		result.companions[field+"."+companionEncoding] = []byte(encoded[companionEncoding])
+	}
+	return result
+}
+
+// isPEMKeypairType returns true for keypair types whose output is PEM encoded
+func isPEMKeypairType(genType string) bool {
+	switch genType {
+	case config.TypeRSA, config.TypeECDSA, config.TypeEd25519:
+		return true
+	default:
+		return false
+	}
+}
+
+// fieldConfigError returns the generation result for an invalid setting of a field
+func fieldConfigError(field, setting string, err error) valueGenerationResult {
+	return valueGenerationResult{
+		err:    fmt.Errorf("invalid %s for field %s: %w", setting, field, err),
+		errMsg: fmt.Sprintf("Invalid %s for field %q: %v", setting, field, err),
+	}
+}
+
+// applyKeyEncoding converts a generated PEM keypair to the encoding requested for the field.
+// PEM output is returned unchanged; DER output contains the raw bytes of the PEM blocks.
+func (r *SecretReconciler) applyKeyEncoding(annotations map[string]string, field string, result valueGenerationResult) valueGenerationResult {
+	switch encoding := r.getFieldKeyEncoding(annotations, field); encoding {
+	case config.KeyEncodingPEM:
+		return result
+	case config.KeyEncodingDER:
+		privateKeyDER, err := generator.PEMToDER(string(result.value))
+		if err != nil {
 			return valueGenerationResult{
-				err:    fmt.Errorf("failed to generate value for field %s: %w", field, genErr),
-				errMsg: fmt.Sprintf("Failed to generate value for field %q: %v", field, genErr),
+				err:    fmt.Errorf("failed to DER-encode private key for field %s: %w", field, err),
+				errMsg: fmt.Sprintf("Failed to DER-encode private key for field %q: %v", field, err),
 			}
 		}
-		return valueGenerationResult{value: []byte(value)}
+		publicKeyDER, err := generator.PEMToDER(string(result.publicKey))
+		if err != nil {
+			return valueGenerationResult{
+				err:    fmt.Errorf("failed to DER-encode public key for field %s: %w", field, err),
+				errMsg: fmt.Sprintf("Failed to DER-encode public key for field %q: %v", field, err),
+			}
+		}
+		result.value = privateKeyDER
+		result.publicKey = publicKeyDER
+		return result
+	default:
+		return valueGenerationResult{
+			err:    fmt.Errorf("unsupported key encoding %q for field %s, must be 'pem' or 'der'", encoding, field),
+			errMsg: fmt.Sprintf("Unsupported key encoding %q for field %q, must be 'pem' or 'der'", encoding, field),
+		}
+	}
+}
+
+// addPublicJWK stores the public key of a generated PEM keypair as a JWK in jwkField, next to the
+// PEM public key
+func (r *SecretReconciler) addPublicJWK(annotations map[string]string, field, jwkField string, result valueGenerationResult) valueGenerationResult {
+	if jwkField == "" || jwkField == field || jwkField == r.getFieldPublicKeyField(annotations, field) {
+		return fieldConfigError(field, "jwk field", fmt.Errorf("JWK field %q must differ from the field and its public key field", jwkField))
+	}
+	jwk, err := generator.PublicKeyToJWK(string(result.publicKey))
+	if err != nil {
+		return valueGenerationResult{
+			err:    fmt.Errorf("failed to convert public key of field %s to JWK: %w", field, err),
+			errMsg: fmt.Sprintf("Failed to convert public key of field %q to JWK: %v", field, err),
+		}
+	}
+	if result.companions == nil {
+		result.companions = make(map[string][]byte, 1)
+	}
+	result.companions[jwkField] = []byte(jwk)
+	return result
+}
+
+// generateJWTValue generates a signed JWT for a field. The token is signed with the key stored in the
+// field named by the jwt-signing-key annotation. Without it, a new Ed25519 key is generated and its
+// public key is stored in the public key field (<field>.pub by default) so consumers can verify the token.
+func (r *SecretReconciler) generateJWTValue(annotations map[string]string, data map[string][]byte, field string) valueGenerationResult {
+	ttl, err := r.getFieldJWTTTL(annotations, field)
+	if err != nil {
+		return valueGenerationResult{
+			err:    fmt.Errorf("invalid JWT configuration for field %s: %w", field, err),
+			errMsg: fmt.Sprintf("Invalid JWT configuration for field %q: %v", field, err),
+		}
+	}
+	if minInterval, _ := r.currentConfig().Rotation.MinIntervalFor(config.TypeJWT); jwtReissueInterval(ttl) < minInterval {
+		err := fmt.Errorf("jwt-ttl %s is too short, reissue interval %s is below minimum %s",
+			ttl, jwtReissueInterval(ttl), minInterval)
+		return valueGenerationResult{
+			err:    fmt.Errorf("invalid JWT configuration for field %s: %w", field, err),
+			errMsg: fmt.Sprintf("Invalid JWT configuration for field %q: %v", field, err),
+		}
+	}
+
+	claims := generator.JWTClaims{
+		Issuer:   getFieldAnnotation(annotations, r.keys().JWTIssuer, field),
+		Subject:  getFieldAnnotation(annotations, r.keys().JWTSubject, field),
+		Audience: parseFields(getFieldAnnotation(annotations, r.keys().JWTAudience, field)),
+		IssuedAt: r.now(),
+		TTL:      ttl,
+	}
+
+	var signingKey, publicKey string
+	if keyField := getFieldAnnotation(annotations, r.keys().JWTSigningKey, field); keyField != "" {
+		keyPEM, ok := data[keyField]
+		if !ok || len(keyPEM) == 0 {
+			return valueGenerationResult{
+				err:    fmt.Errorf("signing key field %s for JWT field %s has no value", keyField, field),
+				errMsg: fmt.Sprintf("Signing key field %q for JWT field %q has no value", keyField, field),
+			}
+		}
+		signingKey = string(keyPEM)
+	} else {
+		signingKey, publicKey, err = r.Generator.GenerateEd25519Keypair()
+		if err != nil {
+			return valueGenerationResult{
+				err:    fmt.Errorf("failed to generate JWT signing key for field %s: %w", field, err),
+				errMsg: fmt.Sprintf("Failed to generate JWT signing key for field %q: %v", field, err),
+			}
+		}
+	}
+
+	token, err := r.Generator.GenerateJWT(signingKey, claims)
+	if err != nil {
+		return valueGenerationResult{
+			err:    fmt.Errorf("failed to generate JWT for field %s: %w", field, err),
+			errMsg: fmt.Sprintf("Failed to generate JWT for field %q: %v", field, err),
+		}
+	}
+
+	result := valueGenerationResult{value: []byte(token)}
+	if publicKey != "" {
+		result.publicKey = []byte(publicKey)
 	}
+	return result
 }
 
 // generateKeypairValue is a helper that generates a keypair using the provided function
@@ -620,27 +2302,77 @@ type rotationCheckResult struct {
 	needsRotation     bool
 	rotationInterval  time.Duration
 	timeUntilRotation *time.Duration
-	deferred          bool       // true if rotation was deferred due to maintenance window
-	deferredUntil     *time.Time // when the next maintenance window starts
+	deferred          bool       // true if rotation was deferred due to maintenance or blackout windows
+	deferredUntil     *time.Time // when rotation is allowed again
 	deferredWindow    string     // name of the window to defer to (for logging)
+	catchingUp        bool       // true if an overdue rotation waits for its catch-up delay
+	overdue           bool       // true if a due rotation is more than catchUpThreshold intervals late
 	err               error
 	errMsg            string
 }
 
 // parseSecretAnnotations parses the autogenerate annotation and returns the list of fields to generate.
 // Returns nil if the annotation is not present or empty.
-func parseSecretAnnotations(annotations map[string]string) []string {
-	autogenerate, ok := annotations[AnnotationAutogenerate]
+func (k *annotationKeys) parseSecretAnnotations(annotations map[string]string) []string {
+	autogenerate, ok := annotations[k.Autogenerate]
 	if !ok || autogenerate == "" {
 		return nil
 	}
 	return parseFields(autogenerate)
 }
 
+// nextDeferralTime returns when a deferred rotation should be retried and the name of
+// the window it was deferred to. By default this is the start of the next maintenance
+// window, or the end of a blackout window if that is later. With SpreadDeferredRotations
+// enabled, a point in the rest of that window is chosen based on key (namespace/name),
+// so deferred Secrets don't all fire at the window opening.
+func (r *SecretReconciler) nextDeferralTime(now time.Time, key string) (time.Time, string) {
+	windows := &r.currentConfig().Rotation.MaintenanceWindows
+	allowedAt := windows.NextRotationAllowed(now)
+	if allowedAt.IsZero() {
+		return time.Time{}, ""
+	}
+
+	// Find the window rotation is allowed in
+	window := windows.GetActiveWindow(allowedAt)
+	if window == nil {
+		return allowedAt, ""
+	}
+
+	if windows.SpreadDeferredRotations {
+		return allowedAt.Add(spreadOffset(key, window.NextEnd(allowedAt).Sub(allowedAt))), window.Name
+	}
+	return allowedAt, window.Name
+}
+
+// blackoutInfo describes the blackout window active at now for deferral messages
+func (r *SecretReconciler) blackoutInfo(now time.Time) string {
+	if blackout := r.currentConfig().Rotation.MaintenanceWindows.GetActiveBlackoutWindow(now); blackout != nil {
+		if blackout.Name != "" {
+			return fmt.Sprintf(", blackout window %s is active", blackout.Name)
+		}
+		return ", a blackout window is active"
+	}
+	return ""
+}
+
+// spreadOffset maps key to a stable offset in [0, span)
+func spreadOffset(key string, span time.Duration) time.Duration {
+	if span <= 0 {
+		return 0
+	}
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(key))
+	return time.Duration(h.Sum64() % uint64(span))
+}
+
 // checkFieldRotation checks if a field needs rotation based on annotations and timestamps.
 // It returns the rotation check result including whether rotation is needed and the time until next rotation.
-func (r *SecretReconciler) checkFieldRotation(annotations map[string]string, field string, generatedAt *time.Time) rotationCheckResult {
-	rotationInterval := r.getFieldRotationInterval(annotations, field)
+// key identifies the Secret (namespace/name) and is used to spread deferred rotations.
+func (r *SecretReconciler) checkFieldRotation(key string, annotations map[string]string, field string, generatedAt *time.Time) rotationCheckResult {
+	namespace, _, _ := strings.Cut(key, "/")
+	rotationInterval := r.getFieldRotationInterval(namespace, annotations, field)
+	generatedAt = r.getFieldRotationBase(annotations, field, generatedAt)
 
 	result := rotationCheckResult{
 		rotationInterval: rotationInterval,
@@ -650,41 +2382,50 @@ func (r *SecretReconciler) checkFieldRotation(annotations map[string]string, fie
 		return result
 	}
 
-	// Validate rotation interval against minInterval
-	if rotationInterval < r.Config.Rotation.MinInterval.Duration() {
-		result.err = fmt.Errorf("rotation interval %s for field %q is below minimum %s",
-			rotationInterval, field, r.Config.Rotation.MinInterval.Duration())
-		result.errMsg = result.err.Error()
-		return result
+	// Validate rotation interval against the minimum of the field's type.
+	// Too short JWT lifetimes are rejected when generating the token instead.
+	if genType := r.getFieldType(annotations, field); genType != config.TypeJWT {
+		if err := r.checkMinRotationInterval(field, genType, rotationInterval); err != nil {
+			result.err = err
+			result.errMsg = err.Error()
+			return result
+		}
+	}
+
+	// Secrets sharing an interval are staggered. The jitter only delays rotations; jwt
+	// fields are reissued on time, before the token expires.
+	if r.getFieldType(annotations, field) != config.TypeJWT {
+		rotationInterval += spreadOffset(key, r.currentConfig().Rotation.Jitter.Bound(rotationInterval))
+		result.rotationInterval = rotationInterval
 	}
 
 	if generatedAt != nil {
 		timeSinceGeneration := r.since(*generatedAt)
 		if timeSinceGeneration >= rotationInterval {
-			// Rotation is due - check if we're in a maintenance window
-			if r.Config.Rotation.MaintenanceWindows.Enabled {
+			// Rotation is due - check if we're in a maintenance window and outside blackout windows.
+			// Expiring JWTs are reissued regardless of maintenance and blackout windows.
+			if r.getFieldType(annotations, field) != config.TypeJWT {
 				now := r.now()
-				if !r.Config.Rotation.MaintenanceWindows.IsInAnyWindow(now) {
-					// Not in maintenance window - defer rotation
+				if !r.currentConfig().Rotation.MaintenanceWindows.IsRotationAllowed(now) {
+					// Not in maintenance window or blacked out - defer rotation
 					result.deferred = true
-					nextWindowStart := r.Config.Rotation.MaintenanceWindows.NextWindowStart(now)
-					if !nextWindowStart.IsZero() {
-						result.deferredUntil = &nextWindowStart
-						timeUntilWindow := nextWindowStart.Sub(now)
+					if deferredUntil, windowName := r.nextDeferralTime(now, key); !deferredUntil.IsZero() {
+						result.deferredUntil = &deferredUntil
+						timeUntilWindow := deferredUntil.Sub(now)
 						result.timeUntilRotation = &timeUntilWindow
-						// Find the window name for logging
-						for i := range r.Config.Rotation.MaintenanceWindows.Windows {
-							w := &r.Config.Rotation.MaintenanceWindows.Windows[i]
-							if w.NextStart(now).Equal(nextWindowStart) {
-								result.deferredWindow = w.Name
-								break
-							}
-						}
+						result.deferredWindow = windowName
 					}
 					return result
 				}
+				// Long overdue rotations after a downtime are staggered
+				if delay := r.catchUpDelay(key, now, timeSinceGeneration, rotationInterval); delay != nil {
+					result.catchingUp = true
+					result.timeUntilRotation = delay
+					return result
+				}
 			}
 			result.needsRotation = true
+			result.overdue = timeSinceGeneration > catchUpThreshold*rotationInterval
 		} else {
 			timeUntilRotation := rotationInterval - timeSinceGeneration
 			result.timeUntilRotation = &timeUntilRotation
@@ -698,27 +2439,67 @@ func (r *SecretReconciler) checkFieldRotation(annotations map[string]string, fie
 	return result
 }
 
+// checkMinRotationInterval returns an error if interval is below the minimum rotation interval of
+// genType, naming the minimum that applied: rotation.minIntervalByType or rotation.minInterval
+func (r *SecretReconciler) checkMinRotationInterval(field, genType string, interval time.Duration) error {
+	minInterval, typeSpecific := r.currentConfig().Rotation.MinIntervalFor(genType)
+	if interval >= minInterval {
+		return nil
+	}
+	if typeSpecific {
+		return fmt.Errorf("rotation interval %s for field %q is below minimum %s for type %s", interval, field, minInterval, genType)
+	}
+	return fmt.Errorf("rotation interval %s for field %q is below minimum %s", interval, field, minInterval)
+}
+
 // generateFieldValue generates a value for a single field based on its configuration.
 // It handles existing values, rotation checks, and value generation.
+// If forceRotation is set, an existing value is rotated regardless of its rotation interval.
+// If rotationThrottled is set, due rotations wait (except for jwt fields).
+// Values whose hash is in takenHashes are regenerated; keypairs are never compared.
 func (r *SecretReconciler) generateFieldValue(
-	secret *corev1.Secret,
+	ctx context.Context,
+	obj client.Object,
+	data map[string][]byte,
 	field string,
 	generatedAt *time.Time,
+	forceRotation bool,
+	rotationThrottled bool,
+	takenHashes map[string]bool,
 	logger logr.Logger,
 ) fieldGenerationResult {
+	annotations := obj.GetAnnotations()
 	result := fieldGenerationResult{field: field}
 
 	// Check if field already has a value
-	_, fieldExists := secret.Data[field]
+	fieldExists := hasFieldValue(data, field)
+	if !fieldExists && r.isInitialGenerationGated(r.now()) {
+		return result
+	}
+	// Values the operator didn't create are never rotated
+	if fieldExists && !r.keys().isManagedField(annotations, field) {
+		logger.V(1).Info("Field has a user-provided value, skipping", "field", field)
+		return result
+	}
+
+	// Immutable values are kept once generated, even by forced rotations
+	if fieldExists && r.keys().isImmutableValueField(annotations, field) {
+		r.logIgnoredRotation(obj, field, forceRotation, logger)
+		return result
+	}
 
 	// Check rotation status
-	rotationCheck := r.checkFieldRotation(secret.Annotations, field, generatedAt)
+	rotationCheck := r.checkFieldRotation(secretKey(obj), annotations, field, generatedAt)
+	if forceRotation && fieldExists {
+		rotationCheck = rotationCheckResult{needsRotation: true}
+	}
 
 	// Handle rotation validation error
 	// Note: We still allow initial generation even if rotation interval is invalid
 	if rotationCheck.err != nil {
 		logger.Error(nil, rotationCheck.errMsg, "field", field)
-		r.EventRecorder.Eventf(secret, nil, corev1.EventTypeWarning, EventReasonRotationFailed, "Rotate", rotationCheck.errMsg)
+		r.eventf(obj, nil, corev1.EventTypeWarning, EventReasonRotationFailed, "Rotate", "%s", rotationCheck.errMsg)
+		r.notify(ctx, obj, Notification{Reason: EventReasonRotationFailed, Fields: []string{field}, Message: rotationCheck.errMsg})
 		// If field exists, skip it (invalid rotation config prevents rotation)
 		// If field doesn't exist, we still generate the initial value
 		if fieldExists {
@@ -734,17 +2515,32 @@ func (r *SecretReconciler) generateFieldValue(
 			windowInfo = fmt.Sprintf(" (window: %s)", rotationCheck.deferredWindow)
 		}
 		if rotationCheck.deferredUntil != nil {
-			msg := fmt.Sprintf("Rotation for field %q deferred until next maintenance window at %s%s",
-				field, rotationCheck.deferredUntil.Format(time.RFC3339), windowInfo)
+			msg := fmt.Sprintf("Rotation for field %q deferred until next maintenance window at %s%s%s",
+				field, rotationCheck.deferredUntil.Format(time.RFC3339), windowInfo, r.blackoutInfo(r.now()))
 			logger.Info(msg, "field", field, "deferredUntil", rotationCheck.deferredUntil)
-			r.EventRecorder.Eventf(secret, nil, corev1.EventTypeNormal, EventReasonRotationDeferred, "Rotate", msg)
+			r.eventf(obj, nil, corev1.EventTypeNormal, EventReasonRotationDeferred, "Rotate", "%s", msg)
 		} else {
-			msg := fmt.Sprintf("Rotation for field %q deferred - no maintenance window configured", field)
+			msg := fmt.Sprintf("Rotation for field %q deferred - no upcoming maintenance window", field)
 			logger.Info(msg, "field", field)
 		}
 		return result
 	}
 
+	// Handle throttled rotation (too many rotations within the throttle period)
+	if rotationThrottled && fieldExists && rotationCheck.needsRotation && r.getFieldType(annotations, field) != config.TypeJWT {
+		logger.V(1).Info("Rotation throttled, skipping", "field", field)
+		return result
+	}
+
+	if rotationCheck.catchingUp && fieldExists {
+		logger.Info("Rotation is long overdue, delaying it to catch up after downtime", "field", field,
+			"requeueAfter", *rotationCheck.timeUntilRotation)
+		return result
+	}
+	if rotationCheck.overdue && fieldExists {
+		logger.Info("Catching up long overdue rotation", "field", field, "interval", rotationCheck.rotationInterval)
+	}
+
 	// Skip if field already has a value and doesn't need rotation
 	if fieldExists && !rotationCheck.needsRotation {
 		logger.V(1).Info("Field already has value, skipping", "field", field)
@@ -752,21 +2548,107 @@ func (r *SecretReconciler) generateFieldValue(
 	}
 
 	// Get field-specific generation parameters
-	genType := r.getFieldType(secret.Annotations, field)
-	length := r.getFieldLength(secret.Annotations, field)
+	genType := r.getFieldType(annotations, field)
+	length := r.getFieldLength(annotations, field)
 
 	// Generate the value based on type
-	genResult := r.generateValue(secret, field, genType, length)
+	var genResult valueGenerationResult
+	_, hasKeyPassphrase := annotations[r.keys().KeyPassphraseFieldPrefix+field]
+	_, hasKeyFormat := annotations[r.keys().KeyFormatPrefix+field]
+	valuePrefix, hasValuePrefix := annotations[r.keys().ValuePrefixPrefix+field]
+	valueSuffix, hasValueSuffix := annotations[r.keys().ValueSuffixPrefix+field]
+	_, hasCompanionEncodings := annotations[r.keys().CompanionEncodingsPrefix+field]
+	jwkField, hasJWKField := annotations[r.keys().JWKFieldPrefix+field]
+	versionField, hasVersionField := annotations[r.keys().VersionFieldPrefix+field]
+	gracePeriod, gracePeriodErr := r.getFieldGracePeriod(annotations, field)
+	count, countErr := r.keys().getFieldCount(annotations, field)
+	rotateStrategy, rotateStrategyErr := r.keys().getFieldRotateStrategy(annotations, field)
+	safetyErr := r.checkFieldSafety(annotations, field, genType)
+	entropyErr := r.checkFieldEntropy(annotations, field, genType)
+	generate := func() valueGenerationResult {
+		genResult := r.generateValue(ctx, obj.GetNamespace(), annotations, data, field, genType, length, valuePrefix)
+		if genResult.err == nil && hasValueSuffix {
+			genResult.value = []byte(string(genResult.value) + valueSuffix)
+		}
+		return genResult
+	}
+	if count > 0 {
+		// Scheduled round-robin rotations keep all but the oldest value; forced ones replace all
+		var kept []string
+		if fieldExists && rotateStrategy == RotateStrategyRoundRobin && !forceRotation {
+			kept = roundRobinKept(data[field], count)
+		}
+		generateOne := generate
+		generate = func() valueGenerationResult {
+			return generateList(generateOne, kept, count)
+		}
+	}
+	switch {
+	case gracePeriodErr != nil:
+		genResult = fieldConfigError(field, "grace period", gracePeriodErr)
+	case countErr != nil:
+		genResult = fieldConfigError(field, "count", countErr)
+	case rotateStrategyErr != nil:
+		genResult = fieldConfigError(field, "rotate strategy", rotateStrategyErr)
+	case count > 0 && !supportsCount(genType):
+		genResult = fieldConfigError(field, "count", fmt.Errorf("count is only supported for string, bytes, and passphrase fields, not %s", genType))
+	case count > 0 && hasCompanionEncodings:
+		genResult = fieldConfigError(field, "count", fmt.Errorf("count can't be combined with companion encodings"))
+	case safetyErr != nil:
+		genResult = fieldConfigError(field, "safe-for requirement", safetyErr)
+	case entropyErr != nil:
+		genResult = fieldConfigError(field, "charset or length", entropyErr)
+	case hasKeyPassphrase && genType != config.TypeRSA && genType != config.TypeECDSA:
+		genResult = fieldConfigError(field, "key passphrase", fmt.Errorf("private key encryption is only supported for rsa and ecdsa fields, not %s", genType))
+	case hasKeyFormat && genType != config.TypeRSA:
+		genResult = fieldConfigError(field, "key format", fmt.Errorf("key-format is only supported for rsa fields, not %s", genType))
+	case hasJWKField && !isPEMKeypairType(genType):
+		genResult = fieldConfigError(field, "jwk field", fmt.Errorf("JWK output is only supported for rsa, ecdsa, and ed25519 fields, not %s", genType))
+	case hasCompanionEncodings && genType != config.TypeBytes:
+		genResult = fieldConfigError(field, "companion encodings", fmt.Errorf("companion encodings are only supported for bytes fields, not %s", genType))
+	case (hasValuePrefix || hasValueSuffix) && genType != config.DefaultType && genType != config.TypeBytes:
+		genResult = fieldConfigError(field, "prefix or suffix", fmt.Errorf("prefix and suffix are only supported for string and bytes fields, not %s", genType))
+	default:
+		genResult = generate()
+		for attempt := 1; genResult.err == nil && genResult.publicKey == nil && takenHashes[valueHash(genResult.value)]; attempt++ {
+			if attempt == maxUniqueAttempts {
+				genResult = valueGenerationResult{
+					err:    fmt.Errorf("no value for field %s unique within its set after %d attempts", field, attempt),
+					errMsg: fmt.Sprintf("No value for field %q unique within its set after %d attempts, increase its length", field, attempt),
+				}
+				break
+			}
+			logger.Info("Generated value collides within uniqueness set, regenerating", "field", field, "attempt", attempt)
+			genResult = generate()
+		}
+	}
+	if genResult.err == nil && hasJWKField {
+		genResult = r.addPublicJWK(annotations, field, jwkField, genResult)
+	}
+	if genResult.err == nil && isPEMKeypairType(genType) {
+		genResult = r.applyKeyEncoding(annotations, field, genResult)
+	}
+	if genResult.err == nil && hasVersionField {
+		genResult = r.addVersion(annotations, data, field, versionField, genResult)
+	}
+	if genResult.err == nil && genResult.publicKey != nil && r.getFieldPublicKeyField(annotations, field) == field {
+		genResult = valueGenerationResult{
+			err:    fmt.Errorf("public key field of field %s must differ from the field itself", field),
+			errMsg: fmt.Sprintf("Public key field of field %q must differ from the field itself", field),
+		}
+	}
 	if genResult.err != nil {
 		result.err = genResult.err
 		result.errMsg = genResult.errMsg
 		result.skipRest = true
 		logger.Error(genResult.err, "Failed to generate value", "field", field, "type", genType)
-		r.EventRecorder.Eventf(secret, nil, corev1.EventTypeWarning, EventReasonGenerationFailed, "Generate", result.errMsg)
+		r.recordGenerationFailure(ctx, obj, result.errMsg)
 		return result
 	}
 	result.value = genResult.value
 	result.publicKey = genResult.publicKey
+	result.companions = genResult.companions
+	result.gracePeriod = gracePeriod
 
 	result.rotated = rotationCheck.needsRotation
 
@@ -781,11 +2663,11 @@ func (r *SecretReconciler) generateFieldValue(
 
 // calculateNextRotation calculates the next rotation time based on all fields with rotation configured.
 // It returns the minimum time until the next rotation across all fields.
-func (r *SecretReconciler) calculateNextRotation(annotations map[string]string, fields []string, generatedAt *time.Time) *time.Duration {
+func (r *SecretReconciler) calculateNextRotation(key string, annotations map[string]string, fields []string, generatedAt *time.Time) *time.Duration {
 	var nextRotation *time.Duration
 
 	for _, field := range fields {
-		rotationCheck := r.checkFieldRotation(annotations, field, generatedAt)
+		rotationCheck := r.checkFieldRotation(key, annotations, field, generatedAt)
 
 		// Skip fields with validation errors
 		if rotationCheck.err != nil {
@@ -809,19 +2691,78 @@ func (r *SecretReconciler) calculateNextRotation(annotations map[string]string,
 
 // SetupWithManager sets up the controller with the Manager
 func (r *SecretReconciler) SetupWithManager(mgr ctrl.Manager) error {
+	// Periodically export metrics about the managed fields, computed from the cache
+	if r.currentConfig().Metrics.InventoryInterval.Duration() > 0 {
+		if err := mgr.Add(manager.RunnableFunc(r.runManagedFieldsMetrics)); err != nil {
+			return err
+		}
+	}
+
+	b := ctrl.NewControllerManagedBy(mgr).
+		Named("secret-generator").
+		For(&corev1.Secret{}).
+		WithOptions(r.controllerOptions())
+	for _, filter := range r.eventFilters() {
+		b = b.WithEventFilter(filter)
+	}
+	return b.Complete(r)
+}
+
+// controllerOptions returns the options of the controllers reconciling with r. Reconcile is safe
+// to run concurrently: the state kept across reconciles is guarded by mutexes.
+func (r *SecretReconciler) controllerOptions() controller.Options {
+	return controller.Options{MaxConcurrentReconciles: r.currentConfig().MaxConcurrentReconciles}
+}
+
+// eventFilters returns the predicates selecting the Secrets to reconcile
+func (r *SecretReconciler) eventFilters() []predicate.Predicate {
+	// Only Secrets in the configured namespaces are reconciled. The filters read the current
+	// configuration, so they follow reloads.
+	inAllowedNamespace := scopePredicate(func(object client.Object) bool {
+		return r.currentConfig().Namespaces.Allows(object.GetNamespace())
+	})
+
+	// Only Secrets matching the label selector are reconciled
+	matchesLabelSelector := scopePredicate(func(object client.Object) bool {
+		return r.currentConfig().Selector().Matches(labels.Set(object.GetLabels()))
+	})
+
 	// Create a predicate that filters secrets with the autogenerate annotation
-	hasAutogenerateAnnotation := predicate.NewPredicateFuncs(func(object client.Object) bool {
+	hasAutogenerateAnnotation := scopePredicate(func(object client.Object) bool {
 		annotations := object.GetAnnotations()
 		if annotations == nil {
 			return false
 		}
-		_, ok := annotations[AnnotationAutogenerate]
+		_, ok := annotations[r.keys().Autogenerate]
 		return ok
 	})
 
-	return ctrl.NewControllerManagedBy(mgr).
-		Named("secret-generator").
-		For(&corev1.Secret{}).
-		WithEventFilter(hasAutogenerateAnnotation).
-		Complete(r)
+	// Writing the status annotations must not trigger another reconcile
+	ignoreStatusUpdates := predicate.Funcs{
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return !r.keys().isStatusOnlyUpdate(e.ObjectOld, e.ObjectNew)
+		},
+	}
+
+	return []predicate.Predicate{inAllowedNamespace, matchesLabelSelector, hasAutogenerateAnnotation, ignoreStatusUpdates}
+}
+
+// scopePredicate selects the objects inScope returns true for. Updates pass if the old or the new
+// object is in scope, so Reconcile also sees an object leave the scope, e.g. when a label is
+// removed, and forgets its next rotation.
+func scopePredicate(inScope func(client.Object) bool) predicate.Funcs {
+	return predicate.Funcs{
+		CreateFunc: func(e event.CreateEvent) bool {
+			return inScope(e.Object)
+		},
+		UpdateFunc: func(e event.UpdateEvent) bool {
+			return inScope(e.ObjectOld) || inScope(e.ObjectNew)
+		},
+		DeleteFunc: func(e event.DeleteEvent) bool {
+			return inScope(e.Object)
+		},
+		GenericFunc: func(e event.GenericEvent) bool {
+			return inScope(e.Object)
+		},
+	}
 }
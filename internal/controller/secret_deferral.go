@@ -0,0 +1,125 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Reasons initial generation of missing fields is deferred
+const (
+	deferralDryRun            = "dry-run mode is enabled"
+	deferralEntropy           = "entropy too low"
+	deferralMaintenanceWindow = "outside maintenance window"
+	deferralBlackoutWindow    = "blackout window is active"
+)
+
+// deferredGeneration describes why missing fields of a Secret aren't generated
+type deferredGeneration struct {
+	reason    string
+	eventType string
+	fields    []string
+	// detail is appended to the event message, e.g. the time generation is deferred until
+	detail string
+}
+
+// generationDeferrals remembers the deferral reason last reported per Secret, so the
+// GenerationDeferred event is only created when the reason changes and not on every requeue.
+type generationDeferrals struct {
+	mu      sync.Mutex
+	reasons map[string]string
+}
+
+// transition records reason for key and returns true if it differs from the recorded one.
+// An empty reason forgets key.
+func (d *generationDeferrals) transition(key, reason string) bool {
+	d.mu.Lock()
+	defer d.mu.Unlock()
+
+	if d.reasons[key] == reason {
+		return false
+	}
+	if reason == "" {
+		delete(d.reasons, key)
+		return true
+	}
+	if d.reasons == nil {
+		d.reasons = make(map[string]string)
+	}
+	d.reasons[key] = reason
+	return true
+}
+
+// missingFields returns the fields of the Secret without a value
+func missingFields(data map[string][]byte, fields []string) []string {
+	var missing []string
+	for _, field := range fields {
+		if !hasFieldValue(data, field) {
+			missing = append(missing, field)
+		}
+	}
+	return missing
+}
+
+// intersectFields returns the fields contained in both lists, in the order of the first
+func intersectFields(fields, other []string) []string {
+	var both []string
+	for _, field := range fields {
+		if slices.Contains(other, field) {
+			both = append(both, field)
+		}
+	}
+	return both
+}
+
+// lowEntropyDeferral returns the deferral of the missing fields whose charset and length
+// fall below the minimum entropy, or nil if there are none
+func (r *SecretReconciler) lowEntropyDeferral(secret *corev1.Secret, missing []string) *deferredGeneration {
+	var fields []string
+	for _, field := range missing {
+		if r.checkFieldEntropy(secret.Annotations, field, r.getFieldType(secret.Annotations, field)) != nil {
+			fields = append(fields, field)
+		}
+	}
+	if len(fields) == 0 {
+		return nil
+	}
+	return &deferredGeneration{reason: deferralEntropy, eventType: corev1.EventTypeWarning, fields: fields}
+}
+
+// reportGenerationDeferral creates a GenerationDeferred event if the reason initial generation
+// of the Secret is deferred changed since the last reconcile. A nil deferral marks the Secret
+// as not deferred, so a later deferral is reported again.
+func (r *SecretReconciler) reportGenerationDeferral(secret *corev1.Secret, deferral *deferredGeneration, logger logr.Logger) {
+	if deferral == nil || len(deferral.fields) == 0 {
+		r.state().deferrals.transition(secretKey(secret), "")
+		return
+	}
+	if !r.state().deferrals.transition(secretKey(secret), deferral.reason) {
+		return
+	}
+	msg := fmt.Sprintf("Initial generation of fields %s deferred: %s%s",
+		strings.Join(deferral.fields, ", "), deferral.reason, deferral.detail)
+	logger.Info(msg, "name", secret.Name, "namespace", secret.Namespace)
+	r.eventf(secret, nil, deferral.eventType, EventReasonGenerationDeferred, "Generate", "%s", msg)
+}
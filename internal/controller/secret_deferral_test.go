@@ -0,0 +1,227 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/generator"
+)
+
+// TestReconcileGenerationDeferredOnce tests that skipping a missing field creates a single
+// GenerationDeferred event, however often the Secret is reconciled
+func TestReconcileGenerationDeferredOnce(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		configure     func(cfg *config.Config)
+		wantEventType string
+		wantMessage   string
+	}{
+		{
+			name: "dry-run",
+			annotations: map[string]string{
+				AnnotationAutogenerate: "password",
+			},
+			configure: func(cfg *config.Config) {
+				cfg.DryRun = true
+			},
+			wantEventType: corev1.EventTypeNormal,
+			wantMessage:   "Initial generation of fields password deferred: dry-run mode is enabled",
+		},
+		{
+			name: "entropy too low",
+			annotations: map[string]string{
+				AnnotationAutogenerate:         "password,pin",
+				AnnotationLengthPrefix + "pin": "4",
+			},
+			configure: func(cfg *config.Config) {
+				cfg.Defaults.MinEntropyBits = 64
+			},
+			wantEventType: corev1.EventTypeWarning,
+			wantMessage:   "Initial generation of fields pin deferred: entropy too low",
+		},
+		{
+			name: "blackout",
+			annotations: map[string]string{
+				AnnotationAutogenerate: "password",
+			},
+			configure: func(cfg *config.Config) {
+				cfg.Rotation.GateInitialGeneration = true
+				cfg.Rotation.MaintenanceWindows = config.MaintenanceWindowsConfig{
+					BlackoutWindows: []config.MaintenanceWindow{{
+						Name:      "code-freeze",
+						Date:      "2026-02-07",
+						StartTime: "00:00",
+						EndTime:   "04:00",
+						Timezone:  "UTC",
+					}},
+				}
+			},
+			wantEventType: corev1.EventTypeNormal,
+			wantMessage:   "Initial generation of fields password deferred: blackout window is active, until next maintenance window at 2026-02-07T04:00:00Z",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "deferred",
+					Namespace:   "default",
+					Annotations: tt.annotations,
+				},
+			}
+
+			cfg := config.NewDefaultConfig()
+			if tt.configure != nil {
+				tt.configure(cfg)
+			}
+
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+			fakeRecorder := NewTestEventRecorder(20)
+			reconciler := &SecretReconciler{
+				Client:        fakeClient,
+				Scheme:        scheme,
+				Generator:     generator.NewSecretGenerator(),
+				Config:        cfg,
+				EventRecorder: fakeRecorder,
+				Clock:         &MockClock{currentTime: time.Date(2026, 2, 7, 1, 0, 0, 0, time.UTC)},
+			}
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}}
+
+			for i := 0; i < 3; i++ {
+				if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+			}
+
+			var deferred []string
+			for len(fakeRecorder.Events) > 0 {
+				if event := <-fakeRecorder.Events; strings.Contains(event, EventReasonGenerationDeferred) {
+					deferred = append(deferred, event)
+				}
+			}
+			if len(deferred) != 1 {
+				t.Fatalf("expected exactly one generation deferred event, got %d: %v", len(deferred), deferred)
+			}
+			if !strings.HasPrefix(deferred[0], tt.wantEventType+" ") || !strings.Contains(deferred[0], tt.wantMessage) {
+				t.Errorf("expected %s event %q, got: %s", tt.wantEventType, tt.wantMessage, deferred[0])
+			}
+		})
+	}
+}
+
+// TestReconcileGenerationDeferredAgainAfterTransition tests that the deferral is reported again
+// once it ended and the Secret is deferred anew
+func TestReconcileGenerationDeferredAgainAfterTransition(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "deferred",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationAutogenerate: "password",
+			},
+		},
+	}
+
+	cfg := config.NewDefaultConfig()
+	cfg.DryRun = true
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	fakeRecorder := NewTestEventRecorder(20)
+	reconciler := &SecretReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Generator:     generator.NewSecretGenerator(),
+		Config:        cfg,
+		EventRecorder: fakeRecorder,
+	}
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}}
+
+	countDeferred := func() int {
+		count := 0
+		for len(fakeRecorder.Events) > 0 {
+			if strings.Contains(<-fakeRecorder.Events, EventReasonGenerationDeferred) {
+				count++
+			}
+		}
+		return count
+	}
+	setAnnotation := func(key, value string) {
+		var current corev1.Secret
+		if err := fakeClient.Get(ctx, req.NamespacedName, &current); err != nil {
+			t.Fatalf("failed to get secret: %v", err)
+		}
+		if value == "" {
+			delete(current.Annotations, key)
+		} else {
+			current.Annotations[key] = value
+		}
+		if err := fakeClient.Update(ctx, &current); err != nil {
+			t.Fatalf("failed to update secret: %v", err)
+		}
+	}
+
+	// Dry run: reported once
+	for i := 0; i < 2; i++ {
+		if _, err := reconciler.Reconcile(ctx, req); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	if got := countDeferred(); got != 1 {
+		t.Fatalf("expected one generation deferred event in dry-run mode, got %d", got)
+	}
+
+	// Dry run disabled: the field is generated, nothing is deferred
+	cfg.DryRun = false
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := countDeferred(); got != 0 {
+		t.Fatalf("expected no generation deferred event after disabling dry-run mode, got %d", got)
+	}
+
+	// A new missing field in dry-run mode again is reported again
+	setAnnotation(AnnotationAutogenerate, "password,token")
+	cfg.DryRun = true
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := countDeferred(); got != 1 {
+		t.Fatalf("expected generation deferred event after enabling dry-run mode again, got %d", got)
+	}
+}
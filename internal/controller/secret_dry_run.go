@@ -0,0 +1,40 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// recordDryRun reports the changes a reconcile would have made to the Secret in dry-run mode.
+// Only field names are reported, never values.
+func (r *SecretReconciler) recordDryRun(secret *corev1.Secret, result secretUpdateResult, logger logr.Logger) {
+	action := "generate"
+	if result.rotated {
+		action = "rotate"
+	}
+	msg := "Dry run: would update templated fields"
+	if len(result.updated) > 0 {
+		msg = fmt.Sprintf("Dry run: would %s fields %s", action, strings.Join(result.updated, ", "))
+	}
+	logger.Info(msg, "name", secret.Name, "namespace", secret.Namespace)
+	r.eventf(secret, nil, corev1.EventTypeNormal, EventReasonDryRunAction, "DryRun", "%s", msg)
+}
@@ -0,0 +1,150 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/generator"
+)
+
+func TestReconcileDryRun(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name          string
+		annotations   map[string]string
+		data          map[string][]byte
+		expectedEvent string
+		// deferredEvent is the GenerationDeferred event expected after the first dry-run event
+		deferredEvent string
+	}{
+		{
+			name: "missing fields",
+			annotations: map[string]string{
+				AnnotationAutogenerate: "password,token",
+			},
+			expectedEvent: "Dry run: would generate fields password, token",
+			deferredEvent: "Initial generation of fields password, token deferred: dry-run mode is enabled",
+		},
+		{
+			name: "due rotation",
+			annotations: map[string]string{
+				AnnotationAutogenerate: "password",
+				AnnotationGeneratedAt:  "2026-02-01T10:00:00Z",
+				AnnotationRotate:       "24h",
+			},
+			data:          map[string][]byte{"password": []byte("old-password")},
+			expectedEvent: "Dry run: would rotate fields password",
+		},
+		{
+			name: "adoption",
+			annotations: map[string]string{
+				AnnotationAutogenerate:   "password",
+				AnnotationExistingValues: "adopt",
+			},
+			data:          map[string][]byte{"password": []byte("old-password")},
+			expectedEvent: "Dry run: would adopt existing values of fields password",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "dry-run",
+					Namespace:   "default",
+					Annotations: tt.annotations,
+				},
+				Data: tt.data,
+			}
+
+			cfg := config.NewDefaultConfig()
+			cfg.DryRun = true
+
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+			fakeRecorder := NewTestEventRecorder(10)
+			reconciler := &SecretReconciler{
+				Client:        fakeClient,
+				Scheme:        scheme,
+				Generator:     generator.NewSecretGenerator(),
+				Config:        cfg,
+				EventRecorder: fakeRecorder,
+				Clock:         &MockClock{currentTime: time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)},
+			}
+			ctx := context.Background()
+			key := types.NamespacedName{Name: "dry-run", Namespace: "default"}
+
+			var original corev1.Secret
+			if err := fakeClient.Get(ctx, key, &original); err != nil {
+				t.Fatalf("failed to get secret: %v", err)
+			}
+
+			// Every reconcile reports the change again, none makes it
+			for i := 0; i < 3; i++ {
+				result, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: key})
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if result.RequeueAfter != 0 {
+					t.Errorf("expected no requeue in dry-run mode, got %s", result.RequeueAfter)
+				}
+
+				select {
+				case event := <-fakeRecorder.Events:
+					if !strings.Contains(event, EventReasonDryRunAction) || !strings.Contains(event, tt.expectedEvent) {
+						t.Errorf("expected dry-run event %q, got: %s", tt.expectedEvent, event)
+					}
+				default:
+					t.Error("expected dry-run event to be recorded")
+				}
+				// The deferral is only reported by the first reconcile
+				if i == 0 && tt.deferredEvent != "" {
+					select {
+					case event := <-fakeRecorder.Events:
+						if !strings.Contains(event, EventReasonGenerationDeferred) || !strings.Contains(event, tt.deferredEvent) {
+							t.Errorf("expected generation deferred event %q, got: %s", tt.deferredEvent, event)
+						}
+					default:
+						t.Error("expected generation deferred event to be recorded")
+					}
+				}
+			}
+
+			var updated corev1.Secret
+			if err := fakeClient.Get(ctx, key, &updated); err != nil {
+				t.Fatalf("failed to get secret: %v", err)
+			}
+			if updated.ResourceVersion != original.ResourceVersion {
+				t.Errorf("expected secret not to be written, resource version %s -> %s", original.ResourceVersion, updated.ResourceVersion)
+			}
+		})
+	}
+}
@@ -0,0 +1,45 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// eventf records an event about regarding, unless the events config disables events with reason
+func (r *SecretReconciler) eventf(regarding, related runtime.Object, eventtype, reason, action, note string, args ...interface{}) {
+	if !r.eventEnabled(reason) {
+		return
+	}
+	r.EventRecorder.Eventf(regarding, related, eventtype, reason, action, note, args...)
+}
+
+// eventEnabled returns whether events with reason are recorded. Reasons outside of the
+// generation, rotation, and failure groups, e.g. TamperDetected or CompromisedRotated, always are.
+func (r *SecretReconciler) eventEnabled(reason string) bool {
+	events := r.currentConfig().Events
+	switch reason {
+	case EventReasonGenerationSucceeded, EventReasonValuesAdopted:
+		return events.OnGeneration
+	case EventReasonRotationSucceeded, EventReasonRotationDeferred:
+		return events.OnRotation
+	case EventReasonGenerationFailed, EventReasonRotationFailed, EventReasonNotificationFailed, EventReasonGenerationRecovered:
+		return events.OnFailure
+	default:
+		return true
+	}
+}
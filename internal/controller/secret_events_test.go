@@ -0,0 +1,152 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/generator"
+)
+
+func TestEventEnabled(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	cfg.Events = config.EventsConfig{OnGeneration: false, OnRotation: true, OnFailure: false}
+	reconciler := &SecretReconciler{Config: cfg}
+
+	tests := []struct {
+		reason   string
+		expected bool
+	}{
+		{EventReasonGenerationSucceeded, false},
+		{EventReasonValuesAdopted, false},
+		{EventReasonRotationSucceeded, true},
+		{EventReasonRotationDeferred, true},
+		{EventReasonGenerationFailed, false},
+		{EventReasonRotationFailed, false},
+		{EventReasonNotificationFailed, false},
+		{EventReasonGenerationRecovered, false},
+		{EventReasonTamperDetected, true},
+		{EventReasonCompromisedRotated, true},
+	}
+	for _, tt := range tests {
+		if got := reconciler.eventEnabled(tt.reason); got != tt.expected {
+			t.Errorf("eventEnabled(%s) = %v, expected %v", tt.reason, got, tt.expected)
+		}
+	}
+}
+
+func TestReconcileEventVerbosity(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	oldTime := time.Now().Add(-2 * time.Hour)
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		data        map[string][]byte
+		events      func(*config.EventsConfig)
+		reason      string
+	}{
+		{
+			name:        "generation",
+			annotations: map[string]string{AnnotationAutogenerate: "password"},
+			events:      func(e *config.EventsConfig) { e.OnGeneration = false },
+			reason:      EventReasonGenerationSucceeded,
+		},
+		{
+			name: "rotation",
+			annotations: map[string]string{
+				AnnotationAutogenerate: "password",
+				AnnotationRotate:       "1h",
+				AnnotationGeneratedAt:  oldTime.Format(time.RFC3339),
+			},
+			data:   map[string][]byte{"password": []byte("old-password")},
+			events: func(e *config.EventsConfig) { e.OnRotation = false },
+			reason: EventReasonRotationSucceeded,
+		},
+		{
+			name: "failure",
+			annotations: map[string]string{
+				AnnotationAutogenerate: "password",
+				AnnotationType:         "unknown",
+			},
+			events: func(e *config.EventsConfig) { e.OnFailure = false },
+			reason: EventReasonGenerationFailed,
+		},
+	}
+
+	for _, tt := range tests {
+		for _, enabled := range []bool{true, false} {
+			name := tt.name + " enabled"
+			if !enabled {
+				name = tt.name + " disabled"
+			}
+			t.Run(name, func(t *testing.T) {
+				secret := &corev1.Secret{
+					ObjectMeta: metav1.ObjectMeta{
+						Name:        "events",
+						Namespace:   "default",
+						Annotations: tt.annotations,
+					},
+					Data: tt.data,
+				}
+				cfg := config.NewDefaultConfig()
+				cfg.Rotation.CreateEvents = true
+				if !enabled {
+					tt.events(&cfg.Events)
+				}
+				fakeRecorder := NewTestEventRecorder(10)
+				reconciler := &SecretReconciler{
+					Client:        fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build(),
+					Scheme:        scheme,
+					Generator:     generator.NewSecretGenerator(),
+					Config:        cfg,
+					EventRecorder: fakeRecorder,
+				}
+
+				req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "events", Namespace: "default"}}
+				if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+
+				recorded := false
+				close(fakeRecorder.Events)
+				for event := range fakeRecorder.Events {
+					if strings.Contains(event, " "+tt.reason+":") {
+						recorded = true
+					}
+				}
+				if recorded != enabled {
+					t.Errorf("expected %s event recorded %v, got %v", tt.reason, enabled, recorded)
+				}
+			})
+		}
+	}
+}
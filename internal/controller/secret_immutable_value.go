@@ -0,0 +1,39 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// isImmutableValueField returns true if field is generate-once (immutable-value.<field>: "true")
+func (k *annotationKeys) isImmutableValueField(annotations map[string]string, field string) bool {
+	immutable, _ := parseBoolAnnotation(annotations, k.ImmutableValuePrefix+field)
+	return immutable
+}
+
+// logIgnoredRotation notes that the existing value of the immutable field isn't rotated although
+// a rotation interval is configured for it or all fields are rotated by force
+func (r *SecretReconciler) logIgnoredRotation(obj client.Object, field string, forceRotation bool, logger logr.Logger) {
+	if interval := r.configuredRotationInterval(obj.GetNamespace(), obj.GetAnnotations(), field); interval > 0 {
+		logger.Info("Field has an immutable value, ignoring its rotation interval", "field", field, "interval", interval)
+	}
+	if forceRotation {
+		logger.Info("Field has an immutable value, leaving it out of the forced rotation", "field", field)
+	}
+}
@@ -0,0 +1,187 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/generator"
+)
+
+func TestGetFieldRotationIntervalImmutableValue(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	cfg.Rotation.NamespaceDefaults = map[string]string{"team-a": "1h"}
+	reconciler := &SecretReconciler{Config: cfg}
+	annotations := map[string]string{
+		AnnotationRotate:                         "24h",
+		AnnotationImmutableValuePrefix + "admin": "true",
+		AnnotationImmutableValuePrefix + "token": "false",
+	}
+
+	if got := reconciler.getFieldRotationInterval("team-a", annotations, "admin"); got != 0 {
+		t.Errorf("expected no rotation for an immutable value, got %v", got)
+	}
+	if got := reconciler.getFieldRotationInterval("team-a", annotations, "token"); got != 24*time.Hour {
+		t.Errorf("expected 24h for a field with immutable-value false, got %v", got)
+	}
+	if got := reconciler.configuredRotationInterval("team-a", annotations, "admin"); got != 24*time.Hour {
+		t.Errorf("expected the configured interval 24h, got %v", got)
+	}
+	if got := reconciler.getFieldRotationInterval("team-a", map[string]string{AnnotationImmutableValuePrefix + "admin": "true"}, "admin"); got != 0 {
+		t.Errorf("expected the namespace default not to apply to an immutable value, got %v", got)
+	}
+}
+
+func TestReconcileImmutableValue(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	oldTime := time.Now().Add(-2 * time.Hour)
+
+	tests := []struct {
+		name           string
+		annotations    map[string]string
+		data           map[string][]byte
+		expectRotated  bool
+		expectRequeued bool
+	}{
+		{
+			name: "generated if missing",
+			annotations: map[string]string{
+				AnnotationAutogenerate:                   "admin",
+				AnnotationRotate:                         "1h",
+				AnnotationImmutableValuePrefix + "admin": "true",
+			},
+		},
+		{
+			name: "rotate annotation ignored",
+			annotations: map[string]string{
+				AnnotationAutogenerate:                   "admin,token",
+				AnnotationRotate:                         "1h",
+				AnnotationGeneratedAt:                    oldTime.Format(time.RFC3339),
+				AnnotationImmutableValuePrefix + "admin": "true",
+			},
+			data: map[string][]byte{
+				"admin": []byte("old-admin"),
+				"token": []byte("old-token"),
+			},
+			expectRotated:  true,
+			expectRequeued: true,
+		},
+		{
+			name: "field rotate annotation ignored",
+			annotations: map[string]string{
+				AnnotationAutogenerate:                   "admin",
+				AnnotationRotatePrefix + "admin":         "1h",
+				AnnotationGeneratedAt:                    oldTime.Format(time.RFC3339),
+				AnnotationImmutableValuePrefix + "admin": "true",
+			},
+			data: map[string][]byte{
+				"admin": []byte("old-admin"),
+			},
+		},
+		{
+			name: "left out of rotate-now",
+			annotations: map[string]string{
+				AnnotationAutogenerate:                   "admin,token",
+				AnnotationRotateNow:                      "true",
+				AnnotationGeneratedAt:                    oldTime.Format(time.RFC3339),
+				AnnotationImmutableValuePrefix + "admin": "true",
+			},
+			data: map[string][]byte{
+				"admin": []byte("old-admin"),
+				"token": []byte("old-token"),
+			},
+			expectRotated: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "bootstrap",
+					Namespace:   "default",
+					Annotations: tt.annotations,
+				},
+				Data: tt.data,
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+			reconciler := &SecretReconciler{
+				Client:        fakeClient,
+				Scheme:        scheme,
+				Generator:     generator.NewSecretGenerator(),
+				Config:        config.NewDefaultConfig(),
+				EventRecorder: NewTestEventRecorder(10),
+			}
+
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: "bootstrap", Namespace: "default"}}
+			result, err := reconciler.Reconcile(context.Background(), req)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if requeued := result.RequeueAfter > 0; requeued != tt.expectRequeued {
+				t.Errorf("expected requeued %v, got requeue after %v", tt.expectRequeued, result.RequeueAfter)
+			}
+
+			var updated corev1.Secret
+			if err := fakeClient.Get(context.Background(), req.NamespacedName, &updated); err != nil {
+				t.Fatalf("failed to get secret: %v", err)
+			}
+			admin := string(updated.Data["admin"])
+			if tt.data == nil {
+				if admin == "" {
+					t.Error("expected the missing immutable value to be generated")
+				}
+			} else if admin != "old-admin" {
+				t.Errorf("expected the immutable value to be kept, got %q", admin)
+			}
+			if tt.expectRotated && string(updated.Data["token"]) == "old-token" {
+				t.Error("expected the other field to be rotated")
+			}
+		})
+	}
+}
+
+// TestIsImmutableValueField tests that immutable-value.<field> accepts the same boolean values
+// as the other flags
+func TestIsImmutableValueField(t *testing.T) {
+	tests := map[string]bool{
+		"true":  true,
+		"True":  true,
+		"1":     true,
+		"false": false,
+		"0":     false,
+	}
+	for value, want := range tests {
+		annotations := map[string]string{AnnotationImmutableValuePrefix + "password": value}
+		if got := defaultAnnotationKeys.isImmutableValueField(annotations, "password"); got != want {
+			t.Errorf("immutable-value.password: %q: expected %v, got %v", value, want, got)
+		}
+	}
+}
@@ -0,0 +1,61 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+)
+
+// valueMAC returns the hex-encoded HMAC-SHA256 of a field value, as stored in value-mac.<field>.
+// The field name is part of the MAC, so values can't be swapped between fields unnoticed.
+func valueMAC(key []byte, field string, value []byte) string {
+	mac := hmac.New(sha256.New, key)
+	mac.Write([]byte(field))
+	mac.Write([]byte{0})
+	mac.Write(value)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// verifyFieldIntegrity emits a TamperDetected Warning event for every field whose value doesn't
+// match the HMAC recorded when the operator wrote it. Fields without a recorded HMAC, e.g. values
+// written before tamper detection was enabled, and removed fields are not checked.
+// The values themselves are never logged.
+func (r *SecretReconciler) verifyFieldIntegrity(secret *corev1.Secret, fields []string, logger logr.Logger) {
+	if r.IntegrityKey == nil {
+		return
+	}
+	macs := r.keys().readManagedMetadata(secret.Annotations).ValueMACs
+	for _, field := range fields {
+		recorded, ok := macs[field]
+		value, exists := secret.Data[field]
+		if !ok || !exists {
+			continue
+		}
+		if hmac.Equal([]byte(recorded), []byte(valueMAC(r.IntegrityKey, field, value))) {
+			continue
+		}
+		msg := fmt.Sprintf("Value of field %q was changed outside the operator", field)
+		logger.Info(msg, "field", field)
+		r.eventf(secret, nil, corev1.EventTypeWarning, EventReasonTamperDetected, "Verify", "%s", msg)
+	}
+}
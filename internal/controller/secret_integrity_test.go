@@ -0,0 +1,161 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/generator"
+)
+
+var testIntegrityKey = []byte("0123456789abcdef0123456789abcdef")
+
+func TestValueMAC(t *testing.T) {
+	mac := valueMAC(testIntegrityKey, "password", []byte("value"))
+	if len(mac) != 64 {
+		t.Errorf("expected a hex-encoded SHA-256 HMAC, got %q", mac)
+	}
+	if strings.Contains(mac, "value") {
+		t.Error("expected the value not to be part of the MAC")
+	}
+	if mac == valueMAC(testIntegrityKey, "token", []byte("value")) {
+		t.Error("expected the MAC to depend on the field name")
+	}
+	if mac == valueMAC([]byte("fedcba9876543210fedcba9876543210"), "password", []byte("value")) {
+		t.Error("expected the MAC to depend on the key")
+	}
+}
+
+func TestReconcileIntegrity(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "integrity-secret",
+			Namespace:   "default",
+			Annotations: map[string]string{AnnotationAutogenerate: "password"},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	fakeRecorder := NewTestEventRecorder(10)
+	reconciler := &SecretReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Generator:     generator.NewSecretGenerator(),
+		Config:        config.NewDefaultConfig(),
+		EventRecorder: fakeRecorder,
+		IntegrityKey:  testIntegrityKey,
+	}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}}
+	ctx := context.Background()
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var updatedSecret corev1.Secret
+	if err := fakeClient.Get(ctx, req.NamespacedName, &updatedSecret); err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+	value := updatedSecret.Data["password"]
+	if got := updatedSecret.Annotations[AnnotationValueMACPrefix+"password"]; got != valueMAC(testIntegrityKey, "password", value) {
+		t.Fatalf("expected the MAC of the generated value, got %q", got)
+	}
+	for len(fakeRecorder.Events) > 0 {
+		<-fakeRecorder.Events
+	}
+
+	// The value written by the operator verifies
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	select {
+	case event := <-fakeRecorder.Events:
+		t.Errorf("expected no event for an unchanged value, got: %s", event)
+	default:
+	}
+
+	// A value changed outside the operator is flagged
+	updatedSecret.Data["password"] = []byte("changed-by-hand")
+	if err := fakeClient.Update(ctx, &updatedSecret); err != nil {
+		t.Fatalf("failed to update secret: %v", err)
+	}
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	select {
+	case event := <-fakeRecorder.Events:
+		if !strings.Contains(event, EventReasonTamperDetected) || !strings.Contains(event, `"password"`) {
+			t.Errorf("expected tamper detected event, got: %s", event)
+		}
+		if strings.Contains(event, "changed-by-hand") || strings.Contains(event, string(value)) {
+			t.Errorf("expected the event not to expose values, got: %s", event)
+		}
+	default:
+		t.Error("expected tamper detected event to be recorded")
+	}
+}
+
+func TestReconcileIntegrityDisabled(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "integrity-secret",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationAutogenerate:                "password",
+				AnnotationValueMACPrefix + "password": valueMAC(testIntegrityKey, "password", []byte("original")),
+			},
+		},
+		Data: map[string][]byte{"password": []byte("changed-by-hand")},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	fakeRecorder := NewTestEventRecorder(10)
+	reconciler := &SecretReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Generator:     generator.NewSecretGenerator(),
+		Config:        config.NewDefaultConfig(),
+		EventRecorder: fakeRecorder,
+	}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}}
+	if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	select {
+	case event := <-fakeRecorder.Events:
+		t.Errorf("expected no event without an integrity key, got: %s", event)
+	default:
+	}
+}
@@ -0,0 +1,100 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	"slices"
+	"strconv"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+)
+
+// maxFieldCount is the largest number of values a list field (count.<field>) may hold
+const maxFieldCount = 100
+
+const (
+	// RotateStrategyAll regenerates all values of a list field on rotation (the default)
+	RotateStrategyAll = "all"
+	// RotateStrategyRoundRobin replaces only the oldest value of a list field on rotation
+	RotateStrategyRoundRobin = "round-robin"
+)
+
+// getFieldCount returns the number of values of a list field (count.<field>), or 0 if the
+// field holds a single value
+func (k *annotationKeys) getFieldCount(annotations map[string]string, field string) (int, error) {
+	value := annotations[k.CountPrefix+field]
+	if value == "" {
+		return 0, nil
+	}
+	count, err := strconv.Atoi(value)
+	if err != nil || count <= 0 || count > maxFieldCount {
+		return 0, fmt.Errorf("invalid count %q, must be an integer between 1 and %d", value, maxFieldCount)
+	}
+	return count, nil
+}
+
+// getFieldRotateStrategy returns how a list field is rotated (rotate-strategy.<field>, default all)
+func (k *annotationKeys) getFieldRotateStrategy(annotations map[string]string, field string) (string, error) {
+	switch value := annotations[k.RotateStrategyPrefix+field]; value {
+	case "", RotateStrategyAll:
+		return RotateStrategyAll, nil
+	case RotateStrategyRoundRobin:
+		return RotateStrategyRoundRobin, nil
+	default:
+		return "", fmt.Errorf("unknown rotate strategy %q, must be %q or %q", value, RotateStrategyAll, RotateStrategyRoundRobin)
+	}
+}
+
+// supportsCount returns true if fields of genType can hold a list of values. Types with
+// companion values, like keypairs, can't.
+func supportsCount(genType string) bool {
+	return genType == config.DefaultType || genType == config.TypeBytes || genType == config.TypePassphrase
+}
+
+// roundRobinKept returns the values of a list field kept by a round-robin rotation: all but the
+// oldest, which is first. It returns nil if current isn't a JSON array of count strings, e.g.
+// because the count changed, so that all values are regenerated.
+func roundRobinKept(current []byte, count int) []string {
+	var values []string
+	if err := json.Unmarshal(current, &values); err != nil || len(values) != count {
+		return nil
+	}
+	return values[1:]
+}
+
+// generateList generates the value of a list field: a JSON array of count values, starting with
+// kept. generate generates a single value.
+func generateList(generate func() valueGenerationResult, kept []string, count int) valueGenerationResult {
+	values := slices.Clone(kept)
+	for len(values) < count {
+		genResult := generate()
+		if genResult.err != nil {
+			return genResult
+		}
+		values = append(values, string(genResult.value))
+	}
+	value, err := json.Marshal(values)
+	if err != nil {
+		return valueGenerationResult{
+			err:    fmt.Errorf("failed to encode list values: %w", err),
+			errMsg: fmt.Sprintf("Failed to encode list values: %v", err),
+		}
+	}
+	return valueGenerationResult{value: value}
+}
@@ -0,0 +1,223 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"slices"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/generator"
+)
+
+// reconcileListField reconciles a Secret with a list field api-keys at the time of clock and
+// returns the values of the field
+func reconcileListField(t *testing.T, reconciler *SecretReconciler, req ctrl.Request) []string {
+	t.Helper()
+	if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var secret corev1.Secret
+	if err := reconciler.Get(context.Background(), req.NamespacedName, &secret); err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+	var values []string
+	if err := json.Unmarshal(secret.Data["api-keys"], &values); err != nil {
+		t.Fatalf("expected a JSON array in api-keys, got %q: %v", secret.Data["api-keys"], err)
+	}
+	return values
+}
+
+// newListFieldReconciler returns a reconciler of a Secret with a list field api-keys of three values
+// rotating hourly, with the given additional annotations
+func newListFieldReconciler(annotations map[string]string) (*SecretReconciler, *MockClock, ctrl.Request) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "api-keys",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationAutogenerate:              "api-keys",
+				AnnotationRotate:                    "1h",
+				AnnotationCountPrefix + "api-keys":  "3",
+				AnnotationLengthPrefix + "api-keys": "24",
+			},
+		},
+	}
+	for key, value := range annotations {
+		secret.Annotations[key] = value
+	}
+
+	mockClock := &MockClock{currentTime: time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)}
+	reconciler := &SecretReconciler{
+		Client:        fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build(),
+		Scheme:        scheme,
+		Generator:     generator.NewSecretGenerator(),
+		Config:        config.NewDefaultConfig(),
+		EventRecorder: NewTestEventRecorder(10),
+		Clock:         mockClock,
+	}
+	return reconciler, mockClock, ctrl.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}}
+}
+
+func TestReconcileListField(t *testing.T) {
+	reconciler, mockClock, req := newListFieldReconciler(nil)
+
+	initial := reconcileListField(t, reconciler, req)
+	if len(initial) != 3 {
+		t.Fatalf("expected 3 values, got %d", len(initial))
+	}
+	for i, value := range initial {
+		if len(value) != 24 {
+			t.Errorf("expected value %d to have length 24, got %d", i, len(value))
+		}
+	}
+	if initial[0] == initial[1] || initial[1] == initial[2] || initial[0] == initial[2] {
+		t.Errorf("expected independent values, got %v", initial)
+	}
+
+	// Before the rotation is due, the values stay
+	mockClock.currentTime = mockClock.currentTime.Add(30 * time.Minute)
+	if got := reconcileListField(t, reconciler, req); !slices.Equal(got, initial) {
+		t.Errorf("expected values to stay before the rotation is due, got %v", got)
+	}
+
+	// By default, rotation replaces all values
+	mockClock.currentTime = mockClock.currentTime.Add(time.Hour)
+	rotated := reconcileListField(t, reconciler, req)
+	if len(rotated) != 3 {
+		t.Fatalf("expected 3 values after rotation, got %d", len(rotated))
+	}
+	for _, value := range rotated {
+		if slices.Contains(initial, value) {
+			t.Errorf("expected all values to be rotated, %q was kept", value)
+		}
+	}
+}
+
+func TestReconcileListFieldRoundRobin(t *testing.T) {
+	reconciler, mockClock, req := newListFieldReconciler(map[string]string{
+		AnnotationRotateStrategyPrefix + "api-keys": RotateStrategyRoundRobin,
+	})
+
+	values := reconcileListField(t, reconciler, req)
+	if len(values) != 3 {
+		t.Fatalf("expected 3 values, got %d", len(values))
+	}
+
+	// Each rotation replaces the oldest value, which is first, and appends a new one
+	for rotation := 1; rotation <= 3; rotation++ {
+		mockClock.currentTime = mockClock.currentTime.Add(time.Hour)
+		rotated := reconcileListField(t, reconciler, req)
+		if len(rotated) != 3 {
+			t.Fatalf("rotation %d: expected 3 values, got %d", rotation, len(rotated))
+		}
+		if !slices.Equal(rotated[:2], values[1:]) {
+			t.Errorf("rotation %d: expected %v to be kept, got %v", rotation, values[1:], rotated[:2])
+		}
+		if slices.Contains(values, rotated[2]) {
+			t.Errorf("rotation %d: expected a new last value, got %q", rotation, rotated[2])
+		}
+		values = rotated
+	}
+}
+
+func TestReconcileListFieldRoundRobinForcedRotation(t *testing.T) {
+	reconciler, _, req := newListFieldReconciler(map[string]string{
+		AnnotationRotateStrategyPrefix + "api-keys": RotateStrategyRoundRobin,
+	})
+	initial := reconcileListField(t, reconciler, req)
+
+	// A manual rotation replaces all values, not just the oldest
+	var secret corev1.Secret
+	if err := reconciler.Get(context.Background(), req.NamespacedName, &secret); err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+	secret.Annotations[AnnotationRotateNow] = "true"
+	if err := reconciler.Update(context.Background(), &secret); err != nil {
+		t.Fatalf("failed to update secret: %v", err)
+	}
+
+	for _, value := range reconcileListField(t, reconciler, req) {
+		if slices.Contains(initial, value) {
+			t.Errorf("expected a manual rotation to replace all values, %q was kept", value)
+		}
+	}
+}
+
+func TestReconcileListFieldInvalidConfig(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		expectedMsg string
+	}{
+		{
+			name:        "invalid count",
+			annotations: map[string]string{AnnotationCountPrefix + "api-keys": "0"},
+			expectedMsg: `Invalid count for field "api-keys": invalid count "0"`,
+		},
+		{
+			name:        "count too high",
+			annotations: map[string]string{AnnotationCountPrefix + "api-keys": "101"},
+			expectedMsg: `Invalid count for field "api-keys": invalid count "101", must be an integer between 1 and 100`,
+		},
+		{
+			name:        "unsupported type",
+			annotations: map[string]string{AnnotationTypePrefix + "api-keys": config.TypeEd25519},
+			expectedMsg: "count is only supported for string, bytes, and passphrase fields, not ed25519",
+		},
+		{
+			name:        "unknown rotate strategy",
+			annotations: map[string]string{AnnotationRotateStrategyPrefix + "api-keys": "oldest"},
+			expectedMsg: `Invalid rotate strategy for field "api-keys": unknown rotate strategy "oldest"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			reconciler, _, req := newListFieldReconciler(tt.annotations)
+			if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var secret corev1.Secret
+			if err := reconciler.Get(context.Background(), req.NamespacedName, &secret); err != nil {
+				t.Fatalf("failed to get secret: %v", err)
+			}
+			if _, ok := secret.Data["api-keys"]; ok {
+				t.Error("expected no value with an invalid configuration")
+			}
+			if got := secret.Annotations[AnnotationLastError]; !strings.Contains(got, tt.expectedMsg) {
+				t.Errorf("expected last error to contain %q, got %q", tt.expectedMsg, got)
+			}
+		})
+	}
+}
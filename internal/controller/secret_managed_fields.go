@@ -0,0 +1,79 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+)
+
+// isManagedField returns true if the operator created the value of field, so it may rotate it
+func (k *annotationKeys) isManagedField(annotations map[string]string, field string) bool {
+	return slices.Contains(k.readManagedMetadata(annotations).ManagedFields, field)
+}
+
+// isUserProvidedField returns true if field has a value the operator didn't create. Such values
+// are neither generated nor rotated.
+func (k *annotationKeys) isUserProvidedField(annotations map[string]string, data map[string][]byte, field string) bool {
+	return hasFieldValue(data, field) && !k.isManagedField(annotations, field)
+}
+
+// dropEmptyPlaceholders removes the empty values of listed fields the operator didn't create, so
+// they are generated like missing fields. An empty stringData value, e.g. `api-key: ""`, reaches
+// the operator as such an empty data value.
+func (k *annotationKeys) dropEmptyPlaceholders(annotations map[string]string, data map[string][]byte, fields []string) {
+	for _, field := range fields {
+		if value, ok := data[field]; ok && len(value) == 0 && !k.isManagedField(annotations, field) {
+			delete(data, field)
+		}
+	}
+}
+
+// rotatableFields returns the fields the operator generates or rotates: those without a value
+// and those whose value it created
+func (k *annotationKeys) rotatableFields(annotations map[string]string, data map[string][]byte, fields []string) []string {
+	var rotatable []string
+	for _, field := range fields {
+		if !k.isUserProvidedField(annotations, data, field) {
+			rotatable = append(rotatable, field)
+		}
+	}
+	return rotatable
+}
+
+// adoptsExistingValues returns true if values present before the first generation become the
+// operator's. An invalid existing-values annotation is reported by adoptExistingValues.
+func (r *SecretReconciler) adoptsExistingValues(annotations map[string]string) bool {
+	mode, err := r.getExistingValuesMode(annotations)
+	return err == nil && mode == config.ExistingValuesAdopt
+}
+
+// recordUserProvidedFields reports the fields whose user-provided values the operator left alone
+func (r *SecretReconciler) recordUserProvidedFields(secret *corev1.Secret, fields []string, logger logr.Logger) {
+	if len(fields) == 0 {
+		return
+	}
+	msg := fmt.Sprintf("Left user-provided values of fields %s alone, they are neither generated nor rotated", strings.Join(fields, ", "))
+	logger.Info(msg)
+	r.eventf(secret, nil, corev1.EventTypeNormal, EventReasonUserValuesKept, "Generate", "%s", msg)
+}
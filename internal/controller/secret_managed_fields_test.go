@@ -0,0 +1,211 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/generator"
+)
+
+// drainEvents returns the events recorded so far
+func drainEvents(recorder *TestEventRecorder) []string {
+	var events []string
+	for len(recorder.Events) > 0 {
+		events = append(events, <-recorder.Events)
+	}
+	return events
+}
+
+// hasEventWithPrefix returns true if one of events starts with prefix
+func hasEventWithPrefix(events []string, prefix string) bool {
+	for _, event := range events {
+		if strings.HasPrefix(event, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+func TestReconcileUserProvidedFieldsNotRotated(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "mixed",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationAutogenerate: "password,api-key",
+				AnnotationRotate:       "1h",
+			},
+		},
+		Data: map[string][]byte{"api-key": []byte("user-api-key")},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	fakeRecorder := NewTestEventRecorder(10)
+	mockClock := &MockClock{currentTime: time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)}
+	reconciler := &SecretReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Generator:     generator.NewSecretGenerator(),
+		Config:        config.NewDefaultConfig(),
+		EventRecorder: fakeRecorder,
+		Clock:         mockClock,
+	}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}}
+	ctx := context.Background()
+
+	// Initial generation only creates password, and reports that api-key is left alone
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var updatedSecret corev1.Secret
+	if err := fakeClient.Get(ctx, req.NamespacedName, &updatedSecret); err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+	if got := updatedSecret.Annotations[AnnotationManagedFields]; got != "password" {
+		t.Errorf("expected managed fields %q, got %q", "password", got)
+	}
+	events := drainEvents(fakeRecorder)
+	if !hasEventWithPrefix(events, "Normal UserValuesKept: Left user-provided values of fields api-key alone") {
+		t.Errorf("expected UserValuesKept event for api-key, got %v", events)
+	}
+	password := string(updatedSecret.Data["password"])
+
+	// Once due, only the generated password is rotated
+	mockClock.currentTime = mockClock.currentTime.Add(2 * time.Hour)
+	result, err := reconciler.Reconcile(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := fakeClient.Get(ctx, req.NamespacedName, &updatedSecret); err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+	if string(updatedSecret.Data["password"]) == password {
+		t.Error("expected generated password to be rotated")
+	}
+	if got := string(updatedSecret.Data["api-key"]); got != "user-api-key" {
+		t.Errorf("expected user-provided api-key to be kept, got %q", got)
+	}
+	if result.RequeueAfter != time.Hour {
+		t.Errorf("expected requeue after the password's rotation interval, got %s", result.RequeueAfter)
+	}
+}
+
+func TestReconcileLegacySecretKeepsRotating(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	// Generated by a version without managed-fields: all listed fields count as the operator's
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "legacy",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationAutogenerate: "password,token",
+				AnnotationRotate:       "1h",
+				AnnotationGeneratedAt:  "2026-02-02T10:00:00Z",
+			},
+		},
+		Data: map[string][]byte{
+			"password": []byte("old-password"),
+			"token":    []byte("old-token"),
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	reconciler := &SecretReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Generator:     generator.NewSecretGenerator(),
+		Config:        config.NewDefaultConfig(),
+		EventRecorder: NewTestEventRecorder(10),
+		Clock:         &MockClock{currentTime: time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)},
+	}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}}
+	if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var updatedSecret corev1.Secret
+	if err := fakeClient.Get(context.Background(), req.NamespacedName, &updatedSecret); err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+	if string(updatedSecret.Data["password"]) == "old-password" || string(updatedSecret.Data["token"]) == "old-token" {
+		t.Errorf("expected all fields to be rotated, got %v", updatedSecret.Data)
+	}
+	if got := updatedSecret.Annotations[AnnotationManagedFields]; got != "password,token" {
+		t.Errorf("expected managed fields %q, got %q", "password,token", got)
+	}
+}
+
+func TestReconcileAdoptedFieldsAreManaged(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "adopted",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationAutogenerate:   "password,api-key",
+				AnnotationExistingValues: config.ExistingValuesAdopt,
+			},
+		},
+		Data: map[string][]byte{"api-key": []byte("user-api-key")},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	fakeRecorder := NewTestEventRecorder(10)
+	reconciler := &SecretReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Generator:     generator.NewSecretGenerator(),
+		Config:        config.NewDefaultConfig(),
+		EventRecorder: fakeRecorder,
+	}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}}
+	if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var updatedSecret corev1.Secret
+	if err := fakeClient.Get(context.Background(), req.NamespacedName, &updatedSecret); err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+	if got := updatedSecret.Annotations[AnnotationManagedFields]; got != "api-key,password" {
+		t.Errorf("expected managed fields %q, got %q", "api-key,password", got)
+	}
+	if events := drainEvents(fakeRecorder); hasEventWithPrefix(events, "Normal UserValuesKept") {
+		t.Errorf("expected no UserValuesKept event for adopted values, got %v", events)
+	}
+}
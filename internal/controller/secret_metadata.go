@@ -0,0 +1,299 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"encoding/json"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+)
+
+// currentMetadataVersion is the layout version of the operator-managed annotations
+// written by this version of the operator
+const currentMetadataVersion = 2
+
+// metadataMigrations migrate the operator-managed annotations of a layout version to the
+// next version, in place. Secrets without a metadata-version annotation are version 0.
+var metadataMigrations = map[int]func(k *annotationKeys, annotations map[string]string){
+	0: (*annotationKeys).migrateMetadataV0,
+	1: (*annotationKeys).migrateMetadataV1,
+}
+
+// managedMetadata holds the annotations the operator sets on generated Secrets.
+// Read it with readManagedMetadata and write it back with writeTo, so the annotation
+// layout is handled in one place.
+type managedMetadata struct {
+	// GeneratedAt is the time values were last generated or rotated
+	GeneratedAt *time.Time
+	// LastRotationWindow is the maintenance window in which the last rotation happened
+	LastRotationWindow string
+	// RotationAnchors maps fields to the time their rotation interval is counted from.
+	// Only used for Secrets with rotate-offset annotations.
+	RotationAnchors map[string]time.Time
+	// ForceRotationTokens are the tokens of the force rotation triggers already applied
+	ForceRotationTokens []string
+	// ManagedFields are the data keys the operator created, sorted
+	ManagedFields []string
+	// ValueHashes maps fields to the SHA-256 hash of their value.
+	// Only used for Secrets with a unique-within-label annotation.
+	ValueHashes map[string]string
+	// ValueMACs maps fields to the HMAC of the value the operator last wrote.
+	// Only used if tamper detection is enabled.
+	ValueMACs map[string]string
+
+	// FieldFormat is the format writeTo stores the per-field metadata in (config.FieldMetadata*).
+	// Both formats are always read, so changing it migrates a Secret on its next write.
+	FieldFormat string
+
+	// keys are the annotation keys writeTo uses, those the metadata was read with
+	keys *annotationKeys
+}
+
+// fieldMetadata is the per-field metadata of a field in the field-metadata JSON annotation
+type fieldMetadata struct {
+	RotationAnchor *time.Time `json:"rotationAnchor,omitempty"`
+	ValueHash      string     `json:"valueHash,omitempty"`
+	ValueMAC       string     `json:"valueMac,omitempty"`
+}
+
+// metadataVersion returns the layout version of the operator-managed annotations
+func (k *annotationKeys) metadataVersion(annotations map[string]string) int {
+	version, err := strconv.Atoi(annotations[k.MetadataVersion])
+	if err != nil || version < 0 {
+		return 0
+	}
+	return version
+}
+
+// readManagedMetadata reads the operator-managed annotations. Older layouts are migrated
+// on a copy of annotations first, so the original is only changed by writeTo.
+func (k *annotationKeys) readManagedMetadata(annotations map[string]string) managedMetadata {
+	if version := k.metadataVersion(annotations); version < currentMetadataVersion {
+		migrated := make(map[string]string, len(annotations))
+		for key, value := range annotations {
+			migrated[key] = value
+		}
+		for ; version < currentMetadataVersion; version++ {
+			metadataMigrations[version](k, migrated)
+		}
+		annotations = migrated
+	}
+
+	m := managedMetadata{keys: k}
+	if t, err := time.Parse(time.RFC3339, annotations[k.GeneratedAt]); err == nil {
+		m.GeneratedAt = &t
+	}
+	m.LastRotationWindow = annotations[k.LastRotationWindow]
+	for key, value := range annotations {
+		field, ok := strings.CutPrefix(key, k.RotationAnchorPrefix)
+		if !ok {
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339, value); err == nil {
+			if m.RotationAnchors == nil {
+				m.RotationAnchors = make(map[string]time.Time)
+			}
+			m.RotationAnchors[field] = t
+		}
+	}
+	m.ForceRotationTokens = parseFields(annotations[k.ForceRotationTokens])
+	m.ManagedFields = parseFields(annotations[k.ManagedFields])
+	for key, value := range annotations {
+		if field, ok := strings.CutPrefix(key, k.ValueHashPrefix); ok && value != "" {
+			m.setValueHash(field, value)
+		}
+		if field, ok := strings.CutPrefix(key, k.ValueMACPrefix); ok && value != "" {
+			m.setValueMAC(field, value)
+		}
+	}
+
+	// Per-field metadata in the JSON annotation takes precedence; an invalid one is ignored
+	var fields map[string]fieldMetadata
+	if err := json.Unmarshal([]byte(annotations[k.FieldMetadata]), &fields); err == nil {
+		for field, meta := range fields {
+			if meta.RotationAnchor != nil {
+				if m.RotationAnchors == nil {
+					m.RotationAnchors = make(map[string]time.Time)
+				}
+				m.RotationAnchors[field] = *meta.RotationAnchor
+			}
+			if meta.ValueHash != "" {
+				m.setValueHash(field, meta.ValueHash)
+			}
+			if meta.ValueMAC != "" {
+				m.setValueMAC(field, meta.ValueMAC)
+			}
+		}
+	}
+	return m
+}
+
+// setValueHash sets the value hash of field
+func (m *managedMetadata) setValueHash(field, hash string) {
+	if m.ValueHashes == nil {
+		m.ValueHashes = make(map[string]string)
+	}
+	m.ValueHashes[field] = hash
+}
+
+// addManagedFields adds keys to the managed data keys
+func (m *managedMetadata) addManagedFields(keys ...string) {
+	for _, key := range keys {
+		if !slices.Contains(m.ManagedFields, key) {
+			m.ManagedFields = append(m.ManagedFields, key)
+		}
+	}
+	slices.Sort(m.ManagedFields)
+}
+
+// setValueMAC sets the value HMAC of field
+func (m *managedMetadata) setValueMAC(field, mac string) {
+	if m.ValueMACs == nil {
+		m.ValueMACs = make(map[string]string)
+	}
+	m.ValueMACs[field] = mac
+}
+
+// writeTo serializes the metadata onto annotations in the current layout.
+// Unset values remove their annotation.
+func (m *managedMetadata) writeTo(annotations map[string]string) {
+	setOrDelete := func(key, value string) {
+		if value != "" {
+			annotations[key] = value
+		} else {
+			delete(annotations, key)
+		}
+	}
+
+	if m.GeneratedAt != nil {
+		annotations[m.keys.GeneratedAt] = m.GeneratedAt.Format(time.RFC3339)
+	} else {
+		delete(annotations, m.keys.GeneratedAt)
+	}
+	setOrDelete(m.keys.LastRotationWindow, m.LastRotationWindow)
+	setOrDelete(m.keys.ForceRotationTokens, strings.Join(m.ForceRotationTokens, ","))
+	setOrDelete(m.keys.ManagedFields, strings.Join(m.ManagedFields, ","))
+	m.writeFieldsTo(annotations)
+	annotations[m.keys.MetadataVersion] = strconv.Itoa(currentMetadataVersion)
+}
+
+// writeFieldsTo serializes the per-field metadata onto annotations in FieldFormat,
+// removing it from the other format
+func (m *managedMetadata) writeFieldsTo(annotations map[string]string) {
+	delete(annotations, m.keys.FieldMetadata)
+	for key := range annotations {
+		if strings.HasPrefix(key, m.keys.RotationAnchorPrefix) || strings.HasPrefix(key, m.keys.ValueHashPrefix) ||
+			strings.HasPrefix(key, m.keys.ValueMACPrefix) {
+			delete(annotations, key)
+		}
+	}
+
+	if m.FieldFormat != config.FieldMetadataJSON {
+		for field, anchor := range m.RotationAnchors {
+			annotations[m.keys.RotationAnchorPrefix+field] = anchor.Format(time.RFC3339)
+		}
+		for field, hash := range m.ValueHashes {
+			annotations[m.keys.ValueHashPrefix+field] = hash
+		}
+		for field, mac := range m.ValueMACs {
+			annotations[m.keys.ValueMACPrefix+field] = mac
+		}
+		return
+	}
+
+	fields := make(map[string]fieldMetadata)
+	for field, anchor := range m.RotationAnchors {
+		// Truncate to the precision of the per-field annotations
+		anchor = anchor.UTC().Truncate(time.Second)
+		meta := fields[field]
+		meta.RotationAnchor = &anchor
+		fields[field] = meta
+	}
+	for field, hash := range m.ValueHashes {
+		meta := fields[field]
+		meta.ValueHash = hash
+		fields[field] = meta
+	}
+	for field, mac := range m.ValueMACs {
+		meta := fields[field]
+		meta.ValueMAC = mac
+		fields[field] = meta
+	}
+	if len(fields) == 0 {
+		return
+	}
+	// Marshaling maps of plain values can't fail; map keys are sorted, so the output is stable
+	data, _ := json.Marshal(fields)
+	annotations[m.keys.FieldMetadata] = string(data)
+}
+
+// migrateMetadataV0 migrates Secrets written before the metadata-version annotation existed.
+// Those may carry empty or unparseable timestamps, which were silently ignored, and force
+// rotation token lists with duplicates or empty entries.
+func (k *annotationKeys) migrateMetadataV0(annotations map[string]string) {
+	for key, value := range annotations {
+		if key != k.GeneratedAt && !strings.HasPrefix(key, k.RotationAnchorPrefix) {
+			continue
+		}
+		if _, err := time.Parse(time.RFC3339, value); err != nil {
+			delete(annotations, key)
+		}
+	}
+
+	if value, ok := annotations[k.ForceRotationTokens]; ok {
+		seen := make(map[string]bool)
+		var tokens []string
+		for _, token := range parseFields(value) {
+			if !seen[token] {
+				seen[token] = true
+				tokens = append(tokens, token)
+			}
+		}
+		if len(tokens) == 0 {
+			delete(annotations, k.ForceRotationTokens)
+		} else {
+			annotations[k.ForceRotationTokens] = strings.Join(tokens, ",")
+		}
+	}
+}
+
+// migrateMetadataV1 migrates Secrets written before the managed-fields annotation existed. The
+// fields listed in autogenerate of a Secret generated before count as created by the operator,
+// so that they keep rotating.
+func (k *annotationKeys) migrateMetadataV1(annotations map[string]string) {
+	if _, ok := annotations[k.ManagedFields]; ok || annotations[k.GeneratedAt] == "" {
+		return
+	}
+	fields := k.parseSecretAnnotations(annotations)
+	if len(fields) == 0 {
+		return
+	}
+	slices.Sort(fields)
+	annotations[k.ManagedFields] = strings.Join(slices.Compact(fields), ",")
+}
+
+// readMetadata reads the operator-managed annotations for updating them; writeTo then stores
+// the per-field metadata in the configured format
+func (r *SecretReconciler) readMetadata(annotations map[string]string) managedMetadata {
+	m := r.keys().readManagedMetadata(annotations)
+	m.FieldFormat = r.currentConfig().Defaults.FieldMetadata
+	return m
+}
@@ -0,0 +1,286 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"reflect"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	apivalidation "k8s.io/apimachinery/pkg/api/validation"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+)
+
+func TestManagedMetadataRoundTrip(t *testing.T) {
+	generatedAt := time.Date(2026, 2, 2, 10, 0, 0, 0, time.UTC)
+	meta := managedMetadata{
+		keys:               defaultAnnotationKeys,
+		GeneratedAt:        &generatedAt,
+		LastRotationWindow: "weekend",
+		RotationAnchors: map[string]time.Time{
+			"primary":   generatedAt,
+			"secondary": generatedAt.Add(12 * time.Hour),
+		},
+		ForceRotationTokens: []string{"incident-42", "migration"},
+		ValueHashes: map[string]string{
+			"primary": valueHash([]byte("value")),
+		},
+		ValueMACs: map[string]string{
+			"secondary": valueMAC([]byte("key"), "secondary", []byte("value")),
+		},
+	}
+
+	annotations := map[string]string{AnnotationAutogenerate: "primary,secondary"}
+	meta.writeTo(annotations)
+
+	if annotations[AnnotationMetadataVersion] != strconv.Itoa(currentMetadataVersion) {
+		t.Errorf("expected metadata version %d, got %q", currentMetadataVersion, annotations[AnnotationMetadataVersion])
+	}
+	if annotations[AnnotationAutogenerate] != "primary,secondary" {
+		t.Error("expected unrelated annotations to be kept")
+	}
+
+	got := defaultAnnotationKeys.readManagedMetadata(annotations)
+	if !reflect.DeepEqual(got, meta) {
+		t.Errorf("metadata did not round-trip:\nwant %+v\ngot  %+v", meta, got)
+	}
+}
+
+func TestManagedMetadataWriteRemovesUnsetValues(t *testing.T) {
+	annotations := map[string]string{
+		AnnotationGeneratedAt:                       "2026-02-02T10:00:00Z",
+		AnnotationLastRotationWindow:                "weekend",
+		AnnotationRotationAnchorPrefix + "obsolete": "2026-02-02T10:00:00Z",
+		AnnotationForceRotationTokens:               "incident-42",
+		AnnotationMetadataVersion:                   "1",
+	}
+
+	meta := managedMetadata{keys: defaultAnnotationKeys}
+	meta.writeTo(annotations)
+
+	want := map[string]string{AnnotationMetadataVersion: strconv.Itoa(currentMetadataVersion)}
+	if !reflect.DeepEqual(annotations, want) {
+		t.Errorf("expected only the metadata version to remain, got %v", annotations)
+	}
+}
+
+func TestManagedMetadataJSONFields(t *testing.T) {
+	generatedAt := time.Date(2026, 2, 2, 10, 0, 0, 0, time.UTC)
+	meta := managedMetadata{
+		keys:        defaultAnnotationKeys,
+		GeneratedAt: &generatedAt,
+		RotationAnchors: map[string]time.Time{
+			"primary":   generatedAt,
+			"secondary": generatedAt.Add(12 * time.Hour),
+		},
+		ValueHashes: map[string]string{
+			"primary": valueHash([]byte("value")),
+			"token":   valueHash([]byte("token")),
+		},
+		FieldFormat: config.FieldMetadataJSON,
+	}
+
+	// Per-field annotations of the other format are replaced
+	annotations := map[string]string{
+		AnnotationRotationAnchorPrefix + "obsolete": "2026-02-02T10:00:00Z",
+		AnnotationValueHashPrefix + "obsolete":      valueHash([]byte("obsolete")),
+	}
+	meta.writeTo(annotations)
+
+	want := map[string]string{
+		AnnotationGeneratedAt: "2026-02-02T10:00:00Z",
+		AnnotationFieldMetadata: `{"primary":{"rotationAnchor":"2026-02-02T10:00:00Z","valueHash":"` + valueHash([]byte("value")) + `"},` +
+			`"secondary":{"rotationAnchor":"2026-02-02T22:00:00Z"},` +
+			`"token":{"valueHash":"` + valueHash([]byte("token")) + `"}}`,
+		AnnotationMetadataVersion: strconv.Itoa(currentMetadataVersion),
+	}
+	if !reflect.DeepEqual(annotations, want) {
+		t.Errorf("unexpected annotations:\nwant %v\ngot  %v", want, annotations)
+	}
+
+	got := defaultAnnotationKeys.readManagedMetadata(annotations)
+	meta.FieldFormat = ""
+	if !reflect.DeepEqual(got, meta) {
+		t.Errorf("metadata did not round-trip:\nwant %+v\ngot  %+v", meta, got)
+	}
+
+	// Switching back restores one annotation per field
+	got.FieldFormat = config.FieldMetadataAnnotations
+	got.writeTo(annotations)
+	if _, ok := annotations[AnnotationFieldMetadata]; ok {
+		t.Error("expected field-metadata annotation to be removed")
+	}
+	if annotations[AnnotationRotationAnchorPrefix+"secondary"] != "2026-02-02T22:00:00Z" {
+		t.Errorf("expected rotation anchor annotation, got %v", annotations)
+	}
+	if annotations[AnnotationValueHashPrefix+"token"] != valueHash([]byte("token")) {
+		t.Errorf("expected value hash annotation, got %v", annotations)
+	}
+}
+
+func TestManagedMetadataJSONFieldsInvalid(t *testing.T) {
+	annotations := map[string]string{
+		AnnotationFieldMetadata:                    "{not json",
+		AnnotationRotationAnchorPrefix + "primary": "2026-02-02T10:00:00Z",
+	}
+
+	meta := defaultAnnotationKeys.readManagedMetadata(annotations)
+	want := map[string]time.Time{"primary": time.Date(2026, 2, 2, 10, 0, 0, 0, time.UTC)}
+	if !reflect.DeepEqual(meta.RotationAnchors, want) {
+		t.Errorf("expected invalid JSON to be ignored, got %v", meta.RotationAnchors)
+	}
+}
+
+func TestManagedMetadataJSONFieldsManyFields(t *testing.T) {
+	const fieldCount = 1000
+	anchor := time.Date(2026, 2, 2, 10, 0, 0, 0, time.UTC)
+	meta := managedMetadata{
+		keys:            defaultAnnotationKeys,
+		GeneratedAt:     &anchor,
+		RotationAnchors: make(map[string]time.Time),
+		ValueHashes:     make(map[string]string),
+		FieldFormat:     config.FieldMetadataJSON,
+	}
+	for i := range fieldCount {
+		field := fmt.Sprintf("field-%04d", i)
+		meta.RotationAnchors[field] = anchor.Add(time.Duration(i) * time.Minute)
+		meta.ValueHashes[field] = valueHash([]byte(field))
+	}
+
+	annotations := map[string]string{}
+	meta.writeTo(annotations)
+
+	// The annotation count doesn't grow with the number of fields
+	if len(annotations) != 3 {
+		t.Errorf("expected 3 annotations, got %d", len(annotations))
+	}
+	for key := range annotations {
+		if strings.HasPrefix(key, AnnotationRotationAnchorPrefix) || strings.HasPrefix(key, AnnotationValueHashPrefix) {
+			t.Errorf("unexpected per-field annotation %s", key)
+		}
+	}
+	if errs := apivalidation.ValidateAnnotations(annotations, field.NewPath("metadata", "annotations")); len(errs) > 0 {
+		t.Errorf("annotations are invalid: %v", errs.ToAggregate())
+	}
+
+	got := defaultAnnotationKeys.readManagedMetadata(annotations)
+	if len(got.RotationAnchors) != fieldCount || len(got.ValueHashes) != fieldCount {
+		t.Fatalf("expected %d fields, got %d anchors and %d hashes", fieldCount, len(got.RotationAnchors), len(got.ValueHashes))
+	}
+	if !got.RotationAnchors["field-0999"].Equal(anchor.Add(999*time.Minute)) || got.ValueHashes["field-0999"] != valueHash([]byte("field-0999")) {
+		t.Errorf("unexpected metadata for field-0999: %v %q", got.RotationAnchors["field-0999"], got.ValueHashes["field-0999"])
+	}
+}
+
+func TestManagedMetadataMigratesV0(t *testing.T) {
+	// Layout written before the metadata-version annotation existed
+	annotations := map[string]string{
+		AnnotationAutogenerate:                       "primary,secondary",
+		AnnotationGeneratedAt:                        "2026-02-02T10:00:00Z",
+		AnnotationRotationAnchorPrefix + "primary":   "2026-02-02T10:00:00Z",
+		AnnotationRotationAnchorPrefix + "secondary": "not a time",
+		AnnotationForceRotationTokens:                "incident-42, ,migration,incident-42",
+	}
+
+	meta := defaultAnnotationKeys.readManagedMetadata(annotations)
+	if annotations[AnnotationRotationAnchorPrefix+"secondary"] != "not a time" {
+		t.Error("expected reading to leave the annotations unchanged")
+	}
+
+	meta.writeTo(annotations)
+	want := map[string]string{
+		AnnotationAutogenerate:                     "primary,secondary",
+		AnnotationGeneratedAt:                      "2026-02-02T10:00:00Z",
+		AnnotationRotationAnchorPrefix + "primary": "2026-02-02T10:00:00Z",
+		AnnotationForceRotationTokens:              "incident-42,migration",
+		AnnotationManagedFields:                    "primary,secondary",
+		AnnotationMetadataVersion:                  strconv.Itoa(currentMetadataVersion),
+	}
+	if !reflect.DeepEqual(annotations, want) {
+		t.Errorf("unexpected migrated annotations:\nwant %v\ngot  %v", want, annotations)
+	}
+}
+
+func TestManagedMetadataMigratesV1(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        []string
+	}{
+		{
+			name: "generated before managed-fields",
+			annotations: map[string]string{
+				AnnotationAutogenerate:    "token, password,token",
+				AnnotationGeneratedAt:     "2026-02-02T10:00:00Z",
+				AnnotationMetadataVersion: "1",
+			},
+			want: []string{"password", "token"},
+		},
+		{
+			name: "managed-fields already recorded",
+			annotations: map[string]string{
+				AnnotationAutogenerate:    "password,token",
+				AnnotationGeneratedAt:     "2026-02-02T10:00:00Z",
+				AnnotationManagedFields:   "password",
+				AnnotationMetadataVersion: "1",
+			},
+			want: []string{"password"},
+		},
+		{
+			name: "nothing generated yet",
+			annotations: map[string]string{
+				AnnotationAutogenerate:    "password",
+				AnnotationMetadataVersion: "1",
+			},
+			want: nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := defaultAnnotationKeys.readManagedMetadata(tt.annotations).ManagedFields; !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expected managed fields %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestMetadataVersion(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        int
+	}{
+		{name: "missing", annotations: map[string]string{}, want: 0},
+		{name: "current", annotations: map[string]string{AnnotationMetadataVersion: "1"}, want: 1},
+		{name: "invalid", annotations: map[string]string{AnnotationMetadataVersion: "v1"}, want: 0},
+		{name: "negative", annotations: map[string]string{AnnotationMetadataVersion: "-1"}, want: 0},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := defaultAnnotationKeys.metadataVersion(tt.annotations); got != tt.want {
+				t.Errorf("expected version %d, got %d", tt.want, got)
+			}
+		})
+	}
+}
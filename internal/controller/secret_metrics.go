@@ -0,0 +1,255 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/x509"
+	"encoding/pem"
+	"strconv"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+	ctrlmetrics "sigs.k8s.io/controller-runtime/pkg/metrics"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/generator"
+)
+
+const (
+	// entropyFloorMet labels fields whose generated value reaches the configured entropy floor
+	entropyFloorMet = "true"
+	// entropyFloorNotMet labels fields whose generated value is below the configured entropy floor
+	entropyFloorNotMet = "false"
+	// entropyFloorNotApplicable labels keypair and jwt fields, whose strength isn't measured in
+	// random characters
+	entropyFloorNotApplicable = "n/a"
+)
+
+// managedFieldsGauge counts the fields managed by the secret generator
+var managedFieldsGauge = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "internal_secrets_operator_managed_fields",
+		Help: "Number of autogenerated Secret fields by type and by whether their entropy meets the configured floor",
+	},
+	[]string{"type", "meets_entropy_floor"},
+)
+
+// secondsUntilRotationGauge holds the time until the next rotation of each Secret, as computed by
+// its last reconcile
+var secondsUntilRotationGauge = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "internal_secrets_operator_seconds_until_rotation",
+		Help: "Seconds until the next rotation of a Secret, including deferrals by maintenance and blackout windows, as of its last reconcile",
+	},
+	[]string{"namespace", "name"},
+)
+
+// certificateExpiryGauge holds the expiry of the certificate in each certificate field, parsed
+// from the Secret. It lets operators alert on expiry independently of the operator's reissues.
+var certificateExpiryGauge = prometheus.NewGaugeVec(
+	prometheus.GaugeOpts{
+		Name: "internal_secrets_operator_certificate_expiry_timestamp_seconds",
+		Help: "Expiry (NotAfter) of the certificate in a certificate field of a Secret, as a Unix timestamp",
+	},
+	[]string{"namespace", "name", "field"},
+)
+
+func init() {
+	ctrlmetrics.Registry.MustRegister(managedFieldsGauge, secondsUntilRotationGauge, certificateExpiryGauge)
+}
+
+// managedFieldsKey is a label combination of managedFieldsGauge
+type managedFieldsKey struct {
+	genType    string
+	meetsFloor string
+}
+
+// fieldEntropyBits estimates the entropy of a generated field value in bits.
+// It returns false for types whose strength isn't determined by random characters
+// (keypairs and jwt) and for fields with an invalid configuration.
+func (r *SecretReconciler) fieldEntropyBits(annotations map[string]string, field, genType string) (float64, bool) {
+	length := r.getFieldLength(annotations, field)
+
+	switch genType {
+	case config.DefaultType, "":
+		charset, err := r.getFieldCharset(annotations, field)
+		if err == nil && r.getFieldExcludeAmbiguous(annotations, field) {
+			charset, err = generator.ExcludeAmbiguous(charset)
+		}
+		if err != nil {
+			return 0, false
+		}
+		return generator.EstimateEntropyBits(len(charset), length), true
+	case config.TypeBytes, config.TypeTOTP:
+		// Trimmed output keeps 4 bits per hex and 6 bits per base64 character
+		if outputLength, err := r.keys().getFieldOutputLength(annotations, field); err == nil && outputLength > 0 && genType == config.TypeBytes {
+			switch r.getFieldEncoding(annotations, field) {
+			case config.EncodingHex:
+				return generator.EstimateEntropyBits(16, outputLength), true
+			case config.EncodingBase64:
+				return generator.EstimateEntropyBits(64, outputLength), true
+			}
+			return generator.EstimateEntropyBits(256, outputLength), true
+		}
+		return generator.EstimateEntropyBits(256, length), true
+	case config.TypeURLSafePassword:
+		return generator.EstimateEntropyBits(len(generator.URLSafeCharset), generator.URLSafePasswordLength(length)), true
+	case config.TypePassphrase:
+		words, err := r.keys().getFieldWords(annotations, field)
+		if err != nil {
+			return 0, false
+		}
+		return float64(words) * generator.PassphraseWordBits(), true
+	case config.TypeNumeric:
+		return generator.EstimateEntropyBits(len(generator.NumericCharset), length), true
+	case config.TypeUUID:
+		// 6 of the 128 bits are fixed version and variant bits
+		return 122, true
+	case config.TypeMAC:
+		// The locally-administered and multicast bits are fixed
+		return 46, true
+	default:
+		return 0, false
+	}
+}
+
+// countManagedFields counts the managed fields of the given Secrets by type and entropy floor.
+// Secrets outside the configured namespaces or not matching the label selector aren't managed
+// and aren't counted.
+func (r *SecretReconciler) countManagedFields(secrets []corev1.Secret) map[managedFieldsKey]int {
+	cfg := r.currentConfig()
+	floor := float64(cfg.Metrics.EntropyFloorBits)
+	selector := cfg.Selector()
+	counts := make(map[managedFieldsKey]int)
+	for i := range secrets {
+		if !cfg.Namespaces.Allows(secrets[i].Namespace) || !selector.Matches(labels.Set(secrets[i].Labels)) {
+			continue
+		}
+		annotations := secrets[i].Annotations
+		for _, field := range r.keys().parseSecretAnnotations(annotations) {
+			genType := r.getFieldType(annotations, field)
+			meetsFloor := entropyFloorNotApplicable
+			if bits, ok := r.fieldEntropyBits(annotations, field, genType); ok {
+				meetsFloor = strconv.FormatBool(bits >= floor)
+			}
+			counts[managedFieldsKey{genType: genType, meetsFloor: meetsFloor}]++
+		}
+	}
+	return counts
+}
+
+// updateManagedFieldsMetrics recomputes managedFieldsGauge from the Secrets in the cache
+func (r *SecretReconciler) updateManagedFieldsMetrics(ctx context.Context) error {
+	// The configuration is read once, so a reload can't change it halfway through the Secrets
+	r = r.withConfig(r.currentConfig())
+
+	var secrets corev1.SecretList
+	if err := r.List(ctx, &secrets); err != nil {
+		return err
+	}
+
+	counts := r.countManagedFields(secrets.Items)
+	managedFieldsGauge.Reset()
+	for key, count := range counts {
+		managedFieldsGauge.WithLabelValues(key.genType, key.meetsFloor).Set(float64(count))
+	}
+
+	// Rebuilding the certificate expiries also removes series of Secrets deleted while a reconcile
+	// couldn't observe it
+	cfg := r.currentConfig()
+	selector := cfg.Selector()
+	certificateExpiryGauge.Reset()
+	for i := range secrets.Items {
+		if cfg.Namespaces.Allows(secrets.Items[i].Namespace) && selector.Matches(labels.Set(secrets.Items[i].Labels)) {
+			r.recordCertificateExpiry(&secrets.Items[i])
+		}
+	}
+	return nil
+}
+
+// runManagedFieldsMetrics updates the managed-field and certificate expiry metrics every InventoryInterval until ctx is done
+func (r *SecretReconciler) runManagedFieldsMetrics(ctx context.Context) error {
+	logger := log.FromContext(ctx).WithName("managed-fields-metrics")
+	ticker := time.NewTicker(r.currentConfig().Metrics.InventoryInterval.Duration())
+	defer ticker.Stop()
+
+	for {
+		if err := r.updateManagedFieldsMetrics(ctx); err != nil {
+			logger.Error(err, "Failed to update managed field metrics")
+		}
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+	}
+}
+
+// recordNextRotation sets the seconds-until-rotation metric of a Secret. nextRotation is the time
+// until the Secret is reconciled for rotation; a rotation deferred by a maintenance or blackout
+// window is reported at the time the window allows it. If nextRotation is nil, the series is removed.
+func (r *SecretReconciler) recordNextRotation(secret *corev1.Secret, nextRotation *time.Duration) {
+	if nextRotation == nil {
+		forgetNextRotation(secret.Namespace, secret.Name)
+		return
+	}
+	now := r.now()
+	seconds := r.nextRotationTime(now.Add(*nextRotation)).Sub(now).Seconds()
+	secondsUntilRotationGauge.WithLabelValues(secret.Namespace, secret.Name).Set(seconds)
+}
+
+// forgetNextRotation removes the seconds-until-rotation series of a Secret that is gone or no
+// longer managed, so it doesn't go stale
+func forgetNextRotation(namespace, name string) {
+	secondsUntilRotationGauge.DeleteLabelValues(namespace, name)
+}
+
+// recordCertificateExpiry sets the certificate expiry metric of each certificate field of a
+// Secret from the NotAfter of its certificate. Series of fields that are no longer certificate
+// fields or don't hold a parseable certificate are removed.
+func (r *SecretReconciler) recordCertificateExpiry(secret *corev1.Secret) {
+	forgetCertificateExpiry(secret.Namespace, secret.Name)
+	for _, field := range r.keys().parseSecretAnnotations(secret.Annotations) {
+		if r.getFieldType(secret.Annotations, field) != config.TypeCertificate {
+			continue
+		}
+		block, _ := pem.Decode(secret.Data[field])
+		if block == nil || block.Type != "CERTIFICATE" {
+			continue
+		}
+		cert, err := x509.ParseCertificate(block.Bytes)
+		if err != nil {
+			continue
+		}
+		certificateExpiryGauge.WithLabelValues(secret.Namespace, secret.Name, field).Set(float64(cert.NotAfter.Unix()))
+	}
+}
+
+// forgetCertificateExpiry removes the certificate expiry series of all fields of a Secret
+func forgetCertificateExpiry(namespace, name string) {
+	certificateExpiryGauge.DeletePartialMatch(prometheus.Labels{"namespace": namespace, "name": name})
+}
+
+// forgetSecretMetrics removes the per-Secret series of a Secret that is gone or no longer managed
+func forgetSecretMetrics(namespace, name string) {
+	forgetNextRotation(namespace, name)
+	forgetCertificateExpiry(namespace, name)
+}
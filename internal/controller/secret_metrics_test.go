@@ -0,0 +1,355 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/generator"
+)
+
+// metricsTestSecrets returns Secrets with a mix of strong, weak, and non-measurable fields
+func metricsTestSecrets() []client.Object {
+	return []client.Object{
+		// 32 alphanumeric characters: ~190 bits
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "strong", Namespace: "default", Annotations: map[string]string{
+			AnnotationAutogenerate: "password,token",
+		}}},
+		// 8 alphanumeric characters: ~48 bits, 6 digits: ~20 bits
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "short", Namespace: "default", Annotations: map[string]string{
+			AnnotationAutogenerate:           "password,pin",
+			AnnotationLength:                 "8",
+			AnnotationCharsetPreset + ".pin": "numeric",
+			AnnotationLengthPrefix + "pin":   "6",
+		}}},
+		// 16 bytes: 128 bits (exactly the floor), 8 bytes: 64 bits
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "bytes", Namespace: "other", Annotations: map[string]string{
+			AnnotationAutogenerate:           "key,nonce",
+			AnnotationType:                   "bytes",
+			AnnotationLength:                 "16",
+			AnnotationLengthPrefix + "nonce": "8",
+		}}},
+		// Keypairs are not measured, uuid and mac are below the floor
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "mixed", Namespace: "other", Annotations: map[string]string{
+			AnnotationAutogenerate:       "tls,id,mac",
+			AnnotationTypePrefix + "tls": "ecdsa",
+			AnnotationTypePrefix + "id":  "uuid",
+			AnnotationTypePrefix + "mac": "mac",
+		}}},
+		// Invalid charset configurations can't be measured
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "invalid", Namespace: "default", Annotations: map[string]string{
+			AnnotationAutogenerate:  "password",
+			AnnotationCharsetPreset: "unknown",
+		}}},
+		// Unmanaged Secrets are ignored
+		&corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "unmanaged", Namespace: "default"}},
+	}
+}
+
+func TestCountManagedFields(t *testing.T) {
+	reconciler := &SecretReconciler{Config: config.NewDefaultConfig()}
+
+	var secrets []corev1.Secret
+	for _, obj := range metricsTestSecrets() {
+		secrets = append(secrets, *obj.(*corev1.Secret))
+	}
+
+	want := map[managedFieldsKey]int{
+		{genType: "string", meetsFloor: "true"}:  2,
+		{genType: "string", meetsFloor: "false"}: 2,
+		{genType: "string", meetsFloor: "n/a"}:   1,
+		{genType: "bytes", meetsFloor: "true"}:   1,
+		{genType: "bytes", meetsFloor: "false"}:  1,
+		{genType: "ecdsa", meetsFloor: "n/a"}:    1,
+		{genType: "uuid", meetsFloor: "false"}:   1,
+		{genType: "mac", meetsFloor: "false"}:    1,
+	}
+
+	got := reconciler.countManagedFields(secrets)
+	if len(got) != len(want) {
+		t.Errorf("expected %d buckets, got %d: %v", len(want), len(got), got)
+	}
+	for key, count := range want {
+		if got[key] != count {
+			t.Errorf("bucket %+v: expected %d, got %d", key, count, got[key])
+		}
+	}
+}
+
+func TestCountManagedFieldsEntropyFloor(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	cfg.Metrics.EntropyFloorBits = 40
+	reconciler := &SecretReconciler{Config: cfg}
+
+	var secrets []corev1.Secret
+	for _, obj := range metricsTestSecrets() {
+		secrets = append(secrets, *obj.(*corev1.Secret))
+	}
+
+	got := reconciler.countManagedFields(secrets)
+	// With a lower floor, only the 6-digit pin remains weak
+	if got[managedFieldsKey{genType: "string", meetsFloor: "false"}] != 1 {
+		t.Errorf("expected 1 weak string field, got %d", got[managedFieldsKey{genType: "string", meetsFloor: "false"}])
+	}
+	for _, genType := range []string{"bytes", "uuid", "mac"} {
+		if weak := got[managedFieldsKey{genType: genType, meetsFloor: "false"}]; weak != 0 {
+			t.Errorf("expected no weak %s fields, got %d", genType, weak)
+		}
+	}
+}
+
+func TestUpdateManagedFieldsMetrics(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(metricsTestSecrets()...).Build()
+	reconciler := &SecretReconciler{
+		Client:    fakeClient,
+		Scheme:    scheme,
+		Generator: generator.NewSecretGenerator(),
+		Config:    config.NewDefaultConfig(),
+	}
+
+	// Stale label combinations from earlier updates are removed
+	managedFieldsGauge.WithLabelValues("rsa", "n/a").Set(5)
+
+	if err := reconciler.updateManagedFieldsMetrics(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := testutil.ToFloat64(managedFieldsGauge.WithLabelValues("string", "false")); got != 2 {
+		t.Errorf("expected 2 weak string fields, got %v", got)
+	}
+	if got := testutil.ToFloat64(managedFieldsGauge.WithLabelValues("bytes", "true")); got != 1 {
+		t.Errorf("expected 1 strong bytes field, got %v", got)
+	}
+	if got := testutil.CollectAndCount(managedFieldsGauge); got != 8 {
+		t.Errorf("expected 8 label combinations, got %d", got)
+	}
+}
+
+func TestSecondsUntilRotationMetric(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	// Monday 10:00, the password was generated 2h ago and rotates daily
+	now := time.Date(2026, 2, 2, 10, 0, 0, 0, time.UTC)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "db-credentials", Namespace: "metrics", Annotations: map[string]string{
+			AnnotationAutogenerate:  "password",
+			AnnotationRotate:        "24h",
+			AnnotationGeneratedAt:   "2026-02-02T08:00:00Z",
+			AnnotationManagedFields: "password",
+		}},
+		Data: map[string][]byte{"password": []byte("value")},
+	}
+
+	tests := []struct {
+		name    string
+		windows config.MaintenanceWindowsConfig
+		want    time.Duration
+	}{
+		{name: "interval", want: 22 * time.Hour},
+		{
+			// Due Tuesday 08:00, rotated in the window on Saturday 03:00
+			name: "deferred by maintenance window",
+			windows: config.MaintenanceWindowsConfig{
+				Enabled: true,
+				Windows: []config.MaintenanceWindow{{
+					Name:      "weekend-night",
+					Days:      []string{"saturday", "sunday"},
+					StartTime: "03:00",
+					EndTime:   "05:00",
+					Timezone:  "UTC",
+				}},
+			},
+			want: 4*24*time.Hour + 17*time.Hour,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.NewDefaultConfig()
+			cfg.Rotation.MaintenanceWindows = tt.windows
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret.DeepCopy()).Build()
+			reconciler := &SecretReconciler{
+				Client:        fakeClient,
+				Scheme:        scheme,
+				Generator:     generator.NewSecretGenerator(),
+				Config:        cfg,
+				EventRecorder: NewTestEventRecorder(10),
+				Clock:         &MockClock{currentTime: now},
+			}
+
+			req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(secret)}
+			if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			got := testutil.ToFloat64(secondsUntilRotationGauge.WithLabelValues(secret.Namespace, secret.Name))
+			if math.Abs(got-tt.want.Seconds()) > 1 {
+				t.Errorf("expected %v seconds until rotation, got %v", tt.want.Seconds(), got)
+			}
+
+			// The series is removed with the Secret
+			if err := fakeClient.Delete(context.Background(), secret.DeepCopy()); err != nil {
+				t.Fatalf("failed to delete secret: %v", err)
+			}
+			if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if secondsUntilRotationGauge.DeleteLabelValues(secret.Namespace, secret.Name) {
+				t.Error("expected the series of the deleted Secret to be removed")
+			}
+		})
+	}
+}
+
+func TestCertificateExpiryMetric(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	now := time.Date(2026, 2, 2, 10, 0, 0, 0, time.UTC)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-tls", Namespace: "metrics", Annotations: map[string]string{
+			AnnotationAutogenerate:                 "tls.crt,client.crt,password",
+			AnnotationType:                         "certificate",
+			AnnotationTypePrefix + "password":      "string",
+			AnnotationCertValidity:                 "30d",
+			AnnotationCertValidity + ".client.crt": "7d",
+		}},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	reconciler := &SecretReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Generator:     generator.NewSecretGenerator(),
+		Config:        config.NewDefaultConfig(),
+		EventRecorder: NewTestEventRecorder(10),
+		Clock:         &MockClock{currentTime: now},
+	}
+	ctx := context.Background()
+	req := ctrl.Request{NamespacedName: client.ObjectKeyFromObject(secret)}
+	defer forgetCertificateExpiry(secret.Namespace, secret.Name)
+
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var updated corev1.Secret
+	if err := fakeClient.Get(ctx, req.NamespacedName, &updated); err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+	for field, validity := range map[string]time.Duration{"tls.crt": 30 * 24 * time.Hour, "client.crt": 7 * 24 * time.Hour} {
+		cert := parseSecretCertificate(t, updated.Data, field, field+".key")
+		got := testutil.ToFloat64(certificateExpiryGauge.WithLabelValues(secret.Namespace, secret.Name, field))
+		if got != float64(cert.NotAfter.Unix()) || got != float64(now.Add(validity).Unix()) {
+			t.Errorf("field %s: expected expiry %d, got %v", field, cert.NotAfter.Unix(), got)
+		}
+	}
+	if certificateExpiryGauge.DeleteLabelValues(secret.Namespace, secret.Name, "password") {
+		t.Error("expected no series for the password field")
+	}
+
+	// The series of a field is removed when it's no longer generated
+	updated.Annotations[AnnotationAutogenerate] = "tls.crt,password"
+	if err := fakeClient.Update(ctx, &updated); err != nil {
+		t.Fatalf("failed to update secret: %v", err)
+	}
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if certificateExpiryGauge.DeleteLabelValues(secret.Namespace, secret.Name, "client.crt") {
+		t.Error("expected the series of the removed field to be removed")
+	}
+	if got := testutil.ToFloat64(certificateExpiryGauge.WithLabelValues(secret.Namespace, secret.Name, "tls.crt")); got != float64(now.Add(30*24*time.Hour).Unix()) {
+		t.Errorf("expected the series of tls.crt to be kept, got %v", got)
+	}
+
+	// All series are removed with the Secret
+	if err := fakeClient.Delete(ctx, &updated); err != nil {
+		t.Fatalf("failed to delete secret: %v", err)
+	}
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if certificateExpiryGauge.DeleteLabelValues(secret.Namespace, secret.Name, "tls.crt") {
+		t.Error("expected the series of the deleted Secret to be removed")
+	}
+}
+
+func TestUpdateManagedFieldsMetricsCertificateExpiry(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	notAfter := time.Date(2026, 3, 1, 0, 0, 0, 0, time.UTC)
+	certificatePEM, privateKeyPEM, err := generator.NewSecretGenerator().GenerateCertificate(generator.CertificateRequest{
+		NotBefore: notAfter.Add(-24 * time.Hour),
+		Validity:  24 * time.Hour,
+	})
+	if err != nil {
+		t.Fatalf("failed to generate certificate: %v", err)
+	}
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "api-tls", Namespace: "default", Annotations: map[string]string{
+			AnnotationAutogenerate: "cert",
+			AnnotationType:         "certificate",
+		}},
+		Data: map[string][]byte{"cert": []byte(certificatePEM), "cert.key": []byte(privateKeyPEM)},
+	}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	reconciler := &SecretReconciler{
+		Client:    fakeClient,
+		Scheme:    scheme,
+		Generator: generator.NewSecretGenerator(),
+		Config:    config.NewDefaultConfig(),
+	}
+	defer certificateExpiryGauge.Reset()
+
+	// Series of Secrets that are gone are removed by the periodic update
+	certificateExpiryGauge.WithLabelValues("default", "deleted", "cert").Set(1)
+
+	if err := reconciler.updateManagedFieldsMetrics(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := testutil.ToFloat64(certificateExpiryGauge.WithLabelValues("default", "api-tls", "cert")); got != float64(notAfter.Unix()) {
+		t.Errorf("expected expiry %d, got %v", notAfter.Unix(), got)
+	}
+	if got := testutil.CollectAndCount(certificateExpiryGauge); got != 1 {
+		t.Errorf("expected 1 series, got %d", got)
+	}
+
+	// Series of Secrets outside the label selector are removed as well
+	reconciler.Config.LabelSelector = "managed-by=iso"
+	if err := reconciler.updateManagedFieldsMetrics(context.Background()); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := testutil.CollectAndCount(certificateExpiryGauge); got != 0 {
+		t.Errorf("expected no series, got %d", got)
+	}
+}
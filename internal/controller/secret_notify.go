@@ -0,0 +1,261 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/log"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+)
+
+// notificationTimeout bounds a notification, which is sent during the reconcile
+const notificationTimeout = 10 * time.Second
+
+// Notifier tells systems outside the cluster about events of Secrets
+type Notifier interface {
+	// Notify sends notification. Notifications with reasons the Notifier isn't interested in
+	// are ignored.
+	Notify(ctx context.Context, notification Notification) error
+}
+
+// Notification describes an event of a Secret. It only holds metadata, never Secret values.
+type Notification struct {
+	// Reason is the reason of the corresponding event, e.g. EventReasonRotationSucceeded
+	Reason    string
+	Namespace string
+	Name      string
+	// Fields are the fields the event is about, if known
+	Fields []string
+	// Message is the message of the corresponding event
+	Message string
+	Time    time.Time
+}
+
+// NewNotifiers returns the Notifiers enabled in cfg
+func NewNotifiers(cfg *config.Config, reader client.Reader) []Notifier {
+	var notifiers []Notifier
+	if notifier := NewRotationNotifier(&cfg.Rotation, reader); notifier != nil {
+		notifiers = append(notifiers, notifier)
+	}
+	if notifier := NewSlackNotifier(&cfg.Notifications.Slack); notifier != nil {
+		notifiers = append(notifiers, notifier)
+	}
+	return notifiers
+}
+
+// RotationNotification is the JSON body POSTed to the rotation webhook
+type RotationNotification struct {
+	Namespace string    `json:"namespace"`
+	Name      string    `json:"name"`
+	Fields    []string  `json:"fields"`
+	RotatedAt time.Time `json:"rotatedAt"`
+}
+
+// RotationNotifier sends rotation notifications to a webhook
+type RotationNotifier struct {
+	// URL receives the notifications
+	URL string
+	// TokenSecret references the bearer token sent with the notifications. If nil, none is sent.
+	TokenSecret *config.SecretKeyReference
+	// Reader reads the token Secret
+	Reader client.Reader
+	// HTTPClient sends the notifications. If nil, a client with notificationTimeout is used.
+	HTTPClient *http.Client
+}
+
+// NewRotationNotifier returns the notifier configured in rotation, or nil if notifyOnRotation is disabled
+func NewRotationNotifier(rotation *config.RotationConfig, reader client.Reader) *RotationNotifier {
+	if !rotation.NotifyOnRotation {
+		return nil
+	}
+	return &RotationNotifier{
+		URL:         rotation.WebhookURL,
+		TokenSecret: rotation.WebhookTokenSecret,
+		Reader:      reader,
+	}
+}
+
+// Notify POSTs a RotationNotification to the webhook if notification is about a rotation
+func (n *RotationNotifier) Notify(ctx context.Context, notification Notification) error {
+	if notification.Reason != EventReasonRotationSucceeded && notification.Reason != EventReasonCompromisedRotated {
+		return nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, notificationTimeout)
+	defer cancel()
+	header := http.Header{}
+	if n.TokenSecret != nil {
+		token, err := n.token(ctx)
+		if err != nil {
+			return err
+		}
+		header.Set("Authorization", "Bearer "+token)
+	}
+	return postJSON(ctx, n.HTTPClient, n.URL, header, RotationNotification{
+		Namespace: notification.Namespace,
+		Name:      notification.Name,
+		Fields:    notification.Fields,
+		RotatedAt: notification.Time.UTC(),
+	})
+}
+
+// token reads the bearer token from the referenced Secret. The token is never part of errors.
+func (n *RotationNotifier) token(ctx context.Context) (string, error) {
+	ref := n.TokenSecret
+	var secret corev1.Secret
+	if err := n.Reader.Get(ctx, client.ObjectKey{Namespace: ref.Namespace, Name: ref.Name}, &secret); err != nil {
+		return "", fmt.Errorf("failed to read webhook token Secret %s/%s: %w", ref.Namespace, ref.Name, err)
+	}
+	token := bytes.TrimSpace(secret.Data[ref.Key])
+	if len(token) == 0 {
+		return "", fmt.Errorf("webhook token Secret %s/%s has no value for key %q", ref.Namespace, ref.Name, ref.Key)
+	}
+	return string(token), nil
+}
+
+// SlackMessage is the JSON body posted to a Slack Incoming Webhook
+type SlackMessage struct {
+	Text string `json:"text"`
+}
+
+// SlackNotifier posts messages about Secrets to a Slack Incoming Webhook
+type SlackNotifier struct {
+	// WebhookURL is the Incoming Webhook. It holds a credential, so it is never logged.
+	WebhookURL string
+	// Reasons are the event reasons messages are posted for
+	Reasons []string
+	// HTTPClient sends the messages. If nil, a client with notificationTimeout is used.
+	HTTPClient *http.Client
+}
+
+// NewSlackNotifier returns the notifier configured in slack, or nil if Slack notifications are disabled
+func NewSlackNotifier(slack *config.SlackNotificationsConfig) *SlackNotifier {
+	if !slack.Enabled() {
+		return nil
+	}
+	return &SlackNotifier{
+		WebhookURL: slack.WebhookURL,
+		Reasons:    slack.Reasons,
+	}
+}
+
+// Notify posts a message about notification if its reason is one of Reasons
+func (n *SlackNotifier) Notify(ctx context.Context, notification Notification) error {
+	if !slices.Contains(n.Reasons, notification.Reason) {
+		return nil
+	}
+	ctx, cancel := context.WithTimeout(ctx, notificationTimeout)
+	defer cancel()
+	return postJSON(ctx, n.HTTPClient, n.WebhookURL, nil, SlackMessage{Text: slackText(notification)})
+}
+
+// slackText formats notification as a Slack message, e.g.
+//
+//	:white_check_mark: *RotationSucceeded* `production/db-credentials`
+//	Successfully rotated values for secret fields
+//	Fields: `password`
+//	Time: 2026-02-02T12:00:00Z
+func slackText(notification Notification) string {
+	icon := ":white_check_mark:"
+	if notification.Reason != EventReasonRotationSucceeded {
+		icon = ":warning:"
+	}
+	var text strings.Builder
+	fmt.Fprintf(&text, "%s *%s* `%s/%s`", icon, notification.Reason, notification.Namespace, notification.Name)
+	if notification.Message != "" {
+		fmt.Fprintf(&text, "\n%s", notification.Message)
+	}
+	if len(notification.Fields) > 0 {
+		fmt.Fprintf(&text, "\nFields: `%s`", strings.Join(notification.Fields, "`, `"))
+	}
+	fmt.Fprintf(&text, "\nTime: %s", notification.Time.UTC().Format(time.RFC3339))
+	return text.String()
+}
+
+// postJSON POSTs payload as JSON to target. Responses other than 2xx are errors. target may hold
+// credentials, so it is never part of errors.
+func postJSON(ctx context.Context, httpClient *http.Client, target string, header http.Header, payload any) error {
+	body, err := json.Marshal(payload)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target, bytes.NewReader(body))
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("invalid request: %w", err)
+	}
+	for key, values := range header {
+		req.Header[key] = values
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	if httpClient == nil {
+		httpClient = &http.Client{Timeout: notificationTimeout}
+	}
+	resp, err := httpClient.Do(req)
+	if err != nil {
+		var urlErr *url.Error
+		if errors.As(err, &urlErr) {
+			err = urlErr.Err
+		}
+		return fmt.Errorf("request failed: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, resp.Body)
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf("webhook responded with %s", resp.Status)
+	}
+	return nil
+}
+
+// notify sends notification to all Notifiers. A failed notification is logged and reported as a
+// Warning event, but doesn't fail the reconcile: what it reports already happened.
+func (r *SecretReconciler) notify(ctx context.Context, obj client.Object, notification Notification) {
+	if len(r.Notifiers) == 0 {
+		return
+	}
+	logger := log.FromContext(ctx)
+	notification.Namespace = obj.GetNamespace()
+	notification.Name = obj.GetName()
+	if notification.Time.IsZero() {
+		notification.Time = r.now()
+	}
+	for _, notifier := range r.Notifiers {
+		if err := notifier.Notify(ctx, notification); err != nil {
+			logger.Error(err, "Failed to send notification", "reason", notification.Reason)
+			r.eventf(obj, nil, corev1.EventTypeWarning, EventReasonNotificationFailed, "Notify",
+				"Failed to send %s notification: %v", notification.Reason, err)
+		}
+	}
+}
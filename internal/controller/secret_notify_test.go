@@ -0,0 +1,362 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/generator"
+)
+
+// receivedNotification is a request received by the test webhook
+type receivedNotification struct {
+	authorization string
+	contentType   string
+	body          RotationNotification
+}
+
+// newNotificationServer returns a webhook answering with status and the channel of the requests it received
+func newNotificationServer(t *testing.T, status int) (*httptest.Server, chan receivedNotification) {
+	t.Helper()
+	received := make(chan receivedNotification, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var body RotationNotification
+		if err := json.NewDecoder(req.Body).Decode(&body); err != nil {
+			t.Errorf("failed to decode notification: %v", err)
+		}
+		received <- receivedNotification{
+			authorization: req.Header.Get("Authorization"),
+			contentType:   req.Header.Get("Content-Type"),
+			body:          body,
+		}
+		w.WriteHeader(status)
+	}))
+	t.Cleanup(server.Close)
+	return server, received
+}
+
+// newRotatingSecret returns a Secret whose password is due for rotation at 2026-02-02T12:00:00Z
+func newRotatingSecret() *corev1.Secret {
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "db-credentials",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationAutogenerate:  "password",
+				AnnotationRotate:        "1h",
+				AnnotationGeneratedAt:   "2026-02-02T10:00:00Z",
+				AnnotationManagedFields: "password",
+			},
+		},
+		Data: map[string][]byte{"password": []byte("old-password")},
+	}
+}
+
+func TestReconcileRotationNotification(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	server, received := newNotificationServer(t, http.StatusNoContent)
+	tokenSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "webhook-token", Namespace: "operator"},
+		Data:       map[string][]byte{"token": []byte("s3cr3t\n")},
+	}
+	secret := newRotatingSecret()
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret, tokenSecret).Build()
+	fakeRecorder := NewTestEventRecorder(10)
+	now := time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)
+	reconciler := &SecretReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Generator:     generator.NewSecretGenerator(),
+		Config:        config.NewDefaultConfig(),
+		EventRecorder: fakeRecorder,
+		Clock:         &MockClock{currentTime: now},
+		Notifiers: []Notifier{&RotationNotifier{
+			URL:         server.URL,
+			TokenSecret: &config.SecretKeyReference{Namespace: "operator", Name: "webhook-token", Key: "token"},
+			Reader:      fakeClient,
+		}},
+	}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}}
+	if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	select {
+	case got := <-received:
+		want := RotationNotification{Namespace: "default", Name: "db-credentials", Fields: []string{"password"}, RotatedAt: now}
+		if !reflect.DeepEqual(got.body, want) {
+			t.Errorf("expected notification %+v, got %+v", want, got.body)
+		}
+		if got.authorization != "Bearer s3cr3t" {
+			t.Errorf("expected bearer token from the referenced Secret, got %q", got.authorization)
+		}
+		if got.contentType != "application/json" {
+			t.Errorf("expected JSON content type, got %q", got.contentType)
+		}
+	default:
+		t.Fatal("expected a rotation notification")
+	}
+
+	// Initial generation isn't a rotation
+	fresh := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name:        "fresh",
+		Namespace:   "default",
+		Annotations: map[string]string{AnnotationAutogenerate: "password"},
+	}}
+	if err := fakeClient.Create(context.Background(), fresh); err != nil {
+		t.Fatalf("failed to create secret: %v", err)
+	}
+	if _, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(fresh)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	select {
+	case got := <-received:
+		t.Errorf("expected no notification for initial generation, got %+v", got.body)
+	default:
+	}
+}
+
+func TestReconcileRotationNotificationFailure(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	server, received := newNotificationServer(t, http.StatusInternalServerError)
+
+	tests := []struct {
+		name     string
+		notifier *RotationNotifier
+	}{
+		{name: "webhook error", notifier: &RotationNotifier{URL: server.URL}},
+		{name: "unreachable webhook", notifier: &RotationNotifier{URL: "http://127.0.0.1:1"}},
+		{name: "missing token Secret", notifier: &RotationNotifier{
+			URL:         server.URL,
+			TokenSecret: &config.SecretKeyReference{Namespace: "operator", Name: "missing", Key: "token"},
+		}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := newRotatingSecret()
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+			fakeRecorder := NewTestEventRecorder(10)
+			tt.notifier.Reader = fakeClient
+			reconciler := &SecretReconciler{
+				Client:        fakeClient,
+				Scheme:        scheme,
+				Generator:     generator.NewSecretGenerator(),
+				Config:        config.NewDefaultConfig(),
+				EventRecorder: fakeRecorder,
+				Clock:         &MockClock{currentTime: time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)},
+				Notifiers:     []Notifier{tt.notifier},
+			}
+
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}}
+			result, err := reconciler.Reconcile(context.Background(), req)
+			if err != nil {
+				t.Fatalf("expected failed notification not to fail the reconcile, got %v", err)
+			}
+			if result.RequeueAfter != time.Hour {
+				t.Errorf("expected requeue for the next rotation after 1h, got %s", result.RequeueAfter)
+			}
+
+			var updatedSecret corev1.Secret
+			if err := fakeClient.Get(context.Background(), req.NamespacedName, &updatedSecret); err != nil {
+				t.Fatalf("failed to get secret: %v", err)
+			}
+			if string(updatedSecret.Data["password"]) == "old-password" {
+				t.Error("expected password to be rotated despite the failed notification")
+			}
+			if events := drainEvents(fakeRecorder); !hasEventWithPrefix(events, "Warning NotificationFailed: Failed to send RotationSucceeded notification") {
+				t.Errorf("expected NotificationFailed event, got %v", events)
+			}
+			for len(received) > 0 {
+				<-received
+			}
+		})
+	}
+}
+
+func TestNewRotationNotifier(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	cfg.Rotation.WebhookURL = "https://hooks.example.com/rotated"
+	if notifier := NewRotationNotifier(&cfg.Rotation, nil); notifier != nil {
+		t.Errorf("expected no notifier without notifyOnRotation, got %+v", notifier)
+	}
+
+	cfg.Rotation.NotifyOnRotation = true
+	notifier := NewRotationNotifier(&cfg.Rotation, nil)
+	if notifier == nil || notifier.URL != cfg.Rotation.WebhookURL {
+		t.Errorf("expected notifier for %s, got %+v", cfg.Rotation.WebhookURL, notifier)
+	}
+}
+
+// newSlackServer returns a Slack Incoming Webhook and the channel of the messages it received
+func newSlackServer(t *testing.T) (*httptest.Server, chan SlackMessage) {
+	t.Helper()
+	received := make(chan SlackMessage, 10)
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, req *http.Request) {
+		var message SlackMessage
+		if err := json.NewDecoder(req.Body).Decode(&message); err != nil {
+			t.Errorf("failed to decode Slack message: %v", err)
+		}
+		received <- message
+		_, _ = w.Write([]byte("ok"))
+	}))
+	t.Cleanup(server.Close)
+	return server, received
+}
+
+func TestReconcileSlackNotification(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	server, received := newSlackServer(t)
+	cfg := config.NewDefaultConfig()
+	cfg.Notifications.Slack.WebhookURL = server.URL
+
+	secret := newRotatingSecret()
+	failing := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{
+		Name:      "signing-key",
+		Namespace: "default",
+		Annotations: map[string]string{
+			AnnotationAutogenerate:                "signing-key",
+			AnnotationTypePrefix + "signing-key":  "ecdsa",
+			AnnotationCurvePrefix + "signing-key": "P-999",
+		},
+	}}
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret, failing).Build()
+	reconciler := &SecretReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Generator:     generator.NewSecretGenerator(),
+		Config:        cfg,
+		EventRecorder: NewTestEventRecorder(10),
+		Clock:         &MockClock{currentTime: time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)},
+		Notifiers:     NewNotifiers(cfg, fakeClient),
+	}
+
+	if _, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(secret)}); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	select {
+	case got := <-received:
+		want := ":white_check_mark: *RotationSucceeded* `default/db-credentials`\n" +
+			"Successfully rotated values for secret fields\n" +
+			"Fields: `password`\n" +
+			"Time: 2026-02-02T12:00:00Z"
+		if got.Text != want {
+			t.Errorf("expected message %q, got %q", want, got.Text)
+		}
+	default:
+		t.Fatal("expected a Slack message for the rotation")
+	}
+
+	var rotated corev1.Secret
+	if err := fakeClient.Get(context.Background(), client.ObjectKeyFromObject(secret), &rotated); err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+
+	// Failures are reported once, not on every retry
+	for i := 0; i < 2; i++ {
+		if _, err := reconciler.Reconcile(context.Background(), ctrl.Request{NamespacedName: client.ObjectKeyFromObject(failing)}); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+	}
+	select {
+	case got := <-received:
+		if !strings.HasPrefix(got.Text, ":warning: *GenerationFailed* `default/signing-key`\n") {
+			t.Errorf("expected GenerationFailed message, got %q", got.Text)
+		}
+		if strings.Contains(got.Text, string(rotated.Data["password"])) {
+			t.Error("expected no Secret values in the message")
+		}
+	default:
+		t.Fatal("expected a Slack message for the failed generation")
+	}
+	select {
+	case got := <-received:
+		t.Errorf("expected a single message for consecutive failures, got %q", got.Text)
+	default:
+	}
+}
+
+func TestSlackNotifierReasons(t *testing.T) {
+	server, received := newSlackServer(t)
+	notification := Notification{
+		Reason:    EventReasonRotationSucceeded,
+		Namespace: "default",
+		Name:      "db-credentials",
+		Fields:    []string{"password"},
+		Time:      time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC),
+	}
+
+	// Disabled notifications send nothing
+	cfg := config.NewDefaultConfig()
+	if notifiers := NewNotifiers(cfg, nil); len(notifiers) != 0 {
+		t.Errorf("expected no notifiers by default, got %v", notifiers)
+	}
+
+	// Reasons that aren't configured send nothing
+	notifier := NewSlackNotifier(&config.SlackNotificationsConfig{WebhookURL: server.URL, Reasons: []string{EventReasonGenerationFailed}})
+	if err := notifier.Notify(context.Background(), notification); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	select {
+	case got := <-received:
+		t.Errorf("expected no message for an unconfigured reason, got %q", got.Text)
+	default:
+	}
+
+	notification.Reason = EventReasonGenerationFailed
+	notification.Fields = nil
+	notification.Message = "Invalid bcrypt configuration: cost 99 is out of range"
+	if err := notifier.Notify(context.Background(), notification); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	select {
+	case got := <-received:
+		want := ":warning: *GenerationFailed* `default/db-credentials`\n" +
+			"Invalid bcrypt configuration: cost 99 is out of range\n" +
+			"Time: 2026-02-02T12:00:00Z"
+		if got.Text != want {
+			t.Errorf("expected message %q, got %q", want, got.Text)
+		}
+	default:
+		t.Fatal("expected a Slack message")
+	}
+}
@@ -0,0 +1,101 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-logr/logr"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+)
+
+// previousValueSuffix is appended to a field name to form the field holding its previous value
+const previousValueSuffix = "-previous"
+
+// getFieldGracePeriod returns how long the previous value of a rotated field is kept, or 0 if it isn't.
+// Priority: grace-period.<field> annotation > grace-period annotation
+func (r *SecretReconciler) getFieldGracePeriod(annotations map[string]string, field string) (time.Duration, error) {
+	value := getFieldAnnotation(annotations, r.keys().GracePeriod, field)
+	if value == "" {
+		return 0, nil
+	}
+	gracePeriod, err := config.ParseDuration(value)
+	if err != nil {
+		return 0, err
+	}
+	if gracePeriod < 0 {
+		return 0, fmt.Errorf("grace period must not be negative, got %s", value)
+	}
+	return gracePeriod, nil
+}
+
+// keepPreviousValue moves the current value of a field that is about to be rotated to
+// <field>-previous and records when it expires. Without a grace period nothing is kept.
+func (r *SecretReconciler) keepPreviousValue(annotations map[string]string, data map[string][]byte, field string, gracePeriod time.Duration) {
+	current, ok := data[field]
+	if !ok || gracePeriod <= 0 {
+		return
+	}
+	data[field+previousValueSuffix] = current
+	annotations[r.keys().PreviousValueExpiresPrefix+field] = r.now().Add(gracePeriod).Format(time.RFC3339)
+}
+
+// clearExpiredPreviousValues removes previous values whose grace period is over, along with
+// their expiry annotation. It returns the removed keys.
+func (r *SecretReconciler) clearExpiredPreviousValues(annotations map[string]string, data map[string][]byte, logger logr.Logger) []string {
+	var removed []string
+	now := r.now()
+	for key, value := range annotations {
+		field, ok := strings.CutPrefix(key, r.keys().PreviousValueExpiresPrefix)
+		if !ok {
+			continue
+		}
+		// An unparseable expiry is treated as expired, so the previous value doesn't linger
+		if expires, err := time.Parse(time.RFC3339, value); err == nil && now.Before(expires) {
+			continue
+		}
+		delete(data, field+previousValueSuffix)
+		delete(annotations, key)
+		logger.Info("Removed previous value after grace period", "field", field)
+		removed = append(removed, field+previousValueSuffix)
+	}
+	slices.Sort(removed)
+	return removed
+}
+
+// nextPreviousValueExpiry returns the time until the next previous value expires, or nil if none is kept
+func (r *SecretReconciler) nextPreviousValueExpiry(annotations map[string]string) *time.Duration {
+	var next *time.Duration
+	for key, value := range annotations {
+		if !strings.HasPrefix(key, r.keys().PreviousValueExpiresPrefix) {
+			continue
+		}
+		expires, err := time.Parse(time.RFC3339, value)
+		if err != nil {
+			continue
+		}
+		until := max(expires.Sub(r.now()), 0)
+		if next == nil || until < *next {
+			next = &until
+		}
+	}
+	return next
+}
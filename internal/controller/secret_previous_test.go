@@ -0,0 +1,168 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/generator"
+)
+
+func TestReconcilePreviousValue(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "db-credentials",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationAutogenerate:                   "password,token",
+				AnnotationGeneratedAt:                    "2026-02-02T10:00:00Z",
+				AnnotationRotate:                         "1h",
+				AnnotationGracePeriodPrefix + "password": "30m",
+			},
+		},
+		Data: map[string][]byte{
+			"password": []byte("old-password"),
+			"token":    []byte("old-token"),
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	mockClock := &MockClock{currentTime: time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)}
+	reconciler := &SecretReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Generator:     generator.NewSecretGenerator(),
+		Config:        config.NewDefaultConfig(),
+		EventRecorder: NewTestEventRecorder(10),
+		Clock:         mockClock,
+	}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}}
+	ctx := context.Background()
+
+	// Rotation keeps the previous password, but not the token without grace period
+	result, err := reconciler.Reconcile(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.RequeueAfter != 30*time.Minute {
+		t.Errorf("expected requeue for previous value removal after 30m, got %s", result.RequeueAfter)
+	}
+
+	var updatedSecret corev1.Secret
+	if err := fakeClient.Get(ctx, req.NamespacedName, &updatedSecret); err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+	if string(updatedSecret.Data["password"]) == "old-password" {
+		t.Fatal("expected password to be rotated")
+	}
+	if got := string(updatedSecret.Data["password-previous"]); got != "old-password" {
+		t.Errorf("expected previous password to be kept, got %q", got)
+	}
+	if got := updatedSecret.Annotations[AnnotationPreviousValueExpiresPrefix+"password"]; got != "2026-02-02T12:30:00Z" {
+		t.Errorf("expected previous value to expire at 2026-02-02T12:30:00Z, got %q", got)
+	}
+	if _, ok := updatedSecret.Data["token-previous"]; ok {
+		t.Error("expected no previous token without grace period")
+	}
+	newPassword := string(updatedSecret.Data["password"])
+
+	// Within the grace period, the previous value stays
+	mockClock.currentTime = mockClock.currentTime.Add(20 * time.Minute)
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := fakeClient.Get(ctx, req.NamespacedName, &updatedSecret); err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+	if _, ok := updatedSecret.Data["password-previous"]; !ok {
+		t.Error("expected previous password to be kept within the grace period")
+	}
+
+	// After the grace period, it is removed without touching the current value
+	mockClock.currentTime = mockClock.currentTime.Add(15 * time.Minute)
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := fakeClient.Get(ctx, req.NamespacedName, &updatedSecret); err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+	if _, ok := updatedSecret.Data["password-previous"]; ok {
+		t.Error("expected previous password to be removed after the grace period")
+	}
+	if _, ok := updatedSecret.Annotations[AnnotationPreviousValueExpiresPrefix+"password"]; ok {
+		t.Error("expected expiry annotation to be removed")
+	}
+	if got := string(updatedSecret.Data["password"]); got != newPassword {
+		t.Errorf("expected current password to be unchanged, got %q", got)
+	}
+}
+
+func TestReconcileInvalidGracePeriod(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "db-credentials",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationAutogenerate: "password",
+				AnnotationGracePeriod:  "soon",
+			},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	fakeRecorder := NewTestEventRecorder(10)
+	reconciler := &SecretReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Generator:     generator.NewSecretGenerator(),
+		Config:        config.NewDefaultConfig(),
+		EventRecorder: fakeRecorder,
+	}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}}
+	if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	select {
+	case event := <-fakeRecorder.Events:
+		if !strings.Contains(event, EventReasonGenerationFailed) || !strings.Contains(event, `Invalid grace period for field "password"`) {
+			t.Errorf("expected generation failed event, got: %s", event)
+		}
+	default:
+		t.Error("expected generation failed event to be recorded")
+	}
+}
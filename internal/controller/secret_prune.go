@@ -0,0 +1,93 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"slices"
+	"strings"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// isPruneEnabled returns true if the object opted into pruning
+func (k *annotationKeys) isPruneEnabled(annotations map[string]string) bool {
+	prune, _ := parseBoolAnnotation(annotations, k.Prune)
+	return prune
+}
+
+// ownsDataKey returns true if key holds the value of field or a value derived from it: its public
+// key, a companion encoding (<field>.<encoding>), its version, or its previous value
+func (r *SecretReconciler) ownsDataKey(annotations map[string]string, field, key string) bool {
+	return key == field || key == r.getFieldPublicKeyField(annotations, field) ||
+		strings.HasPrefix(key, field+".") || key == r.keys().getFieldVersionField(annotations, field) ||
+		key == field+previousValueSuffix
+}
+
+// pruneFields removes the values the operator created for fields no longer listed in fields,
+// along with their per-field annotations, if the object opted into pruning. Keys the operator
+// didn't create are never removed. It returns the removed keys.
+func (r *SecretReconciler) pruneFields(annotations map[string]string, data map[string][]byte, fields []string, logger logr.Logger) []string {
+	if !r.keys().isPruneEnabled(annotations) {
+		return nil
+	}
+	meta := r.readMetadata(annotations)
+	var pruned, kept []string
+	for _, key := range meta.ManagedFields {
+		owned := slices.ContainsFunc(fields, func(field string) bool {
+			return r.ownsDataKey(annotations, field, key)
+		})
+		if owned {
+			kept = append(kept, key)
+			continue
+		}
+		if _, ok := data[key]; ok {
+			delete(data, key)
+			pruned = append(pruned, key)
+		}
+		// The previous value of a removed field goes with it
+		if _, ok := data[key+previousValueSuffix]; ok {
+			delete(data, key+previousValueSuffix)
+			pruned = append(pruned, key+previousValueSuffix)
+		}
+		delete(annotations, r.keys().PreviousValueExpiresPrefix+key)
+		delete(meta.RotationAnchors, key)
+		delete(meta.ValueHashes, key)
+		delete(meta.ValueMACs, key)
+	}
+	if len(kept) == len(meta.ManagedFields) {
+		return nil
+	}
+	meta.ManagedFields = kept
+	meta.writeTo(annotations)
+	if len(pruned) > 0 {
+		logger.Info("Pruned fields removed from autogenerate", "keys", pruned)
+	}
+	return pruned
+}
+
+// recordPrunedFields reports the removed keys, or the keys that would be removed in dry-run mode
+func (r *SecretReconciler) recordPrunedFields(obj client.Object, pruned []string) {
+	if r.currentConfig().DryRun {
+		r.eventf(obj, nil, corev1.EventTypeNormal, EventReasonDryRunAction, "DryRun",
+			"Dry run: would prune fields %s", strings.Join(pruned, ", "))
+		return
+	}
+	r.eventf(obj, nil, corev1.EventTypeNormal, EventReasonFieldsPruned, "Prune",
+		"Pruned fields %s, which are no longer listed in autogenerate", strings.Join(pruned, ", "))
+}
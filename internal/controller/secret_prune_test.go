@@ -0,0 +1,192 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/generator"
+)
+
+func TestReconcileRecordsManagedFields(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "managed",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationAutogenerate:               "password,signing-key,api-key",
+				AnnotationTypePrefix + "signing-key": "ed25519",
+			},
+		},
+		Data: map[string][]byte{"api-key": []byte("provided")},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	reconciler := &SecretReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Generator:     generator.NewSecretGenerator(),
+		Config:        config.NewDefaultConfig(),
+		EventRecorder: NewTestEventRecorder(10),
+	}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}}
+	if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var updatedSecret corev1.Secret
+	if err := fakeClient.Get(context.Background(), req.NamespacedName, &updatedSecret); err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+	// The provided api-key isn't managed
+	if got := updatedSecret.Annotations[AnnotationManagedFields]; got != "password,signing-key,signing-key.pub" {
+		t.Errorf("expected managed fields %q, got %q", "password,signing-key,signing-key.pub", got)
+	}
+}
+
+func TestReconcilePrune(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name       string
+		prune      bool
+		dryRun     bool
+		wantPruned bool
+		wantEvent  string
+	}{
+		{name: "prune", prune: true, wantPruned: true, wantEvent: "Normal FieldsPruned: Pruned fields old-key, old-key.pub, old-token, old-token-previous"},
+		{name: "prune disabled", prune: false, wantPruned: false},
+		{name: "dry run", prune: true, dryRun: true, wantPruned: false, wantEvent: "Normal DryRunAction: Dry run: would prune fields old-key, old-key.pub, old-token, old-token-previous"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotations := map[string]string{
+				AnnotationAutogenerate:                             "password",
+				AnnotationGeneratedAt:                              "2026-02-02T10:00:00Z",
+				AnnotationManagedFields:                            "old-key,old-key.pub,old-token,password",
+				AnnotationPreviousValueExpiresPrefix + "old-token": "2099-01-01T00:00:00Z",
+				AnnotationValueMACPrefix + "old-token":             "mac",
+			}
+			if tt.prune {
+				annotations[AnnotationPrune] = "true"
+			}
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "pruned",
+					Namespace:   "default",
+					Annotations: annotations,
+				},
+				Data: map[string][]byte{
+					"password":           []byte("kept-password"),
+					"old-key":            []byte("generated-key"),
+					"old-key.pub":        []byte("generated-public-key"),
+					"old-token":          []byte("generated-token"),
+					"old-token-previous": []byte("previous-token"),
+					"user-config":        []byte("provided"),
+				},
+			}
+
+			cfg := config.NewDefaultConfig()
+			cfg.DryRun = tt.dryRun
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+			fakeRecorder := NewTestEventRecorder(10)
+			reconciler := &SecretReconciler{
+				Client:        fakeClient,
+				Scheme:        scheme,
+				Generator:     generator.NewSecretGenerator(),
+				Config:        cfg,
+				EventRecorder: fakeRecorder,
+			}
+
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}}
+			if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var updatedSecret corev1.Secret
+			if err := fakeClient.Get(context.Background(), req.NamespacedName, &updatedSecret); err != nil {
+				t.Fatalf("failed to get secret: %v", err)
+			}
+			for _, key := range []string{"old-key", "old-key.pub", "old-token", "old-token-previous"} {
+				if _, ok := updatedSecret.Data[key]; ok == tt.wantPruned {
+					t.Errorf("expected %s pruned=%v", key, tt.wantPruned)
+				}
+			}
+			if string(updatedSecret.Data["password"]) != "kept-password" || string(updatedSecret.Data["user-config"]) != "provided" {
+				t.Errorf("expected listed and provided fields to be kept, got %v", updatedSecret.Data)
+			}
+			if tt.wantPruned {
+				if got := updatedSecret.Annotations[AnnotationManagedFields]; got != "password" {
+					t.Errorf("expected managed fields %q, got %q", "password", got)
+				}
+				for _, key := range []string{AnnotationPreviousValueExpiresPrefix + "old-token", AnnotationValueMACPrefix + "old-token"} {
+					if _, ok := updatedSecret.Annotations[key]; ok {
+						t.Errorf("expected annotation %s to be removed", key)
+					}
+				}
+			}
+
+			events := drainEvents(fakeRecorder)
+			found := tt.wantEvent == ""
+			for _, event := range events {
+				if strings.HasPrefix(event, tt.wantEvent) && tt.wantEvent != "" {
+					found = true
+				}
+				if tt.wantEvent == "" && strings.Contains(strings.ToLower(event), "prune") {
+					t.Errorf("expected no prune event, got %q", event)
+				}
+			}
+			if !found {
+				t.Errorf("expected event %q, got %v", tt.wantEvent, events)
+			}
+		})
+	}
+}
+
+// TestIsPruneEnabled tests that prune accepts the same boolean values as the other flags
+func TestIsPruneEnabled(t *testing.T) {
+	tests := map[string]bool{
+		"true":  true,
+		"True":  true,
+		"1":     true,
+		"false": false,
+		"0":     false,
+	}
+	for value, want := range tests {
+		annotations := map[string]string{AnnotationPrune: value}
+		if got := defaultAnnotationKeys.isPruneEnabled(annotations); got != want {
+			t.Errorf("prune: %q: expected %v, got %v", value, want, got)
+		}
+	}
+}
@@ -18,7 +18,9 @@ package controller
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"sync"
 
 	corev1 "k8s.io/api/core/v1"
 	apierrors "k8s.io/apimachinery/pkg/api/errors"
@@ -28,6 +30,7 @@ import (
 	ctrl "sigs.k8s.io/controller-runtime"
 	"sigs.k8s.io/controller-runtime/pkg/builder"
 	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 	"sigs.k8s.io/controller-runtime/pkg/handler"
 	"sigs.k8s.io/controller-runtime/pkg/log"
 	"sigs.k8s.io/controller-runtime/pkg/predicate"
@@ -46,20 +49,49 @@ const (
 	EventReasonPushFailed = "PushFailed"
 	// EventReasonSourceDeleted indicates that the source secret was deleted.
 	EventReasonSourceDeleted = "SourceDeleted"
+	// EventReasonCleanupFailed indicates that replicas of a deleted source could not be deleted.
+	EventReasonCleanupFailed = "CleanupFailed"
 	// EventReasonConflictingFeatures indicates conflicting feature annotations.
 	EventReasonConflictingFeatures = "ConflictingFeatures"
 )
 
+// namespaceCreatedPredicate only passes Namespace creation events
+var namespaceCreatedPredicate = predicate.Funcs{
+	CreateFunc:  func(event.CreateEvent) bool { return true },
+	UpdateFunc:  func(event.UpdateEvent) bool { return false },
+	DeleteFunc:  func(event.DeleteEvent) bool { return false },
+	GenericFunc: func(event.GenericEvent) bool { return false },
+}
+
 // SecretReplicatorReconciler reconciles Secrets for replication
 type SecretReplicatorReconciler struct {
 	client.Client
 	Scheme        *runtime.Scheme
 	Config        *config.Config
 	EventRecorder events.EventRecorder
+	// Drain lets in-flight reconciles finish on shutdown. If nil, they are canceled right away.
+	Drain *ReconcileDrain
+
+	// annotationKeys are the replication annotation keys for the configured prefix, see keys
+	annotationKeys     *replicator.Annotations
+	annotationKeysOnce sync.Once
 }
 
+// keys returns the replication annotation keys for the configured annotation prefix.
+// They are built on first use; the prefix can't change while running.
+func (r *SecretReplicatorReconciler) keys() *replicator.Annotations {
+	r.annotationKeysOnce.Do(func() {
+		r.annotationKeys = replicator.NewAnnotations(r.Config.AnnotationPrefix)
+	})
+	return r.annotationKeys
+}
+
+// +kubebuilder:rbac:groups="",resources=namespaces,verbs=get;list;watch
+
 // Reconcile handles Secret replication (both pull and push)
 func (r *SecretReplicatorReconciler) Reconcile(ctx context.Context, req ctrl.Request) (ctrl.Result, error) {
+	ctx, done := r.Drain.Track(ctx)
+	defer done()
 	log := log.FromContext(ctx)
 
 	// Fetch the Secret
@@ -79,7 +111,7 @@ func (r *SecretReplicatorReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	}
 
 	// Check for conflicting annotations (autogenerate + replicate-from)
-	if replicator.HasConflictingAnnotations(secret) {
+	if r.keys().HasConflictingAnnotations(secret) {
 		r.EventRecorder.Eventf(secret, nil, corev1.EventTypeWarning, EventReasonConflictingFeatures, "Reconcile",
 			"Secret has both 'autogenerate' and 'replicate-from' annotations. These features cannot be used together.")
 		log.Info("Skipping Secret with conflicting annotations", "namespace", secret.Namespace, "name", secret.Name)
@@ -87,12 +119,12 @@ func (r *SecretReplicatorReconciler) Reconcile(ctx context.Context, req ctrl.Req
 	}
 
 	// Handle pull-based replication
-	if secret.Annotations[replicator.AnnotationReplicateFrom] != "" {
+	if secret.Annotations[r.keys().ReplicateFrom] != "" {
 		return r.handlePullReplication(ctx, secret)
 	}
 
 	// Handle push-based replication
-	if secret.Annotations[replicator.AnnotationReplicateTo] != "" {
+	if secret.Annotations[r.keys().ReplicateTo] != "" {
 		return r.handlePushReplication(ctx, secret)
 	}
 
@@ -104,11 +136,11 @@ func (r *SecretReplicatorReconciler) handlePullReplication(ctx context.Context,
 	log := log.FromContext(ctx)
 
 	// Parse source reference
-	sourceRef := targetSecret.Annotations[replicator.AnnotationReplicateFrom]
+	sourceRef := targetSecret.Annotations[r.keys().ReplicateFrom]
 	sourceNamespace, sourceName, err := replicator.ParseSourceReference(sourceRef)
 	if err != nil {
 		r.EventRecorder.Eventf(targetSecret, nil, corev1.EventTypeWarning, EventReasonReplicationFailed, "Pull",
-			fmt.Sprintf("Invalid source reference: %v", err))
+			"Invalid source reference: %v", err)
 		log.Error(err, "invalid source reference", "sourceRef", sourceRef)
 		return ctrl.Result{}, nil // Don't requeue - user needs to fix annotation
 	}
@@ -119,7 +151,7 @@ func (r *SecretReplicatorReconciler) handlePullReplication(ctx context.Context,
 	if err := r.Get(ctx, sourceKey, sourceSecret); err != nil {
 		if apierrors.IsNotFound(err) {
 			r.EventRecorder.Eventf(targetSecret, nil, corev1.EventTypeWarning, EventReasonReplicationFailed, "Pull",
-				fmt.Sprintf("Source Secret %s not found", sourceRef))
+				"Source Secret %s not found", sourceRef)
 			log.Info("Source Secret not found", "source", sourceRef)
 			return ctrl.Result{}, nil
 		}
@@ -130,35 +162,35 @@ func (r *SecretReplicatorReconciler) handlePullReplication(ctx context.Context,
 	// Check if source Secret was deleted
 	if replicator.IsBeingDeleted(sourceSecret) {
 		r.EventRecorder.Eventf(targetSecret, nil, corev1.EventTypeWarning, EventReasonSourceDeleted, "Pull",
-			fmt.Sprintf("Source Secret %s is being deleted. Target will keep last known data.", sourceRef))
+			"Source Secret %s is being deleted. Target will keep last known data.", sourceRef)
 		log.Info("Source Secret being deleted - keeping snapshot", "source", sourceRef)
 		return ctrl.Result{}, nil
 	}
 
 	// Validate replication is allowed (mutual consent or global pull-based permission)
-	sourceAllowlist := sourceSecret.Annotations[replicator.AnnotationReplicatableFromNamespaces]
+	sourceAllowlist := sourceSecret.Annotations[r.keys().ReplicatableFromNamespaces]
 	allowed, denyReason := replicator.ValidatePullConsent(r.Config.GlobalPullBasedPermissions, replicator.KindSecret,
 		sourceNamespace, sourceName, sourceAllowlist, targetSecret.Namespace)
 	if !allowed {
 		r.EventRecorder.Eventf(targetSecret, nil, corev1.EventTypeWarning, EventReasonReplicationFailed, "Pull",
-			fmt.Sprintf("Replication not allowed: %s", denyReason))
+			"Replication not allowed: %s", denyReason)
 		log.Info("Replication not allowed", "source", sourceRef, "reason", denyReason)
 		return ctrl.Result{}, nil // Don't requeue - consent required
 	}
 
 	// Replicate data from source to target
-	replicator.ReplicateSecret(sourceSecret, targetSecret)
+	r.keys().ReplicateSecret(sourceSecret, targetSecret)
 
 	// Update target Secret
 	if err := r.Update(ctx, targetSecret); err != nil {
 		r.EventRecorder.Eventf(targetSecret, nil, corev1.EventTypeWarning, EventReasonReplicationFailed, "Pull",
-			fmt.Sprintf("Failed to update target Secret: %v", err))
+			"Failed to update target Secret: %v", err)
 		log.Error(err, "failed to update target Secret")
 		return ctrl.Result{}, err
 	}
 
 	r.EventRecorder.Eventf(targetSecret, nil, corev1.EventTypeNormal, EventReasonReplicationSucceeded, "Pull",
-		fmt.Sprintf("Successfully replicated from %s", sourceRef))
+		"Successfully replicated from %s", sourceRef)
 	log.Info("Pull replication succeeded", "target", fmt.Sprintf("%s/%s", targetSecret.Namespace, targetSecret.Name), "source", sourceRef)
 
 	return ctrl.Result{}, nil
@@ -169,7 +201,7 @@ func (r *SecretReplicatorReconciler) handlePushReplication(ctx context.Context,
 	log := log.FromContext(ctx)
 
 	// Parse target namespaces
-	targetNSList := sourceSecret.Annotations[replicator.AnnotationReplicateTo]
+	targetNSList := sourceSecret.Annotations[r.keys().ReplicateTo]
 	targetNamespaces := replicator.ParseTargetNamespaces(targetNSList)
 
 	if len(targetNamespaces) == 0 {
@@ -210,12 +242,12 @@ func (r *SecretReplicatorReconciler) pushToNamespace(ctx context.Context, source
 	if err != nil {
 		if apierrors.IsNotFound(err) {
 			// Target doesn't exist - create it
-			targetSecret = replicator.CreateReplicatedSecret(sourceSecret, targetNS)
+			targetSecret = r.keys().CreateReplicatedSecret(sourceSecret, targetNS)
 			if err := r.Create(ctx, targetSecret); err != nil {
 				// Determine if this is an expected error (namespace not found, permission denied, etc.)
 				reasonMsg := humanReadableErrorReason(err)
 				r.EventRecorder.Eventf(sourceSecret, nil, corev1.EventTypeWarning, EventReasonPushFailed, "Push",
-					fmt.Sprintf("Could not replicate to namespace %s: %s", targetNS, reasonMsg))
+					"Could not replicate to namespace %s: %s", targetNS, reasonMsg)
 				log.V(1).Info("Could not replicate to namespace", "targetNamespace", targetNS, "reason", reasonMsg)
 				return
 			}
@@ -226,25 +258,25 @@ func (r *SecretReplicatorReconciler) pushToNamespace(ctx context.Context, source
 		// Unexpected error reading target
 		reasonMsg := humanReadableErrorReason(err)
 		r.EventRecorder.Eventf(sourceSecret, nil, corev1.EventTypeWarning, EventReasonPushFailed, "Push",
-			fmt.Sprintf("Could not access namespace %s: %s", targetNS, reasonMsg))
+			"Could not access namespace %s: %s", targetNS, reasonMsg)
 		log.V(1).Info("Could not access namespace", "targetNamespace", targetNS, "reason", reasonMsg)
 		return
 	}
 
 	// Target exists - check if we own it
-	if !replicator.IsOwnedByUs(targetSecret, sourceRef) {
+	if !r.keys().IsOwnedByUs(targetSecret, sourceRef) {
 		r.EventRecorder.Eventf(sourceSecret, nil, corev1.EventTypeWarning, EventReasonPushFailed, "Push",
-			fmt.Sprintf("Secret already exists in namespace %s and is not managed by this replication", targetNS))
+			"Secret already exists in namespace %s and is not managed by this replication", targetNS)
 		log.V(1).Info("Target Secret exists but is not owned by us", "targetNamespace", targetNS, "name", sourceSecret.Name)
 		return
 	}
 
 	// We own it - update it
-	replicator.ReplicateSecret(sourceSecret, targetSecret)
+	r.keys().ReplicateSecret(sourceSecret, targetSecret)
 	if err := r.Update(ctx, targetSecret); err != nil {
 		reasonMsg := humanReadableErrorReason(err)
 		r.EventRecorder.Eventf(sourceSecret, nil, corev1.EventTypeWarning, EventReasonPushFailed, "Push",
-			fmt.Sprintf("Could not update Secret in namespace %s: %s", targetNS, reasonMsg))
+			"Could not update Secret in namespace %s: %s", targetNS, reasonMsg)
 		log.V(1).Info("Could not update Secret in namespace", "targetNamespace", targetNS, "reason", reasonMsg)
 		return
 	}
@@ -287,7 +319,7 @@ func (r *SecretReplicatorReconciler) handleDeletion(ctx context.Context, sourceS
 	}
 
 	// Only handle deletion for secrets with replicate-to annotation
-	if sourceSecret.Annotations[replicator.AnnotationReplicateTo] == "" {
+	if sourceSecret.Annotations[r.keys().ReplicateTo] == "" {
 		// Remove finalizer and let it be deleted
 		replicator.RemoveFinalizer(sourceSecret)
 		if err := r.Update(ctx, sourceSecret); err != nil {
@@ -306,16 +338,29 @@ func (r *SecretReplicatorReconciler) handleDeletion(ctx context.Context, sourceS
 		return ctrl.Result{}, err
 	}
 
-	// Delete all pushed Secrets
+	// Delete all pushed Secrets. Failures don't stop the cleanup of the others; the finalizer is
+	// kept and the deletion retried until all replicas are gone.
+	var deleteErrs []error
 	for i := range secretList.Items {
 		secret := &secretList.Items[i]
-		if replicator.GetReplicatedFromAnnotation(secret) == sourceRef {
-			if err := r.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
-				log.Error(err, "failed to delete replicated Secret", "namespace", secret.Namespace, "name", secret.Name)
-				return ctrl.Result{}, err
-			}
-			log.Info("Deleted replicated Secret", "namespace", secret.Namespace, "name", secret.Name)
+		if r.keys().GetReplicatedFromAnnotation(secret) != sourceRef {
+			continue
+		}
+		if r.keys().IsRetargeted(secret, sourceRef) {
+			log.Info("Keeping retargeted replicated Secret", "namespace", secret.Namespace, "name", secret.Name)
+			continue
+		}
+		if err := r.Delete(ctx, secret); err != nil && !apierrors.IsNotFound(err) {
+			log.Error(err, "failed to delete replicated Secret", "namespace", secret.Namespace, "name", secret.Name)
+			deleteErrs = append(deleteErrs, fmt.Errorf("%s/%s: %w", secret.Namespace, secret.Name, err))
+			continue
 		}
+		log.Info("Deleted replicated Secret", "namespace", secret.Namespace, "name", secret.Name)
+	}
+	if len(deleteErrs) > 0 {
+		r.EventRecorder.Eventf(sourceSecret, nil, corev1.EventTypeWarning, EventReasonCleanupFailed, "Cleanup",
+			"Could not delete %d replicated Secret(s), retrying", len(deleteErrs))
+		return ctrl.Result{}, fmt.Errorf("failed to delete replicated Secrets: %w", errors.Join(deleteErrs...))
 	}
 
 	// Remove finalizer from source Secret
@@ -349,8 +394,8 @@ func (r *SecretReplicatorReconciler) SetupWithManagerAndName(mgr ctrl.Manager, n
 		}
 
 		// Watch Secrets with replication annotations
-		hasReplicateFrom := secret.Annotations[replicator.AnnotationReplicateFrom] != ""
-		hasReplicateTo := secret.Annotations[replicator.AnnotationReplicateTo] != ""
+		hasReplicateFrom := secret.Annotations[r.keys().ReplicateFrom] != ""
+		hasReplicateTo := secret.Annotations[r.keys().ReplicateTo] != ""
 
 		return hasReplicateFrom || hasReplicateTo
 	})
@@ -363,7 +408,7 @@ func (r *SecretReplicatorReconciler) SetupWithManagerAndName(mgr ctrl.Manager, n
 		}
 		// Secrets with replicatable-from-namespaces can be sources
 		if secret.Annotations != nil &&
-			secret.Annotations[replicator.AnnotationReplicatableFromNamespaces] != "" {
+			secret.Annotations[r.keys().ReplicatableFromNamespaces] != "" {
 			return true
 		}
 		// Secrets covered by a global pull-based permission can be sources too
@@ -387,6 +432,12 @@ func (r *SecretReplicatorReconciler) SetupWithManagerAndName(mgr ctrl.Manager, n
 			&corev1.Secret{},
 			handler.EnqueueRequestsFromMapFunc(r.findPushSourcesForTarget),
 		).
+		// Watch Namespace creation to push Secrets into target namespaces that didn't exist yet
+		Watches(
+			&corev1.Namespace{},
+			handler.EnqueueRequestsFromMapFunc(r.findPushSourcesForNamespace),
+			builder.WithPredicates(namespaceCreatedPredicate),
+		).
 		Complete(r)
 }
 
@@ -416,7 +467,7 @@ func (r *SecretReplicatorReconciler) findTargetsForSource(ctx context.Context, o
 		}
 
 		// Check if this target pulls from our source
-		targetSourceRef := target.Annotations[replicator.AnnotationReplicateFrom]
+		targetSourceRef := target.Annotations[r.keys().ReplicateFrom]
 		if targetSourceRef == sourceRef {
 			requests = append(requests, reconcile.Request{
 				NamespacedName: types.NamespacedName{
@@ -460,7 +511,7 @@ func (r *SecretReplicatorReconciler) findPushSourcesForTarget(ctx context.Contex
 		}
 
 		// Check if this source pushes to the namespace where the Secret changed
-		replicateTo := source.Annotations[replicator.AnnotationReplicateTo]
+		replicateTo := source.Annotations[r.keys().ReplicateTo]
 		if replicateTo == "" {
 			continue
 		}
@@ -492,3 +543,46 @@ func (r *SecretReplicatorReconciler) findPushSourcesForTarget(ctx context.Contex
 
 	return requests
 }
+
+// findPushSourcesForNamespace finds all source Secrets that push to a newly created namespace.
+// This pre-provisions the replicas instead of waiting for the next change of the source.
+func (r *SecretReplicatorReconciler) findPushSourcesForNamespace(ctx context.Context, obj client.Object) []reconcile.Request {
+	namespace, ok := obj.(*corev1.Namespace)
+	if !ok {
+		return nil
+	}
+
+	log := log.FromContext(ctx)
+
+	secretList := &corev1.SecretList{}
+	if err := r.List(ctx, secretList); err != nil {
+		log.Error(err, "failed to list Secrets for namespace mapping", "namespace", namespace.Name)
+		return nil
+	}
+
+	var requests []reconcile.Request
+	for i := range secretList.Items {
+		source := &secretList.Items[i]
+		if source.Annotations == nil {
+			continue
+		}
+
+		for _, targetNS := range replicator.ParseTargetNamespaces(source.Annotations[r.keys().ReplicateTo]) {
+			if targetNS == namespace.Name {
+				requests = append(requests, reconcile.Request{
+					NamespacedName: types.NamespacedName{
+						Namespace: source.Namespace,
+						Name:      source.Name,
+					},
+				})
+				break
+			}
+		}
+	}
+
+	if len(requests) > 0 {
+		log.Info("Triggering reconciliation of push sources for new namespace", "namespace", namespace.Name, "sourceCount", len(requests))
+	}
+
+	return requests
+}
@@ -31,6 +31,7 @@ import (
 	"sigs.k8s.io/controller-runtime/pkg/client"
 	"sigs.k8s.io/controller-runtime/pkg/client/fake"
 	"sigs.k8s.io/controller-runtime/pkg/client/interceptor"
+	"sigs.k8s.io/controller-runtime/pkg/event"
 
 	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
 	"github.com/guided-traffic/internal-secrets-operator/pkg/replicator"
@@ -1749,6 +1750,173 @@ func TestSecretReplicatorReconciler_HandleDeletionDeleteError(t *testing.T) {
 	}
 }
 
+func TestSecretReplicatorReconciler_HandleDeletionKeepsRetargetedReplica(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	sourceSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "push-retargeted",
+			Namespace:         "production",
+			DeletionTimestamp: &metav1.Time{Time: metav1.Now().Time},
+			Finalizers:        []string{replicator.FinalizerReplicateToCleanup},
+			Annotations: map[string]string{
+				replicator.AnnotationReplicateTo: "staging,development",
+			},
+		},
+	}
+
+	replica := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "push-retargeted",
+			Namespace: "staging",
+			Annotations: map[string]string{
+				replicator.AnnotationReplicatedFrom: "production/push-retargeted",
+			},
+		},
+	}
+
+	// Manually pointed at another source, but not yet replicated from it
+	retargetedReplica := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "push-retargeted",
+			Namespace: "development",
+			Annotations: map[string]string{
+				replicator.AnnotationReplicatedFrom: "production/push-retargeted",
+				replicator.AnnotationReplicateFrom:  "shared/push-retargeted",
+			},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(sourceSecret, replica, retargetedReplica).
+		Build()
+
+	reconciler := &SecretReplicatorReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Config:        config.NewDefaultConfig(),
+		EventRecorder: NewTestEventRecorder(10),
+	}
+
+	req := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: sourceSecret.Namespace,
+			Name:      sourceSecret.Name,
+		},
+	}
+
+	if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() error = %v", err)
+	}
+
+	err := fakeClient.Get(context.Background(), types.NamespacedName{Namespace: "staging", Name: "push-retargeted"}, &corev1.Secret{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("Expected replica in staging to be deleted, got err = %v", err)
+	}
+	if err := fakeClient.Get(context.Background(), types.NamespacedName{Namespace: "development", Name: "push-retargeted"}, &corev1.Secret{}); err != nil {
+		t.Errorf("Expected retargeted replica in development to be kept, got err = %v", err)
+	}
+}
+
+func TestSecretReplicatorReconciler_HandleDeletionPartialFailure(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	sourceSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:              "push-partial",
+			Namespace:         "production",
+			DeletionTimestamp: &metav1.Time{Time: metav1.Now().Time},
+			Finalizers:        []string{replicator.FinalizerReplicateToCleanup},
+			Annotations: map[string]string{
+				replicator.AnnotationReplicateTo: "development,staging",
+			},
+		},
+	}
+
+	var replicas []client.Object
+	for _, ns := range []string{"development", "staging"} {
+		replicas = append(replicas, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "push-partial",
+				Namespace: ns,
+				Annotations: map[string]string{
+					replicator.AnnotationReplicatedFrom: "production/push-partial",
+				},
+			},
+		})
+	}
+
+	failDelete := true
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(append(replicas, sourceSecret)...).
+		WithInterceptorFuncs(interceptor.Funcs{
+			Delete: func(ctx context.Context, client client.WithWatch, obj client.Object, opts ...client.DeleteOption) error {
+				if obj.GetNamespace() == "development" && failDelete {
+					return fmt.Errorf("simulated delete error")
+				}
+				return client.Delete(ctx, obj, opts...)
+			},
+		}).
+		Build()
+
+	recorder := NewTestEventRecorder(10)
+	reconciler := &SecretReplicatorReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Config:        config.NewDefaultConfig(),
+		EventRecorder: recorder,
+	}
+
+	req := ctrl.Request{
+		NamespacedName: types.NamespacedName{
+			Namespace: sourceSecret.Namespace,
+			Name:      sourceSecret.Name,
+		},
+	}
+
+	if _, err := reconciler.Reconcile(context.Background(), req); err == nil {
+		t.Fatal("Expected error from Reconcile when a replica can't be deleted")
+	}
+
+	// The other replica is deleted anyway
+	err := fakeClient.Get(context.Background(), types.NamespacedName{Namespace: "staging", Name: "push-partial"}, &corev1.Secret{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("Expected replica in staging to be deleted, got err = %v", err)
+	}
+
+	// The finalizer is kept for the retry
+	updatedSource := &corev1.Secret{}
+	if err := fakeClient.Get(context.Background(), req.NamespacedName, updatedSource); err != nil {
+		t.Fatalf("Failed to get source secret: %v", err)
+	}
+	if !replicator.HasFinalizer(updatedSource) {
+		t.Error("Expected finalizer to be kept while replicas remain")
+	}
+
+	select {
+	case e := <-recorder.Events:
+		if !strings.Contains(e, EventReasonCleanupFailed) {
+			t.Errorf("Expected CleanupFailed event, got: %s", e)
+		}
+	default:
+		t.Error("Expected CleanupFailed event")
+	}
+
+	// The retry completes the cleanup
+	failDelete = false
+	if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("Reconcile() retry error = %v", err)
+	}
+	err = fakeClient.Get(context.Background(), types.NamespacedName{Namespace: "development", Name: "push-partial"}, &corev1.Secret{})
+	if !apierrors.IsNotFound(err) {
+		t.Errorf("Expected replica in development to be deleted on retry, got err = %v", err)
+	}
+}
+
 func TestSecretReplicatorReconciler_HandleDeletionRemoveFinalizerError(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = corev1.AddToScheme(scheme)
@@ -2275,6 +2443,133 @@ func TestSecretReplicatorReconciler_FindPushSourcesForTarget(t *testing.T) {
 	}
 }
 
+func TestSecretReplicatorReconciler_FindPushSourcesForNamespace(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	objects := []client.Object{
+		// Pushes to the new namespace among others (already reconciled, so it has the finalizer)
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:       "db-credentials",
+				Namespace:  "production",
+				Finalizers: []string{replicator.FinalizerReplicateToCleanup},
+				Annotations: map[string]string{
+					replicator.AnnotationReplicateTo: "staging, preview-42",
+				},
+			},
+			Data: map[string][]byte{
+				"password": []byte("secret-password"),
+			},
+		},
+		// Pushes to other namespaces only
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "api-key",
+				Namespace: "production",
+				Annotations: map[string]string{
+					replicator.AnnotationReplicateTo: "staging",
+				},
+			},
+		},
+		// Pulls instead of pushing
+		&corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "pulled",
+				Namespace: "staging",
+				Annotations: map[string]string{
+					replicator.AnnotationReplicateFrom: "production/api-key",
+				},
+			},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().
+		WithScheme(scheme).
+		WithObjects(objects...).
+		Build()
+
+	reconciler := &SecretReplicatorReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Config:        config.NewDefaultConfig(),
+		EventRecorder: NewTestEventRecorder(10),
+	}
+
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "preview-42"}}
+	requests := reconciler.findPushSourcesForNamespace(context.Background(), namespace)
+
+	if len(requests) != 1 {
+		t.Fatalf("Expected 1 request, got %d", len(requests))
+	}
+	if requests[0].Namespace != "production" || requests[0].Name != "db-credentials" {
+		t.Errorf("Expected request for production/db-credentials, got %s/%s", requests[0].Namespace, requests[0].Name)
+	}
+
+	// Reconciling the source creates the replica in the new namespace without touching the source
+	var sourceBefore corev1.Secret
+	sourceKey := types.NamespacedName{Namespace: "production", Name: "db-credentials"}
+	if err := fakeClient.Get(context.Background(), sourceKey, &sourceBefore); err != nil {
+		t.Fatalf("Failed to get source secret: %v", err)
+	}
+	if _, err := reconciler.Reconcile(context.Background(), requests[0]); err != nil {
+		t.Fatalf("Reconcile failed: %v", err)
+	}
+
+	var replica corev1.Secret
+	if err := fakeClient.Get(context.Background(), types.NamespacedName{Namespace: "preview-42", Name: "db-credentials"}, &replica); err != nil {
+		t.Fatalf("Expected replica in new namespace: %v", err)
+	}
+	if replica.Annotations[replicator.AnnotationReplicatedFrom] != "production/db-credentials" {
+		t.Errorf("Expected replicated-from annotation, got %q", replica.Annotations[replicator.AnnotationReplicatedFrom])
+	}
+	if string(replica.Data["password"]) != "secret-password" {
+		t.Errorf("Expected replicated data, got %q", replica.Data["password"])
+	}
+
+	var sourceAfter corev1.Secret
+	if err := fakeClient.Get(context.Background(), sourceKey, &sourceAfter); err != nil {
+		t.Fatalf("Failed to get source secret: %v", err)
+	}
+	if sourceAfter.ResourceVersion != sourceBefore.ResourceVersion {
+		t.Error("Expected source secret to be unchanged")
+	}
+}
+
+func TestSecretReplicatorReconciler_FindPushSourcesForNamespaceNonNamespace(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = corev1.AddToScheme(scheme)
+
+	reconciler := &SecretReplicatorReconciler{
+		Client:        fake.NewClientBuilder().WithScheme(scheme).Build(),
+		Scheme:        scheme,
+		Config:        config.NewDefaultConfig(),
+		EventRecorder: NewTestEventRecorder(10),
+	}
+
+	secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Name: "preview-42", Namespace: "default"}}
+	if requests := reconciler.findPushSourcesForNamespace(context.Background(), secret); requests != nil {
+		t.Errorf("Expected nil requests for non-Namespace object, got %d requests", len(requests))
+	}
+}
+
+func TestNamespaceCreatedPredicate(t *testing.T) {
+	namespace := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{Name: "preview-42"}}
+
+	if !namespaceCreatedPredicate.Create(event.CreateEvent{Object: namespace}) {
+		t.Error("Expected create events to pass")
+	}
+	if namespaceCreatedPredicate.Update(event.UpdateEvent{ObjectOld: namespace, ObjectNew: namespace}) {
+		t.Error("Expected update events to be filtered")
+	}
+	if namespaceCreatedPredicate.Delete(event.DeleteEvent{Object: namespace}) {
+		t.Error("Expected delete events to be filtered")
+	}
+	if namespaceCreatedPredicate.Generic(event.GenericEvent{Object: namespace}) {
+		t.Error("Expected generic events to be filtered")
+	}
+}
+
 func TestSecretReplicatorReconciler_FindPushSourcesForTargetNonSecret(t *testing.T) {
 	scheme := runtime.NewScheme()
 	_ = corev1.AddToScheme(scheme)
@@ -0,0 +1,142 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"reflect"
+	"strconv"
+	"time"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// Values of the status annotation
+const (
+	StatusReady = "Ready"
+	StatusError = "Error"
+)
+
+// statusAnnotations returns the annotations written by updateStatus
+func (k *annotationKeys) statusAnnotations() []string {
+	return []string{k.Status, k.LastError, k.FailureCount, k.NextRotationTime}
+}
+
+// updateStatus writes the status annotations of a reconciled Secret or ConfigMap. errMsg is the error of the
+// reconcile, or empty if it succeeded; nextRotation is the time until the next rotation, or nil
+// if none is scheduled. A failed reconcile increments the failure count, a successful one clears it.
+// The Secret is only patched if an annotation changed, and never in dry-run mode.
+func (r *SecretReconciler) updateStatus(ctx context.Context, obj client.Object, nextRotation *time.Duration, errMsg string, logger logr.Logger) error {
+	if r.currentConfig().DryRun {
+		return nil
+	}
+	annotations := obj.GetAnnotations()
+	status := map[string]string{r.keys().Status: StatusReady}
+	if errMsg != "" {
+		status[r.keys().Status] = StatusError
+		status[r.keys().LastError] = errMsg
+		status[r.keys().FailureCount] = strconv.Itoa(r.keys().getFailureCount(annotations) + 1)
+	}
+	if nextRotation != nil {
+		status[r.keys().NextRotationTime] = r.nextRotationTime(r.now().Add(*nextRotation)).Format(time.RFC3339)
+	}
+
+	changed := false
+	for _, key := range r.keys().statusAnnotations() {
+		if annotations[key] != status[key] {
+			changed = true
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	patch := client.MergeFrom(obj.DeepCopyObject().(client.Object))
+	if annotations == nil {
+		annotations = make(map[string]string)
+		obj.SetAnnotations(annotations)
+	}
+	for _, key := range r.keys().statusAnnotations() {
+		if value, ok := status[key]; ok {
+			annotations[key] = value
+		} else {
+			delete(annotations, key)
+		}
+	}
+	if err := r.Patch(ctx, obj, patch); err != nil {
+		logger.Error(err, "Failed to update status annotations")
+		return client.IgnoreNotFound(err)
+	}
+	return nil
+}
+
+// nextRotationTime returns when a rotation due at t happens: rotations due outside the
+// maintenance windows or inside a blackout window wait until rotation is allowed again
+func (r *SecretReconciler) nextRotationTime(t time.Time) time.Time {
+	if next := r.currentConfig().Rotation.MaintenanceWindows.NextRotationAllowed(t); !next.IsZero() {
+		return next
+	}
+	return t
+}
+
+// isStatusOnlyUpdate returns true if the update of a Secret or ConfigMap changed the status
+// annotations and nothing else the operator reads, so it needs no reconcile
+func (k *annotationKeys) isStatusOnlyUpdate(oldObj, newObj client.Object) bool {
+	oldValues, ok := objectValues(oldObj)
+	if !ok {
+		return false
+	}
+	newValues, ok := objectValues(newObj)
+	if !ok {
+		return false
+	}
+
+	withoutStatus := func(annotations map[string]string) map[string]string {
+		stripped := make(map[string]string, len(annotations))
+		for key, value := range annotations {
+			stripped[key] = value
+		}
+		for _, key := range k.statusAnnotations() {
+			delete(stripped, key)
+		}
+		return stripped
+	}
+
+	for _, key := range k.statusAnnotations() {
+		if oldObj.GetAnnotations()[key] != newObj.GetAnnotations()[key] {
+			return reflect.DeepEqual(withoutStatus(oldObj.GetAnnotations()), withoutStatus(newObj.GetAnnotations())) &&
+				reflect.DeepEqual(oldObj.GetLabels(), newObj.GetLabels()) &&
+				reflect.DeepEqual(oldValues, newValues) &&
+				oldObj.GetDeletionTimestamp().Equal(newObj.GetDeletionTimestamp())
+		}
+	}
+	return false
+}
+
+// objectValues returns the values of a Secret or ConfigMap. It returns false for other objects.
+func objectValues(obj client.Object) (map[string][]byte, bool) {
+	switch o := obj.(type) {
+	case *corev1.Secret:
+		return o.Data, true
+	case *corev1.ConfigMap:
+		return configMapValues(o), true
+	default:
+		return nil, false
+	}
+}
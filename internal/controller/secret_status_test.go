@@ -0,0 +1,255 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/generator"
+)
+
+func TestReconcileStatus(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "status-secret",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationAutogenerate: "password",
+				AnnotationRotate:       "24h",
+			},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	mockClock := &MockClock{currentTime: time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)}
+	reconciler := &SecretReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Generator:     generator.NewSecretGenerator(),
+		Config:        config.NewDefaultConfig(),
+		EventRecorder: NewTestEventRecorder(10),
+		Clock:         mockClock,
+	}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}}
+	ctx := context.Background()
+	reconcile := func() *corev1.Secret {
+		t.Helper()
+		if _, err := reconciler.Reconcile(ctx, req); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var updatedSecret corev1.Secret
+		if err := fakeClient.Get(ctx, req.NamespacedName, &updatedSecret); err != nil {
+			t.Fatalf("failed to get secret: %v", err)
+		}
+		return &updatedSecret
+	}
+
+	// Initial generation
+	updatedSecret := reconcile()
+	if got := updatedSecret.Annotations[AnnotationStatus]; got != StatusReady {
+		t.Errorf("expected status %q, got %q", StatusReady, got)
+	}
+	if got := updatedSecret.Annotations[AnnotationNextRotationTime]; got != "2026-02-03T12:00:00Z" {
+		t.Errorf("expected next rotation time 2026-02-03T12:00:00Z, got %q", got)
+	}
+	if _, ok := updatedSecret.Annotations[AnnotationLastRotationTime]; ok {
+		t.Error("expected no last rotation time before the first rotation")
+	}
+
+	// Rotation
+	mockClock.currentTime = mockClock.currentTime.Add(25 * time.Hour)
+	updatedSecret = reconcile()
+	if got := updatedSecret.Annotations[AnnotationLastRotationTime]; got != "2026-02-03T13:00:00Z" {
+		t.Errorf("expected last rotation time 2026-02-03T13:00:00Z, got %q", got)
+	}
+	if got := updatedSecret.Annotations[AnnotationNextRotationTime]; got != "2026-02-04T13:00:00Z" {
+		t.Errorf("expected next rotation time 2026-02-04T13:00:00Z, got %q", got)
+	}
+
+	// A failing reconcile reports the error
+	updatedSecret.Annotations[AnnotationType] = "unknown"
+	if err := fakeClient.Update(ctx, updatedSecret); err != nil {
+		t.Fatalf("failed to update secret: %v", err)
+	}
+	mockClock.currentTime = mockClock.currentTime.Add(25 * time.Hour)
+	updatedSecret = reconcile()
+	if got := updatedSecret.Annotations[AnnotationStatus]; got != StatusError {
+		t.Errorf("expected status %q, got %q", StatusError, got)
+	}
+	if got := updatedSecret.Annotations[AnnotationLastError]; !strings.Contains(got, "unknown") {
+		t.Errorf("expected last error naming the unknown type, got %q", got)
+	}
+	if _, ok := updatedSecret.Annotations[AnnotationNextRotationTime]; ok {
+		t.Error("expected no next rotation time while failing")
+	}
+
+	// Fixing the Secret clears the error
+	delete(updatedSecret.Annotations, AnnotationType)
+	if err := fakeClient.Update(ctx, updatedSecret); err != nil {
+		t.Fatalf("failed to update secret: %v", err)
+	}
+	updatedSecret = reconcile()
+	if got := updatedSecret.Annotations[AnnotationStatus]; got != StatusReady {
+		t.Errorf("expected status %q, got %q", StatusReady, got)
+	}
+	if _, ok := updatedSecret.Annotations[AnnotationLastError]; ok {
+		t.Error("expected last error to be removed")
+	}
+}
+
+func TestReconcileStatusUnchangedNotWritten(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "status-secret",
+			Namespace:   "default",
+			Annotations: map[string]string{AnnotationAutogenerate: "password"},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	reconciler := &SecretReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Generator:     generator.NewSecretGenerator(),
+		Config:        config.NewDefaultConfig(),
+		EventRecorder: NewTestEventRecorder(10),
+	}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}}
+	ctx := context.Background()
+	var versions []string
+	for range 3 {
+		if _, err := reconciler.Reconcile(ctx, req); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var updatedSecret corev1.Secret
+		if err := fakeClient.Get(ctx, req.NamespacedName, &updatedSecret); err != nil {
+			t.Fatalf("failed to get secret: %v", err)
+		}
+		versions = append(versions, updatedSecret.ResourceVersion)
+	}
+	if versions[1] != versions[2] {
+		t.Errorf("expected an unchanged Secret not to be written again, got resource versions %v", versions)
+	}
+}
+
+func TestNextRotationTime(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	cfg.Rotation.MaintenanceWindows = config.MaintenanceWindowsConfig{
+		Enabled: true,
+		Windows: []config.MaintenanceWindow{
+			{
+				Name:      "weekend-night",
+				Days:      []string{"saturday", "sunday"},
+				StartTime: "03:00",
+				EndTime:   "05:00",
+				Timezone:  "UTC",
+			},
+		},
+	}
+	reconciler := &SecretReconciler{Config: cfg}
+
+	// Monday 12:00 waits for Saturday 03:00
+	due := time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)
+	if got, want := reconciler.nextRotationTime(due), time.Date(2026, 2, 7, 3, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("expected %s, got %s", want, got)
+	}
+
+	// Inside a window the rotation happens when due
+	due = time.Date(2026, 2, 7, 4, 0, 0, 0, time.UTC)
+	if got := reconciler.nextRotationTime(due); !got.Equal(due) {
+		t.Errorf("expected %s, got %s", due, got)
+	}
+}
+
+func TestIsStatusOnlyUpdate(t *testing.T) {
+	base := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Annotations: map[string]string{AnnotationAutogenerate: "password"},
+		},
+		Data: map[string][]byte{"password": []byte("value")},
+	}
+
+	statusChanged := base.DeepCopy()
+	statusChanged.Annotations[AnnotationStatus] = StatusReady
+	statusChanged.Annotations[AnnotationNextRotationTime] = "2026-02-03T12:00:00Z"
+
+	statusAndData := statusChanged.DeepCopy()
+	statusAndData.Data["password"] = []byte("changed")
+
+	statusAndAnnotation := statusChanged.DeepCopy()
+	statusAndAnnotation.Annotations[AnnotationRotateNow] = "true"
+
+	tests := []struct {
+		name   string
+		newObj *corev1.Secret
+		want   bool
+	}{
+		{name: "status only", newObj: statusChanged, want: true},
+		{name: "unchanged", newObj: base.DeepCopy(), want: false},
+		{name: "status and data", newObj: statusAndData, want: false},
+		{name: "status and other annotation", newObj: statusAndAnnotation, want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := defaultAnnotationKeys.isStatusOnlyUpdate(base, tt.newObj); got != tt.want {
+				t.Errorf("expected %v, got %v", tt.want, got)
+			}
+		})
+	}
+}
+
+func TestAppendRotationHistory(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	cfg.Rotation.HistorySize = 2
+	reconciler := &SecretReconciler{Config: cfg}
+	start := time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)
+
+	// An unparsable history is started over
+	annotations := map[string]string{AnnotationRotationHistory: "not json"}
+	reconciler.appendRotationHistory(annotations, start)
+	if got := annotations[AnnotationRotationHistory]; got != `["2026-02-02T12:00:00Z"]` {
+		t.Errorf("expected a new history, got %s", got)
+	}
+
+	// The oldest entries are dropped beyond the history size
+	reconciler.appendRotationHistory(annotations, start.Add(time.Hour))
+	reconciler.appendRotationHistory(annotations, start.Add(2*time.Hour))
+	if got := annotations[AnnotationRotationHistory]; got != `["2026-02-02T13:00:00Z","2026-02-02T14:00:00Z"]` {
+		t.Errorf("expected the last two rotations, got %s", got)
+	}
+}
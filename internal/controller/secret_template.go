@@ -0,0 +1,247 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"bytes"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"text/template"
+	"text/template/parse"
+)
+
+// templateFields returns the fields defined by template.<field> annotations, sorted by name
+func (k *annotationKeys) templateFields(annotations map[string]string) []string {
+	var fields []string
+	for key := range annotations {
+		if field, ok := strings.CutPrefix(key, k.TemplatePrefix); ok && field != "" {
+			fields = append(fields, field)
+		}
+	}
+	sort.Strings(fields)
+	return fields
+}
+
+// templateCycleError reports templated fields that reference each other in a cycle
+type templateCycleError struct {
+	// fields is the cycle, starting and ending with the same field
+	fields []string
+}
+
+func (e *templateCycleError) Error() string {
+	return "template cycle between fields " + strings.Join(e.fields, " -> ")
+}
+
+// checkTemplateCycles returns a *templateCycleError if the templated fields of annotations
+// reference each other in a cycle. Templates that don't parse are skipped; they fail where
+// they are validated or rendered.
+func (k *annotationKeys) checkTemplateCycles(annotations map[string]string) error {
+	fields := k.templateFields(annotations)
+	if len(fields) == 0 {
+		return nil
+	}
+	isTemplated := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		isTemplated[field] = true
+	}
+	references := make(map[string][]string, len(fields))
+	for _, field := range fields {
+		tmpl, err := template.New(field).Parse(annotations[k.TemplatePrefix+field])
+		if err != nil {
+			continue
+		}
+		refs := make(map[string]bool)
+		collectTemplateReferences(tmpl.Root, refs)
+		for ref := range refs {
+			references[field] = append(references[field], ref)
+		}
+		sort.Strings(references[field])
+	}
+	_, err := templateOrder(fields, references, isTemplated)
+	return err
+}
+
+// renderTemplateFields renders the templated fields of the Secret from its other fields, after
+// all autogenerated fields are produced. Templates may reference templated fields, which are
+// rendered first. It returns whether a templated value changed. Nothing is written on error.
+func (r *SecretReconciler) renderTemplateFields(annotations map[string]string, data map[string][]byte, autogenerated []string) (bool, error) {
+	fields := r.keys().templateFields(annotations)
+	if len(fields) == 0 {
+		return false, nil
+	}
+
+	isTemplated := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		isTemplated[field] = true
+	}
+	for _, field := range autogenerated {
+		if isTemplated[field] {
+			return false, fmt.Errorf("field %q is both autogenerated and templated", field)
+		}
+	}
+
+	// Parse the templates and collect the fields they reference
+	templates := make(map[string]*template.Template, len(fields))
+	references := make(map[string][]string, len(fields))
+	for _, field := range fields {
+		tmpl, err := template.New(field).Option("missingkey=error").Parse(annotations[r.keys().TemplatePrefix+field])
+		if err != nil {
+			return false, fmt.Errorf("invalid template for field %q: %w", field, err)
+		}
+		templates[field] = tmpl
+		refs := make(map[string]bool)
+		collectTemplateReferences(tmpl.Root, refs)
+		for ref := range refs {
+			if !isTemplated[ref] {
+				if _, ok := data[ref]; !ok {
+					return false, fmt.Errorf("template for field %q references nonexistent field %q", field, ref)
+				}
+			}
+			references[field] = append(references[field], ref)
+		}
+		sort.Strings(references[field])
+	}
+
+	order, err := templateOrder(fields, references, isTemplated)
+	if err != nil {
+		return false, err
+	}
+
+	// Render in dependency order from the non-templated fields
+	values := make(map[string]string, len(data))
+	for field, value := range data {
+		if !isTemplated[field] {
+			values[field] = string(value)
+		}
+	}
+	rendered := make(map[string][]byte, len(order))
+	for _, field := range order {
+		var buf bytes.Buffer
+		if err := templates[field].Execute(&buf, values); err != nil {
+			return false, fmt.Errorf("failed to render template for field %q: %w", field, err)
+		}
+		values[field] = buf.String()
+		rendered[field] = buf.Bytes()
+	}
+
+	changed := false
+	for field, value := range rendered {
+		if current, ok := data[field]; !ok || !bytes.Equal(current, value) {
+			data[field] = value
+			changed = true
+		}
+	}
+	return changed, nil
+}
+
+// templateOrder sorts the templated fields so that every field comes after the templated
+// fields it references. It returns an error naming the fields of a reference cycle.
+func templateOrder(fields []string, references map[string][]string, isTemplated map[string]bool) ([]string, error) {
+	const (
+		unvisited = iota
+		visiting
+		done
+	)
+	state := make(map[string]int, len(fields))
+	order := make([]string, 0, len(fields))
+
+	var visit func(field string, path []string) error
+	visit = func(field string, path []string) error {
+		switch state[field] {
+		case done:
+			return nil
+		case visiting:
+			start := 0
+			for path[start] != field {
+				start++
+			}
+			return &templateCycleError{fields: append(slices.Clone(path[start:]), field)}
+		}
+		state[field] = visiting
+		for _, ref := range references[field] {
+			if isTemplated[ref] {
+				if err := visit(ref, append(path, field)); err != nil {
+					return err
+				}
+			}
+		}
+		state[field] = done
+		order = append(order, field)
+		return nil
+	}
+
+	for _, field := range fields {
+		if err := visit(field, nil); err != nil {
+			return nil, err
+		}
+	}
+	return order, nil
+}
+
+// collectTemplateReferences adds the fields a template references as {{ .field }}, {{ $.field }}
+// or {{ index . "field" }} to refs. References inside range and with blocks are resolved against
+// a different dot and may be missed; executing the template still fails on missing fields then.
+func collectTemplateReferences(node parse.Node, refs map[string]bool) {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return
+		}
+		for _, child := range n.Nodes {
+			collectTemplateReferences(child, refs)
+		}
+	case *parse.ActionNode:
+		collectTemplateReferences(n.Pipe, refs)
+	case *parse.PipeNode:
+		if n == nil {
+			return
+		}
+		for _, cmd := range n.Cmds {
+			collectTemplateReferences(cmd, refs)
+		}
+	case *parse.CommandNode:
+		if len(n.Args) >= 3 {
+			ident, isIdent := n.Args[0].(*parse.IdentifierNode)
+			_, isDot := n.Args[1].(*parse.DotNode)
+			key, isString := n.Args[2].(*parse.StringNode)
+			if isIdent && ident.Ident == "index" && isDot && isString {
+				refs[key.Text] = true
+			}
+		}
+		for _, arg := range n.Args {
+			collectTemplateReferences(arg, refs)
+		}
+	case *parse.FieldNode:
+		refs[n.Ident[0]] = true
+	case *parse.VariableNode:
+		if len(n.Ident) > 1 && n.Ident[0] == "$" {
+			refs[n.Ident[1]] = true
+		}
+	case *parse.ChainNode:
+		collectTemplateReferences(n.Node, refs)
+	case *parse.IfNode:
+		collectTemplateReferences(n.Pipe, refs)
+		collectTemplateReferences(n.List, refs)
+		collectTemplateReferences(n.ElseList, refs)
+	case *parse.RangeNode:
+		collectTemplateReferences(n.Pipe, refs)
+	case *parse.WithNode:
+		collectTemplateReferences(n.Pipe, refs)
+	}
+}
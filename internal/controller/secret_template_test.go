@@ -0,0 +1,311 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/generator"
+)
+
+func TestRenderTemplateFields(t *testing.T) {
+	reconciler := &SecretReconciler{}
+
+	tests := []struct {
+		name        string
+		templates   map[string]string
+		expected    map[string]string
+		expectedErr string
+	}{
+		{
+			name:      "composes generated fields",
+			templates: map[string]string{"dsn": "postgres://{{ .username }}:{{ .password }}@db:5432/app"},
+			expected:  map[string]string{"dsn": "postgres://admin:s3cret@db:5432/app"},
+		},
+		{
+			name: "references other templated fields",
+			templates: map[string]string{
+				"url":    "{{ .dsn }}?sslmode=require",
+				"dsn":    `postgres://{{ index . "username" }}:{{ $.password }}@db/app`,
+				"header": "Basic {{ .username }}",
+			},
+			expected: map[string]string{
+				"url":    "postgres://admin:s3cret@db/app?sslmode=require",
+				"dsn":    "postgres://admin:s3cret@db/app",
+				"header": "Basic admin",
+			},
+		},
+		{
+			name:        "nonexistent field",
+			templates:   map[string]string{"dsn": "{{ .username }}:{{ .passwd }}"},
+			expectedErr: `references nonexistent field "passwd"`,
+		},
+		{
+			name: "cycle",
+			templates: map[string]string{
+				"a": "{{ .b }}",
+				"b": "{{ .c }}",
+				"c": "{{ .a }}",
+			},
+			expectedErr: "template cycle between fields a -> b -> c -> a",
+		},
+		{
+			name:        "templated and autogenerated",
+			templates:   map[string]string{"password": "{{ .username }}"},
+			expectedErr: `field "password" is both autogenerated and templated`,
+		},
+		{
+			name:        "parse error",
+			templates:   map[string]string{"dsn": "{{ .username"},
+			expectedErr: `invalid template for field "dsn"`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{Annotations: map[string]string{}},
+				Data: map[string][]byte{
+					"username": []byte("admin"),
+					"password": []byte("s3cret"),
+				},
+			}
+			for field, tmpl := range tt.templates {
+				secret.Annotations[AnnotationTemplatePrefix+field] = tmpl
+			}
+
+			changed, err := reconciler.renderTemplateFields(secret.Annotations, secret.Data, []string{"username", "password"})
+			if tt.expectedErr != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.expectedErr) {
+					t.Fatalf("expected error containing %q, got %v", tt.expectedErr, err)
+				}
+				if changed || len(secret.Data) != 2 {
+					t.Error("expected Secret data not to change on error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !changed {
+				t.Error("expected templated fields to change")
+			}
+			for field, want := range tt.expected {
+				if got := string(secret.Data[field]); got != want {
+					t.Errorf("expected %s to be %q, got %q", field, want, got)
+				}
+			}
+
+			// Rendering again with the same values changes nothing
+			if changed, _ := reconciler.renderTemplateFields(secret.Annotations, secret.Data, []string{"username", "password"}); changed {
+				t.Error("expected rendering unchanged values not to change the Secret")
+			}
+		})
+	}
+}
+
+func TestCheckTemplateCycles(t *testing.T) {
+	tests := []struct {
+		name        string
+		templates   map[string]string
+		expectedErr string
+	}{
+		{
+			name: "two fields",
+			templates: map[string]string{
+				"dsn": "{{ .url }}",
+				"url": "{{ .dsn }}",
+			},
+			expectedErr: "template cycle between fields dsn -> url -> dsn",
+		},
+		{
+			name: "three fields",
+			templates: map[string]string{
+				"a": "{{ .b }}",
+				"b": `{{ index . "c" }}`,
+				"c": "{{ $.a }}:{{ .password }}",
+			},
+			expectedErr: "template cycle between fields a -> b -> c -> a",
+		},
+		{
+			name:        "self reference",
+			templates:   map[string]string{"dsn": "{{ .dsn }}"},
+			expectedErr: "template cycle between fields dsn -> dsn",
+		},
+		{
+			name: "dependency graph without cycle",
+			templates: map[string]string{
+				"url":    "{{ .dsn }}?sslmode=require",
+				"dsn":    "postgres://{{ .username }}:{{ .password }}@db/app",
+				"header": "{{ .dsn }} {{ .url }}",
+			},
+		},
+		{
+			name: "invalid template skipped",
+			templates: map[string]string{
+				"dsn": "{{ .url",
+				"url": "{{ .dsn }}",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			annotations := map[string]string{AnnotationAutogenerate: "username,password"}
+			for field, tmpl := range tt.templates {
+				annotations[AnnotationTemplatePrefix+field] = tmpl
+			}
+
+			err := defaultAnnotationKeys.checkTemplateCycles(annotations)
+			if tt.expectedErr == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || err.Error() != tt.expectedErr {
+				t.Errorf("expected error %q, got %v", tt.expectedErr, err)
+			}
+		})
+	}
+}
+
+func TestReconcileTemplateFields(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		data        map[string][]byte
+		expectedErr string
+	}{
+		{
+			name: "rendered on generation",
+			annotations: map[string]string{
+				AnnotationAutogenerate:           "username,password",
+				AnnotationTemplatePrefix + "dsn": "postgres://{{ .username }}:{{ .password }}@db/app",
+			},
+		},
+		{
+			name: "re-rendered on rotation",
+			annotations: map[string]string{
+				AnnotationAutogenerate:           "username,password",
+				AnnotationGeneratedAt:            "2026-02-01T10:00:00Z",
+				AnnotationRotate:                 "24h",
+				AnnotationTemplatePrefix + "dsn": "postgres://{{ .username }}:{{ .password }}@db/app",
+			},
+			data: map[string][]byte{
+				"username": []byte("old-username"),
+				"password": []byte("old-password"),
+				"dsn":      []byte("postgres://old-username:old-password@db/app"),
+			},
+		},
+		{
+			name: "nonexistent field",
+			annotations: map[string]string{
+				AnnotationAutogenerate:           "username,password",
+				AnnotationTemplatePrefix + "dsn": "postgres://{{ .user }}:{{ .password }}@db/app",
+			},
+			expectedErr: `references nonexistent field "user"`,
+		},
+		{
+			name: "cycle",
+			annotations: map[string]string{
+				AnnotationAutogenerate:           "password",
+				AnnotationTemplatePrefix + "dsn": "{{ .url }}",
+				AnnotationTemplatePrefix + "url": "{{ .dsn }}",
+			},
+			expectedErr: "template cycle between fields dsn -> url -> dsn",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:        "templated",
+					Namespace:   "default",
+					Annotations: tt.annotations,
+				},
+				Data: tt.data,
+			}
+
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+			fakeRecorder := NewTestEventRecorder(10)
+			reconciler := &SecretReconciler{
+				Client:        fakeClient,
+				Scheme:        scheme,
+				Generator:     generator.NewSecretGenerator(),
+				Config:        config.NewDefaultConfig(),
+				EventRecorder: fakeRecorder,
+				Clock:         &MockClock{currentTime: time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)},
+			}
+			ctx := context.Background()
+
+			if _, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "templated", Namespace: "default"}}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var updated corev1.Secret
+			if err := fakeClient.Get(ctx, types.NamespacedName{Name: "templated", Namespace: "default"}, &updated); err != nil {
+				t.Fatalf("failed to get secret: %v", err)
+			}
+
+			if tt.expectedErr != "" {
+				if _, ok := updated.Data["dsn"]; ok {
+					t.Error("expected templated field not to be written")
+				}
+				if _, ok := updated.Data["password"]; ok {
+					t.Error("expected no value to be generated")
+				}
+				select {
+				case event := <-fakeRecorder.Events:
+					if !strings.Contains(event, EventReasonGenerationFailed) || !strings.Contains(event, tt.expectedErr) {
+						t.Errorf("expected generation failed event, got: %s", event)
+					}
+				default:
+					t.Error("expected generation failed event to be recorded")
+				}
+				return
+			}
+
+			username := string(updated.Data["username"])
+			password := string(updated.Data["password"])
+			if username == "old-username" || password == "old-password" {
+				t.Fatal("expected username and password to be generated")
+			}
+			expected := fmt.Sprintf("postgres://%s:%s@db/app", username, password)
+			if got := string(updated.Data["dsn"]); got != expected {
+				t.Errorf("expected dsn %q, got %q", expected, got)
+			}
+		})
+	}
+}
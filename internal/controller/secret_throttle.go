@@ -0,0 +1,126 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"sync"
+	"time"
+
+	"github.com/go-logr/logr"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+)
+
+// rotationThrottle counts scheduled rotations across all Secrets handled by the reconciler.
+// Only the leader reconciles, so this limits rotations cluster-wide.
+type rotationThrottle struct {
+	mu sync.Mutex
+	// recent holds the times of the rotations within the last period, oldest first
+	recent []time.Time
+}
+
+// reserve records a rotation at now if fewer than limit rotations happened within the period
+// before now. Otherwise it returns false and how long until the oldest of them leaves the period.
+func (t *rotationThrottle) reserve(now time.Time, limit int, period time.Duration) (bool, time.Duration) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	expired := 0
+	for expired < len(t.recent) && !now.Before(t.recent[expired].Add(period)) {
+		expired++
+	}
+	t.recent = t.recent[expired:]
+
+	if len(t.recent) >= limit {
+		return false, t.recent[0].Add(period).Sub(now)
+	}
+	t.recent = append(t.recent, now)
+	return true, 0
+}
+
+// release gives back a rotation reserved at the given time, so it no longer counts against the limit.
+func (t *rotationThrottle) release(at time.Time) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	for i := len(t.recent) - 1; i >= 0; i-- {
+		if t.recent[i].Equal(at) {
+			t.recent = append(t.recent[:i], t.recent[i+1:]...)
+			return
+		}
+	}
+}
+
+// throttleSlot is a slot of the rotation throttle reserved for a due rotation. Unless the
+// rotation is written and the slot kept, release gives it back, so failed, immutable, dry-run,
+// and conflicting reconciles don't use up the budget. A nil slot is a no-op.
+type throttleSlot struct {
+	throttle *rotationThrottle
+	at       time.Time
+	kept     bool
+}
+
+// keep marks the rotation the slot was reserved for as done.
+func (s *throttleSlot) keep() {
+	if s != nil {
+		s.kept = true
+	}
+}
+
+// release gives the slot back unless it was kept.
+func (s *throttleSlot) release() {
+	if s == nil || s.kept {
+		return
+	}
+	s.throttle.release(s.at)
+}
+
+// checkRotationThrottle reserves a slot of the rotation throttle if a scheduled rotation of the
+// Secret is due, and returns it. The caller keeps the slot once the rotation is written and
+// releases it otherwise. If no slot is free, it returns true and the time until one frees up;
+// the due rotations then wait, while missing fields are still generated. Forced rotations and
+// jwt reissues aren't throttled.
+func (r *SecretReconciler) checkRotationThrottle(obj client.Object, data map[string][]byte, fields []string, generatedAt *time.Time, forceRotation bool, logger logr.Logger) (bool, *time.Duration, *throttleSlot) {
+	throttle := r.currentConfig().Rotation.Throttle
+	if throttle.MaxRotations <= 0 || forceRotation {
+		return false, nil, nil
+	}
+
+	due := false
+	for _, field := range fields {
+		if !hasFieldValue(data, field) || r.getFieldType(obj.GetAnnotations(), field) == config.TypeJWT {
+			continue
+		}
+		if r.checkFieldRotation(secretKey(obj), obj.GetAnnotations(), field, generatedAt).needsRotation {
+			due = true
+			break
+		}
+	}
+	if !due {
+		return false, nil, nil
+	}
+
+	now := r.now()
+	ok, retryAfter := r.state().throttle.reserve(now, throttle.MaxRotations, throttle.Period.Duration())
+	if ok {
+		return false, nil, &throttleSlot{throttle: &r.state().throttle, at: now}
+	}
+	logger.Info("Rotation throttled, too many rotations within the throttle period",
+		"maxRotations", throttle.MaxRotations, "period", throttle.Period.Duration(), "retryAfter", retryAfter)
+	return true, &retryAfter, nil
+}
@@ -0,0 +1,304 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/generator"
+)
+
+func TestRotationThrottleReserve(t *testing.T) {
+	var throttle rotationThrottle
+	start := time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)
+
+	for _, offset := range []time.Duration{0, 10 * time.Second} {
+		if ok, _ := throttle.reserve(start.Add(offset), 2, time.Minute); !ok {
+			t.Fatalf("expected rotation at +%s to be allowed", offset)
+		}
+	}
+
+	ok, retryAfter := throttle.reserve(start.Add(20*time.Second), 2, time.Minute)
+	if ok {
+		t.Fatal("expected third rotation within the period to be throttled")
+	}
+	if retryAfter != 40*time.Second {
+		t.Errorf("expected retry after 40s, got %s", retryAfter)
+	}
+
+	// The first rotation leaves the period
+	if ok, _ := throttle.reserve(start.Add(time.Minute), 2, time.Minute); !ok {
+		t.Error("expected rotation to be allowed once the oldest one left the period")
+	}
+	ok, retryAfter = throttle.reserve(start.Add(time.Minute), 2, time.Minute)
+	if ok {
+		t.Fatal("expected rotation to be throttled again")
+	}
+	if retryAfter != 10*time.Second {
+		t.Errorf("expected retry after 10s, got %s", retryAfter)
+	}
+}
+
+func TestRotationThrottleRelease(t *testing.T) {
+	var throttle rotationThrottle
+	start := time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)
+
+	throttle.reserve(start, 1, time.Minute)
+	throttle.release(start)
+	if ok, _ := throttle.reserve(start.Add(time.Second), 1, time.Minute); !ok {
+		t.Fatal("expected released slot to be free again")
+	}
+
+	slot := &throttleSlot{throttle: &throttle, at: start.Add(time.Second)}
+	slot.keep()
+	slot.release()
+	if ok, _ := throttle.reserve(start.Add(2*time.Second), 1, time.Minute); ok {
+		t.Error("expected kept slot not to be released")
+	}
+
+	// A nil slot, returned when nothing was reserved, is a no-op
+	var none *throttleSlot
+	none.keep()
+	none.release()
+}
+
+func TestReconcileRotationThrottle(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	const secretCount = 5
+	objects := make([]client.Object, 0, secretCount+1)
+	for i := 0; i < secretCount; i++ {
+		objects = append(objects, &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      fmt.Sprintf("due-%d", i),
+				Namespace: "default",
+				Annotations: map[string]string{
+					AnnotationAutogenerate: "password",
+					AnnotationGeneratedAt:  "2026-02-01T10:00:00Z",
+					AnnotationRotate:       "24h",
+				},
+			},
+			Data: map[string][]byte{"password": []byte("old-password")},
+		})
+	}
+	// A forced rotation isn't throttled, and doesn't use up a slot
+	objects = append(objects, &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "forced",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationAutogenerate: "password",
+				AnnotationGeneratedAt:  "2026-02-02T11:00:00Z",
+				AnnotationRotateNow:    "true",
+			},
+		},
+		Data: map[string][]byte{"password": []byte("old-password")},
+	})
+
+	cfg := config.NewDefaultConfig()
+	cfg.Rotation.Throttle.MaxRotations = 2
+	cfg.Rotation.Throttle.Period = config.Duration(time.Minute)
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(objects...).Build()
+	mockClock := &MockClock{currentTime: time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)}
+	reconciler := &SecretReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Generator:     generator.NewSecretGenerator(),
+		Config:        cfg,
+		EventRecorder: NewTestEventRecorder(50),
+		Clock:         mockClock,
+	}
+	ctx := context.Background()
+
+	rotated := func(name string) bool {
+		var secret corev1.Secret
+		if err := fakeClient.Get(ctx, types.NamespacedName{Name: name, Namespace: "default"}, &secret); err != nil {
+			t.Fatalf("failed to get secret %s: %v", name, err)
+		}
+		return string(secret.Data["password"]) != "old-password"
+	}
+
+	// Reconcile all Secrets once per period, like the throttle's requeues do
+	var rotatedPerPeriod []int
+	pending := map[string]bool{}
+	for i := 0; i < secretCount; i++ {
+		pending[fmt.Sprintf("due-%d", i)] = true
+	}
+	for period := 0; len(pending) > 0 && period < secretCount; period++ {
+		count := 0
+		for i := 0; i < secretCount; i++ {
+			name := fmt.Sprintf("due-%d", i)
+			if !pending[name] {
+				continue
+			}
+			result, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: name, Namespace: "default"}})
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if rotated(name) {
+				delete(pending, name)
+				count++
+			} else if result.RequeueAfter != time.Minute {
+				t.Errorf("expected throttled secret %s to requeue after 1m, got %s", name, result.RequeueAfter)
+			}
+		}
+		rotatedPerPeriod = append(rotatedPerPeriod, count)
+
+		if period == 0 {
+			if _, err := reconciler.Reconcile(ctx, ctrl.Request{NamespacedName: types.NamespacedName{Name: "forced", Namespace: "default"}}); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !rotated("forced") {
+				t.Error("expected forced rotation not to be throttled")
+			}
+		}
+
+		mockClock.currentTime = mockClock.currentTime.Add(time.Minute)
+	}
+
+	if fmt.Sprint(rotatedPerPeriod) != "[2 2 1]" {
+		t.Errorf("expected rotations to be spread as [2 2 1] per period, got %v", rotatedPerPeriod)
+	}
+}
+
+func TestReconcileRotationThrottleGeneratesMissingFields(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "throttled",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationAutogenerate: "password,token",
+				AnnotationGeneratedAt:  "2026-02-01T10:00:00Z",
+				AnnotationRotate:       "24h",
+			},
+		},
+		Data: map[string][]byte{"password": []byte("old-password")},
+	}
+
+	cfg := config.NewDefaultConfig()
+	cfg.Rotation.Throttle.MaxRotations = 1
+	cfg.Rotation.Throttle.Period = config.Duration(time.Minute)
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	mockClock := &MockClock{currentTime: time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)}
+	reconciler := &SecretReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Generator:     generator.NewSecretGenerator(),
+		Config:        cfg,
+		EventRecorder: NewTestEventRecorder(10),
+		Clock:         mockClock,
+	}
+	// Another Secret used up the only slot
+	reconciler.throttle.reserve(mockClock.currentTime.Add(-15*time.Second), 1, time.Minute)
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}}
+	result, err := reconciler.Reconcile(context.Background(), req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.RequeueAfter != 45*time.Second {
+		t.Errorf("expected requeue after 45s, got %s", result.RequeueAfter)
+	}
+
+	var updatedSecret corev1.Secret
+	if err := fakeClient.Get(context.Background(), req.NamespacedName, &updatedSecret); err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+	if string(updatedSecret.Data["password"]) != "old-password" {
+		t.Error("expected throttled password not to be rotated")
+	}
+	if len(updatedSecret.Data["token"]) == 0 {
+		t.Error("expected missing token to be generated despite the throttle")
+	}
+}
+
+func TestReconcileRotationThrottleReleasesUnwrittenRotations(t *testing.T) {
+	immutable := true
+	tests := []struct {
+		name      string
+		dryRun    bool
+		immutable *bool
+	}{
+		{name: "dry run", dryRun: true},
+		{name: "immutable secret", immutable: &immutable},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = clientgoscheme.AddToScheme(scheme)
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "due",
+					Namespace: "default",
+					Annotations: map[string]string{
+						AnnotationAutogenerate: "password",
+						AnnotationGeneratedAt:  "2026-02-01T10:00:00Z",
+						AnnotationRotate:       "24h",
+					},
+				},
+				Data:      map[string][]byte{"password": []byte("old-password")},
+				Immutable: tt.immutable,
+			}
+
+			cfg := config.NewDefaultConfig()
+			cfg.DryRun = tt.dryRun
+			cfg.Rotation.Throttle.MaxRotations = 1
+			cfg.Rotation.Throttle.Period = config.Duration(time.Minute)
+
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+			mockClock := &MockClock{currentTime: time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)}
+			reconciler := &SecretReconciler{
+				Client:        fakeClient,
+				Scheme:        scheme,
+				Generator:     generator.NewSecretGenerator(),
+				Config:        cfg,
+				EventRecorder: NewTestEventRecorder(10),
+				Clock:         mockClock,
+			}
+
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}}
+			if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if ok, _ := reconciler.throttle.reserve(mockClock.currentTime, 1, time.Minute); !ok {
+				t.Error("expected the unwritten rotation not to use up the throttle slot")
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+
+	"github.com/go-logr/logr"
+	corev1 "k8s.io/api/core/v1"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+// maxUniqueAttempts is how often a value that collides within its uniqueness set is generated
+// before giving up. Collisions are only likely for short values from small charsets.
+const maxUniqueAttempts = 10
+
+// valueHash returns the hex-encoded SHA-256 hash of a field value, as stored in value-hash.<field>
+func valueHash(value []byte) string {
+	sum := sha256.Sum256(value)
+	return hex.EncodeToString(sum[:])
+}
+
+// uniquenessSetHashes returns the value hashes of the other Secrets in the uniqueness set of
+// secret, by field. The set consists of all Secrets sharing the value of the label named by the
+// unique-within-label annotation. Only value hashes are read, never the values themselves.
+// It returns nil if uniqueness isn't requested or the Secret doesn't carry the label.
+func (r *SecretReconciler) uniquenessSetHashes(ctx context.Context, secret *corev1.Secret, logger logr.Logger) (map[string]map[string]bool, error) {
+	labelKey := secret.Annotations[r.keys().UniqueWithinLabel]
+	if labelKey == "" {
+		return nil, nil
+	}
+	labelValue, ok := secret.Labels[labelKey]
+	if !ok {
+		msg := fmt.Sprintf("Secret has no label %q, value uniqueness is not checked", labelKey)
+		logger.Info(msg)
+		r.eventf(secret, nil, corev1.EventTypeWarning, EventReasonGenerationFailed, "Generate", "%s", msg)
+		return nil, nil
+	}
+
+	var secrets corev1.SecretList
+	if err := r.List(ctx, &secrets, client.MatchingLabels{labelKey: labelValue}); err != nil {
+		logger.Error(err, "Failed to list Secrets of uniqueness set", "label", labelKey, "value", labelValue)
+		return nil, err
+	}
+
+	taken := make(map[string]map[string]bool)
+	for i := range secrets.Items {
+		other := &secrets.Items[i]
+		if other.Namespace == secret.Namespace && other.Name == secret.Name {
+			continue
+		}
+		for field, hash := range r.keys().readManagedMetadata(other.Annotations).ValueHashes {
+			if taken[field] == nil {
+				taken[field] = make(map[string]bool)
+			}
+			taken[field][hash] = true
+		}
+	}
+	return taken, nil
+}
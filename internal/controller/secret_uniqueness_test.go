@@ -0,0 +1,221 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/generator"
+)
+
+// scriptedGenerator returns the scripted string values in order, then falls back to random values
+type scriptedGenerator struct {
+	*generator.SecretGenerator
+	values []string
+}
+
+func (g *scriptedGenerator) GenerateWithPrefix(prefix, genType string, length int, charset string) (string, error) {
+	if len(g.values) > 0 {
+		value := g.values[0]
+		g.values = g.values[1:]
+		return prefix + value, nil
+	}
+	return g.SecretGenerator.GenerateWithPrefix(prefix, genType, length, charset)
+}
+
+// shardSecret returns a Secret in the uniqueness set "shard-set=<set>"
+func shardSecret(name, set string, annotations map[string]string) *corev1.Secret {
+	allAnnotations := map[string]string{
+		AnnotationAutogenerate:      "token",
+		AnnotationLength:            "4",
+		AnnotationUniqueWithinLabel: "shard-set",
+	}
+	for k, v := range annotations {
+		allAnnotations[k] = v
+	}
+	return &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        name,
+			Namespace:   "default",
+			Labels:      map[string]string{"shard-set": set},
+			Annotations: allAnnotations,
+		},
+	}
+}
+
+func TestReconcileUniqueWithinLabel(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	existingHash := map[string]string{AnnotationValueHashPrefix + "token": valueHash([]byte("AAAA"))}
+
+	tests := []struct {
+		name      string
+		existing  client.Object
+		values    []string
+		wantValue string
+	}{
+		{
+			name:      "collision is regenerated",
+			existing:  shardSecret("shard-a", "tokens", existingHash),
+			values:    []string{"AAAA", "AAAA", "BBBB"},
+			wantValue: "BBBB",
+		},
+		{
+			name:      "other sets are not compared",
+			existing:  shardSecret("shard-a", "other-tokens", existingHash),
+			values:    []string{"AAAA"},
+			wantValue: "AAAA",
+		},
+		{
+			name:      "other fields are not compared",
+			existing:  shardSecret("shard-a", "tokens", map[string]string{AnnotationValueHashPrefix + "password": valueHash([]byte("AAAA"))}),
+			values:    []string{"AAAA"},
+			wantValue: "AAAA",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := shardSecret("shard-b", "tokens", nil)
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(tt.existing, secret).Build()
+			reconciler := &SecretReconciler{
+				Client:        fakeClient,
+				Scheme:        scheme,
+				Generator:     &scriptedGenerator{SecretGenerator: generator.NewSecretGenerator(), values: tt.values},
+				Config:        config.NewDefaultConfig(),
+				EventRecorder: NewTestEventRecorder(10),
+			}
+
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}}
+			if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			var updatedSecret corev1.Secret
+			if err := fakeClient.Get(context.Background(), req.NamespacedName, &updatedSecret); err != nil {
+				t.Fatalf("failed to get secret: %v", err)
+			}
+			if got := string(updatedSecret.Data["token"]); got != tt.wantValue {
+				t.Errorf("expected token %q, got %q", tt.wantValue, got)
+			}
+			if got := updatedSecret.Annotations[AnnotationValueHashPrefix+"token"]; got != valueHash([]byte(tt.wantValue)) {
+				t.Errorf("expected value hash of %q, got %q", tt.wantValue, got)
+			}
+		})
+	}
+}
+
+func TestReconcileUniqueWithinLabelExhausted(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	existing := shardSecret("shard-a", "tokens", map[string]string{AnnotationValueHashPrefix + "token": valueHash([]byte("AAAA"))})
+	secret := shardSecret("shard-b", "tokens", nil)
+
+	values := make([]string, maxUniqueAttempts)
+	for i := range values {
+		values[i] = "AAAA"
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(existing, secret).Build()
+	fakeRecorder := NewTestEventRecorder(10)
+	reconciler := &SecretReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Generator:     &scriptedGenerator{SecretGenerator: generator.NewSecretGenerator(), values: values},
+		Config:        config.NewDefaultConfig(),
+		EventRecorder: fakeRecorder,
+	}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}}
+	if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var updatedSecret corev1.Secret
+	if err := fakeClient.Get(context.Background(), req.NamespacedName, &updatedSecret); err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+	if _, ok := updatedSecret.Data["token"]; ok {
+		t.Error("expected no value to be stored")
+	}
+
+	select {
+	case event := <-fakeRecorder.Events:
+		if !strings.Contains(event, EventReasonGenerationFailed) || !strings.Contains(event, "unique within its set") {
+			t.Errorf("expected generation failed event, got: %s", event)
+		}
+	default:
+		t.Error("expected generation failed event to be recorded")
+	}
+}
+
+func TestReconcileUniqueWithinLabelMissingLabel(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	secret := shardSecret("shard-b", "tokens", nil)
+	secret.Labels = nil
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	fakeRecorder := NewTestEventRecorder(10)
+	reconciler := &SecretReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Generator:     generator.NewSecretGenerator(),
+		Config:        config.NewDefaultConfig(),
+		EventRecorder: fakeRecorder,
+	}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}}
+	if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	var updatedSecret corev1.Secret
+	if err := fakeClient.Get(context.Background(), req.NamespacedName, &updatedSecret); err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+	if len(updatedSecret.Data["token"]) != 4 {
+		t.Errorf("expected a 4 character token, got %q", updatedSecret.Data["token"])
+	}
+	if _, ok := updatedSecret.Annotations[AnnotationValueHashPrefix+"token"]; ok {
+		t.Error("expected no value hash without uniqueness set")
+	}
+
+	select {
+	case event := <-fakeRecorder.Events:
+		if !strings.Contains(event, `no label "shard-set"`) {
+			t.Errorf("expected missing label warning, got: %s", event)
+		}
+	default:
+		t.Error("expected missing label warning to be recorded")
+	}
+}
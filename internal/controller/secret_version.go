@@ -0,0 +1,59 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+)
+
+// getFieldVersionField returns the name of the field receiving the version counter of field
+// (version-field.<field>), or "" if field isn't versioned
+func (k *annotationKeys) getFieldVersionField(annotations map[string]string, field string) string {
+	return annotations[k.VersionFieldPrefix+field]
+}
+
+// nextFieldVersion returns the version of a new value stored alongside it in versionField: the
+// current version plus one, so the initial value has version 1. A missing or invalid counter,
+// e.g. after someone edited it, starts over at 1.
+func nextFieldVersion(data map[string][]byte, versionField string) uint64 {
+	current, err := strconv.ParseUint(string(data[versionField]), 10, 64)
+	if err != nil {
+		return 1
+	}
+	return current + 1
+}
+
+// addVersion stores the incremented version counter of field in versionField, next to the newly
+// generated value, so both are written in the same update
+func (r *SecretReconciler) addVersion(annotations map[string]string, data map[string][]byte, field, versionField string, result valueGenerationResult) valueGenerationResult {
+	if versionField == "" || versionField == field || versionField == r.getFieldPublicKeyField(annotations, field) {
+		return fieldConfigError(field, "version field", fmt.Errorf("version field %q must differ from the field and its public key field", versionField))
+	}
+	if slices.Contains(r.keys().parseSecretAnnotations(annotations), versionField) {
+		return fieldConfigError(field, "version field", fmt.Errorf("version field %q is generated itself", versionField))
+	}
+	if _, ok := result.companions[versionField]; ok {
+		return fieldConfigError(field, "version field", fmt.Errorf("version field %q is already written by the field", versionField))
+	}
+	if result.companions == nil {
+		result.companions = make(map[string][]byte, 1)
+	}
+	result.companions[versionField] = []byte(strconv.FormatUint(nextFieldVersion(data, versionField), 10))
+	return result
+}
@@ -0,0 +1,174 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client/fake"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/generator"
+)
+
+func TestNextFieldVersion(t *testing.T) {
+	tests := []struct {
+		name     string
+		data     map[string][]byte
+		expected uint64
+	}{
+		{"no counter", nil, 1},
+		{"counter", map[string][]byte{"token-version": []byte("41")}, 42},
+		{"invalid counter", map[string][]byte{"token-version": []byte("v3")}, 1},
+		{"negative counter", map[string][]byte{"token-version": []byte("-1")}, 1},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := &corev1.Secret{Data: tt.data}
+			if got := nextFieldVersion(secret.Data, "token-version"); got != tt.expected {
+				t.Errorf("expected version %d, got %d", tt.expected, got)
+			}
+		})
+	}
+}
+
+func TestReconcileVersionField(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "versioned",
+			Namespace: "default",
+			Annotations: map[string]string{
+				AnnotationAutogenerate:                 "token",
+				AnnotationRotate:                       "1h",
+				AnnotationVersionFieldPrefix + "token": "token-version",
+				AnnotationPrune:                        "true",
+			},
+		},
+	}
+
+	fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+	mockClock := &MockClock{currentTime: time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)}
+	reconciler := &SecretReconciler{
+		Client:        fakeClient,
+		Scheme:        scheme,
+		Generator:     generator.NewSecretGenerator(),
+		Config:        config.NewDefaultConfig(),
+		EventRecorder: NewTestEventRecorder(10),
+		Clock:         mockClock,
+	}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}}
+	ctx := context.Background()
+	var updatedSecret corev1.Secret
+	reconcile := func() {
+		t.Helper()
+		if _, err := reconciler.Reconcile(ctx, req); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if err := fakeClient.Get(ctx, req.NamespacedName, &updatedSecret); err != nil {
+			t.Fatalf("failed to get secret: %v", err)
+		}
+	}
+
+	reconcile()
+	if got := string(updatedSecret.Data["token-version"]); got != "1" {
+		t.Errorf("expected version 1 after the initial generation, got %q", got)
+	}
+
+	// Reconciles without a rotation keep the version
+	mockClock.currentTime = mockClock.currentTime.Add(30 * time.Minute)
+	reconcile()
+	if got := string(updatedSecret.Data["token-version"]); got != "1" {
+		t.Errorf("expected version 1 without a rotation, got %q", got)
+	}
+
+	// A rotation increments the version, and pruning keeps the version field
+	mockClock.currentTime = mockClock.currentTime.Add(time.Hour)
+	reconcile()
+	if got := string(updatedSecret.Data["token-version"]); got != "2" {
+		t.Errorf("expected version 2 after the rotation, got %q", got)
+	}
+	if !defaultAnnotationKeys.isManagedField(updatedSecret.Annotations, "token-version") {
+		t.Error("expected the version field to be managed by the operator")
+	}
+}
+
+func TestReconcileVersionFieldInvalid(t *testing.T) {
+	tests := []struct {
+		name         string
+		autogenerate string
+		versionField string
+		expectedMsg  string
+	}{
+		{"field itself", "token", "token", `version field "token" must differ from the field`},
+		{"generated field", "token,other", "other", `version field "other" is generated itself`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = clientgoscheme.AddToScheme(scheme)
+
+			secret := &corev1.Secret{
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "versioned",
+					Namespace: "default",
+					Annotations: map[string]string{
+						AnnotationAutogenerate:                 tt.autogenerate,
+						AnnotationVersionFieldPrefix + "token": tt.versionField,
+					},
+				},
+			}
+			fakeClient := fake.NewClientBuilder().WithScheme(scheme).WithObjects(secret).Build()
+			reconciler := &SecretReconciler{
+				Client:        fakeClient,
+				Scheme:        scheme,
+				Generator:     generator.NewSecretGenerator(),
+				Config:        config.NewDefaultConfig(),
+				EventRecorder: NewTestEventRecorder(10),
+			}
+
+			req := ctrl.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: secret.Namespace}}
+			if _, err := reconciler.Reconcile(context.Background(), req); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			var updatedSecret corev1.Secret
+			if err := fakeClient.Get(context.Background(), req.NamespacedName, &updatedSecret); err != nil {
+				t.Fatalf("failed to get secret: %v", err)
+			}
+			if _, ok := updatedSecret.Data["token"]; ok {
+				t.Error("expected no value with an invalid version field")
+			}
+			if got := updatedSecret.Annotations[AnnotationLastError]; !strings.Contains(got, tt.expectedMsg) {
+				t.Errorf("expected last error to contain %q, got %q", tt.expectedMsg, got)
+			}
+		})
+	}
+}
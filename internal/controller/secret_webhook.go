@@ -0,0 +1,335 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"text/template"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/webhook"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+)
+
+const (
+	// SecretValidationPath is the path the validating webhook for Secrets is served at
+	SecretValidationPath = "/validate-v1-secret"
+	// SecretDefaultingPath is the path the defaulting webhook for Secrets is served at
+	SecretDefaultingPath = "/mutate-v1-secret"
+)
+
+// +kubebuilder:webhook:path=/validate-v1-secret,mutating=false,failurePolicy=ignore,sideEffects=None,groups="",resources=secrets,verbs=create;update,versions=v1,name=vsecret.iso.gtrfc.com,admissionReviewVersions=v1
+// +kubebuilder:webhook:path=/mutate-v1-secret,mutating=true,failurePolicy=ignore,sideEffects=None,groups="",resources=secrets,verbs=create;update,versions=v1,name=msecret.iso.gtrfc.com,admissionReviewVersions=v1
+
+// SecretValidator is a validating admission webhook that rejects Secrets with malformed secret
+// generator annotations, so mistakes surface when the Secret is applied instead of as events
+// at reconcile time.
+type SecretValidator struct {
+	Config *config.Config
+	// Reloadable holds the configuration after reloads of the configuration file. If nil, Config
+	// is used.
+	Reloadable *ReloadableConfig
+	Decoder    admission.Decoder
+}
+
+// currentConfig returns the configuration in use: the one held by Reloadable, or Config
+func (v *SecretValidator) currentConfig() *config.Config {
+	if cfg := v.Reloadable.Load(); cfg != nil {
+		return cfg
+	}
+	return v.Config
+}
+
+// SetupWithManager registers the webhook with the Manager's webhook server
+func (v *SecretValidator) SetupWithManager(mgr ctrl.Manager) error {
+	if v.Decoder == nil {
+		v.Decoder = admission.NewDecoder(mgr.GetScheme())
+	}
+	mgr.GetWebhookServer().Register(SecretValidationPath, &webhook.Admission{Handler: v})
+	return nil
+}
+
+// Handle admits Secrets whose annotations are valid and denies the others, naming every problem
+func (v *SecretValidator) Handle(_ context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+	// The configuration is read once, so a reload can't change it halfway through the request
+	cfg := v.currentConfig()
+
+	// Secrets outside the configured namespaces are never touched, so any annotation is fine
+	if !cfg.Namespaces.Allows(req.Namespace) {
+		return admission.Allowed("")
+	}
+
+	var secret corev1.Secret
+	if err := v.Decoder.Decode(req, &secret); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	// Neither are Secrets not matching the label selector
+	if !cfg.Selector().Matches(labels.Set(secret.Labels)) {
+		return admission.Allowed("")
+	}
+
+	problems := validate(cfg, &secret)
+	// Updates are only denied for problems the old Secret didn't have, so Secrets that were
+	// invalid before stay writable, e.g. for the status the operator records on them
+	if len(problems) > 0 && req.Operation == admissionv1.Update {
+		var oldSecret corev1.Secret
+		if err := v.Decoder.DecodeRaw(req.OldObject, &oldSecret); err != nil {
+			return admission.Errored(http.StatusBadRequest, err)
+		}
+		oldProblems := validate(cfg, &oldSecret)
+		problems = slices.DeleteFunc(problems, func(problem string) bool {
+			return slices.Contains(oldProblems, problem)
+		})
+	}
+	if len(problems) > 0 {
+		return admission.Denied("invalid annotations: " + strings.Join(problems, "; "))
+	}
+	return admission.Allowed("")
+}
+
+// Validate returns the problems of the secret generator annotations of secret, each naming the
+// annotation as written in the cluster. Secrets without autogenerate have none. It ignores the
+// namespace and label filters, so it is also used to check Secrets outside of admission.
+func (v *SecretValidator) Validate(secret *corev1.Secret) []string {
+	return validate(v.currentConfig(), secret)
+}
+
+// validate returns the problems of the secret generator annotations of secret under cfg, see
+// SecretValidator.Validate
+func validate(cfg *config.Config, secret *corev1.Secret) []string {
+	existing := make(map[string]bool, len(secret.Data)+len(secret.StringData))
+	for field := range secret.Data {
+		existing[field] = true
+	}
+	for field := range secret.StringData {
+		existing[field] = true
+	}
+	return validateAnnotations(cfg, secret.Annotations, existing)
+}
+
+// HasAutogenerateAnnotation returns true if secret has an autogenerate annotation, i.e. the secret
+// generator manages it if it passes the namespace and label filters
+func (v *SecretValidator) HasAutogenerateAnnotation(secret *corev1.Secret) bool {
+	_, ok := secret.Annotations[newAnnotationKeys(v.currentConfig().AnnotationPrefix).Autogenerate]
+	return ok
+}
+
+// validateAnnotations returns the problems of the secret generator annotations under cfg. existing
+// holds the fields the Secret already has values for. Secrets without autogenerate aren't checked.
+func validateAnnotations(cfg *config.Config, annotations map[string]string, existing map[string]bool) []string {
+	r := &SecretReconciler{Config: cfg}
+	fields := r.keys().parseSecretAnnotations(annotations)
+	if len(fields) == 0 {
+		return nil
+	}
+
+	var problems []string
+	report := func(key, format string, args ...any) {
+		problems = append(problems, key+": "+fmt.Sprintf(format, args...))
+	}
+
+	keys := make([]string, 0, len(annotations))
+	for key := range annotations {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	// Value formats
+	for _, key := range keys {
+		value := annotations[key]
+		if value == "" {
+			continue
+		}
+		switch {
+		case key == r.keys().Length || strings.HasPrefix(key, r.keys().LengthPrefix):
+			if length, err := strconv.Atoi(value); err != nil || length <= 0 {
+				report(key, "invalid length %q, must be a positive integer", value)
+			}
+		case strings.HasPrefix(key, r.keys().CountPrefix):
+			if _, err := r.keys().getFieldCount(annotations, strings.TrimPrefix(key, r.keys().CountPrefix)); err != nil {
+				report(key, "%v", err)
+			}
+		case strings.HasPrefix(key, r.keys().RotateStrategyPrefix):
+			if _, err := r.keys().getFieldRotateStrategy(annotations, strings.TrimPrefix(key, r.keys().RotateStrategyPrefix)); err != nil {
+				report(key, "%v", err)
+			}
+		case strings.HasPrefix(key, r.keys().OutputLengthPrefix):
+			if _, err := r.keys().getFieldOutputLength(annotations, strings.TrimPrefix(key, r.keys().OutputLengthPrefix)); err != nil {
+				report(key, "%v", err)
+			}
+		case key == r.keys().Type || strings.HasPrefix(key, r.keys().TypePrefix):
+			if !config.IsValidType(value) {
+				report(key, "unknown type %q, must be one of %s", value, strings.Join(config.Types, ", "))
+			}
+		case key == r.keys().Rotate || strings.HasPrefix(key, r.keys().RotatePrefix):
+			if interval, err := config.ParseDuration(value); err != nil || interval < 0 {
+				report(key, "invalid rotation interval %q", value)
+			}
+		}
+	}
+
+	// Rotation intervals below the minimum of the field's type. jwt, certificate, and bcrypt fields
+	// don't use rotate annotations.
+	for _, field := range fields {
+		genType := r.getFieldType(annotations, field)
+		if genType == config.TypeJWT || genType == config.TypeCertificate || genType == config.TypeBcrypt {
+			continue
+		}
+		key := r.keys().RotatePrefix + field
+		if annotations[key] == "" {
+			key = r.keys().Rotate
+		}
+		interval, err := config.ParseDuration(annotations[key])
+		if err != nil || interval <= 0 {
+			continue
+		}
+		if err := r.checkMinRotationInterval(field, genType, interval); err != nil {
+			report(key, "%v", err)
+		}
+	}
+
+	// Template sources
+	templated := r.keys().templateFields(annotations)
+	for _, field := range templated {
+		key := r.keys().TemplatePrefix + field
+		tmpl, err := template.New(field).Parse(annotations[key])
+		if err != nil {
+			report(key, "invalid template: %v", err)
+			continue
+		}
+		refs := make(map[string]bool)
+		collectTemplateReferences(tmpl.Root, refs)
+		missing := make([]string, 0, len(refs))
+		for ref := range refs {
+			if !existing[ref] && !slices.Contains(fields, ref) && !slices.Contains(templated, ref) {
+				missing = append(missing, ref)
+			}
+		}
+		sort.Strings(missing)
+		for _, ref := range missing {
+			report(key, "references nonexistent field %q", ref)
+		}
+	}
+	var cycle *templateCycleError
+	if err := r.keys().checkTemplateCycles(annotations); errors.As(err, &cycle) {
+		report(r.keys().TemplatePrefix+cycle.fields[0], "%v", err)
+	}
+
+	return problems
+}
+
+// SecretDefaulter is a mutating admission webhook that writes the default type and length from
+// the config into Secrets with an autogenerate annotation, so the stored object shows the
+// settings its values are generated with. Annotations that are set are left alone.
+type SecretDefaulter struct {
+	Config *config.Config
+	// Reloadable holds the configuration after reloads of the configuration file. If nil, Config
+	// is used.
+	Reloadable *ReloadableConfig
+	Decoder    admission.Decoder
+}
+
+// currentConfig returns the configuration in use: the one held by Reloadable, or Config
+func (d *SecretDefaulter) currentConfig() *config.Config {
+	if cfg := d.Reloadable.Load(); cfg != nil {
+		return cfg
+	}
+	return d.Config
+}
+
+// SetupWithManager registers the webhook with the Manager's webhook server
+func (d *SecretDefaulter) SetupWithManager(mgr ctrl.Manager) error {
+	if d.Decoder == nil {
+		d.Decoder = admission.NewDecoder(mgr.GetScheme())
+	}
+	mgr.GetWebhookServer().Register(SecretDefaultingPath, &webhook.Admission{Handler: d})
+	return nil
+}
+
+// Handle returns a JSON patch adding the missing type and length annotations
+func (d *SecretDefaulter) Handle(_ context.Context, req admission.Request) admission.Response {
+	if req.Operation != admissionv1.Create && req.Operation != admissionv1.Update {
+		return admission.Allowed("")
+	}
+	cfg := d.currentConfig()
+	if !cfg.Namespaces.Allows(req.Namespace) {
+		return admission.Allowed("")
+	}
+
+	var secret corev1.Secret
+	if err := d.Decoder.Decode(req, &secret); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	if !cfg.Selector().Matches(labels.Set(secret.Labels)) {
+		return admission.Allowed("")
+	}
+	keys := newAnnotationKeys(cfg.AnnotationPrefix)
+	annotations := secret.Annotations
+	if len(keys.parseSecretAnnotations(annotations)) == 0 {
+		return admission.Allowed("")
+	}
+
+	defaults := make(map[string]string, 2)
+	if _, ok := annotations[keys.Type]; !ok {
+		defaults[keys.Type] = cfg.Defaults.Type
+	}
+	if _, ok := annotations[keys.Length]; !ok {
+		defaults[keys.Length] = strconv.Itoa(cfg.Defaults.Length)
+	}
+	if len(defaults) == 0 {
+		return admission.Allowed("")
+	}
+
+	// Patch the object as it was sent, so that only the annotations are part of the patch
+	var obj map[string]any
+	if err := json.Unmarshal(req.Object.Raw, &obj); err != nil {
+		return admission.Errored(http.StatusBadRequest, err)
+	}
+	metadata, ok := obj["metadata"].(map[string]any)
+	if !ok {
+		return admission.Errored(http.StatusBadRequest, fmt.Errorf("secret has no metadata"))
+	}
+	objAnnotations, _ := metadata["annotations"].(map[string]any)
+	if objAnnotations == nil {
+		objAnnotations = make(map[string]any, len(defaults))
+	}
+	for key, value := range defaults {
+		objAnnotations[key] = value
+	}
+	metadata["annotations"] = objAnnotations
+	defaulted, err := json.Marshal(obj)
+	if err != nil {
+		return admission.Errored(http.StatusInternalServerError, err)
+	}
+	return admission.PatchResponseFromRaw(req.Object.Raw, defaulted)
+}
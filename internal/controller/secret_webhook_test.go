@@ -0,0 +1,472 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package controller
+
+import (
+	"context"
+	"encoding/json"
+	"maps"
+	"strings"
+	"testing"
+
+	admissionv1 "k8s.io/api/admission/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	clientgoscheme "k8s.io/client-go/kubernetes/scheme"
+	"sigs.k8s.io/controller-runtime/pkg/webhook/admission"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+)
+
+// newTestSecretValidator returns a SecretValidator using cfg
+func newTestSecretValidator(t *testing.T, cfg *config.Config) *SecretValidator {
+	t.Helper()
+
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	return &SecretValidator{Config: cfg, Decoder: admission.NewDecoder(scheme)}
+}
+
+// secretAdmissionRequest returns an admission request for operation on a Secret with the given
+// annotations and data. Updates are of a Secret without annotations and data.
+func secretAdmissionRequest(t *testing.T, operation admissionv1.Operation, annotations map[string]string, data map[string][]byte) admission.Request {
+	t.Helper()
+
+	req := admission.Request{AdmissionRequest: admissionv1.AdmissionRequest{
+		UID:       "uid",
+		Kind:      metav1.GroupVersionKind{Version: "v1", Kind: "Secret"},
+		Resource:  metav1.GroupVersionResource{Version: "v1", Resource: "secrets"},
+		Name:      "webhook-secret",
+		Namespace: "default",
+		Operation: operation,
+		Object:    rawSecret(t, annotations, data),
+	}}
+	if operation == admissionv1.Update {
+		req.OldObject = rawSecret(t, nil, nil)
+	}
+	return req
+}
+
+// rawSecret returns the JSON encoding of the Secret of secretAdmissionRequest
+func rawSecret(t *testing.T, annotations map[string]string, data map[string][]byte) runtime.RawExtension {
+	t.Helper()
+
+	secret := &corev1.Secret{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Secret"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:        "webhook-secret",
+			Namespace:   "default",
+			Annotations: annotations,
+		},
+		Data: data,
+	}
+	raw, err := json.Marshal(secret)
+	if err != nil {
+		t.Fatalf("failed to marshal secret: %v", err)
+	}
+	return runtime.RawExtension{Raw: raw}
+}
+
+func TestSecretValidatorHandle(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		data        map[string][]byte
+		// wantDenied lists substrings of the denial message; empty means the Secret is allowed
+		wantDenied []string
+	}{
+		{
+			name: "valid annotations",
+			annotations: map[string]string{
+				AnnotationAutogenerate:               "password,dsn",
+				AnnotationLength:                     "32",
+				AnnotationTypePrefix + "password":    "url-safe-password",
+				AnnotationRotatePrefix + "password":  "24h",
+				AnnotationTemplatePrefix + "dsn-url": "postgres://{{ .username }}:{{ .password }}@db",
+			},
+			data: map[string][]byte{"username": []byte("app")},
+		},
+		{
+			name: "no autogenerate",
+			annotations: map[string]string{
+				AnnotationLength: "abc",
+			},
+		},
+		{
+			name: "non-integer length",
+			annotations: map[string]string{
+				AnnotationAutogenerate:              "password",
+				AnnotationLengthPrefix + "password": "abc",
+			},
+			wantDenied: []string{`iso.gtrfc.com/length.password: invalid length "abc", must be a positive integer`},
+		},
+		{
+			name: "non-positive default length",
+			annotations: map[string]string{
+				AnnotationAutogenerate: "password",
+				AnnotationLength:       "0",
+			},
+			wantDenied: []string{`iso.gtrfc.com/length: invalid length "0"`},
+		},
+		{
+			name: "non-positive output length",
+			annotations: map[string]string{
+				AnnotationAutogenerate:                 "token",
+				AnnotationTypePrefix + "token":         "bytes",
+				AnnotationOutputLengthPrefix + "token": "-4",
+			},
+			wantDenied: []string{`iso.gtrfc.com/output-length.token: invalid output length "-4", must be a positive integer`},
+		},
+		{
+			name: "invalid count and rotate strategy",
+			annotations: map[string]string{
+				AnnotationAutogenerate:                      "api-keys",
+				AnnotationCountPrefix + "api-keys":          "many",
+				AnnotationRotateStrategyPrefix + "api-keys": "oldest",
+			},
+			wantDenied: []string{
+				`iso.gtrfc.com/count.api-keys: invalid count "many", must be an integer between 1 and 100`,
+				`iso.gtrfc.com/rotate-strategy.api-keys: unknown rotate strategy "oldest", must be "all" or "round-robin"`,
+			},
+		},
+		{
+			name: "unknown type",
+			annotations: map[string]string{
+				AnnotationAutogenerate:            "password",
+				AnnotationTypePrefix + "password": "strnig",
+			},
+			wantDenied: []string{`iso.gtrfc.com/type.password: unknown type "strnig"`},
+		},
+		{
+			name: "invalid rotate duration",
+			annotations: map[string]string{
+				AnnotationAutogenerate: "password",
+				AnnotationRotate:       "daily",
+			},
+			wantDenied: []string{`iso.gtrfc.com/rotate: invalid rotation interval "daily"`},
+		},
+		{
+			name: "rotate below minInterval",
+			annotations: map[string]string{
+				AnnotationAutogenerate:              "password",
+				AnnotationRotatePrefix + "password": "1m",
+			},
+			wantDenied: []string{`iso.gtrfc.com/rotate.password: rotation interval 1m0s for field "password" is below minimum 5m0s`},
+		},
+		{
+			name: "rotate below minInterval ignored for jwt",
+			annotations: map[string]string{
+				AnnotationAutogenerate:         "token",
+				AnnotationTypePrefix + "token": "jwt",
+				AnnotationRotate:               "1m",
+			},
+		},
+		{
+			name: "template references missing source",
+			annotations: map[string]string{
+				AnnotationAutogenerate:           "password",
+				AnnotationTemplatePrefix + "dsn": "postgres://{{ .username }}:{{ .password }}@db",
+			},
+			wantDenied: []string{`iso.gtrfc.com/template.dsn: references nonexistent field "username"`},
+		},
+		{
+			name: "template cycle",
+			annotations: map[string]string{
+				AnnotationAutogenerate:           "password",
+				AnnotationTemplatePrefix + "dsn": "{{ .url }}:{{ .password }}",
+				AnnotationTemplatePrefix + "url": "{{ .dsn }}",
+			},
+			wantDenied: []string{"iso.gtrfc.com/template.dsn: template cycle between fields dsn -> url -> dsn"},
+		},
+		{
+			name: "invalid template",
+			annotations: map[string]string{
+				AnnotationAutogenerate:           "password",
+				AnnotationTemplatePrefix + "dsn": "{{ .password",
+			},
+			wantDenied: []string{"iso.gtrfc.com/template.dsn: invalid template"},
+		},
+		{
+			name: "all problems are reported",
+			annotations: map[string]string{
+				AnnotationAutogenerate:            "password",
+				AnnotationLength:                  "abc",
+				AnnotationTypePrefix + "password": "unknown",
+			},
+			wantDenied: []string{"iso.gtrfc.com/length: invalid length", "iso.gtrfc.com/type.password: unknown type"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			validator := newTestSecretValidator(t, config.NewDefaultConfig())
+
+			for _, operation := range []admissionv1.Operation{admissionv1.Create, admissionv1.Update} {
+				resp := validator.Handle(context.Background(), secretAdmissionRequest(t, operation, tt.annotations, tt.data))
+				if len(tt.wantDenied) == 0 {
+					if !resp.Allowed {
+						t.Errorf("%s: expected Secret to be allowed, got denied: %s", operation, resp.Result.Message)
+					}
+					continue
+				}
+				if resp.Allowed {
+					t.Fatalf("%s: expected Secret to be denied", operation)
+				}
+				for _, want := range tt.wantDenied {
+					if !strings.Contains(resp.Result.Message, want) {
+						t.Errorf("%s: expected denial message to contain %q, got: %s", operation, want, resp.Result.Message)
+					}
+				}
+			}
+		})
+	}
+}
+
+func TestSecretValidatorHandleDelete(t *testing.T) {
+	validator := newTestSecretValidator(t, config.NewDefaultConfig())
+
+	req := secretAdmissionRequest(t, admissionv1.Delete, nil, nil)
+	req.Object = runtime.RawExtension{}
+	if resp := validator.Handle(context.Background(), req); !resp.Allowed {
+		t.Errorf("expected delete to be allowed, got: %s", resp.Result.Message)
+	}
+}
+
+func TestSecretValidatorHandleUpdateOfInvalidSecret(t *testing.T) {
+	validator := newTestSecretValidator(t, config.NewDefaultConfig())
+	invalid := map[string]string{
+		AnnotationAutogenerate: "password",
+		AnnotationLength:       "abc",
+	}
+
+	// Updates that leave the annotations alone are allowed, e.g. the operator recording its status
+	updated := maps.Clone(invalid)
+	updated[AnnotationStatus] = "Failed"
+	req := secretAdmissionRequest(t, admissionv1.Update, updated, map[string][]byte{"other": []byte("value")})
+	req.OldObject = rawSecret(t, invalid, nil)
+	if resp := validator.Handle(context.Background(), req); !resp.Allowed {
+		t.Errorf("expected update of the invalid Secret to be allowed, got: %s", resp.Result.Message)
+	}
+
+	// New problems are denied, naming only those
+	updated[AnnotationTypePrefix+"password"] = "unknown"
+	req = secretAdmissionRequest(t, admissionv1.Update, updated, nil)
+	req.OldObject = rawSecret(t, invalid, nil)
+	resp := validator.Handle(context.Background(), req)
+	if resp.Allowed {
+		t.Fatal("expected the new problem to be denied")
+	}
+	if !strings.Contains(resp.Result.Message, "type.password: unknown type") || strings.Contains(resp.Result.Message, "invalid length") {
+		t.Errorf("expected the denial to name only the new problem, got: %s", resp.Result.Message)
+	}
+
+	// So is a changed invalid value
+	updated = maps.Clone(invalid)
+	updated[AnnotationLength] = "xyz"
+	req = secretAdmissionRequest(t, admissionv1.Update, updated, nil)
+	req.OldObject = rawSecret(t, invalid, nil)
+	if resp := validator.Handle(context.Background(), req); resp.Allowed {
+		t.Error("expected the changed invalid length to be denied")
+	}
+}
+
+func TestSecretValidatorHandleExcludedNamespace(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	cfg.Namespaces.Exclude = []string{"default"}
+	validator := newTestSecretValidator(t, cfg)
+
+	req := secretAdmissionRequest(t, admissionv1.Create, map[string]string{
+		AnnotationAutogenerate: "password",
+		AnnotationLength:       "abc",
+	}, nil)
+	if resp := validator.Handle(context.Background(), req); !resp.Allowed {
+		t.Errorf("expected Secret in excluded namespace to be allowed, got: %s", resp.Result.Message)
+	}
+}
+
+func TestSecretValidatorHandleAnnotationPrefix(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	cfg.AnnotationPrefix = "secrets.acme.internal/"
+	validator := newTestSecretValidator(t, cfg)
+
+	req := secretAdmissionRequest(t, admissionv1.Create, map[string]string{
+		"secrets.acme.internal/autogenerate":    "password",
+		"secrets.acme.internal/length.password": "abc",
+		"iso.gtrfc.com/length.password":         "also-ignored",
+	}, nil)
+	resp := validator.Handle(context.Background(), req)
+	if resp.Allowed {
+		t.Fatal("expected Secret to be denied")
+	}
+	if !strings.Contains(resp.Result.Message, `secrets.acme.internal/length.password: invalid length "abc"`) {
+		t.Errorf("expected denial to name the configured prefix, got: %s", resp.Result.Message)
+	}
+	if strings.Contains(resp.Result.Message, "also-ignored") {
+		t.Errorf("expected annotations with the built-in prefix to be ignored, got: %s", resp.Result.Message)
+	}
+}
+
+func TestSecretValidatorHandleBadRequest(t *testing.T) {
+	validator := newTestSecretValidator(t, config.NewDefaultConfig())
+
+	req := secretAdmissionRequest(t, admissionv1.Create, nil, nil)
+	req.Object = runtime.RawExtension{Raw: []byte("not json")}
+	resp := validator.Handle(context.Background(), req)
+	if resp.Allowed || resp.Result.Code != 400 {
+		t.Errorf("expected bad request, got allowed=%v code=%d", resp.Allowed, resp.Result.Code)
+	}
+}
+
+func TestSecretDefaulterHandle(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		// wantPatch maps the paths of the expected add operations to their values
+		wantPatch map[string]string
+	}{
+		{
+			name:        "type and length missing",
+			annotations: map[string]string{AnnotationAutogenerate: "password"},
+			wantPatch: map[string]string{
+				"/metadata/annotations/iso.gtrfc.com~1type":   "string",
+				"/metadata/annotations/iso.gtrfc.com~1length": "32",
+			},
+		},
+		{
+			name: "type set",
+			annotations: map[string]string{
+				AnnotationAutogenerate: "password",
+				AnnotationType:         "bytes",
+			},
+			wantPatch: map[string]string{
+				"/metadata/annotations/iso.gtrfc.com~1length": "32",
+			},
+		},
+		{
+			name: "length set",
+			annotations: map[string]string{
+				AnnotationAutogenerate: "password",
+				AnnotationLength:       "64",
+			},
+			wantPatch: map[string]string{
+				"/metadata/annotations/iso.gtrfc.com~1type": "string",
+			},
+		},
+		{
+			name: "type and length set",
+			annotations: map[string]string{
+				AnnotationAutogenerate: "password",
+				AnnotationType:         "bytes",
+				AnnotationLength:       "64",
+			},
+		},
+		{
+			name:        "no autogenerate",
+			annotations: map[string]string{"team": "payments"},
+		},
+		{
+			name: "no annotations",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			scheme := runtime.NewScheme()
+			_ = clientgoscheme.AddToScheme(scheme)
+			defaulter := &SecretDefaulter{Config: config.NewDefaultConfig(), Decoder: admission.NewDecoder(scheme)}
+
+			resp := defaulter.Handle(context.Background(), secretAdmissionRequest(t, admissionv1.Create, tt.annotations, nil))
+			if !resp.Allowed {
+				t.Fatalf("expected Secret to be allowed, got: %s", resp.Result.Message)
+			}
+
+			patch := make(map[string]string, len(resp.Patches))
+			for _, op := range resp.Patches {
+				if op.Operation != "add" {
+					t.Errorf("expected only add operations, got %s %s", op.Operation, op.Path)
+				}
+				value, _ := op.Value.(string)
+				patch[op.Path] = value
+			}
+			if len(patch) != len(tt.wantPatch) {
+				t.Fatalf("expected patch %v, got %v", tt.wantPatch, patch)
+			}
+			for path, value := range tt.wantPatch {
+				if patch[path] != value {
+					t.Errorf("expected %s to be set to %q, got %q", path, value, patch[path])
+				}
+			}
+			if len(tt.wantPatch) > 0 && (resp.PatchType == nil || *resp.PatchType != admissionv1.PatchTypeJSONPatch) {
+				t.Errorf("expected JSON patch type, got %v", resp.PatchType)
+			}
+		})
+	}
+}
+
+func TestSecretDefaulterHandleConfig(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	cfg := config.NewDefaultConfig()
+	cfg.AnnotationPrefix = "secrets.acme.internal/"
+	cfg.Defaults.Type = config.TypeBytes
+	cfg.Defaults.Length = 48
+	defaulter := &SecretDefaulter{Config: cfg, Decoder: admission.NewDecoder(scheme)}
+
+	req := secretAdmissionRequest(t, admissionv1.Update, map[string]string{
+		"secrets.acme.internal/autogenerate": "password",
+		"iso.gtrfc.com/type":                 "rsa",
+	}, nil)
+	resp := defaulter.Handle(context.Background(), req)
+	if !resp.Allowed {
+		t.Fatalf("expected Secret to be allowed, got: %s", resp.Result.Message)
+	}
+
+	// Apply the add operations to the annotations
+	var secret corev1.Secret
+	if err := json.Unmarshal(req.Object.Raw, &secret); err != nil {
+		t.Fatalf("failed to unmarshal secret: %v", err)
+	}
+	for _, op := range resp.Patches {
+		key := strings.ReplaceAll(strings.TrimPrefix(op.Path, "/metadata/annotations/"), "~1", "/")
+		secret.Annotations[key], _ = op.Value.(string)
+	}
+	if secret.Annotations["secrets.acme.internal/type"] != "bytes" {
+		t.Errorf("expected type with the configured prefix and default, got %v", secret.Annotations)
+	}
+	if secret.Annotations["secrets.acme.internal/length"] != "48" {
+		t.Errorf("expected length with the configured prefix and default, got %v", secret.Annotations)
+	}
+	if secret.Annotations["iso.gtrfc.com/type"] != "rsa" {
+		t.Errorf("expected annotation with the built-in prefix to be kept, got %v", secret.Annotations)
+	}
+}
+
+func TestSecretDefaulterHandleExcludedNamespace(t *testing.T) {
+	scheme := runtime.NewScheme()
+	_ = clientgoscheme.AddToScheme(scheme)
+	cfg := config.NewDefaultConfig()
+	cfg.Namespaces.Exclude = []string{"default"}
+	defaulter := &SecretDefaulter{Config: cfg, Decoder: admission.NewDecoder(scheme)}
+
+	resp := defaulter.Handle(context.Background(), secretAdmissionRequest(t, admissionv1.Create, map[string]string{
+		AnnotationAutogenerate: "password",
+	}, nil))
+	if !resp.Allowed || len(resp.Patches) != 0 {
+		t.Errorf("expected Secret in excluded namespace to be admitted unchanged, got %v", resp.Patches)
+	}
+}
@@ -18,13 +18,19 @@ limitations under the License.
 package config
 
 import (
+	"bytes"
 	"fmt"
+	"maps"
+	"net/url"
 	"os"
 	"path/filepath"
+	"slices"
+	"strconv"
 	"strings"
 	"time"
 
 	"gopkg.in/yaml.v3"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/util/validation"
 )
 
@@ -32,6 +38,9 @@ const (
 	// DefaultConfigPath is the default path to the configuration file
 	DefaultConfigPath = "/etc/secret-operator/config.yaml"
 
+	// DefaultAnnotationPrefix is the default prefix of the operator's annotations
+	DefaultAnnotationPrefix = "iso.gtrfc.com/"
+
 	// DefaultType is the default generation type
 	DefaultType = "string"
 
@@ -65,12 +74,136 @@ const (
 	// DefaultSLHDSAParam is the default SLH-DSA parameter set
 	DefaultSLHDSAParam = "128s"
 
+	// TypeAge is the age (X25519) keypair type, compatible with SOPS
+	TypeAge = "age"
+
+	// TypeSSHRSA is the RSA SSH keypair type (OpenSSH private key, authorized_keys public key)
+	TypeSSHRSA = "ssh-rsa"
+
+	// TypeSSHEd25519 is the Ed25519 SSH keypair type (OpenSSH private key, authorized_keys public key)
+	TypeSSHEd25519 = "ssh-ed25519"
+
+	// TypeMAC is the random locally-administered unicast MAC address type
+	TypeMAC = "mac"
+
+	// TypeCertificate is an X.509 certificate with its private key in a companion field, reissued before it expires
+	TypeCertificate = "certificate"
+
+	// DefaultCertificateValidity is the default validity period of generated certificates (90 days)
+	DefaultCertificateValidity = 90 * 24 * time.Hour
+
+	// DefaultCertificateKeyUsage is the default key usage of generated certificates
+	DefaultCertificateKeyUsage = "digital-signature"
+
+	// DefaultCertificateUsage is the default extended key usage of generated certificates
+	DefaultCertificateUsage = "server"
+
+	// CertModeSelfSigned makes generated certificates self-signed
+	CertModeSelfSigned = "self-signed"
+
+	// CertModeCASigned makes generated certificates signed by a CA stored in another Secret
+	CertModeCASigned = "ca-signed"
+
+	// DefaultCertMode is the default signing mode of generated certificates
+	DefaultCertMode = CertModeSelfSigned
+
+	// TypeUUID is the random RFC 4122 version 4 UUID type
+	TypeUUID = "uuid"
+
+	// TypeURLSafePassword is a password type safe for URL userinfo components without escaping
+	TypeURLSafePassword = "url-safe-password"
+
+	// TypeNumeric is a fixed-width string of random digits, e.g. for PINs
+	TypeNumeric = "numeric"
+
+	// TypePassphrase is a diceware-style passphrase of random words, e.g. "correct-horse-battery-staple"
+	TypePassphrase = "passphrase"
+
+	// DefaultPassphraseWords is the default number of words of passphrases (77 bits of entropy)
+	DefaultPassphraseWords = 7
+
+	// DefaultPassphraseSeparator is the default separator between the words of passphrases
+	DefaultPassphraseSeparator = "-"
+
+	// TypeTOTP is a base32-encoded random TOTP (RFC 6238) shared secret type
+	TypeTOTP = "totp"
+
+	// DefaultTOTPSecretLength is the default number of random bytes of TOTP secrets (160 bits, as in RFC 4226)
+	DefaultTOTPSecretLength = 20
+
+	// TypeJWT is a signed JSON Web Token type, reissued before it expires
+	TypeJWT = "jwt"
+
+	// TypeBcrypt is the bcrypt hash of another field's value, e.g. for htpasswd files
+	TypeBcrypt = "bcrypt"
+
+	// TypeHtpasswd is an htpasswd line "user:bcrypthash" for a generated password, which is
+	// stored in plaintext alongside
+	TypeHtpasswd = "htpasswd"
+
+	// DefaultBcryptCost is the default bcrypt cost factor
+	DefaultBcryptCost = 10
+
+	// DefaultJWTTTL is the default lifetime of generated JWTs
+	DefaultJWTTTL = time.Hour
+
 	// DefaultRSAKeySize is the default RSA key size in bits
 	DefaultRSAKeySize = 2048
 
 	// DefaultECDSACurve is the default ECDSA curve
 	DefaultECDSACurve = "P-256"
 
+	// KeyEncodingPEM stores keypairs as PEM text
+	KeyEncodingPEM = "pem"
+
+	// KeyEncodingDER stores keypairs as binary DER
+	KeyEncodingDER = "der"
+
+	// DefaultKeyEncoding is the default encoding for PEM keypair types
+	DefaultKeyEncoding = KeyEncodingPEM
+
+	// KeyFormatPKCS1 stores RSA private keys in PKCS#1 format ("RSA PRIVATE KEY")
+	KeyFormatPKCS1 = "pkcs1"
+
+	// KeyFormatPKCS8 stores RSA private keys in PKCS#8 format ("PRIVATE KEY")
+	KeyFormatPKCS8 = "pkcs8"
+
+	// DefaultKeyFormat is the default format for RSA private keys
+	DefaultKeyFormat = KeyFormatPKCS1
+
+	// EncodingRaw stores bytes values as raw binary data
+	EncodingRaw = "raw"
+
+	// EncodingHex stores bytes values hex-encoded
+	EncodingHex = "hex"
+
+	// EncodingBase64 stores bytes values base64-encoded (standard encoding with padding)
+	EncodingBase64 = "base64"
+
+	// DefaultEncoding is the default encoding for the bytes type
+	DefaultEncoding = EncodingRaw
+
+	// ExistingValuesIgnore leaves pre-existing field values alone without starting their rotation schedule
+	ExistingValuesIgnore = "ignore"
+
+	// ExistingValuesAdopt adopts pre-existing field values, starting their rotation schedule at adoption
+	ExistingValuesAdopt = "adopt"
+
+	// DefaultExistingValues is the default handling of pre-existing field values
+	DefaultExistingValues = ExistingValuesIgnore
+
+	// FieldMetadataAnnotations stores per-field metadata in one annotation per field and kind
+	FieldMetadataAnnotations = "annotations"
+
+	// FieldMetadataJSON stores all per-field metadata in a single JSON annotation
+	FieldMetadataJSON = "json"
+
+	// DefaultFieldMetadata is the default storage format of per-field metadata
+	DefaultFieldMetadata = FieldMetadataAnnotations
+
+	// MinIntegrityKeyLength is the minimum length in bytes of the tamper detection HMAC key
+	MinIntegrityKeyLength = 32
+
 	// DefaultLength is the default length for generated values
 	DefaultLength = 32
 
@@ -79,14 +212,158 @@ const (
 
 	// DefaultRotationMinInterval is the minimum allowed rotation interval
 	DefaultRotationMinInterval = 5 * time.Minute
+
+	// DefaultRotationHistorySize is the number of rotation timestamps kept in the rotation-history annotation
+	DefaultRotationHistorySize = 5
+
+	// DefaultRotationThrottlePeriod is the time window the rotation throttle counts rotations in
+	DefaultRotationThrottlePeriod = time.Minute
+
+	// DefaultFailureBackoffInitialDelay is the delay before retrying a Secret after its first failed generation
+	DefaultFailureBackoffInitialDelay = 30 * time.Second
+
+	// DefaultFailureBackoffMaxDelay caps the delay between retries of a Secret whose generation keeps failing
+	DefaultFailureBackoffMaxDelay = time.Hour
+
+	// DefaultFailureBackoffMaxFailures is the number of consecutive failures after which retries stop
+	DefaultFailureBackoffMaxFailures = 10
+
+	// DefaultMetricsInventoryInterval is how often the managed-field metrics are recomputed
+	DefaultMetricsInventoryInterval = 5 * time.Minute
+
+	// DefaultEntropyFloorBits is the minimum entropy a generated value needs to not be reported as weak
+	DefaultEntropyFloorBits = 128
+
+	// DefaultMaxConcurrentReconciles is the number of Secrets the secret generator reconciles at a time
+	DefaultMaxConcurrentReconciles = 1
+
+	// DefaultShutdownGracePeriod is how long in-flight reconciles may finish when the operator shuts down
+	DefaultShutdownGracePeriod = 30 * time.Second
+
+	// DefaultLeaderElectionLeaseDuration is how long non-leader replicas wait to take over an unrenewed lease
+	DefaultLeaderElectionLeaseDuration = 15 * time.Second
+
+	// DefaultLeaderElectionRenewDeadline is how long the leader tries to renew its lease before giving up leadership
+	DefaultLeaderElectionRenewDeadline = 10 * time.Second
+
+	// DefaultLeaderElectionRetryPeriod is how long replicas wait between leader election attempts
+	DefaultLeaderElectionRetryPeriod = 2 * time.Second
+
+	// DefaultLeaderElectionResourceName is the name of the Lease the replicas elect a leader with
+	DefaultLeaderElectionResourceName = "secret-operator.guided-traffic.com"
 )
 
+// Types lists all generation types accepted by the type annotations
+var Types = []string{
+	DefaultType, TypeBytes, TypeRSA, TypeECDSA, TypeEd25519, TypeMLKEM, TypeMLDSA, TypeSLHDSA, TypeAge,
+	TypeSSHRSA, TypeSSHEd25519, TypeMAC, TypeUUID, TypeURLSafePassword, TypeNumeric, TypePassphrase,
+	TypeTOTP, TypeJWT, TypeCertificate, TypeBcrypt, TypeHtpasswd,
+}
+
+// NotificationReasons lists the event reasons notifications can be sent for
+var NotificationReasons = []string{"RotationSucceeded", "CompromisedRotated", "RotationFailed", "GenerationFailed"}
+
+// DefaultSlackNotificationReasons are the event reasons Slack is notified about by default
+var DefaultSlackNotificationReasons = []string{"RotationSucceeded", "RotationFailed", "GenerationFailed"}
+
+// IsValidType returns true if genType is one of Types
+func IsValidType(genType string) bool {
+	return slices.Contains(Types, genType)
+}
+
 // Config holds the operator configuration
 type Config struct {
 	Defaults                   DefaultsConfig              `yaml:"defaults"`
 	Rotation                   RotationConfig              `yaml:"rotation"`
 	Features                   FeaturesConfig              `yaml:"features"`
+	Metrics                    MetricsConfig               `yaml:"metrics"`
+	Events                     EventsConfig                `yaml:"events"`
+	Integrity                  IntegrityConfig             `yaml:"integrity"`
+	FailureBackoff             FailureBackoffConfig        `yaml:"failureBackoff"`
+	Namespaces                 NamespacesConfig            `yaml:"namespaces"`
+	Notifications              NotificationsConfig         `yaml:"notifications"`
+	LeaderElection             LeaderElectionConfig        `yaml:"leaderElection"`
 	GlobalPullBasedPermissions []GlobalPullBasedPermission `yaml:"globalPullBasedPermissions"`
+
+	// AnnotationPrefix is the prefix of the secret generator's annotations, a DNS subdomain followed by "/"
+	AnnotationPrefix string `yaml:"annotationPrefix"`
+	// LabelSelector restricts the secret generator to Secrets whose labels match it, e.g.
+	// "managed-by=iso". Empty means all Secrets with an autogenerate annotation.
+	LabelSelector string `yaml:"labelSelector"`
+	// DryRun makes the secret generator report the changes it would make instead of making them
+	DryRun bool `yaml:"dryRun"`
+	// MaxConcurrentReconciles is the number of Secrets the secret generator and the ConfigMap
+	// generator each reconcile in parallel
+	MaxConcurrentReconciles int `yaml:"maxConcurrentReconciles"`
+	// ShutdownGracePeriod is how long in-flight reconciles may finish when the operator shuts
+	// down, e.g. during a rolling upgrade, before they are canceled
+	ShutdownGracePeriod Duration `yaml:"shutdownGracePeriod"`
+}
+
+// Selector returns the parsed LabelSelector. An empty LabelSelector selects everything; so does
+// an invalid one, which Validate rejects.
+func (c *Config) Selector() labels.Selector {
+	selector, err := labels.Parse(c.LabelSelector)
+	if err != nil {
+		return labels.Everything()
+	}
+	return selector
+}
+
+// validateAnnotationPrefix checks that prefix is a DNS subdomain followed by "/", so that it
+// forms valid annotation keys. An empty prefix stands for DefaultAnnotationPrefix.
+func validateAnnotationPrefix(prefix string) error {
+	if prefix == "" {
+		return nil
+	}
+	domain, ok := strings.CutSuffix(prefix, "/")
+	if !ok {
+		return fmt.Errorf("invalid annotationPrefix %q: must end with \"/\"", prefix)
+	}
+	if errs := validation.IsDNS1123Subdomain(domain); len(errs) > 0 {
+		return fmt.Errorf("invalid annotationPrefix %q: %s", prefix, strings.Join(errs, "; "))
+	}
+	return nil
+}
+
+// LeaderElectionConfig holds the leader election settings for running several operator replicas.
+// Only the elected leader reconciles; the others take over when its lease expires.
+type LeaderElectionConfig struct {
+	// Enabled turns on leader election, like the --leader-elect flag
+	Enabled bool `yaml:"enabled"`
+	// LeaseDuration is how long non-leader replicas wait before taking over an unrenewed lease
+	LeaseDuration Duration `yaml:"leaseDuration"`
+	// RenewDeadline is how long the leader tries to renew its lease before giving up leadership.
+	// It must be less than LeaseDuration.
+	RenewDeadline Duration `yaml:"renewDeadline"`
+	// RetryPeriod is how long replicas wait between attempts to acquire or renew the lease.
+	// It must be less than RenewDeadline.
+	RetryPeriod Duration `yaml:"retryPeriod"`
+	// ResourceName is the name of the Lease in the operator's namespace
+	ResourceName string `yaml:"resourceName"`
+}
+
+// Validate checks that the durations are positive and ordered retryPeriod < renewDeadline <
+// leaseDuration, and that the resource name is a valid object name
+func (c *LeaderElectionConfig) Validate() error {
+	for _, d := range []struct {
+		name     string
+		duration Duration
+	}{{"leaseDuration", c.LeaseDuration}, {"renewDeadline", c.RenewDeadline}, {"retryPeriod", c.RetryPeriod}} {
+		if d.duration.Duration() <= 0 {
+			return fmt.Errorf("%s must be positive, got %s", d.name, d.duration.Duration())
+		}
+	}
+	if c.RenewDeadline >= c.LeaseDuration {
+		return fmt.Errorf("renewDeadline %s must be less than leaseDuration %s", c.RenewDeadline.Duration(), c.LeaseDuration.Duration())
+	}
+	if c.RetryPeriod >= c.RenewDeadline {
+		return fmt.Errorf("retryPeriod %s must be less than renewDeadline %s", c.RetryPeriod.Duration(), c.RenewDeadline.Duration())
+	}
+	if errs := validation.IsDNS1123Subdomain(c.ResourceName); len(errs) > 0 {
+		return fmt.Errorf("invalid resourceName %q: %s", c.ResourceName, strings.Join(errs, "; "))
+	}
+	return nil
 }
 
 // FeaturesConfig holds feature toggle configuration
@@ -94,6 +371,130 @@ type FeaturesConfig struct {
 	SecretGenerator     bool `yaml:"secretGenerator"`
 	SecretReplicator    bool `yaml:"secretReplicator"`
 	ConfigMapReplicator bool `yaml:"configMapReplicator"`
+	// ConfigMapGenerator generates values into ConfigMaps with the secret generator's annotations
+	ConfigMapGenerator bool `yaml:"configMapGenerator"`
+	// ValidatingWebhook rejects Secrets with malformed secret generator annotations at admission.
+	// It requires a serving certificate and a ValidatingWebhookConfiguration.
+	ValidatingWebhook bool `yaml:"validatingWebhook"`
+	// DefaultingWebhook writes the default type and length into Secrets with an autogenerate
+	// annotation at admission. Like ValidatingWebhook, it requires a serving certificate.
+	DefaultingWebhook bool `yaml:"defaultingWebhook"`
+}
+
+// EventsConfig selects the Kubernetes events the secret generator records. All are enabled by
+// default; disabling them reduces the noise in the event stream of busy clusters.
+type EventsConfig struct {
+	// OnGeneration records GenerationSucceeded and ValuesAdopted events
+	OnGeneration bool `yaml:"onGeneration"`
+	// OnRotation records RotationSucceeded and RotationDeferred events. RotationSucceeded
+	// additionally requires rotation.createEvents, a manual rotation, or a consumer.
+	OnRotation bool `yaml:"onRotation"`
+	// OnFailure records GenerationFailed, RotationFailed, NotificationFailed, and the
+	// GenerationRecovered events following failures
+	OnFailure bool `yaml:"onFailure"`
+}
+
+// MetricsConfig holds the configuration for the managed-field inventory metrics
+type MetricsConfig struct {
+	// InventoryInterval is how often the managed-field metrics are recomputed from the
+	// cache. Zero disables them.
+	InventoryInterval Duration `yaml:"inventoryInterval"`
+	// EntropyFloorBits is the entropy in bits below which a generated value is reported as weak
+	EntropyFloorBits int `yaml:"entropyFloorBits"`
+}
+
+// NamespacesConfig restricts the namespaces the secret generator acts on. Both lists hold
+// namespace names or glob patterns (*, ?, [a-z]).
+type NamespacesConfig struct {
+	// Include lists the namespaces to act on; empty means all namespaces
+	Include []string `yaml:"include"`
+	// Exclude lists namespaces to leave alone, even if they are included
+	Exclude []string `yaml:"exclude"`
+}
+
+// Allows returns true if Secrets in namespace are to be reconciled
+func (c *NamespacesConfig) Allows(namespace string) bool {
+	if len(c.Include) > 0 && !matchesAnyNamespacePattern(namespace, c.Include) {
+		return false
+	}
+	return !matchesAnyNamespacePattern(namespace, c.Exclude)
+}
+
+// Validate checks that all namespace patterns are valid glob patterns
+func (c *NamespacesConfig) Validate() error {
+	for _, list := range []struct {
+		name     string
+		patterns []string
+	}{{"include", c.Include}, {"exclude", c.Exclude}} {
+		for i, pattern := range list.patterns {
+			if pattern == "" {
+				return fmt.Errorf("%s[%d] must not be empty", list.name, i)
+			}
+			if _, err := filepath.Match(pattern, "probe"); err != nil {
+				return fmt.Errorf("invalid glob pattern %q in %s[%d]: %w", pattern, list.name, i, err)
+			}
+		}
+	}
+	return nil
+}
+
+// matchesAnyNamespacePattern returns true if namespace matches one of the glob patterns
+func matchesAnyNamespacePattern(namespace string, patterns []string) bool {
+	for _, pattern := range patterns {
+		if matched, _ := filepath.Match(pattern, namespace); matched {
+			return true
+		}
+	}
+	return false
+}
+
+// FailureBackoffConfig holds the retry configuration for Secrets whose generation fails.
+// Retries back off exponentially from InitialDelay up to MaxDelay, and stop after MaxFailures
+// consecutive failures until the Secret is changed.
+type FailureBackoffConfig struct {
+	// InitialDelay is the delay before the first retry; it doubles with every further failure
+	InitialDelay Duration `yaml:"initialDelay"`
+	// MaxDelay caps the delay between retries
+	MaxDelay Duration `yaml:"maxDelay"`
+	// MaxFailures is the number of consecutive failures after which retries stop; 0 disables retries
+	MaxFailures int `yaml:"maxFailures"`
+}
+
+// IntegrityConfig holds the configuration for tamper detection of generated values.
+// The operator stores an HMAC of each value it writes and verifies it on reconcile.
+// It is disabled unless a key source is set.
+type IntegrityConfig struct {
+	// KeyFile is the path of a file holding the HMAC key, e.g. a mounted Secret
+	KeyFile string `yaml:"keyFile"`
+	// KeyEnv is the name of an environment variable holding the HMAC key
+	KeyEnv string `yaml:"keyEnv"`
+}
+
+// Enabled returns true if a key source is configured
+func (c *IntegrityConfig) Enabled() bool {
+	return c.KeyFile != "" || c.KeyEnv != ""
+}
+
+// LoadKey reads the HMAC key from the configured source. Surrounding whitespace is ignored.
+// It returns nil if tamper detection is disabled.
+func (c *IntegrityConfig) LoadKey() ([]byte, error) {
+	var key []byte
+	switch {
+	case c.KeyFile != "":
+		data, err := os.ReadFile(filepath.Clean(c.KeyFile))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read integrity key file: %w", err)
+		}
+		key = bytes.TrimSpace(data)
+	case c.KeyEnv != "":
+		key = bytes.TrimSpace([]byte(os.Getenv(c.KeyEnv)))
+	default:
+		return nil, nil
+	}
+	if len(key) < MinIntegrityKeyLength {
+		return nil, fmt.Errorf("integrity key must be at least %d bytes, got %d", MinIntegrityKeyLength, len(key))
+	}
+	return key, nil
 }
 
 // GlobalPullBasedPermission grants pull-based replication from source objects
@@ -178,19 +579,237 @@ type DefaultsConfig struct {
 	Type   string        `yaml:"type"`
 	Length int           `yaml:"length"`
 	String StringOptions `yaml:"string"`
+	// ExistingValues controls how values already present in a field before the operator first
+	// generates a value for the Secret are handled: "ignore" or "adopt"
+	ExistingValues string `yaml:"existingValues"`
+	// FieldMetadata controls how per-field metadata (rotation anchors, value hashes) is stored:
+	// "annotations" (one annotation per field) or "json" (a single annotation for all fields)
+	FieldMetadata string `yaml:"fieldMetadata"`
+	// MinEntropyBits rejects string, bytes and url-safe-password fields whose charset and length
+	// give less entropy than this. 0 disables the check.
+	MinEntropyBits int `yaml:"minEntropyBits"`
 }
 
 // RotationConfig holds the configuration for secret rotation
 type RotationConfig struct {
-	MinInterval        Duration                 `yaml:"minInterval"`
-	CreateEvents       bool                     `yaml:"createEvents"`
-	MaintenanceWindows MaintenanceWindowsConfig `yaml:"maintenanceWindows"`
+	MinInterval           Duration                 `yaml:"minInterval"`
+	CreateEvents          bool                     `yaml:"createEvents"`
+	MaintenanceWindows    MaintenanceWindowsConfig `yaml:"maintenanceWindows"`
+	ForceRotationTriggers []ForceRotationTrigger   `yaml:"forceRotationTriggers"`
+	// MinIntervalByType overrides MinInterval for fields of a generation type, e.g. a higher
+	// floor for expensive rsa keys
+	MinIntervalByType map[string]Duration `yaml:"minIntervalByType"`
+	// NamespaceDefaults maps namespaces to the rotation interval of fields in their Secrets
+	// without a rotate or rotate.<field> annotation
+	NamespaceDefaults map[string]string `yaml:"namespaceDefaults"`
+	// GateInitialGeneration defers initial generation of missing fields to the next
+	// maintenance window, too. Secrets may stay empty until then.
+	GateInitialGeneration bool `yaml:"gateInitialGeneration"`
+	// Throttle limits how many Secrets may rotate within a period
+	Throttle RotationThrottleConfig `yaml:"throttle"`
+	// Jitter delays scheduled rotations by a stable per-Secret amount, so Secrets sharing a
+	// rotation interval don't all come due at once
+	Jitter Jitter `yaml:"jitter"`
+	// MaxCatchUpDelay bounds a stable per-Secret delay of rotations that are more than twice
+	// overdue when the operator starts, so they don't all happen at once after a downtime.
+	// 0 rotates them right away.
+	MaxCatchUpDelay Duration `yaml:"maxCatchUpDelay"`
+	// NotifyOnRotation POSTs a notification to WebhookURL after every rotation
+	NotifyOnRotation bool `yaml:"notifyOnRotation"`
+	// WebhookURL is the http(s) URL rotation notifications are sent to
+	WebhookURL string `yaml:"webhookURL"`
+	// WebhookTokenSecret optionally references the bearer token sent with notifications
+	WebhookTokenSecret *SecretKeyReference `yaml:"webhookTokenSecret"`
+	// HistorySize is the number of rotation timestamps kept in the rotation-history annotation
+	HistorySize int `yaml:"historySize"`
+}
+
+// NotificationsConfig holds the configuration of notifications about Secrets sent outside the cluster
+type NotificationsConfig struct {
+	Slack SlackNotificationsConfig `yaml:"slack"`
+}
+
+// SlackNotificationsConfig holds the configuration of Slack notifications
+type SlackNotificationsConfig struct {
+	// WebhookURL is the Slack Incoming Webhook messages are posted to. Empty disables Slack notifications.
+	WebhookURL string `yaml:"webhookURL"`
+	// Reasons are the event reasons a message is posted for, a subset of NotificationReasons
+	Reasons []string `yaml:"reasons"`
+}
+
+// Enabled returns true if Slack notifications are configured
+func (s *SlackNotificationsConfig) Enabled() bool {
+	return s.WebhookURL != ""
+}
+
+// MinIntervalFor returns the minimum rotation interval of fields of genType, and whether it is
+// the type-specific one from MinIntervalByType rather than MinInterval
+func (r *RotationConfig) MinIntervalFor(genType string) (time.Duration, bool) {
+	if minInterval, ok := r.MinIntervalByType[genType]; ok {
+		return minInterval.Duration(), true
+	}
+	return r.MinInterval.Duration(), false
+}
+
+// NamespaceDefault returns the default rotation interval of Secrets in namespace, or 0 if
+// NamespaceDefaults has none
+func (r *RotationConfig) NamespaceDefault(namespace string) time.Duration {
+	value, ok := r.NamespaceDefaults[namespace]
+	if !ok {
+		return 0
+	}
+	interval, err := ParseDuration(value)
+	if err != nil {
+		// This should not happen if Validate() was called
+		return 0
+	}
+	return interval
+}
+
+// SecretKeyReference references a key of a Secret
+type SecretKeyReference struct {
+	Namespace string `yaml:"namespace"`
+	Name      string `yaml:"name"`
+	Key       string `yaml:"key"`
+}
+
+// Validate validates the reference
+func (s *SecretKeyReference) Validate() error {
+	if s.Namespace == "" || s.Name == "" || s.Key == "" {
+		return fmt.Errorf("namespace, name, and key must not be empty")
+	}
+	return nil
+}
+
+// Jitter is the maximum delay added to a rotation interval, either a duration ("30m") or a
+// percentage of the interval ("10%"). The zero value adds no delay.
+type Jitter struct {
+	// Max is the maximum delay if the jitter is given as a duration
+	Max time.Duration
+	// Percent is the maximum delay in percent of the interval if the jitter is given as a percentage
+	Percent float64
+}
+
+// UnmarshalYAML implements yaml.Unmarshaler for Jitter
+func (j *Jitter) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var s string
+	if err := unmarshal(&s); err != nil {
+		return err
+	}
+
+	jitter, err := ParseJitter(s)
+	if err != nil {
+		return err
+	}
+
+	*j = jitter
+	return nil
+}
+
+// MarshalYAML implements yaml.Marshaler for Jitter
+func (j Jitter) MarshalYAML() (interface{}, error) {
+	return j.String(), nil
+}
+
+// String returns the jitter in the format accepted by ParseJitter
+func (j Jitter) String() string {
+	if j.Percent != 0 {
+		return strconv.FormatFloat(j.Percent, 'f', -1, 64) + "%"
+	}
+	return j.Max.String()
+}
+
+// ParseJitter parses a jitter given as a duration ("30m", "1d") or a percentage ("10%")
+func ParseJitter(s string) (Jitter, error) {
+	if percent, ok := strings.CutSuffix(s, "%"); ok {
+		value, err := strconv.ParseFloat(strings.TrimSpace(percent), 64)
+		if err != nil {
+			return Jitter{}, fmt.Errorf("invalid jitter percentage %q: %w", s, err)
+		}
+		return Jitter{Percent: value}, nil
+	}
+	duration, err := ParseDuration(s)
+	if err != nil {
+		return Jitter{}, err
+	}
+	return Jitter{Max: duration}, nil
+}
+
+// Bound returns the maximum delay added to interval
+func (j Jitter) Bound(interval time.Duration) time.Duration {
+	if j.Percent != 0 {
+		return time.Duration(float64(interval) * j.Percent / 100)
+	}
+	return j.Max
+}
+
+// Validate checks that the jitter is a non-negative duration or a percentage between 0 and 100
+func (j Jitter) Validate() error {
+	if j.Max < 0 {
+		return fmt.Errorf("jitter must be non-negative, got %s", j.Max)
+	}
+	if !(j.Percent >= 0 && j.Percent <= 100) {
+		return fmt.Errorf("jitter percentage must be between 0%% and 100%%, got %s", j.String())
+	}
+	return nil
+}
+
+// RotationThrottleConfig limits scheduled rotations across all Secrets, so that a backlog of
+// due rotations (e.g. after operator downtime) drains gradually
+type RotationThrottleConfig struct {
+	// MaxRotations is the number of Secrets allowed to rotate per Period; 0 disables the throttle
+	MaxRotations int `yaml:"maxRotations"`
+	// Period is the sliding time window MaxRotations applies to
+	Period Duration `yaml:"period"`
+}
+
+// ForceRotationTrigger forces a one-time rotation of all managed Secrets matching a label selector.
+// Each Secret records the token it was rotated for, so changing Token triggers the rotation again.
+type ForceRotationTrigger struct {
+	// Selector is a Kubernetes label selector (e.g. "team=payments,tier!=dev")
+	Selector string `yaml:"selector"`
+	// Token identifies this trigger; change it to force another rotation
+	Token string `yaml:"token"`
+	// IgnoreMaintenanceWindows rotates immediately instead of waiting for the next window
+	IgnoreMaintenanceWindows bool `yaml:"ignoreMaintenanceWindows"`
+}
+
+// Validate validates a single force rotation trigger
+func (f *ForceRotationTrigger) Validate() error {
+	if strings.TrimSpace(f.Selector) == "" {
+		return fmt.Errorf("selector must not be empty")
+	}
+	if _, err := labels.Parse(f.Selector); err != nil {
+		return fmt.Errorf("invalid selector %q: %w", f.Selector, err)
+	}
+	if f.Token == "" {
+		return fmt.Errorf("token must not be empty")
+	}
+	return nil
+}
+
+// Matches returns true if the given labels match the trigger's selector
+func (f *ForceRotationTrigger) Matches(objLabels map[string]string) bool {
+	selector, err := labels.Parse(f.Selector)
+	if err != nil {
+		// This should not happen if Validate() was called
+		return false
+	}
+	return selector.Matches(labels.Set(objLabels))
 }
 
 // MaintenanceWindowsConfig holds the configuration for maintenance windows
 type MaintenanceWindowsConfig struct {
 	Enabled bool                `yaml:"enabled"`
 	Windows []MaintenanceWindow `yaml:"windows"`
+	// SpreadDeferredRotations requeues deferred rotations at a point inside the
+	// upcoming window (stable per Secret) instead of exactly at its start.
+	SpreadDeferredRotations bool `yaml:"spreadDeferredRotations"`
+	// DefaultTimezone is applied to windows that don't specify a timezone
+	DefaultTimezone string `yaml:"defaultTimezone"`
+	// BlackoutWindows block rotation, even inside a maintenance window. They apply
+	// even if maintenance windows are disabled.
+	BlackoutWindows []MaintenanceWindow `yaml:"blackoutWindows"`
 }
 
 // MaintenanceWindow defines a time window during which secret rotation is allowed
@@ -200,6 +819,9 @@ type MaintenanceWindow struct {
 	StartTime string   `yaml:"startTime"`
 	EndTime   string   `yaml:"endTime"`
 	Timezone  string   `yaml:"timezone"`
+	// Date restricts the window to a single calendar day (YYYY-MM-DD)
+	// instead of recurring on Days
+	Date string `yaml:"date"`
 }
 
 // StringOptions holds the character set options for string generation
@@ -240,30 +862,80 @@ func (d Duration) Duration() time.Duration {
 	return time.Duration(d)
 }
 
-// ParseDuration parses a duration string with support for day suffix (d)
+// longDurationUnits are the units ParseDuration supports in addition to those of
+// time.ParseDuration, in the order they have to appear in
+var longDurationUnits = []struct {
+	suffix byte
+	unit   time.Duration
+}{
+	{'w', 7 * 24 * time.Hour},
+	{'d', 24 * time.Hour},
+}
+
+// ParseDuration parses a duration string with support for week (w) and day (d) suffixes.
+// Weeks and days come first and compose with the units of time.ParseDuration, e.g. "1w3d12h".
 func ParseDuration(s string) (time.Duration, error) {
 	if s == "" {
 		return 0, nil
 	}
 
-	// Handle day suffix by converting to hours
-	// e.g., "7d" -> "168h"
-	if len(s) > 1 && s[len(s)-1] == 'd' {
-		// Parse the number of days
-		daysStr := s[:len(s)-1]
-		var days float64
-		if _, err := fmt.Sscanf(daysStr, "%f", &days); err != nil {
-			return 0, fmt.Errorf("invalid duration: %s", s)
+	rest := s
+	negative := false
+	if rest[0] == '-' || rest[0] == '+' {
+		negative = rest[0] == '-'
+		rest = rest[1:]
+	}
+
+	var total time.Duration
+	parsed := false
+	for _, u := range longDurationUnits {
+		i := 0
+		for i < len(rest) && (rest[i] >= '0' && rest[i] <= '9' || rest[i] == '.') {
+			i++
+		}
+		if i == 0 || i == len(rest) || rest[i] != u.suffix {
+			continue
+		}
+		value, err := strconv.ParseFloat(rest[:i], 64)
+		if err != nil {
+			return 0, invalidDurationError(s)
 		}
-		return time.Duration(days * 24 * float64(time.Hour)), nil
+		total += time.Duration(value * float64(u.unit))
+		rest = rest[i+1:]
+		parsed = true
+	}
+
+	if rest != "" {
+		// A sign is only allowed in front of the whole duration
+		if rest[0] == '-' || rest[0] == '+' {
+			return 0, invalidDurationError(s)
+		}
+		d, err := time.ParseDuration(rest)
+		if err != nil {
+			return 0, invalidDurationError(s)
+		}
+		total += d
+	} else if !parsed {
+		return 0, invalidDurationError(s)
+	}
+
+	if negative {
+		total = -total
 	}
+	return total, nil
+}
 
-	return time.ParseDuration(s)
+// invalidDurationError is returned by ParseDuration for durations it can't parse
+func invalidDurationError(s string) error {
+	return fmt.Errorf("invalid duration %q: expected a number with unit w, d, h, m, s, ms, us or ns, in that order (e.g. 90d, 2w, 1w3d12h)", s)
 }
 
 // NewDefaultConfig creates a Config with default values
 func NewDefaultConfig() *Config {
 	return &Config{
+		AnnotationPrefix:        DefaultAnnotationPrefix,
+		MaxConcurrentReconciles: DefaultMaxConcurrentReconciles,
+		ShutdownGracePeriod:     Duration(DefaultShutdownGracePeriod),
 		Defaults: DefaultsConfig{
 			Type:   DefaultType,
 			Length: DefaultLength,
@@ -274,30 +946,59 @@ func NewDefaultConfig() *Config {
 				SpecialChars:        false,
 				AllowedSpecialChars: DefaultAllowedSpecialChars,
 			},
+			ExistingValues: DefaultExistingValues,
+			FieldMetadata:  DefaultFieldMetadata,
 		},
 		Rotation: RotationConfig{
 			MinInterval:  Duration(DefaultRotationMinInterval),
 			CreateEvents: false,
+			HistorySize:  DefaultRotationHistorySize,
+			Throttle: RotationThrottleConfig{
+				Period: Duration(DefaultRotationThrottlePeriod),
+			},
 		},
 		Features: FeaturesConfig{
 			SecretGenerator:     true,
 			SecretReplicator:    true,
 			ConfigMapReplicator: true,
 		},
+		Metrics: MetricsConfig{
+			InventoryInterval: Duration(DefaultMetricsInventoryInterval),
+			EntropyFloorBits:  DefaultEntropyFloorBits,
+		},
+		Events: EventsConfig{
+			OnGeneration: true,
+			OnRotation:   true,
+			OnFailure:    true,
+		},
+		FailureBackoff: FailureBackoffConfig{
+			InitialDelay: Duration(DefaultFailureBackoffInitialDelay),
+			MaxDelay:     Duration(DefaultFailureBackoffMaxDelay),
+			MaxFailures:  DefaultFailureBackoffMaxFailures,
+		},
+		Notifications: NotificationsConfig{
+			Slack: SlackNotificationsConfig{
+				Reasons: slices.Clone(DefaultSlackNotificationReasons),
+			},
+		},
+		LeaderElection: LeaderElectionConfig{
+			LeaseDuration: Duration(DefaultLeaderElectionLeaseDuration),
+			RenewDeadline: Duration(DefaultLeaderElectionRenewDeadline),
+			RetryPeriod:   Duration(DefaultLeaderElectionRetryPeriod),
+			ResourceName:  DefaultLeaderElectionResourceName,
+		},
 	}
 }
 
 // LoadConfig loads configuration from a YAML file.
 // If the file does not exist, it returns the default configuration.
 func LoadConfig(path string) (*Config, error) {
-	config := NewDefaultConfig()
-
 	// Clean the path to prevent directory traversal
 	cleanPath := filepath.Clean(path)
 
 	// Check if file exists
 	if _, err := os.Stat(cleanPath); os.IsNotExist(err) {
-		return config, nil
+		return NewDefaultConfig(), nil
 	}
 
 	data, err := os.ReadFile(cleanPath)
@@ -305,24 +1006,18 @@ func LoadConfig(path string) (*Config, error) {
 		return nil, fmt.Errorf("failed to read config file: %w", err)
 	}
 
+	return Parse(data)
+}
+
+// Parse parses the content of a configuration file on top of the defaults and validates
+// the result. Like LoadConfig, it ignores unknown keys.
+func Parse(data []byte) (*Config, error) {
+	config := NewDefaultConfig()
 	if err := yaml.Unmarshal(data, config); err != nil {
 		return nil, fmt.Errorf("failed to parse config file: %w", err)
 	}
 
-	// Apply defaults for zero values
-	if config.Defaults.Type == "" {
-		config.Defaults.Type = DefaultType
-	}
-	if config.Defaults.Length == 0 {
-		config.Defaults.Length = DefaultLength
-	}
-	if config.Defaults.String.AllowedSpecialChars == "" {
-		config.Defaults.String.AllowedSpecialChars = DefaultAllowedSpecialChars
-	}
-	// Apply defaults for rotation config
-	if config.Rotation.MinInterval == 0 {
-		config.Rotation.MinInterval = Duration(DefaultRotationMinInterval)
-	}
+	config.applyDefaults()
 
 	// Validate the configuration
 	if err := config.Validate(); err != nil {
@@ -332,8 +1027,87 @@ func LoadConfig(path string) (*Config, error) {
 	return config, nil
 }
 
+// applyDefaults sets the defaults of settings left at their zero value, e.g. by an empty key
+// in the configuration file
+func (c *Config) applyDefaults() {
+	if c.AnnotationPrefix == "" {
+		c.AnnotationPrefix = DefaultAnnotationPrefix
+	}
+	if c.Defaults.Type == "" {
+		c.Defaults.Type = DefaultType
+	}
+	if c.Defaults.Length == 0 {
+		c.Defaults.Length = DefaultLength
+	}
+	if c.Defaults.String.AllowedSpecialChars == "" {
+		c.Defaults.String.AllowedSpecialChars = DefaultAllowedSpecialChars
+	}
+	if c.Defaults.ExistingValues == "" {
+		c.Defaults.ExistingValues = DefaultExistingValues
+	}
+	if c.Defaults.FieldMetadata == "" {
+		c.Defaults.FieldMetadata = DefaultFieldMetadata
+	}
+	// Apply defaults for rotation config
+	if c.Rotation.MinInterval == 0 {
+		c.Rotation.MinInterval = Duration(DefaultRotationMinInterval)
+	}
+	if c.Rotation.Throttle.Period == 0 {
+		c.Rotation.Throttle.Period = Duration(DefaultRotationThrottlePeriod)
+	}
+	if c.Rotation.HistorySize == 0 {
+		c.Rotation.HistorySize = DefaultRotationHistorySize
+	}
+	c.Rotation.MaintenanceWindows.ApplyDefaultTimezone()
+	if c.Metrics.EntropyFloorBits == 0 {
+		c.Metrics.EntropyFloorBits = DefaultEntropyFloorBits
+	}
+	if c.FailureBackoff.InitialDelay == 0 {
+		c.FailureBackoff.InitialDelay = Duration(DefaultFailureBackoffInitialDelay)
+	}
+	if c.FailureBackoff.MaxDelay == 0 {
+		c.FailureBackoff.MaxDelay = Duration(DefaultFailureBackoffMaxDelay)
+	}
+	if c.MaxConcurrentReconciles == 0 {
+		c.MaxConcurrentReconciles = DefaultMaxConcurrentReconciles
+	}
+	if c.ShutdownGracePeriod == 0 {
+		c.ShutdownGracePeriod = Duration(DefaultShutdownGracePeriod)
+	}
+	if c.LeaderElection.LeaseDuration == 0 {
+		c.LeaderElection.LeaseDuration = Duration(DefaultLeaderElectionLeaseDuration)
+	}
+	if c.LeaderElection.RenewDeadline == 0 {
+		c.LeaderElection.RenewDeadline = Duration(DefaultLeaderElectionRenewDeadline)
+	}
+	if c.LeaderElection.RetryPeriod == 0 {
+		c.LeaderElection.RetryPeriod = Duration(DefaultLeaderElectionRetryPeriod)
+	}
+	if c.LeaderElection.ResourceName == "" {
+		c.LeaderElection.ResourceName = DefaultLeaderElectionResourceName
+	}
+}
+
 // Validate validates the configuration
 func (c *Config) Validate() error {
+	// Validate annotation prefix
+	if err := validateAnnotationPrefix(c.AnnotationPrefix); err != nil {
+		return err
+	}
+
+	// Validate label selector
+	if _, err := labels.Parse(c.LabelSelector); err != nil {
+		return fmt.Errorf("invalid labelSelector %q: %w", c.LabelSelector, err)
+	}
+
+	// Validate concurrency
+	if c.MaxConcurrentReconciles < 0 {
+		return fmt.Errorf("maxConcurrentReconciles must not be negative, got %d", c.MaxConcurrentReconciles)
+	}
+	if c.ShutdownGracePeriod.Duration() < 0 {
+		return fmt.Errorf("shutdownGracePeriod must not be negative, got %s", c.ShutdownGracePeriod.Duration())
+	}
+
 	// Validate generation type
 	switch c.Defaults.Type {
 	case DefaultType, TypeBytes, TypeRSA, TypeECDSA, TypeEd25519:
@@ -358,16 +1132,160 @@ func (c *Config) Validate() error {
 		return fmt.Errorf("allowedSpecialChars must not be empty when specialChars is enabled")
 	}
 
+	// Validate existing values handling
+	switch c.Defaults.ExistingValues {
+	case "", ExistingValuesIgnore, ExistingValuesAdopt:
+		// valid modes, empty means ignore
+	default:
+		return fmt.Errorf("invalid existingValues: %s, must be 'ignore' or 'adopt'", c.Defaults.ExistingValues)
+	}
+
+	// Validate per-field metadata format
+	switch c.Defaults.FieldMetadata {
+	case "", FieldMetadataAnnotations, FieldMetadataJSON:
+		// valid formats, empty means annotations
+	default:
+		return fmt.Errorf("invalid fieldMetadata: %s, must be 'annotations' or 'json'", c.Defaults.FieldMetadata)
+	}
+
+	// Validate minimum entropy
+	if c.Defaults.MinEntropyBits < 0 {
+		return fmt.Errorf("minEntropyBits must be non-negative, got %d", c.Defaults.MinEntropyBits)
+	}
+
 	// Validate rotation minInterval
 	if c.Rotation.MinInterval.Duration() < 0 {
 		return fmt.Errorf("rotation minInterval must be non-negative, got %s", c.Rotation.MinInterval.Duration())
 	}
 
-	// Validate maintenance windows if enabled
+	for genType, minInterval := range c.Rotation.MinIntervalByType {
+		if !IsValidType(genType) {
+			return fmt.Errorf("invalid rotation minIntervalByType type %q, must be one of %s", genType, strings.Join(Types, ", "))
+		}
+		if minInterval.Duration() < 0 {
+			return fmt.Errorf("rotation minIntervalByType %s must be non-negative, got %s", genType, minInterval.Duration())
+		}
+	}
+	// A namespace default applies to fields of every type, so it must satisfy the strictest minimum
+	minDefault, minDefaultSource := c.Rotation.MinInterval.Duration(), "minInterval"
+	for _, genType := range slices.Sorted(maps.Keys(c.Rotation.MinIntervalByType)) {
+		if minInterval := c.Rotation.MinIntervalByType[genType].Duration(); minInterval > minDefault {
+			minDefault, minDefaultSource = minInterval, "minIntervalByType "+genType
+		}
+	}
+	for namespace, value := range c.Rotation.NamespaceDefaults {
+		if errs := validation.IsDNS1123Label(namespace); len(errs) > 0 {
+			return fmt.Errorf("invalid rotation namespaceDefaults namespace %q: %s", namespace, strings.Join(errs, ", "))
+		}
+		interval, err := ParseDuration(value)
+		if err != nil {
+			return fmt.Errorf("invalid rotation namespaceDefaults interval for namespace %s: %w", namespace, err)
+		}
+		if interval < minDefault {
+			return fmt.Errorf("rotation namespaceDefaults interval %s for namespace %s is below minimum %s of %s",
+				interval, namespace, minDefault, minDefaultSource)
+		}
+	}
+	if c.Rotation.MaxCatchUpDelay.Duration() < 0 {
+		return fmt.Errorf("rotation maxCatchUpDelay must be non-negative, got %s", c.Rotation.MaxCatchUpDelay.Duration())
+	}
+	if c.Rotation.HistorySize < 0 {
+		return fmt.Errorf("rotation historySize must be non-negative, got %d", c.Rotation.HistorySize)
+	}
+
+	// Validate rotation throttle
+	if c.Rotation.Throttle.MaxRotations < 0 {
+		return fmt.Errorf("rotation throttle maxRotations must be non-negative, got %d", c.Rotation.Throttle.MaxRotations)
+	}
+	if c.Rotation.Throttle.MaxRotations > 0 && c.Rotation.Throttle.Period.Duration() <= 0 {
+		return fmt.Errorf("rotation throttle period must be positive, got %s", c.Rotation.Throttle.Period.Duration())
+	}
+
+	// Validate rotation jitter
+	if err := c.Rotation.Jitter.Validate(); err != nil {
+		return fmt.Errorf("rotation %w", err)
+	}
+
+	// Validate rotation notifications
+	if c.Rotation.NotifyOnRotation {
+		if u, err := url.Parse(c.Rotation.WebhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("rotation webhookURL must be an http or https URL when notifyOnRotation is enabled, got %q", c.Rotation.WebhookURL)
+		}
+		if c.Rotation.WebhookTokenSecret != nil {
+			if err := c.Rotation.WebhookTokenSecret.Validate(); err != nil {
+				return fmt.Errorf("rotation webhookTokenSecret: %w", err)
+			}
+		}
+	}
+
+	// Validate Slack notifications. The webhook URL is a credential, so it is never part of errors.
+	if slack := c.Notifications.Slack; slack.Enabled() {
+		if u, err := url.Parse(slack.WebhookURL); err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("notifications slack webhookURL must be an http or https URL")
+		}
+		if len(slack.Reasons) == 0 {
+			return fmt.Errorf("notifications slack reasons must not be empty")
+		}
+		for _, reason := range slack.Reasons {
+			if !slices.Contains(NotificationReasons, reason) {
+				return fmt.Errorf("invalid notifications slack reason %q, must be one of %s", reason, strings.Join(NotificationReasons, ", "))
+			}
+		}
+	}
+
+	// Validate leader election if enabled
+	if c.LeaderElection.Enabled {
+		if err := c.LeaderElection.Validate(); err != nil {
+			return fmt.Errorf("leaderElection %w", err)
+		}
+	}
+
+	// Validate maintenance windows if enabled; blackout windows apply regardless
 	if c.Rotation.MaintenanceWindows.Enabled {
 		if err := c.Rotation.MaintenanceWindows.Validate(); err != nil {
 			return fmt.Errorf("maintenance windows configuration error: %w", err)
 		}
+	} else if err := c.Rotation.MaintenanceWindows.ValidateBlackoutWindows(); err != nil {
+		return fmt.Errorf("maintenance windows configuration error: %w", err)
+	}
+
+	// Validate force rotation triggers
+	for i := range c.Rotation.ForceRotationTriggers {
+		if err := c.Rotation.ForceRotationTriggers[i].Validate(); err != nil {
+			return fmt.Errorf("rotation forceRotationTriggers[%d]: %w", i, err)
+		}
+	}
+
+	// Validate metrics
+	if c.Metrics.InventoryInterval.Duration() < 0 {
+		return fmt.Errorf("metrics inventoryInterval must be non-negative, got %s", c.Metrics.InventoryInterval.Duration())
+	}
+	if c.Metrics.EntropyFloorBits < 0 {
+		return fmt.Errorf("metrics entropyFloorBits must be non-negative, got %d", c.Metrics.EntropyFloorBits)
+	}
+
+	// Validate failure backoff
+	if c.FailureBackoff.MaxFailures < 0 {
+		return fmt.Errorf("failureBackoff maxFailures must be non-negative, got %d", c.FailureBackoff.MaxFailures)
+	}
+	if c.FailureBackoff.MaxFailures > 0 {
+		if c.FailureBackoff.InitialDelay.Duration() <= 0 {
+			return fmt.Errorf("failureBackoff initialDelay must be positive, got %s", c.FailureBackoff.InitialDelay.Duration())
+		}
+		if c.FailureBackoff.MaxDelay.Duration() < c.FailureBackoff.InitialDelay.Duration() {
+			return fmt.Errorf("failureBackoff maxDelay must not be less than initialDelay (%s), got %s",
+				c.FailureBackoff.InitialDelay.Duration(), c.FailureBackoff.MaxDelay.Duration())
+		}
+	}
+
+	// Validate namespace filters
+	if err := c.Namespaces.Validate(); err != nil {
+		return fmt.Errorf("namespaces: %w", err)
+	}
+
+	// Validate integrity key source
+	if c.Integrity.KeyFile != "" && c.Integrity.KeyEnv != "" {
+		return fmt.Errorf("integrity keyFile and keyEnv are mutually exclusive")
 	}
 
 	// Validate global pull-based permissions
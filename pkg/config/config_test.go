@@ -19,9 +19,12 @@ package config
 import (
 	"os"
 	"path/filepath"
+	"reflect"
 	"strings"
 	"testing"
 	"time"
+
+	"k8s.io/apimachinery/pkg/labels"
 )
 
 func TestNewDefaultConfig(t *testing.T) {
@@ -48,6 +51,12 @@ func TestNewDefaultConfig(t *testing.T) {
 	if cfg.Defaults.String.AllowedSpecialChars != DefaultAllowedSpecialChars {
 		t.Errorf("expected allowedSpecialChars %q, got %q", DefaultAllowedSpecialChars, cfg.Defaults.String.AllowedSpecialChars)
 	}
+	if cfg.Defaults.ExistingValues != ExistingValuesIgnore {
+		t.Errorf("expected existingValues %q, got %q", ExistingValuesIgnore, cfg.Defaults.ExistingValues)
+	}
+	if cfg.Defaults.FieldMetadata != FieldMetadataAnnotations {
+		t.Errorf("expected fieldMetadata %q, got %q", FieldMetadataAnnotations, cfg.Defaults.FieldMetadata)
+	}
 	// Test rotation defaults
 	if cfg.Rotation.MinInterval.Duration() != DefaultRotationMinInterval {
 		t.Errorf("expected rotation minInterval %v, got %v", DefaultRotationMinInterval, cfg.Rotation.MinInterval.Duration())
@@ -62,6 +71,10 @@ func TestNewDefaultConfig(t *testing.T) {
 	if !cfg.Features.SecretReplicator {
 		t.Error("expected features.secretReplicator to be true")
 	}
+	// Test event defaults
+	if !cfg.Events.OnGeneration || !cfg.Events.OnRotation || !cfg.Events.OnFailure {
+		t.Errorf("expected all events to be enabled, got %+v", cfg.Events)
+	}
 }
 
 func TestLoadConfigFileNotExists(t *testing.T) {
@@ -318,6 +331,56 @@ func TestConfigValidate(t *testing.T) {
 			},
 			wantError: false,
 		},
+		{
+			name: "invalid existingValues",
+			config: &Config{
+				Defaults: DefaultsConfig{
+					Type:           "string",
+					Length:         32,
+					String:         StringOptions{Uppercase: true},
+					ExistingValues: "overwrite",
+				},
+			},
+			wantError: true,
+			errorMsg:  "invalid existingValues",
+		},
+		{
+			name: "valid adopt existingValues",
+			config: &Config{
+				Defaults: DefaultsConfig{
+					Type:           "string",
+					Length:         32,
+					String:         StringOptions{Uppercase: true},
+					ExistingValues: "adopt",
+				},
+			},
+			wantError: false,
+		},
+		{
+			name: "invalid fieldMetadata",
+			config: &Config{
+				Defaults: DefaultsConfig{
+					Type:          "string",
+					Length:        32,
+					String:        StringOptions{Uppercase: true},
+					FieldMetadata: "yaml",
+				},
+			},
+			wantError: true,
+			errorMsg:  "invalid fieldMetadata",
+		},
+		{
+			name: "valid json fieldMetadata",
+			config: &Config{
+				Defaults: DefaultsConfig{
+					Type:          "string",
+					Length:        32,
+					String:        StringOptions{Uppercase: true},
+					FieldMetadata: "json",
+				},
+			},
+			wantError: false,
+		},
 		{
 			name: "valid with only numbers",
 			config: &Config{
@@ -562,6 +625,66 @@ func TestParseDuration(t *testing.T) {
 			input:   "abcd",
 			wantErr: true,
 		},
+		{
+			name:     "30 days",
+			input:    "30d",
+			expected: 30 * 24 * time.Hour,
+		},
+		{
+			name:     "weeks",
+			input:    "2w",
+			expected: 14 * 24 * time.Hour,
+		},
+		{
+			name:     "weeks and days",
+			input:    "1w2d",
+			expected: 9 * 24 * time.Hour,
+		},
+		{
+			name:     "weeks, days and standard units",
+			input:    "1w3d12h30m",
+			expected: 10*24*time.Hour + 12*time.Hour + 30*time.Minute,
+		},
+		{
+			name:     "days and hours",
+			input:    "7d12h",
+			expected: 7*24*time.Hour + 12*time.Hour,
+		},
+		{
+			name:     "negative days",
+			input:    "-1d",
+			expected: -24 * time.Hour,
+		},
+		{
+			name:    "months are not supported",
+			input:   "1mo",
+			wantErr: true,
+		},
+		{
+			name:    "days before weeks",
+			input:   "1d1w",
+			wantErr: true,
+		},
+		{
+			name:    "hours before days",
+			input:   "12h1d",
+			wantErr: true,
+		},
+		{
+			name:    "unit without number",
+			input:   "d",
+			wantErr: true,
+		},
+		{
+			name:    "sign inside duration",
+			input:   "1d-12h",
+			wantErr: true,
+		},
+		{
+			name:    "sign only",
+			input:   "-",
+			wantErr: true,
+		},
 	}
 
 	for _, tt := range tests {
@@ -570,6 +693,8 @@ func TestParseDuration(t *testing.T) {
 			if tt.wantErr {
 				if err == nil {
 					t.Error("expected error, got nil")
+				} else if !strings.Contains(err.Error(), "invalid duration") {
+					t.Errorf("unexpected error message: %v", err)
 				}
 				return
 			}
@@ -676,6 +801,12 @@ defaults:
 	if cfg.Rotation.CreateEvents {
 		t.Error("expected default createEvents to be false")
 	}
+	if cfg.Rotation.Throttle.MaxRotations != 0 {
+		t.Errorf("expected rotation throttle to be disabled by default, got maxRotations %d", cfg.Rotation.Throttle.MaxRotations)
+	}
+	if cfg.Rotation.Throttle.Period.Duration() != DefaultRotationThrottlePeriod {
+		t.Errorf("expected default throttle period %v, got %v", DefaultRotationThrottlePeriod, cfg.Rotation.Throttle.Period.Duration())
+	}
 }
 
 func TestLoadConfigWithFeatureToggles(t *testing.T) {
@@ -814,6 +945,116 @@ func TestConfigValidateNegativeRotationMinInterval(t *testing.T) {
 	}
 }
 
+func TestConfigValidateNegativeRotationMaxCatchUpDelay(t *testing.T) {
+	cfg := NewDefaultConfig()
+	cfg.Rotation.MaxCatchUpDelay = Duration(-time.Minute)
+
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "rotation maxCatchUpDelay must be non-negative") {
+		t.Errorf("expected error for negative rotation maxCatchUpDelay, got %v", err)
+	}
+}
+
+func TestRotationMinIntervalByType(t *testing.T) {
+	cfg := NewDefaultConfig()
+	cfg.Rotation.MinIntervalByType = map[string]Duration{TypeRSA: Duration(24 * time.Hour)}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got, typeSpecific := cfg.Rotation.MinIntervalFor(TypeRSA); got != 24*time.Hour || !typeSpecific {
+		t.Errorf("expected the rsa minimum 24h, got %s (type-specific: %v)", got, typeSpecific)
+	}
+	if got, typeSpecific := cfg.Rotation.MinIntervalFor(DefaultType); got != DefaultRotationMinInterval || typeSpecific {
+		t.Errorf("expected the global minimum %s, got %s (type-specific: %v)", DefaultRotationMinInterval, got, typeSpecific)
+	}
+
+	cfg.Rotation.MinIntervalByType = map[string]Duration{"rsa4096": Duration(time.Hour)}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), `invalid rotation minIntervalByType type "rsa4096"`) {
+		t.Errorf("expected error for unknown type, got %v", err)
+	}
+	cfg.Rotation.MinIntervalByType = map[string]Duration{TypeRSA: Duration(-time.Hour)}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "rotation minIntervalByType rsa must be non-negative") {
+		t.Errorf("expected error for negative minimum, got %v", err)
+	}
+}
+
+func TestRotationNamespaceDefaults(t *testing.T) {
+	cfg := NewDefaultConfig()
+	cfg.Rotation.NamespaceDefaults = map[string]string{"team-a": "7d", "team-b": "12h"}
+	if err := cfg.Validate(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if got := cfg.Rotation.NamespaceDefault("team-a"); got != 7*24*time.Hour {
+		t.Errorf("expected the team-a default 168h, got %s", got)
+	}
+	if got := cfg.Rotation.NamespaceDefault("default"); got != 0 {
+		t.Errorf("expected no default for namespace default, got %s", got)
+	}
+
+	cfg.Rotation.NamespaceDefaults = map[string]string{"team-a": "weekly"}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "invalid rotation namespaceDefaults interval for namespace team-a") {
+		t.Errorf("expected error for unparsable interval, got %v", err)
+	}
+	cfg.Rotation.NamespaceDefaults = map[string]string{"team-a": "1m"}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "rotation namespaceDefaults interval 1m0s for namespace team-a is below minimum") {
+		t.Errorf("expected error for interval below minInterval, got %v", err)
+	}
+	cfg.Rotation.NamespaceDefaults = map[string]string{"team-a": "7d"}
+	cfg.Rotation.MinIntervalByType = map[string]Duration{TypeRSA: Duration(30 * 24 * time.Hour)}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "rotation namespaceDefaults interval 168h0m0s for namespace team-a is below minimum 720h0m0s of minIntervalByType rsa") {
+		t.Errorf("expected error for interval below minIntervalByType, got %v", err)
+	}
+	cfg.Rotation.MinIntervalByType = nil
+	cfg.Rotation.NamespaceDefaults = map[string]string{"Team_A": "7d"}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), `invalid rotation namespaceDefaults namespace "Team_A"`) {
+		t.Errorf("expected error for invalid namespace, got %v", err)
+	}
+}
+
+func TestLoadConfigRotationNamespaceDefaults(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	content := `rotation:
+  namespaceDefaults:
+    team-a: 30d
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := cfg.Rotation.NamespaceDefault("team-a"); got != 30*24*time.Hour {
+		t.Errorf("expected the team-a default 720h, got %s", got)
+	}
+}
+
+func TestLoadConfigEvents(t *testing.T) {
+	tmpDir := t.TempDir()
+	configPath := filepath.Join(tmpDir, "config.yaml")
+	content := `events:
+  onGeneration: false
+`
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Events.OnGeneration {
+		t.Error("expected events.onGeneration to be false")
+	}
+	if !cfg.Events.OnRotation || !cfg.Events.OnFailure {
+		t.Errorf("expected the events not set to keep their defaults, got %+v", cfg.Events)
+	}
+}
+
 func TestDurationUnmarshalYAMLParseError(t *testing.T) {
 	tmpDir := t.TempDir()
 	configPath := filepath.Join(tmpDir, "config.yaml")
@@ -1006,3 +1247,641 @@ rotation:
 		t.Errorf("expected rotation minInterval %v, got %v", DefaultRotationMinInterval, cfg.Rotation.MinInterval.Duration())
 	}
 }
+
+func TestConfigValidateForceRotationTriggers(t *testing.T) {
+	tests := []struct {
+		name     string
+		trigger  ForceRotationTrigger
+		errorMsg string
+	}{
+		{
+			name:    "valid trigger",
+			trigger: ForceRotationTrigger{Selector: "team=payments,tier!=dev", Token: "incident-42"},
+		},
+		{
+			name:     "empty selector",
+			trigger:  ForceRotationTrigger{Selector: " ", Token: "incident-42"},
+			errorMsg: "selector must not be empty",
+		},
+		{
+			name:     "invalid selector",
+			trigger:  ForceRotationTrigger{Selector: "team in payments", Token: "incident-42"},
+			errorMsg: "invalid selector",
+		},
+		{
+			name:     "empty token",
+			trigger:  ForceRotationTrigger{Selector: "team=payments"},
+			errorMsg: "token must not be empty",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultConfig()
+			cfg.Rotation.ForceRotationTriggers = []ForceRotationTrigger{tt.trigger}
+			err := cfg.Validate()
+			if tt.errorMsg == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil {
+				t.Fatalf("expected error containing %q, got nil", tt.errorMsg)
+			}
+			if !strings.Contains(err.Error(), "forceRotationTriggers[0]") || !strings.Contains(err.Error(), tt.errorMsg) {
+				t.Errorf("expected error containing %q, got %v", tt.errorMsg, err)
+			}
+		})
+	}
+}
+
+func TestForceRotationTriggerMatches(t *testing.T) {
+	trigger := ForceRotationTrigger{Selector: "team=payments,tier!=dev", Token: "incident-42"}
+
+	tests := []struct {
+		labels map[string]string
+		want   bool
+	}{
+		{labels: map[string]string{"team": "payments", "tier": "prod"}, want: true},
+		{labels: map[string]string{"team": "payments"}, want: true},
+		{labels: map[string]string{"team": "payments", "tier": "dev"}, want: false},
+		{labels: map[string]string{"team": "search"}, want: false},
+		{labels: nil, want: false},
+	}
+
+	for _, tt := range tests {
+		if got := trigger.Matches(tt.labels); got != tt.want {
+			t.Errorf("Matches(%v) = %v, want %v", tt.labels, got, tt.want)
+		}
+	}
+}
+
+func TestLoadConfigMetrics(t *testing.T) {
+	tests := []struct {
+		name         string
+		content      string
+		wantInterval time.Duration
+		wantFloor    int
+	}{
+		{
+			name:         "defaults",
+			content:      "defaults:\n  length: 32\n",
+			wantInterval: DefaultMetricsInventoryInterval,
+			wantFloor:    DefaultEntropyFloorBits,
+		},
+		{
+			name:         "custom values",
+			content:      "metrics:\n  inventoryInterval: 1h\n  entropyFloorBits: 96\n",
+			wantInterval: time.Hour,
+			wantFloor:    96,
+		},
+		{
+			name:         "disabled",
+			content:      "metrics:\n  inventoryInterval: 0s\n",
+			wantInterval: 0,
+			wantFloor:    DefaultEntropyFloorBits,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			configPath := filepath.Join(t.TempDir(), "config.yaml")
+			if err := os.WriteFile(configPath, []byte(tt.content), 0644); err != nil {
+				t.Fatalf("failed to write config file: %v", err)
+			}
+
+			cfg, err := LoadConfig(configPath)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if cfg.Metrics.InventoryInterval.Duration() != tt.wantInterval {
+				t.Errorf("expected inventoryInterval %v, got %v", tt.wantInterval, cfg.Metrics.InventoryInterval.Duration())
+			}
+			if cfg.Metrics.EntropyFloorBits != tt.wantFloor {
+				t.Errorf("expected entropyFloorBits %d, got %d", tt.wantFloor, cfg.Metrics.EntropyFloorBits)
+			}
+		})
+	}
+}
+
+func TestConfigValidateMinEntropyBits(t *testing.T) {
+	cfg := NewDefaultConfig()
+	if cfg.Defaults.MinEntropyBits != 0 {
+		t.Errorf("expected minimum entropy check to be disabled by default, got %d", cfg.Defaults.MinEntropyBits)
+	}
+
+	cfg.Defaults.MinEntropyBits = -1
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "minEntropyBits must be non-negative") {
+		t.Errorf("expected minEntropyBits error, got %v", err)
+	}
+}
+
+func TestConfigValidateRotationThrottle(t *testing.T) {
+	cfg := NewDefaultConfig()
+	cfg.Rotation.Throttle.MaxRotations = -1
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "maxRotations must be non-negative") {
+		t.Errorf("expected maxRotations error, got %v", err)
+	}
+
+	cfg = NewDefaultConfig()
+	cfg.Rotation.Throttle.MaxRotations = 10
+	cfg.Rotation.Throttle.Period = 0
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "throttle period must be positive") {
+		t.Errorf("expected period error, got %v", err)
+	}
+
+	// The period doesn't matter while the throttle is disabled
+	cfg.Rotation.Throttle.MaxRotations = 0
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestIsValidType(t *testing.T) {
+	for _, genType := range []string{DefaultType, TypeBytes, TypeECDSA, TypeSSHEd25519, TypePassphrase, TypeJWT, TypeBcrypt} {
+		if !IsValidType(genType) {
+			t.Errorf("expected %q to be a valid type", genType)
+		}
+	}
+	for _, genType := range []string{"", "String", "password", "rsa4096"} {
+		if IsValidType(genType) {
+			t.Errorf("expected %q to be an invalid type", genType)
+		}
+	}
+}
+
+func TestParseJitter(t *testing.T) {
+	tests := []struct {
+		input    string
+		expected Jitter
+		bound    time.Duration
+		wantErr  bool
+	}{
+		{input: "", expected: Jitter{}, bound: 0},
+		{input: "30m", expected: Jitter{Max: 30 * time.Minute}, bound: 30 * time.Minute},
+		{input: "1d", expected: Jitter{Max: 24 * time.Hour}, bound: 24 * time.Hour},
+		{input: "10%", expected: Jitter{Percent: 10}, bound: 144 * time.Minute},
+		{input: "2.5%", expected: Jitter{Percent: 2.5}, bound: 36 * time.Minute},
+		{input: "ten%", wantErr: true},
+		{input: "soon", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.input, func(t *testing.T) {
+			jitter, err := ParseJitter(tt.input)
+			if tt.wantErr {
+				if err == nil {
+					t.Errorf("expected error for %q", tt.input)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if jitter != tt.expected {
+				t.Errorf("expected %+v, got %+v", tt.expected, jitter)
+			}
+			// Bound of a 24h interval
+			if bound := jitter.Bound(24 * time.Hour); bound != tt.bound {
+				t.Errorf("expected bound %s, got %s", tt.bound, bound)
+			}
+		})
+	}
+}
+
+func TestLoadConfigRotationJitter(t *testing.T) {
+	for jitter, expected := range map[string]Jitter{"10%": {Percent: 10}, "30m": {Max: 30 * time.Minute}} {
+		configPath := filepath.Join(t.TempDir(), "config.yaml")
+		configContent := "rotation:\n  jitter: \"" + jitter + "\"\n"
+		if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+			t.Fatalf("failed to write config file: %v", err)
+		}
+
+		cfg, err := LoadConfig(configPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.Rotation.Jitter != expected {
+			t.Errorf("expected jitter %+v, got %+v", expected, cfg.Rotation.Jitter)
+		}
+	}
+}
+
+func TestConfigValidateRotationJitter(t *testing.T) {
+	tests := []struct {
+		jitter  Jitter
+		wantErr string
+	}{
+		{jitter: Jitter{}},
+		{jitter: Jitter{Max: time.Hour}},
+		{jitter: Jitter{Percent: 100}},
+		{jitter: Jitter{Max: -time.Hour}, wantErr: "jitter must be non-negative"},
+		{jitter: Jitter{Percent: -5}, wantErr: "jitter percentage must be between 0% and 100%"},
+		{jitter: Jitter{Percent: 150}, wantErr: "jitter percentage must be between 0% and 100%"},
+	}
+
+	for _, tt := range tests {
+		cfg := NewDefaultConfig()
+		cfg.Rotation.Jitter = tt.jitter
+		err := cfg.Validate()
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("jitter %s: unexpected error: %v", tt.jitter, err)
+			}
+			continue
+		}
+		if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("jitter %s: expected error containing %q, got %v", tt.jitter, tt.wantErr, err)
+		}
+	}
+}
+
+func TestConfigValidateRotationNotifications(t *testing.T) {
+	token := &SecretKeyReference{Namespace: "operator", Name: "webhook-token", Key: "token"}
+	tests := []struct {
+		name    string
+		notify  bool
+		url     string
+		token   *SecretKeyReference
+		wantErr string
+	}{
+		{name: "disabled", notify: false, url: ""},
+		{name: "https", notify: true, url: "https://hooks.example.com/rotated", token: token},
+		{name: "http", notify: true, url: "http://reloader.tools.svc:8080/reload"},
+		{name: "missing URL", notify: true, url: "", wantErr: "webhookURL must be an http or https URL"},
+		{name: "unsupported scheme", notify: true, url: "ftp://hooks.example.com", wantErr: "webhookURL must be an http or https URL"},
+		{name: "incomplete token reference", notify: true, url: "https://hooks.example.com", token: &SecretKeyReference{Name: "webhook-token"}, wantErr: "webhookTokenSecret"},
+	}
+
+	for _, tt := range tests {
+		cfg := NewDefaultConfig()
+		cfg.Rotation.NotifyOnRotation = tt.notify
+		cfg.Rotation.WebhookURL = tt.url
+		cfg.Rotation.WebhookTokenSecret = tt.token
+		err := cfg.Validate()
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", tt.name, err)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.wantErr, err)
+		}
+	}
+}
+
+func TestConfigValidateSlackNotifications(t *testing.T) {
+	tests := []struct {
+		name    string
+		url     string
+		reasons []string
+		wantErr string
+	}{
+		{name: "disabled", url: ""},
+		{name: "default reasons", url: "https://hooks.slack.com/services/T000/B000/XXXX"},
+		{name: "custom reasons", url: "https://hooks.slack.com/services/T000/B000/XXXX", reasons: []string{"CompromisedRotated"}},
+		{name: "unsupported scheme", url: "hooks.slack.com/services/T000", wantErr: "webhookURL must be an http or https URL"},
+		{name: "no reasons", url: "https://hooks.slack.com/services/T000/B000/XXXX", reasons: []string{}, wantErr: "reasons must not be empty"},
+		{name: "unknown reason", url: "https://hooks.slack.com/services/T000/B000/XXXX", reasons: []string{"GenerationSucceeded"}, wantErr: `invalid notifications slack reason "GenerationSucceeded"`},
+	}
+
+	for _, tt := range tests {
+		cfg := NewDefaultConfig()
+		cfg.Notifications.Slack.WebhookURL = tt.url
+		if tt.reasons != nil {
+			cfg.Notifications.Slack.Reasons = tt.reasons
+		}
+		err := cfg.Validate()
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("%s: unexpected error: %v", tt.name, err)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("%s: expected error containing %q, got %v", tt.name, tt.wantErr, err)
+		} else if strings.Contains(err.Error(), "XXXX") {
+			t.Errorf("%s: expected the webhook URL not to be part of the error, got %v", tt.name, err)
+		}
+	}
+
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	configContent := `
+notifications:
+  slack:
+    webhookURL: https://hooks.slack.com/services/T000/B000/XXXX
+`
+	if err := os.WriteFile(configPath, []byte(configContent), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !reflect.DeepEqual(cfg.Notifications.Slack.Reasons, DefaultSlackNotificationReasons) {
+		t.Errorf("expected default reasons %v, got %v", DefaultSlackNotificationReasons, cfg.Notifications.Slack.Reasons)
+	}
+}
+
+func TestConfigValidateAnnotationPrefix(t *testing.T) {
+	tests := []struct {
+		prefix  string
+		wantErr string
+	}{
+		{prefix: DefaultAnnotationPrefix},
+		{prefix: "secrets.acme.internal/"},
+		{prefix: "", wantErr: ""},
+		{prefix: "secrets.acme.internal", wantErr: `must end with "/"`},
+		{prefix: "Secrets_Acme/", wantErr: "invalid annotationPrefix"},
+		{prefix: "acme/secrets/", wantErr: "invalid annotationPrefix"},
+	}
+
+	for _, tt := range tests {
+		cfg := NewDefaultConfig()
+		cfg.AnnotationPrefix = tt.prefix
+		err := cfg.Validate()
+		if tt.wantErr == "" {
+			if err != nil {
+				t.Errorf("prefix %q: unexpected error: %v", tt.prefix, err)
+			}
+		} else if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+			t.Errorf("prefix %q: expected error containing %q, got %v", tt.prefix, tt.wantErr, err)
+		}
+	}
+}
+
+func TestConfigLabelSelector(t *testing.T) {
+	tests := []struct {
+		selector string
+		labels   map[string]string
+		want     bool
+		wantErr  string
+	}{
+		{selector: "", labels: nil, want: true},
+		{selector: "managed-by=iso", labels: map[string]string{"managed-by": "iso"}, want: true},
+		{selector: "managed-by=iso", labels: map[string]string{"app": "web"}, want: false},
+		{selector: "tier in (backend, db),!legacy", labels: map[string]string{"tier": "db"}, want: true},
+		{selector: "tier in (backend, db),!legacy", labels: map[string]string{"tier": "db", "legacy": "true"}, want: false},
+		{selector: "tier in backend", wantErr: "invalid labelSelector"},
+	}
+
+	for _, tt := range tests {
+		cfg := NewDefaultConfig()
+		cfg.LabelSelector = tt.selector
+		err := cfg.Validate()
+		if tt.wantErr != "" {
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("selector %q: expected error containing %q, got %v", tt.selector, tt.wantErr, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Errorf("selector %q: unexpected error: %v", tt.selector, err)
+		}
+		if got := cfg.Selector().Matches(labels.Set(tt.labels)); got != tt.want {
+			t.Errorf("selector %q with labels %v: expected %v, got %v", tt.selector, tt.labels, tt.want, got)
+		}
+	}
+}
+
+func TestNamespacesConfigAllows(t *testing.T) {
+	tests := []struct {
+		name      string
+		config    NamespacesConfig
+		namespace string
+		want      bool
+	}{
+		{name: "no filters", namespace: "kube-system", want: true},
+		{name: "included", config: NamespacesConfig{Include: []string{"team-a", "team-b"}}, namespace: "team-b", want: true},
+		{name: "not included", config: NamespacesConfig{Include: []string{"team-a"}}, namespace: "team-b", want: false},
+		{name: "included by wildcard", config: NamespacesConfig{Include: []string{"team-*"}}, namespace: "team-c", want: true},
+		{name: "wildcard includes all", config: NamespacesConfig{Include: []string{"*"}}, namespace: "default", want: true},
+		{name: "excluded", config: NamespacesConfig{Exclude: []string{"kube-system"}}, namespace: "kube-system", want: false},
+		{name: "not excluded", config: NamespacesConfig{Exclude: []string{"kube-system"}}, namespace: "default", want: true},
+		{name: "exclude wins over include", config: NamespacesConfig{Include: []string{"*"}, Exclude: []string{"kube-*"}}, namespace: "kube-public", want: false},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := tt.config.Allows(tt.namespace); got != tt.want {
+				t.Errorf("Allows(%q) = %v, want %v", tt.namespace, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestConfigValidateNamespaces(t *testing.T) {
+	cfg := NewDefaultConfig()
+	cfg.Namespaces.Include = []string{"team-["}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "invalid glob pattern") {
+		t.Errorf("expected glob pattern error, got %v", err)
+	}
+
+	cfg = NewDefaultConfig()
+	cfg.Namespaces.Exclude = []string{""}
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "exclude[0] must not be empty") {
+		t.Errorf("expected empty pattern error, got %v", err)
+	}
+}
+
+func TestConfigValidateFailureBackoff(t *testing.T) {
+	cfg := NewDefaultConfig()
+	cfg.FailureBackoff.MaxFailures = -1
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "maxFailures must be non-negative") {
+		t.Errorf("expected maxFailures error, got %v", err)
+	}
+
+	cfg = NewDefaultConfig()
+	cfg.FailureBackoff.InitialDelay = 0
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "initialDelay must be positive") {
+		t.Errorf("expected initialDelay error, got %v", err)
+	}
+
+	cfg = NewDefaultConfig()
+	cfg.FailureBackoff.MaxDelay = Duration(time.Second)
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "maxDelay must not be less than initialDelay") {
+		t.Errorf("expected maxDelay error, got %v", err)
+	}
+
+	// The delays don't matter while retries are disabled
+	cfg.FailureBackoff.MaxFailures = 0
+	if err := cfg.Validate(); err != nil {
+		t.Errorf("unexpected error: %v", err)
+	}
+}
+
+func TestConfigValidateMetrics(t *testing.T) {
+	cfg := NewDefaultConfig()
+	cfg.Metrics.InventoryInterval = Duration(-time.Minute)
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "inventoryInterval must be non-negative") {
+		t.Errorf("expected inventoryInterval error, got %v", err)
+	}
+
+	cfg = NewDefaultConfig()
+	cfg.Metrics.EntropyFloorBits = -1
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "entropyFloorBits must be non-negative") {
+		t.Errorf("expected entropyFloorBits error, got %v", err)
+	}
+}
+
+func TestLoadConfigMaxConcurrentReconciles(t *testing.T) {
+	for content, expected := range map[string]int{"dryRun: false\n": DefaultMaxConcurrentReconciles, "maxConcurrentReconciles: 8\n": 8} {
+		configPath := filepath.Join(t.TempDir(), "config.yaml")
+		if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write config file: %v", err)
+		}
+
+		cfg, err := LoadConfig(configPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.MaxConcurrentReconciles != expected {
+			t.Errorf("expected maxConcurrentReconciles %d, got %d", expected, cfg.MaxConcurrentReconciles)
+		}
+	}
+
+	cfg := NewDefaultConfig()
+	cfg.MaxConcurrentReconciles = -1
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "maxConcurrentReconciles must not be negative") {
+		t.Errorf("expected maxConcurrentReconciles error, got %v", err)
+	}
+}
+
+func TestLoadConfigRotationHistorySize(t *testing.T) {
+	for content, expected := range map[string]int{"dryRun: false\n": DefaultRotationHistorySize, "rotation:\n  historySize: 10\n": 10} {
+		configPath := filepath.Join(t.TempDir(), "config.yaml")
+		if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+			t.Fatalf("failed to write config file: %v", err)
+		}
+
+		cfg, err := LoadConfig(configPath)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if cfg.Rotation.HistorySize != expected {
+			t.Errorf("expected rotation historySize %d, got %d", expected, cfg.Rotation.HistorySize)
+		}
+	}
+
+	cfg := NewDefaultConfig()
+	cfg.Rotation.HistorySize = -1
+	if err := cfg.Validate(); err == nil || !strings.Contains(err.Error(), "historySize must be non-negative") {
+		t.Errorf("expected historySize error, got %v", err)
+	}
+}
+
+func TestLoadConfigLeaderElection(t *testing.T) {
+	configPath := filepath.Join(t.TempDir(), "config.yaml")
+	content := "leaderElection:\n  enabled: true\n  leaseDuration: 30s\n  resourceName: iso-leader\n"
+	if err := os.WriteFile(configPath, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+
+	cfg, err := LoadConfig(configPath)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	le := cfg.LeaderElection
+	if !le.Enabled || le.LeaseDuration.Duration() != 30*time.Second || le.ResourceName != "iso-leader" {
+		t.Errorf("expected configured leader election, got %+v", le)
+	}
+	// Unset durations keep their defaults
+	if le.RenewDeadline.Duration() != DefaultLeaderElectionRenewDeadline || le.RetryPeriod.Duration() != DefaultLeaderElectionRetryPeriod {
+		t.Errorf("expected default renewDeadline and retryPeriod, got %s and %s", le.RenewDeadline.Duration(), le.RetryPeriod.Duration())
+	}
+}
+
+func TestConfigValidateLeaderElection(t *testing.T) {
+	tests := []struct {
+		name     string
+		modify   func(*LeaderElectionConfig)
+		errorMsg string
+	}{
+		{name: "defaults", modify: func(*LeaderElectionConfig) {}},
+		{name: "zero lease duration", modify: func(c *LeaderElectionConfig) { c.LeaseDuration = 0 }, errorMsg: "leaseDuration must be positive"},
+		{name: "negative retry period", modify: func(c *LeaderElectionConfig) { c.RetryPeriod = Duration(-time.Second) }, errorMsg: "retryPeriod must be positive"},
+		{name: "renew deadline not less than lease", modify: func(c *LeaderElectionConfig) { c.RenewDeadline = c.LeaseDuration }, errorMsg: "renewDeadline 15s must be less than leaseDuration 15s"},
+		{name: "retry period not less than renew deadline", modify: func(c *LeaderElectionConfig) { c.RetryPeriod = Duration(time.Minute) }, errorMsg: "retryPeriod 1m0s must be less than renewDeadline 10s"},
+		{name: "invalid resource name", modify: func(c *LeaderElectionConfig) { c.ResourceName = "Not_A_Name" }, errorMsg: `invalid resourceName "Not_A_Name"`},
+		{name: "empty resource name", modify: func(c *LeaderElectionConfig) { c.ResourceName = "" }, errorMsg: "invalid resourceName"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := NewDefaultConfig()
+			cfg.LeaderElection.Enabled = true
+			tt.modify(&cfg.LeaderElection)
+			err := cfg.Validate()
+			if tt.errorMsg == "" {
+				if err != nil {
+					t.Errorf("unexpected error: %v", err)
+				}
+				return
+			}
+			if err == nil || !strings.Contains(err.Error(), "leaderElection "+tt.errorMsg) {
+				t.Errorf("expected error containing %q, got %v", tt.errorMsg, err)
+			}
+
+			// The settings are only checked with leader election enabled
+			cfg.LeaderElection.Enabled = false
+			if err := cfg.Validate(); err != nil {
+				t.Errorf("unexpected error with leader election disabled: %v", err)
+			}
+		})
+	}
+}
+
+func TestIntegrityConfigLoadKey(t *testing.T) {
+	key := strings.Repeat("k", MinIntegrityKeyLength)
+	keyFile := filepath.Join(t.TempDir(), "integrity-key")
+	if err := os.WriteFile(keyFile, []byte(key+"\n"), 0o600); err != nil {
+		t.Fatalf("failed to write key file: %v", err)
+	}
+	shortKeyFile := filepath.Join(t.TempDir(), "short-key")
+	if err := os.WriteFile(shortKeyFile, []byte("too-short"), 0o600); err != nil {
+		t.Fatalf("failed to write key file: %v", err)
+	}
+	t.Setenv("ISO_TEST_INTEGRITY_KEY", key)
+
+	tests := []struct {
+		name     string
+		config   IntegrityConfig
+		wantKey  string
+		errorMsg string
+	}{
+		{name: "disabled", config: IntegrityConfig{}},
+		{name: "key file", config: IntegrityConfig{KeyFile: keyFile}, wantKey: key},
+		{name: "key env", config: IntegrityConfig{KeyEnv: "ISO_TEST_INTEGRITY_KEY"}, wantKey: key},
+		{name: "missing file", config: IntegrityConfig{KeyFile: filepath.Join(t.TempDir(), "missing")}, errorMsg: "failed to read integrity key file"},
+		{name: "short key", config: IntegrityConfig{KeyFile: shortKeyFile}, errorMsg: "at least 32 bytes, got 9"},
+		{name: "unset env", config: IntegrityConfig{KeyEnv: "ISO_TEST_INTEGRITY_KEY_UNSET"}, errorMsg: "at least 32 bytes, got 0"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.config.LoadKey()
+			if tt.errorMsg != "" {
+				if err == nil || !strings.Contains(err.Error(), tt.errorMsg) {
+					t.Fatalf("expected error containing %q, got %v", tt.errorMsg, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(got) != tt.wantKey {
+				t.Errorf("expected key %q, got %q", tt.wantKey, got)
+			}
+			if tt.config.Enabled() != (tt.wantKey != "") {
+				t.Errorf("expected Enabled() = %v", tt.wantKey != "")
+			}
+		})
+	}
+}
+
+func TestConfigValidateIntegrity(t *testing.T) {
+	cfg := NewDefaultConfig()
+	cfg.Integrity = IntegrityConfig{KeyFile: "/etc/iso/key", KeyEnv: "ISO_KEY"}
+	err := cfg.Validate()
+	if err == nil || !strings.Contains(err.Error(), "mutually exclusive") {
+		t.Errorf("expected mutually exclusive error, got %v", err)
+	}
+}
@@ -0,0 +1,91 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"flag"
+	"fmt"
+)
+
+// Command-line flags overriding configuration defaults
+const (
+	FlagDefaultType          = "default-type"
+	FlagDefaultLength        = "default-length"
+	FlagRotationMinInterval  = "rotation-min-interval"
+	FlagRotationCreateEvents = "rotation-create-events"
+)
+
+// Flags holds command-line overrides of configuration defaults, so simple deployments
+// can be tuned without a configuration file. Only flags set on the command line are
+// applied, on top of the configuration file and the built-in defaults.
+type Flags struct {
+	fs                   *flag.FlagSet
+	defaultType          string
+	defaultLength        int
+	rotationMinInterval  string
+	rotationCreateEvents bool
+}
+
+// BindFlags registers the override flags on fs
+func (f *Flags) BindFlags(fs *flag.FlagSet) {
+	f.fs = fs
+	fs.StringVar(&f.defaultType, FlagDefaultType, "",
+		"Default generation type (overrides defaults.type of the configuration file).")
+	fs.IntVar(&f.defaultLength, FlagDefaultLength, 0,
+		"Default length of generated values (overrides defaults.length of the configuration file).")
+	fs.StringVar(&f.rotationMinInterval, FlagRotationMinInterval, "",
+		"Minimum allowed rotation interval, e.g. 5m or 1d (overrides rotation.minInterval of the configuration file).")
+	fs.BoolVar(&f.rotationCreateEvents, FlagRotationCreateEvents, false,
+		"Emit events on rotation (overrides rotation.createEvents of the configuration file).")
+}
+
+// Apply overrides config with the flags set on the command line and validates the result
+func (f *Flags) Apply(config *Config) error {
+	if f.fs == nil {
+		return nil
+	}
+	set := make(map[string]bool)
+	f.fs.Visit(func(fl *flag.Flag) {
+		set[fl.Name] = true
+	})
+
+	if set[FlagDefaultType] {
+		config.Defaults.Type = f.defaultType
+	}
+	if set[FlagDefaultLength] {
+		if f.defaultLength <= 0 {
+			return fmt.Errorf("--%s must be positive, got %d", FlagDefaultLength, f.defaultLength)
+		}
+		// Only string and bytes values have a length
+		if config.Defaults.Type != DefaultType && config.Defaults.Type != TypeBytes {
+			return fmt.Errorf("--%s has no effect with default type %s", FlagDefaultLength, config.Defaults.Type)
+		}
+		config.Defaults.Length = f.defaultLength
+	}
+	if set[FlagRotationMinInterval] {
+		minInterval, err := ParseDuration(f.rotationMinInterval)
+		if err != nil {
+			return fmt.Errorf("invalid --%s: %w", FlagRotationMinInterval, err)
+		}
+		config.Rotation.MinInterval = Duration(minInterval)
+	}
+	if set[FlagRotationCreateEvents] {
+		config.Rotation.CreateEvents = f.rotationCreateEvents
+	}
+
+	return config.Validate()
+}
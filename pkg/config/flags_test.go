@@ -0,0 +1,147 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"flag"
+	"strings"
+	"testing"
+	"time"
+)
+
+// parseFlags binds Flags to a new FlagSet and parses args
+func parseFlags(t *testing.T, args ...string) *Flags {
+	t.Helper()
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	flags := &Flags{}
+	flags.BindFlags(fs)
+	if err := fs.Parse(args); err != nil {
+		t.Fatalf("failed to parse flags: %v", err)
+	}
+	return flags
+}
+
+func TestFlagsOverrideDefaults(t *testing.T) {
+	flags := parseFlags(t,
+		"--default-type=bytes",
+		"--default-length=64",
+		"--rotation-min-interval=1d",
+		"--rotation-create-events",
+	)
+
+	cfg := NewDefaultConfig()
+	if err := flags.Apply(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Defaults.Type != TypeBytes {
+		t.Errorf("expected type %q, got %q", TypeBytes, cfg.Defaults.Type)
+	}
+	if cfg.Defaults.Length != 64 {
+		t.Errorf("expected length 64, got %d", cfg.Defaults.Length)
+	}
+	if cfg.Rotation.MinInterval.Duration() != 24*time.Hour {
+		t.Errorf("expected minInterval 24h, got %s", cfg.Rotation.MinInterval.Duration())
+	}
+	if !cfg.Rotation.CreateEvents {
+		t.Error("expected createEvents to be true")
+	}
+}
+
+func TestFlagsUnsetKeepConfig(t *testing.T) {
+	flags := parseFlags(t)
+
+	cfg := NewDefaultConfig()
+	cfg.Defaults.Length = 48
+	cfg.Rotation.CreateEvents = true
+	if err := flags.Apply(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Defaults.Type != DefaultType {
+		t.Errorf("expected type %q, got %q", DefaultType, cfg.Defaults.Type)
+	}
+	if cfg.Defaults.Length != 48 {
+		t.Errorf("expected length 48 to be kept, got %d", cfg.Defaults.Length)
+	}
+	if cfg.Rotation.MinInterval.Duration() != DefaultRotationMinInterval {
+		t.Errorf("expected minInterval %s, got %s", DefaultRotationMinInterval, cfg.Rotation.MinInterval.Duration())
+	}
+	if !cfg.Rotation.CreateEvents {
+		t.Error("expected createEvents to be kept")
+	}
+}
+
+func TestFlagsExplicitFalse(t *testing.T) {
+	flags := parseFlags(t, "--rotation-create-events=false")
+
+	cfg := NewDefaultConfig()
+	cfg.Rotation.CreateEvents = true
+	if err := flags.Apply(cfg); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Rotation.CreateEvents {
+		t.Error("expected createEvents to be overridden to false")
+	}
+}
+
+func TestFlagsApplyErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		errorMsg string
+	}{
+		{
+			name:     "invalid type",
+			args:     []string{"--default-type=uuid"},
+			errorMsg: "invalid default type",
+		},
+		{
+			name:     "zero length",
+			args:     []string{"--default-length=0"},
+			errorMsg: "--default-length must be positive",
+		},
+		{
+			name:     "length with keypair type",
+			args:     []string{"--default-type=ed25519", "--default-length=16"},
+			errorMsg: "has no effect with default type ed25519",
+		},
+		{
+			name:     "invalid min interval",
+			args:     []string{"--rotation-min-interval=soon"},
+			errorMsg: "invalid --rotation-min-interval",
+		},
+		{
+			name:     "negative min interval",
+			args:     []string{"--rotation-min-interval=-5m"},
+			errorMsg: "rotation minInterval must be non-negative",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			flags := parseFlags(t, tt.args...)
+			err := flags.Apply(NewDefaultConfig())
+			if err == nil {
+				t.Fatal("expected error, got nil")
+			}
+			if !strings.Contains(err.Error(), tt.errorMsg) {
+				t.Errorf("expected error containing %q, got %q", tt.errorMsg, err.Error())
+			}
+		})
+	}
+}
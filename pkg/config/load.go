@@ -0,0 +1,149 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"unicode"
+
+	"gopkg.in/yaml.v3"
+)
+
+// EnvPrefix is the prefix of the environment variables overriding configuration settings.
+// The rest of a variable's name is the setting's YAML path in upper snake case, e.g.
+// ISO_ROTATION_MIN_INTERVAL for rotation.minInterval.
+const EnvPrefix = "ISO_"
+
+// LoadFromFile loads the configuration from the YAML file at path on top of the defaults,
+// applies the environment overrides (see LoadFromEnv), and validates the result. Unlike
+// LoadConfig, the file must exist and unknown keys are rejected, so typos don't go unnoticed.
+func LoadFromFile(path string) (*Config, error) {
+	data, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, fmt.Errorf("failed to read config file: %w", err)
+	}
+	return ParseStrict(data)
+}
+
+// ParseStrict parses the content of a configuration file like LoadFromFile does: on top of the
+// defaults, rejecting unknown keys, with the environment overrides applied and validated.
+func ParseStrict(data []byte) (*Config, error) {
+	config := NewDefaultConfig()
+	if err := decodeStrict(data, config); err != nil {
+		return nil, fmt.Errorf("failed to parse config file: %w", err)
+	}
+	return config.finishLoad()
+}
+
+// LoadFromEnv returns the defaults overridden by the environment and validates the result.
+// Each setting is read from EnvPrefix followed by its YAML path in upper snake case. Values are
+// YAML, so lists and objects like rotation.maintenanceWindows.windows can be given in flow
+// style, e.g. ISO_ROTATION_MAINTENANCE_WINDOWS_WINDOWS='[{name: nightly, days: [sunday], ...}]'.
+// Empty variables are ignored.
+func LoadFromEnv() (*Config, error) {
+	return NewDefaultConfig().finishLoad()
+}
+
+// finishLoad applies the environment overrides and the defaults of settings left empty,
+// and validates the result
+func (c *Config) finishLoad() (*Config, error) {
+	if err := applyEnv(reflect.ValueOf(c).Elem(), EnvPrefix); err != nil {
+		return nil, err
+	}
+	c.applyDefaults()
+	if err := c.Validate(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// applyEnv overrides the YAML settings of the struct v with the environment variables named
+// prefix plus their key. Nested structs are descended into, unless they unmarshal themselves.
+func applyEnv(v reflect.Value, prefix string) error {
+	t := v.Type()
+	for i := range t.NumField() {
+		key, _, _ := strings.Cut(t.Field(i).Tag.Get("yaml"), ",")
+		if key == "" || key == "-" {
+			continue
+		}
+		name := prefix + envName(key)
+		field := v.Field(i)
+		if field.Kind() == reflect.Struct && !unmarshalsYAML(field) {
+			if err := applyEnv(field, name+"_"); err != nil {
+				return err
+			}
+			continue
+		}
+
+		value, ok := os.LookupEnv(name)
+		if !ok || value == "" {
+			continue
+		}
+		// The variable replaces the setting, so lists and maps aren't merged
+		field.Set(reflect.Zero(field.Type()))
+		if err := decodeStrict([]byte(value), field.Addr().Interface()); err != nil {
+			return fmt.Errorf("invalid %s: %w", name, err)
+		}
+	}
+	return nil
+}
+
+// unmarshalsYAML returns true if the addressable value v implements its own YAML unmarshaling
+func unmarshalsYAML(v reflect.Value) bool {
+	switch v.Addr().Interface().(type) {
+	case yaml.Unmarshaler, interface {
+		UnmarshalYAML(unmarshal func(interface{}) error) error
+	}:
+		return true
+	}
+	return false
+}
+
+// envName converts a camelCase YAML key to upper snake case, e.g. webhookURL to WEBHOOK_URL
+func envName(key string) string {
+	runes := []rune(key)
+	var b strings.Builder
+	for i, r := range runes {
+		if i > 0 && unicode.IsUpper(r) {
+			prev := runes[i-1]
+			nextLower := i+1 < len(runes) && unicode.IsLower(runes[i+1])
+			if unicode.IsLower(prev) || unicode.IsDigit(prev) || nextLower {
+				b.WriteByte('_')
+			}
+		}
+		b.WriteRune(unicode.ToUpper(r))
+	}
+	return b.String()
+}
+
+// decodeStrict decodes the YAML document data into out, rejecting keys out has no field for.
+// An empty document leaves out unchanged.
+func decodeStrict(data []byte, out interface{}) error {
+	decoder := yaml.NewDecoder(bytes.NewReader(data))
+	decoder.KnownFields(true)
+	if err := decoder.Decode(out); err != nil && !errors.Is(err, io.EOF) {
+		return err
+	}
+	return nil
+}
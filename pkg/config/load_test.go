@@ -0,0 +1,230 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package config
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+)
+
+// representativeConfig is a configuration file setting defaults, rotation, and maintenance windows
+const representativeConfig = `
+defaults:
+  type: bytes
+  length: 48
+rotation:
+  minInterval: 10m
+  createEvents: true
+  throttle:
+    maxRotations: 20
+  jitter: 10%
+  maintenanceWindows:
+    enabled: true
+    defaultTimezone: Europe/Berlin
+    windows:
+      - name: weekend-night
+        days: [saturday, sunday]
+        startTime: "03:00"
+        endTime: "05:00"
+      - name: release-day
+        date: "2026-03-01"
+        startTime: "12:00"
+        endTime: "14:00"
+        timezone: UTC
+`
+
+// writeConfig writes content to a configuration file and returns its path
+func writeConfig(t *testing.T, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), "config.yaml")
+	if err := os.WriteFile(path, []byte(content), 0644); err != nil {
+		t.Fatalf("failed to write config file: %v", err)
+	}
+	return path
+}
+
+func TestLoadFromFile(t *testing.T) {
+	cfg, err := LoadFromFile(writeConfig(t, representativeConfig))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	if cfg.Defaults.Type != TypeBytes || cfg.Defaults.Length != 48 {
+		t.Errorf("expected defaults bytes/48, got %s/%d", cfg.Defaults.Type, cfg.Defaults.Length)
+	}
+	// Defaults not set in the file are kept
+	if cfg.Defaults.String.AllowedSpecialChars != DefaultAllowedSpecialChars {
+		t.Errorf("expected default allowed special chars, got %q", cfg.Defaults.String.AllowedSpecialChars)
+	}
+
+	if cfg.Rotation.MinInterval.Duration() != 10*time.Minute || !cfg.Rotation.CreateEvents {
+		t.Errorf("expected rotation minInterval 10m with events, got %s/%v", cfg.Rotation.MinInterval.Duration(), cfg.Rotation.CreateEvents)
+	}
+	if cfg.Rotation.Throttle.MaxRotations != 20 || cfg.Rotation.Throttle.Period.Duration() != DefaultRotationThrottlePeriod {
+		t.Errorf("expected throttle 20 per %s, got %d per %s", DefaultRotationThrottlePeriod, cfg.Rotation.Throttle.MaxRotations, cfg.Rotation.Throttle.Period.Duration())
+	}
+	if cfg.Rotation.Jitter.Percent != 10 {
+		t.Errorf("expected jitter 10%%, got %s", cfg.Rotation.Jitter)
+	}
+	if cfg.Rotation.HistorySize != DefaultRotationHistorySize {
+		t.Errorf("expected default history size, got %d", cfg.Rotation.HistorySize)
+	}
+
+	windows := cfg.Rotation.MaintenanceWindows
+	if err := windows.Validate(); err != nil {
+		t.Errorf("expected valid maintenance windows, got %v", err)
+	}
+	if !windows.Enabled || len(windows.Windows) != 2 {
+		t.Fatalf("expected 2 enabled maintenance windows, got %+v", windows)
+	}
+	// The default timezone applies to windows without one
+	if windows.Windows[0].Timezone != "Europe/Berlin" || windows.Windows[1].Timezone != "UTC" {
+		t.Errorf("expected timezones Europe/Berlin and UTC, got %s and %s", windows.Windows[0].Timezone, windows.Windows[1].Timezone)
+	}
+	if !windows.IsInAnyWindow(time.Date(2026, 2, 7, 3, 30, 0, 0, time.UTC)) {
+		t.Error("expected Saturday 04:30 Berlin time to be inside the weekend window")
+	}
+}
+
+func TestLoadFromFileErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		content string
+		wantErr string
+	}{
+		{"unknown key", "rotation:\n  minIntervall: 10m\n", "field minIntervall not found"},
+		{"unknown window key", "rotation:\n  maintenanceWindows:\n    windows:\n      - name: w\n        day: monday\n", "field day not found"},
+		{"invalid duration", "rotation:\n  minInterval: soon\n", "failed to parse config file"},
+		{"invalid window", "rotation:\n  maintenanceWindows:\n    enabled: true\n    windows:\n      - name: w\n        days: [someday]\n        startTime: \"03:00\"\n        endTime: \"05:00\"\n", "someday"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := LoadFromFile(writeConfig(t, tt.content))
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+
+	if _, err := LoadFromFile(filepath.Join(t.TempDir(), "missing.yaml")); err == nil {
+		t.Error("expected an error for a missing file")
+	}
+}
+
+func TestLoadFromFileEmpty(t *testing.T) {
+	cfg, err := LoadFromFile(writeConfig(t, ""))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Defaults.Type != DefaultType || cfg.Rotation.MinInterval.Duration() != DefaultRotationMinInterval {
+		t.Errorf("expected the default configuration, got %+v", cfg)
+	}
+}
+
+func TestLoadFromFileEnvOverrides(t *testing.T) {
+	t.Setenv("ISO_DEFAULTS_LENGTH", "64")
+	t.Setenv("ISO_ROTATION_MIN_INTERVAL", "1h")
+	t.Setenv("ISO_ROTATION_MAINTENANCE_WINDOWS_WINDOWS", `[{name: nightly, days: [monday], startTime: "01:00", endTime: "02:00"}]`)
+
+	cfg, err := LoadFromFile(writeConfig(t, representativeConfig))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if cfg.Defaults.Type != TypeBytes || cfg.Defaults.Length != 64 {
+		t.Errorf("expected the file's type with the overridden length, got %s/%d", cfg.Defaults.Type, cfg.Defaults.Length)
+	}
+	if cfg.Rotation.MinInterval.Duration() != time.Hour {
+		t.Errorf("expected rotation minInterval 1h, got %s", cfg.Rotation.MinInterval.Duration())
+	}
+	// The variable replaces the file's windows, and the file's default timezone applies
+	windows := cfg.Rotation.MaintenanceWindows.Windows
+	if len(windows) != 1 || windows[0].Name != "nightly" || windows[0].Timezone != "Europe/Berlin" {
+		t.Errorf("expected the nightly window in Europe/Berlin, got %+v", windows)
+	}
+}
+
+func TestLoadFromEnv(t *testing.T) {
+	t.Setenv("ISO_DRY_RUN", "true")
+	t.Setenv("ISO_ROTATION_CREATE_EVENTS", "true")
+	t.Setenv("ISO_ROTATION_JITTER", "30m")
+	t.Setenv("ISO_ROTATION_MAINTENANCE_WINDOWS_ENABLED", "true")
+	t.Setenv("ISO_ROTATION_MAINTENANCE_WINDOWS_WINDOWS", `[{name: nightly, days: [monday], startTime: "01:00", endTime: "02:00", timezone: UTC}]`)
+	t.Setenv("ISO_NAMESPACES_INCLUDE", "[team-a, team-b]")
+	t.Setenv("ISO_LABEL_SELECTOR", "")
+
+	cfg, err := LoadFromEnv()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cfg.DryRun || !cfg.Rotation.CreateEvents {
+		t.Errorf("expected dryRun and rotation events, got %v/%v", cfg.DryRun, cfg.Rotation.CreateEvents)
+	}
+	if cfg.Rotation.Jitter.Max != 30*time.Minute {
+		t.Errorf("expected jitter 30m, got %s", cfg.Rotation.Jitter)
+	}
+	if !cfg.Rotation.MaintenanceWindows.Enabled || len(cfg.Rotation.MaintenanceWindows.Windows) != 1 {
+		t.Errorf("expected one enabled maintenance window, got %+v", cfg.Rotation.MaintenanceWindows)
+	}
+	if len(cfg.Namespaces.Include) != 2 {
+		t.Errorf("expected 2 included namespaces, got %v", cfg.Namespaces.Include)
+	}
+	// Unset and empty variables keep the defaults
+	if cfg.Defaults.Length != DefaultLength || cfg.LabelSelector != "" {
+		t.Errorf("expected default length and no label selector, got %d/%q", cfg.Defaults.Length, cfg.LabelSelector)
+	}
+}
+
+func TestLoadFromEnvErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		env     map[string]string
+		wantErr string
+	}{
+		{"invalid value", map[string]string{"ISO_DEFAULTS_LENGTH": "long"}, "invalid ISO_DEFAULTS_LENGTH"},
+		{"unknown window key", map[string]string{"ISO_ROTATION_MAINTENANCE_WINDOWS_WINDOWS": "[{name: w, day: monday}]"}, "field day not found"},
+		{"invalid setting", map[string]string{"ISO_DEFAULTS_TYPE": "unknown"}, "unknown"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+			_, err := LoadFromEnv()
+			if err == nil || !strings.Contains(err.Error(), tt.wantErr) {
+				t.Errorf("expected error containing %q, got %v", tt.wantErr, err)
+			}
+		})
+	}
+}
+
+func TestEnvName(t *testing.T) {
+	for key, want := range map[string]string{
+		"dryRun":                  "DRY_RUN",
+		"defaults":                "DEFAULTS",
+		"minInterval":             "MIN_INTERVAL",
+		"webhookURL":              "WEBHOOK_URL",
+		"spreadDeferredRotations": "SPREAD_DEFERRED_ROTATIONS",
+		"entropyFloorBits":        "ENTROPY_FLOOR_BITS",
+	} {
+		if got := envName(key); got != want {
+			t.Errorf("envName(%q) = %q, want %q", key, got, want)
+		}
+	}
+}
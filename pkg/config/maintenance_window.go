@@ -18,10 +18,17 @@ package config
 
 import (
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 )
 
+// dateLayout is the format of MaintenanceWindow.Date
+const dateLayout = "2006-01-02"
+
+// maxRotationAllowedSteps limits the search of NextRotationAllowed
+const maxRotationAllowedSteps = 100
+
 // validDays maps day names to time.Weekday values
 var validDays = map[string]time.Weekday{
 	"sunday":    time.Sunday,
@@ -33,13 +40,25 @@ var validDays = map[string]time.Weekday{
 	"saturday":  time.Saturday,
 }
 
+// dayShorthands maps shorthand tokens in MaintenanceWindow.Days to the weekdays they stand for
+var dayShorthands = map[string][]time.Weekday{
+	"weekdays": {time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday},
+	"weekends": {time.Saturday, time.Sunday},
+	"daily":    {time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday},
+}
+
 // Validate validates the MaintenanceWindowsConfig
 func (m *MaintenanceWindowsConfig) Validate() error {
 	if len(m.Windows) == 0 {
 		return fmt.Errorf("at least one maintenance window must be defined when enabled")
 	}
 
-	for i, window := range m.Windows {
+	if err := m.validateDefaultTimezone(); err != nil {
+		return err
+	}
+
+	for i := range m.Windows {
+		window := m.resolve(&m.Windows[i])
 		if err := window.Validate(); err != nil {
 			if window.Name != "" {
 				return fmt.Errorf("window '%s': %w", window.Name, err)
@@ -48,21 +67,91 @@ func (m *MaintenanceWindowsConfig) Validate() error {
 		}
 	}
 
+	return m.ValidateBlackoutWindows()
+}
+
+// ValidateBlackoutWindows validates the blackout windows. It is called by Validate and
+// separately when maintenance windows are disabled, as blackout windows apply regardless.
+func (m *MaintenanceWindowsConfig) ValidateBlackoutWindows() error {
+	if len(m.BlackoutWindows) == 0 {
+		return nil
+	}
+
+	if err := m.validateDefaultTimezone(); err != nil {
+		return err
+	}
+
+	for i := range m.BlackoutWindows {
+		window := m.resolve(&m.BlackoutWindows[i])
+		if err := window.Validate(); err != nil {
+			if window.Name != "" {
+				return fmt.Errorf("blackout window '%s': %w", window.Name, err)
+			}
+			return fmt.Errorf("blackoutWindows[%d]: %w", i, err)
+		}
+	}
+
+	return nil
+}
+
+// validateDefaultTimezone validates the DefaultTimezone, if set
+func (m *MaintenanceWindowsConfig) validateDefaultTimezone() error {
+	if m.DefaultTimezone != "" {
+		if _, err := time.LoadLocation(m.DefaultTimezone); err != nil {
+			return fmt.Errorf("invalid defaultTimezone '%s': %w", m.DefaultTimezone, err)
+		}
+	}
 	return nil
 }
 
+// ApplyDefaultTimezone sets DefaultTimezone on all windows without an explicit timezone, so the
+// loaded configuration shows the timezones in effect. The methods of MaintenanceWindowsConfig
+// resolve the default themselves, so it isn't required for configurations built in code.
+func (m *MaintenanceWindowsConfig) ApplyDefaultTimezone() {
+	if m.DefaultTimezone == "" {
+		return
+	}
+	for i := range m.Windows {
+		if m.Windows[i].Timezone == "" {
+			m.Windows[i].Timezone = m.DefaultTimezone
+		}
+	}
+	for i := range m.BlackoutWindows {
+		if m.BlackoutWindows[i].Timezone == "" {
+			m.BlackoutWindows[i].Timezone = m.DefaultTimezone
+		}
+	}
+}
+
+// resolve returns w with its effective timezone: its own, or DefaultTimezone if it has none.
+// w itself is returned if it needs no default, otherwise a copy.
+func (m *MaintenanceWindowsConfig) resolve(w *MaintenanceWindow) *MaintenanceWindow {
+	if w.Timezone != "" || m.DefaultTimezone == "" {
+		return w
+	}
+	resolved := *w
+	resolved.Timezone = m.DefaultTimezone
+	return &resolved
+}
+
 // Validate validates a single MaintenanceWindow
 func (w *MaintenanceWindow) Validate() error {
 	// Validate name (optional but recommended)
 	// No validation needed, empty name is allowed
 
-	// Validate days
-	if len(w.Days) == 0 {
-		return fmt.Errorf("at least one day must be specified")
+	// Validate days or date
+	if len(w.Days) == 0 && w.Date == "" {
+		return fmt.Errorf("at least one day or a date must be specified")
+	}
+
+	if w.Date != "" {
+		if _, err := ParseDate(w.Date, time.UTC); err != nil {
+			return err
+		}
 	}
 
 	for _, day := range w.Days {
-		if _, err := ParseDay(day); err != nil {
+		if _, err := ParseDays(day); err != nil {
 			return err
 		}
 	}
@@ -88,7 +177,7 @@ func (w *MaintenanceWindow) Validate() error {
 
 	// Validate timezone
 	if w.Timezone == "" {
-		return fmt.Errorf("timezone must be specified")
+		return fmt.Errorf("timezone must be specified (or set defaultTimezone)")
 	}
 
 	if _, err := time.LoadLocation(w.Timezone); err != nil {
@@ -107,6 +196,69 @@ func ParseDay(day string) (time.Weekday, error) {
 	return time.Sunday, fmt.Errorf("invalid day: '%s', must be one of: sunday, monday, tuesday, wednesday, thursday, friday, saturday", day)
 }
 
+// ParseDays parses an entry of MaintenanceWindow.Days, which is a day name, a shorthand
+// (weekdays, weekends, daily) or an inclusive range of day names such as "monday-friday".
+// Weeks start on monday, so a range must not wrap around: "friday-monday" is rejected.
+func ParseDays(days string) ([]time.Weekday, error) {
+	normalized := strings.ToLower(strings.TrimSpace(days))
+	if weekdays, ok := dayShorthands[normalized]; ok {
+		return weekdays, nil
+	}
+
+	from, to, isRange := strings.Cut(normalized, "-")
+	if !isRange {
+		weekday, err := ParseDay(days)
+		if err != nil {
+			return nil, fmt.Errorf("%w, or one of: weekdays, weekends, daily, or a range such as monday-friday", err)
+		}
+		return []time.Weekday{weekday}, nil
+	}
+
+	first, err := ParseDay(from)
+	if err != nil {
+		return nil, fmt.Errorf("invalid day range '%s': %w", days, err)
+	}
+	last, err := ParseDay(to)
+	if err != nil {
+		return nil, fmt.Errorf("invalid day range '%s': %w", days, err)
+	}
+	// Count monday as the first day of the week
+	firstIndex, lastIndex := (int(first)+6)%7, (int(last)+6)%7
+	if firstIndex > lastIndex {
+		return nil, fmt.Errorf("invalid day range '%s': %s comes after %s, ranges must not wrap around the end of the week (monday-sunday)",
+			days, strings.TrimSpace(from), strings.TrimSpace(to))
+	}
+
+	weekdays := make([]time.Weekday, 0, lastIndex-firstIndex+1)
+	for i := firstIndex; i <= lastIndex; i++ {
+		weekdays = append(weekdays, time.Weekday((i+1)%7))
+	}
+	return weekdays, nil
+}
+
+// weekdays returns the weekdays of the window with shorthands and ranges expanded.
+// Invalid entries are skipped; they are rejected by Validate.
+func (w *MaintenanceWindow) weekdays() []time.Weekday {
+	weekdays := make([]time.Weekday, 0, len(w.Days))
+	for _, day := range w.Days {
+		expanded, err := ParseDays(day)
+		if err != nil {
+			continue
+		}
+		weekdays = append(weekdays, expanded...)
+	}
+	return weekdays
+}
+
+// ParseDate parses a date string in YYYY-MM-DD format as midnight in loc
+func ParseDate(date string, loc *time.Location) (time.Time, error) {
+	d, err := time.ParseInLocation(dateLayout, strings.TrimSpace(date), loc)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date '%s', expected YYYY-MM-DD", date)
+	}
+	return d, nil
+}
+
 // ParseTime parses a time string in HH:MM format
 func ParseTime(timeStr string) (hour, minute int, err error) {
 	if timeStr == "" {
@@ -149,18 +301,13 @@ func (w *MaintenanceWindow) IsInWindow(t time.Time) bool {
 	// Convert to the window's timezone
 	localTime := t.In(loc)
 
-	// Check if the day matches
+	// Check if the day matches; a date replaces the recurring days
 	currentDay := localTime.Weekday()
 	dayMatches := false
-	for _, day := range w.Days {
-		weekday, err := ParseDay(day)
-		if err != nil {
-			continue
-		}
-		if weekday == currentDay {
-			dayMatches = true
-			break
-		}
+	if w.Date != "" {
+		dayMatches = localTime.Format(dateLayout) == strings.TrimSpace(w.Date)
+	} else {
+		dayMatches = slices.Contains(w.weekdays(), currentDay)
 	}
 
 	if !dayMatches {
@@ -188,7 +335,7 @@ func (m *MaintenanceWindowsConfig) IsInAnyWindow(t time.Time) bool {
 	}
 
 	for i := range m.Windows {
-		if m.Windows[i].IsInWindow(t) {
+		if m.resolve(&m.Windows[i]).IsInWindow(t) {
 			return true
 		}
 	}
@@ -196,6 +343,51 @@ func (m *MaintenanceWindowsConfig) IsInAnyWindow(t time.Time) bool {
 	return false
 }
 
+// IsBlackedOut checks if the given time falls within any of the blackout windows.
+// Blackout windows apply even if maintenance windows are disabled.
+func (m *MaintenanceWindowsConfig) IsBlackedOut(t time.Time) bool {
+	return m.GetActiveBlackoutWindow(t) != nil
+}
+
+// GetActiveBlackoutWindow returns the active blackout window for the given time, or nil if none is active
+func (m *MaintenanceWindowsConfig) GetActiveBlackoutWindow(t time.Time) *MaintenanceWindow {
+	for i := range m.BlackoutWindows {
+		if window := m.resolve(&m.BlackoutWindows[i]); window.IsInWindow(t) {
+			return window
+		}
+	}
+
+	return nil
+}
+
+// IsRotationAllowed checks if rotation may happen at the given time: inside a maintenance
+// window (or with maintenance windows disabled) and outside all blackout windows
+func (m *MaintenanceWindowsConfig) IsRotationAllowed(t time.Time) bool {
+	return m.IsInAnyWindow(t) && !m.IsBlackedOut(t)
+}
+
+// NextRotationAllowed returns the earliest time from t on at which rotation is allowed,
+// or zero time if there is none
+func (m *MaintenanceWindowsConfig) NextRotationAllowed(t time.Time) time.Time {
+	// Each step skips to the end of a blackout or the start of a window. Overlapping
+	// windows need a few steps; the limit guards against configurations that never allow rotation.
+	for step := 0; step < maxRotationAllowedSteps; step++ {
+		if m.IsRotationAllowed(t) {
+			return t
+		}
+		if blackout := m.GetActiveBlackoutWindow(t); blackout != nil {
+			t = blackout.NextEnd(t)
+		} else {
+			t = m.NextWindowStart(t)
+		}
+		if t.IsZero() {
+			return time.Time{}
+		}
+	}
+
+	return time.Time{}
+}
+
 // GetActiveWindow returns the active maintenance window for the given time, or nil if none is active
 func (m *MaintenanceWindowsConfig) GetActiveWindow(t time.Time) *MaintenanceWindow {
 	if !m.Enabled {
@@ -203,8 +395,8 @@ func (m *MaintenanceWindowsConfig) GetActiveWindow(t time.Time) *MaintenanceWind
 	}
 
 	for i := range m.Windows {
-		if m.Windows[i].IsInWindow(t) {
-			return &m.Windows[i]
+		if window := m.resolve(&m.Windows[i]); window.IsInWindow(t) {
+			return window
 		}
 	}
 
@@ -213,21 +405,36 @@ func (m *MaintenanceWindowsConfig) GetActiveWindow(t time.Time) *MaintenanceWind
 
 // NextWindowStart calculates the next maintenance window start time from the given time
 func (m *MaintenanceWindowsConfig) NextWindowStart(t time.Time) time.Time {
+	_, start := m.NextWindow(t)
+	return start
+}
+
+// NextWindow returns the maintenance window starting next from the given time and its start
+// time. A window active at t starts at the start of its current occurrence. If two windows start
+// at the same time, the first configured one is returned. If maintenance windows are disabled or
+// no window starts anymore, it returns nil and zero time.
+func (m *MaintenanceWindowsConfig) NextWindow(t time.Time) (*MaintenanceWindow, time.Time) {
 	if !m.Enabled || len(m.Windows) == 0 {
-		// If disabled, return zero time
-		return time.Time{}
+		return nil, time.Time{}
 	}
 
 	var earliest time.Time
+	var window *MaintenanceWindow
 
 	for i := range m.Windows {
-		next := m.Windows[i].NextStart(t)
+		w := m.resolve(&m.Windows[i])
+		next := w.NextStart(t)
+		if next.IsZero() {
+			// One-off window in the past
+			continue
+		}
 		if earliest.IsZero() || next.Before(earliest) {
 			earliest = next
+			window = w
 		}
 	}
 
-	return earliest
+	return window, earliest
 }
 
 // NextStart calculates the next start time for this window from the given time
@@ -241,16 +448,22 @@ func (w *MaintenanceWindow) NextStart(t time.Time) time.Time {
 	startHour, startMinute, _ := ParseTime(w.StartTime)
 	endHour, endMinute, _ := ParseTime(w.EndTime)
 
-	// Parse the days
-	windowDays := make([]time.Weekday, 0, len(w.Days))
-	for _, day := range w.Days {
-		weekday, err := ParseDay(day)
+	// A one-off window has a single occurrence, which is over once it has ended
+	if w.Date != "" {
+		date, err := ParseDate(w.Date, loc)
 		if err != nil {
-			continue
+			return time.Time{}
+		}
+		start := time.Date(date.Year(), date.Month(), date.Day(), startHour, startMinute, 0, 0, loc)
+		if !localTime.Before(start.Add(w.Duration())) {
+			return time.Time{}
 		}
-		windowDays = append(windowDays, weekday)
+		return start
 	}
 
+	// Parse the days
+	windowDays := w.weekdays()
+
 	if len(windowDays) == 0 {
 		return time.Time{}
 	}
@@ -294,6 +507,38 @@ func (w *MaintenanceWindow) NextStart(t time.Time) time.Time {
 	return time.Time{}
 }
 
+// NextStartAfter calculates the start of the first occurrence of this window that starts after
+// the given time. Unlike NextStart, it skips an occurrence that is already open, e.g. to schedule
+// the rotation following the one happening now. It returns zero time if there is none.
+func (w *MaintenanceWindow) NextStartAfter(t time.Time) time.Time {
+	start := w.NextStart(t)
+	if start.IsZero() || start.After(t) {
+		return start
+	}
+	// Inside the window: the occurrence after its end
+	return w.NextStart(start.Add(w.Duration()))
+}
+
+// Duration returns the length of a single occurrence of this window
+func (w *MaintenanceWindow) Duration() time.Duration {
+	startHour, startMinute, _ := ParseTime(w.StartTime)
+	endHour, endMinute, _ := ParseTime(w.EndTime)
+
+	startMinutes := startHour*60 + startMinute
+	endMinutes := endHour*60 + endMinute
+
+	return time.Duration(endMinutes-startMinutes) * time.Minute
+}
+
+// NextEnd calculates the end time of the window occurrence returned by NextStart
+func (w *MaintenanceWindow) NextEnd(t time.Time) time.Time {
+	start := w.NextStart(t)
+	if start.IsZero() {
+		return time.Time{}
+	}
+	return start.Add(w.Duration())
+}
+
 // DurationUntilNextWindow calculates the duration until the next maintenance window starts
 func (m *MaintenanceWindowsConfig) DurationUntilNextWindow(t time.Time) time.Duration {
 	if !m.Enabled {
@@ -17,6 +17,8 @@ limitations under the License.
 package config
 
 import (
+	"os"
+	"path/filepath"
 	"testing"
 	"time"
 
@@ -60,6 +62,42 @@ func TestParseDay(t *testing.T) {
 	}
 }
 
+func TestParseDays(t *testing.T) {
+	tests := []struct {
+		name        string
+		input       string
+		expected    []time.Weekday
+		expectError bool
+	}{
+		{"single day", "monday", []time.Weekday{time.Monday}, false},
+		{"weekdays", "weekdays", []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}, false},
+		{"weekends", "weekends", []time.Weekday{time.Saturday, time.Sunday}, false},
+		{"daily", "daily", []time.Weekday{time.Sunday, time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday, time.Saturday}, false},
+		{"shorthand uppercase", "  WEEKDAYS ", []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}, false},
+		{"range", "monday-friday", []time.Weekday{time.Monday, time.Tuesday, time.Wednesday, time.Thursday, time.Friday}, false},
+		{"range to sunday", "saturday-sunday", []time.Weekday{time.Saturday, time.Sunday}, false},
+		{"range with spaces", "Tuesday - Thursday", []time.Weekday{time.Tuesday, time.Wednesday, time.Thursday}, false},
+		{"single day range", "wednesday-wednesday", []time.Weekday{time.Wednesday}, false},
+		{"wrapping range", "friday-monday", nil, true},
+		{"range with invalid day", "monday-funday", nil, true},
+		{"open range", "monday-", nil, true},
+		{"invalid day", "funday", nil, true},
+		{"empty string", "", nil, true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			result, err := ParseDays(tt.input)
+			if tt.expectError {
+				assert.Error(t, err)
+			} else {
+				assert.NoError(t, err)
+				assert.Equal(t, tt.expected, result)
+			}
+		})
+	}
+}
+
 func TestParseTime(t *testing.T) {
 	tests := []struct {
 		name           string
@@ -136,7 +174,7 @@ func TestMaintenanceWindowValidate(t *testing.T) {
 				Timezone:  "UTC",
 			},
 			expectError: true,
-			errorMsg:    "at least one day must be specified",
+			errorMsg:    "at least one day or a date must be specified",
 		},
 		{
 			name: "invalid day",
@@ -150,6 +188,29 @@ func TestMaintenanceWindowValidate(t *testing.T) {
 			expectError: true,
 			errorMsg:    "invalid day",
 		},
+		{
+			name: "wrapping day range",
+			window: MaintenanceWindow{
+				Name:      "test",
+				Days:      []string{"friday-monday"},
+				StartTime: "03:00",
+				EndTime:   "05:00",
+				Timezone:  "UTC",
+			},
+			expectError: true,
+			errorMsg:    "must not wrap around",
+		},
+		{
+			name: "valid shorthand and range",
+			window: MaintenanceWindow{
+				Name:      "test",
+				Days:      []string{"weekends", "monday-wednesday"},
+				StartTime: "03:00",
+				EndTime:   "05:00",
+				Timezone:  "UTC",
+			},
+			expectError: false,
+		},
 		{
 			name: "invalid startTime",
 			window: MaintenanceWindow{
@@ -222,6 +283,39 @@ func TestMaintenanceWindowValidate(t *testing.T) {
 			expectError: true,
 			errorMsg:    "invalid timezone",
 		},
+		{
+			name: "valid date without days",
+			window: MaintenanceWindow{
+				Name:      "migration",
+				Date:      "2026-03-14",
+				StartTime: "03:00",
+				EndTime:   "05:00",
+				Timezone:  "UTC",
+			},
+			expectError: false,
+		},
+		{
+			name: "invalid date",
+			window: MaintenanceWindow{
+				Date:      "14.03.2026",
+				StartTime: "03:00",
+				EndTime:   "05:00",
+				Timezone:  "UTC",
+			},
+			expectError: true,
+			errorMsg:    "invalid date",
+		},
+		{
+			name: "impossible date",
+			window: MaintenanceWindow{
+				Date:      "2026-02-30",
+				StartTime: "03:00",
+				EndTime:   "05:00",
+				Timezone:  "UTC",
+			},
+			expectError: true,
+			errorMsg:    "invalid date",
+		},
 	}
 
 	for _, tt := range tests {
@@ -473,6 +567,31 @@ func TestMaintenanceWindowIsInWindow(t *testing.T) {
 	}
 }
 
+func TestMaintenanceWindowDayShorthands(t *testing.T) {
+	window := MaintenanceWindow{
+		Name:      "business-nights",
+		Days:      []string{"monday-friday"},
+		StartTime: "03:00",
+		EndTime:   "05:00",
+		Timezone:  "UTC",
+	}
+
+	// 2026-02-06 is a Friday
+	assert.True(t, window.IsInWindow(time.Date(2026, 2, 6, 4, 0, 0, 0, time.UTC)))
+	assert.False(t, window.IsInWindow(time.Date(2026, 2, 7, 4, 0, 0, 0, time.UTC)))
+	// Friday after the window: the next start is on Monday
+	assert.Equal(t, time.Date(2026, 2, 9, 3, 0, 0, 0, time.UTC), window.NextStart(time.Date(2026, 2, 6, 6, 0, 0, 0, time.UTC)))
+
+	window.Days = []string{"weekends"}
+	assert.True(t, window.IsInWindow(time.Date(2026, 2, 8, 4, 0, 0, 0, time.UTC)))
+	assert.False(t, window.IsInWindow(time.Date(2026, 2, 9, 4, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2026, 2, 7, 3, 0, 0, 0, time.UTC), window.NextStart(time.Date(2026, 2, 6, 6, 0, 0, 0, time.UTC)))
+
+	window.Days = []string{"daily"}
+	assert.True(t, window.IsInWindow(time.Date(2026, 2, 10, 4, 0, 0, 0, time.UTC)))
+	assert.Equal(t, time.Date(2026, 2, 11, 3, 0, 0, 0, time.UTC), window.NextStart(time.Date(2026, 2, 10, 6, 0, 0, 0, time.UTC)))
+}
+
 func TestMaintenanceWindowsConfigIsInAnyWindow(t *testing.T) {
 	berlinLoc, _ := time.LoadLocation("Europe/Berlin")
 
@@ -672,6 +791,74 @@ func TestMaintenanceWindowsConfigNextWindowStart(t *testing.T) {
 	})
 }
 
+func TestMaintenanceWindowNextStartAfter(t *testing.T) {
+	berlinLoc, _ := time.LoadLocation("Europe/Berlin")
+
+	weekend := MaintenanceWindow{
+		Name:      "weekend-night",
+		Days:      []string{"saturday", "sunday"},
+		StartTime: "03:00",
+		EndTime:   "05:00",
+		Timezone:  "Europe/Berlin",
+	}
+	saturday := MaintenanceWindow{
+		Name:      "saturday-night",
+		Days:      []string{"saturday"},
+		StartTime: "03:00",
+		EndTime:   "05:00",
+		Timezone:  "Europe/Berlin",
+	}
+
+	t.Run("inside window returns tomorrow's start", func(t *testing.T) {
+		// Saturday 04:00 - inside window, next occurrence is Sunday
+		testTime := time.Date(2026, 2, 7, 4, 0, 0, 0, berlinLoc)
+
+		expected := time.Date(2026, 2, 8, 3, 0, 0, 0, berlinLoc) // Sunday 03:00
+		assert.Equal(t, expected, weekend.NextStartAfter(testTime))
+		// NextStart still returns the open occurrence
+		assert.Equal(t, time.Date(2026, 2, 7, 3, 0, 0, 0, berlinLoc), weekend.NextStart(testTime))
+	})
+
+	t.Run("inside window returns next week's start", func(t *testing.T) {
+		// Saturday 04:00 - inside the only window of the week
+		testTime := time.Date(2026, 2, 7, 4, 0, 0, 0, berlinLoc)
+
+		expected := time.Date(2026, 2, 14, 3, 0, 0, 0, berlinLoc) // Next Saturday 03:00
+		assert.Equal(t, expected, saturday.NextStartAfter(testTime))
+	})
+
+	t.Run("at window start returns the next occurrence", func(t *testing.T) {
+		testTime := time.Date(2026, 2, 7, 3, 0, 0, 0, berlinLoc)
+
+		expected := time.Date(2026, 2, 14, 3, 0, 0, 0, berlinLoc)
+		assert.Equal(t, expected, saturday.NextStartAfter(testTime))
+	})
+
+	t.Run("outside window matches NextStart", func(t *testing.T) {
+		for _, testTime := range []time.Time{
+			time.Date(2026, 2, 7, 2, 0, 0, 0, berlinLoc),  // Saturday before the window
+			time.Date(2026, 2, 7, 5, 0, 0, 0, berlinLoc),  // Saturday at the window end
+			time.Date(2026, 2, 9, 10, 0, 0, 0, berlinLoc), // Monday
+		} {
+			assert.Equal(t, weekend.NextStart(testTime), weekend.NextStartAfter(testTime), "at %s", testTime)
+		}
+	})
+
+	t.Run("inside one-off window returns zero time", func(t *testing.T) {
+		release := MaintenanceWindow{
+			Name:      "release",
+			Date:      "2026-02-07",
+			StartTime: "03:00",
+			EndTime:   "05:00",
+			Timezone:  "Europe/Berlin",
+		}
+		testTime := time.Date(2026, 2, 7, 4, 0, 0, 0, berlinLoc)
+
+		assert.True(t, release.NextStartAfter(testTime).IsZero())
+		assert.Equal(t, time.Date(2026, 2, 7, 3, 0, 0, 0, berlinLoc), release.NextStart(testTime))
+	})
+}
+
 func TestMaintenanceWindowsConfigDurationUntilNextWindow(t *testing.T) {
 	berlinLoc, _ := time.LoadLocation("Europe/Berlin")
 
@@ -824,3 +1011,443 @@ func TestMultipleWindowsNextStart(t *testing.T) {
 		assert.Equal(t, expected, next)
 	})
 }
+
+func TestMultipleWindowsNextWindow(t *testing.T) {
+	berlinLoc, _ := time.LoadLocation("Europe/Berlin")
+
+	// Config with two windows - one closer than the other
+	config := MaintenanceWindowsConfig{
+		Enabled: true,
+		Windows: []MaintenanceWindow{
+			{
+				Name:      "saturday-window",
+				Days:      []string{"saturday"},
+				StartTime: "03:00",
+				EndTime:   "05:00",
+				Timezone:  "Europe/Berlin",
+			},
+			{
+				Name:      "wednesday-window",
+				Days:      []string{"wednesday"},
+				StartTime: "02:00",
+				EndTime:   "04:00",
+				Timezone:  "Europe/Berlin",
+			},
+		},
+	}
+
+	t.Run("picks closer window (Wednesday)", func(t *testing.T) {
+		// Monday - Wednesday is closer than Saturday
+		testTime := time.Date(2026, 2, 2, 10, 0, 0, 0, berlinLoc) // Monday
+		window, next := config.NextWindow(testTime)
+
+		expected := time.Date(2026, 2, 4, 2, 0, 0, 0, berlinLoc) // Wednesday 02:00
+		assert.Equal(t, expected, next)
+		require.NotNil(t, window)
+		assert.Equal(t, "wednesday-window", window.Name)
+		assert.Equal(t, next, config.NextWindowStart(testTime))
+	})
+
+	t.Run("picks closer window (Saturday)", func(t *testing.T) {
+		// Thursday - Saturday is closer than next Wednesday
+		testTime := time.Date(2026, 2, 5, 10, 0, 0, 0, berlinLoc) // Thursday
+		window, next := config.NextWindow(testTime)
+
+		expected := time.Date(2026, 2, 7, 3, 0, 0, 0, berlinLoc) // Saturday 03:00
+		assert.Equal(t, expected, next)
+		require.NotNil(t, window)
+		assert.Equal(t, "saturday-window", window.Name)
+	})
+
+	t.Run("active window", func(t *testing.T) {
+		// Saturday 04:00 - inside the Saturday window
+		testTime := time.Date(2026, 2, 7, 4, 0, 0, 0, berlinLoc)
+		window, next := config.NextWindow(testTime)
+
+		assert.Equal(t, time.Date(2026, 2, 7, 3, 0, 0, 0, berlinLoc), next)
+		require.NotNil(t, window)
+		assert.Equal(t, "saturday-window", window.Name)
+	})
+
+	t.Run("returns the configured window", func(t *testing.T) {
+		window, _ := config.NextWindow(time.Date(2026, 2, 2, 10, 0, 0, 0, berlinLoc))
+		assert.Same(t, &config.Windows[1], window)
+	})
+
+	t.Run("disabled", func(t *testing.T) {
+		disabled := config
+		disabled.Enabled = false
+		window, next := disabled.NextWindow(time.Date(2026, 2, 2, 10, 0, 0, 0, berlinLoc))
+		assert.Nil(t, window)
+		assert.True(t, next.IsZero())
+	})
+
+	t.Run("no windows", func(t *testing.T) {
+		empty := MaintenanceWindowsConfig{Enabled: true}
+		window, next := empty.NextWindow(time.Date(2026, 2, 2, 10, 0, 0, 0, berlinLoc))
+		assert.Nil(t, window)
+		assert.True(t, next.IsZero())
+	})
+
+	t.Run("only past one-off windows", func(t *testing.T) {
+		past := MaintenanceWindowsConfig{
+			Enabled: true,
+			Windows: []MaintenanceWindow{
+				{Name: "release", Date: "2026-01-15", StartTime: "12:00", EndTime: "14:00", Timezone: "UTC"},
+			},
+		}
+		window, next := past.NextWindow(time.Date(2026, 2, 2, 10, 0, 0, 0, time.UTC))
+		assert.Nil(t, window)
+		assert.True(t, next.IsZero())
+	})
+}
+
+func TestMaintenanceWindowDurationAndNextEnd(t *testing.T) {
+	berlinLoc, _ := time.LoadLocation("Europe/Berlin")
+
+	window := MaintenanceWindow{
+		Name:      "weekend-night",
+		Days:      []string{"saturday", "sunday"},
+		StartTime: "03:00",
+		EndTime:   "05:30",
+		Timezone:  "Europe/Berlin",
+	}
+
+	assert.Equal(t, 150*time.Minute, window.Duration())
+
+	t.Run("next end before window", func(t *testing.T) {
+		// Saturday 02:00 - before window starts
+		testTime := time.Date(2026, 2, 7, 2, 0, 0, 0, berlinLoc)
+		expected := time.Date(2026, 2, 7, 5, 30, 0, 0, berlinLoc)
+		assert.Equal(t, expected, window.NextEnd(testTime))
+	})
+
+	t.Run("next end inside window is current window end", func(t *testing.T) {
+		// Saturday 04:00 - inside window
+		testTime := time.Date(2026, 2, 7, 4, 0, 0, 0, berlinLoc)
+		expected := time.Date(2026, 2, 7, 5, 30, 0, 0, berlinLoc)
+		assert.Equal(t, expected, window.NextEnd(testTime))
+	})
+
+	t.Run("invalid timezone returns zero time", func(t *testing.T) {
+		invalid := window
+		invalid.Timezone = "Invalid/Zone"
+		assert.True(t, invalid.NextEnd(time.Now()).IsZero())
+	})
+}
+
+func TestMaintenanceWindowsConfigDefaultTimezone(t *testing.T) {
+	writeConfig := func(t *testing.T, content string) string {
+		t.Helper()
+		path := filepath.Join(t.TempDir(), "config.yaml")
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o600))
+		return path
+	}
+
+	t.Run("window without timezone inherits default", func(t *testing.T) {
+		path := writeConfig(t, `
+rotation:
+  maintenanceWindows:
+    enabled: true
+    defaultTimezone: "Europe/Berlin"
+    windows:
+      - name: "inherits"
+        days: ["saturday"]
+        startTime: "03:00"
+        endTime: "05:00"
+      - name: "overrides"
+        days: ["sunday"]
+        startTime: "03:00"
+        endTime: "05:00"
+        timezone: "UTC"
+`)
+		cfg, err := LoadConfig(path)
+		require.NoError(t, err)
+
+		windows := cfg.Rotation.MaintenanceWindows.Windows
+		require.Len(t, windows, 2)
+		assert.Equal(t, "Europe/Berlin", windows[0].Timezone)
+		assert.Equal(t, "UTC", windows[1].Timezone)
+
+		// Saturday 03:30 Berlin time (02:30 UTC in February) is inside the inherited window
+		assert.True(t, windows[0].IsInWindow(time.Date(2026, 2, 7, 2, 30, 0, 0, time.UTC)))
+	})
+
+	t.Run("neither window nor default timezone set", func(t *testing.T) {
+		path := writeConfig(t, `
+rotation:
+  maintenanceWindows:
+    enabled: true
+    windows:
+      - name: "no-timezone"
+        days: ["saturday"]
+        startTime: "03:00"
+        endTime: "05:00"
+`)
+		_, err := LoadConfig(path)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "timezone must be specified")
+	})
+
+	t.Run("invalid default timezone", func(t *testing.T) {
+		path := writeConfig(t, `
+rotation:
+  maintenanceWindows:
+    enabled: true
+    defaultTimezone: "Invalid/Zone"
+    windows:
+      - name: "weekend"
+        days: ["saturday"]
+        startTime: "03:00"
+        endTime: "05:00"
+        timezone: "UTC"
+`)
+		_, err := LoadConfig(path)
+		require.Error(t, err)
+		assert.Contains(t, err.Error(), "invalid defaultTimezone")
+	})
+
+	t.Run("default resolved without ApplyDefaultTimezone", func(t *testing.T) {
+		berlinLoc, _ := time.LoadLocation("Europe/Berlin")
+		window := MaintenanceWindow{Name: "inherits", Days: []string{"saturday"}, StartTime: "03:00", EndTime: "05:00"}
+		blackout := MaintenanceWindow{Name: "freeze", Days: []string{"saturday"}, StartTime: "04:00", EndTime: "05:00"}
+		config := MaintenanceWindowsConfig{
+			Enabled:         true,
+			DefaultTimezone: "Europe/Berlin",
+			Windows:         []MaintenanceWindow{window},
+			BlackoutWindows: []MaintenanceWindow{blackout},
+		}
+		require.NoError(t, config.Validate())
+
+		// Saturday 03:30 Berlin time is inside the window, 04:30 inside the blackout
+		inWindow := time.Date(2026, 2, 7, 3, 30, 0, 0, berlinLoc)
+		assert.True(t, config.IsRotationAllowed(inWindow))
+		assert.Equal(t, "Europe/Berlin", config.GetActiveWindow(inWindow).Timezone)
+		blackedOut := time.Date(2026, 2, 7, 4, 30, 0, 0, berlinLoc)
+		assert.True(t, config.IsBlackedOut(blackedOut))
+		assert.Equal(t, time.Date(2026, 2, 14, 3, 0, 0, 0, berlinLoc), config.NextRotationAllowed(blackedOut))
+		assert.Equal(t, time.Date(2026, 2, 14, 3, 0, 0, 0, berlinLoc), config.NextWindowStart(time.Date(2026, 2, 9, 12, 0, 0, 0, berlinLoc)))
+
+		// The windows themselves are left alone
+		assert.Empty(t, config.Windows[0].Timezone)
+		assert.Empty(t, config.BlackoutWindows[0].Timezone)
+	})
+}
+
+func TestMaintenanceWindowDate(t *testing.T) {
+	berlinLoc, _ := time.LoadLocation("Europe/Berlin")
+
+	// Saturday, 2026-03-14
+	window := MaintenanceWindow{
+		Name:      "migration",
+		Date:      "2026-03-14",
+		StartTime: "03:00",
+		EndTime:   "05:00",
+		Timezone:  "Europe/Berlin",
+	}
+	start := time.Date(2026, 3, 14, 3, 0, 0, 0, berlinLoc)
+
+	t.Run("before the date", func(t *testing.T) {
+		testTime := time.Date(2026, 3, 7, 4, 0, 0, 0, berlinLoc) // previous Saturday
+		assert.False(t, window.IsInWindow(testTime))
+		assert.Equal(t, start, window.NextStart(testTime))
+	})
+
+	t.Run("on the date before the window", func(t *testing.T) {
+		testTime := time.Date(2026, 3, 14, 2, 59, 0, 0, berlinLoc)
+		assert.False(t, window.IsInWindow(testTime))
+		assert.Equal(t, start, window.NextStart(testTime))
+	})
+
+	t.Run("during the window", func(t *testing.T) {
+		testTime := time.Date(2026, 3, 14, 4, 0, 0, 0, berlinLoc)
+		assert.True(t, window.IsInWindow(testTime))
+		assert.Equal(t, start, window.NextStart(testTime))
+		assert.Equal(t, time.Date(2026, 3, 14, 5, 0, 0, 0, berlinLoc), window.NextEnd(testTime))
+	})
+
+	t.Run("during the window in another timezone", func(t *testing.T) {
+		// 03:30 Berlin time is 02:30 UTC in March
+		assert.True(t, window.IsInWindow(time.Date(2026, 3, 14, 2, 30, 0, 0, time.UTC)))
+	})
+
+	t.Run("after the window", func(t *testing.T) {
+		testTime := time.Date(2026, 3, 14, 5, 0, 0, 0, berlinLoc)
+		assert.False(t, window.IsInWindow(testTime))
+		assert.True(t, window.NextStart(testTime).IsZero())
+		assert.True(t, window.NextEnd(testTime).IsZero())
+	})
+
+	t.Run("same weekday later is not in window", func(t *testing.T) {
+		testTime := time.Date(2026, 3, 21, 4, 0, 0, 0, berlinLoc) // following Saturday
+		assert.False(t, window.IsInWindow(testTime))
+		assert.True(t, window.NextStart(testTime).IsZero())
+	})
+
+	t.Run("past one-off window is skipped by config", func(t *testing.T) {
+		config := MaintenanceWindowsConfig{
+			Enabled: true,
+			Windows: []MaintenanceWindow{
+				window,
+				{
+					Name:      "weekly",
+					Days:      []string{"sunday"},
+					StartTime: "03:00",
+					EndTime:   "05:00",
+					Timezone:  "Europe/Berlin",
+				},
+			},
+		}
+
+		// Before the date, the one-off window comes first
+		testTime := time.Date(2026, 3, 13, 12, 0, 0, 0, berlinLoc)
+		assert.Equal(t, start, config.NextWindowStart(testTime))
+		assert.Equal(t, 15*time.Hour, config.DurationUntilNextWindow(testTime))
+
+		// Afterwards only the recurring window remains
+		testTime = time.Date(2026, 3, 16, 12, 0, 0, 0, berlinLoc)
+		assert.Equal(t, time.Date(2026, 3, 22, 3, 0, 0, 0, berlinLoc), config.NextWindowStart(testTime))
+	})
+
+	t.Run("only past one-off windows", func(t *testing.T) {
+		config := MaintenanceWindowsConfig{Enabled: true, Windows: []MaintenanceWindow{window}}
+		testTime := time.Date(2026, 4, 1, 12, 0, 0, 0, berlinLoc)
+		assert.False(t, config.IsInAnyWindow(testTime))
+		assert.True(t, config.NextWindowStart(testTime).IsZero())
+		assert.Equal(t, time.Duration(0), config.DurationUntilNextWindow(testTime))
+	})
+}
+
+func TestMaintenanceWindowsConfigBlackoutWindows(t *testing.T) {
+	berlinLoc, _ := time.LoadLocation("Europe/Berlin")
+
+	config := MaintenanceWindowsConfig{
+		Enabled: true,
+		Windows: []MaintenanceWindow{
+			{
+				Name:      "weekend-night",
+				Days:      []string{"saturday", "sunday"},
+				StartTime: "03:00",
+				EndTime:   "05:00",
+				Timezone:  "Europe/Berlin",
+			},
+		},
+		BlackoutWindows: []MaintenanceWindow{
+			{
+				Name:      "code-freeze",
+				Date:      "2026-02-07", // Saturday
+				StartTime: "00:00",
+				EndTime:   "04:00",
+				Timezone:  "Europe/Berlin",
+			},
+		},
+	}
+
+	t.Run("blackout overlapping maintenance window suppresses rotation", func(t *testing.T) {
+		testTime := time.Date(2026, 2, 7, 3, 30, 0, 0, berlinLoc)
+		assert.True(t, config.IsInAnyWindow(testTime))
+		assert.True(t, config.IsBlackedOut(testTime))
+		assert.False(t, config.IsRotationAllowed(testTime))
+		assert.Equal(t, "code-freeze", config.GetActiveBlackoutWindow(testTime).Name)
+		// Rest of the maintenance window after the blackout
+		assert.Equal(t, time.Date(2026, 2, 7, 4, 0, 0, 0, berlinLoc), config.NextRotationAllowed(testTime))
+	})
+
+	t.Run("maintenance window after the blackout allows rotation", func(t *testing.T) {
+		testTime := time.Date(2026, 2, 7, 4, 30, 0, 0, berlinLoc)
+		assert.False(t, config.IsBlackedOut(testTime))
+		assert.True(t, config.IsRotationAllowed(testTime))
+		assert.Equal(t, testTime, config.NextRotationAllowed(testTime))
+	})
+
+	t.Run("window start inside blackout waits for blackout end", func(t *testing.T) {
+		testTime := time.Date(2026, 2, 6, 22, 0, 0, 0, berlinLoc) // Friday
+		assert.Equal(t, time.Date(2026, 2, 7, 4, 0, 0, 0, berlinLoc), config.NextRotationAllowed(testTime))
+	})
+
+	t.Run("blackout covering a whole window skips to the next one", func(t *testing.T) {
+		covering := config
+		covering.BlackoutWindows = []MaintenanceWindow{
+			{
+				Date:      "2026-02-07",
+				StartTime: "02:00",
+				EndTime:   "06:00",
+				Timezone:  "Europe/Berlin",
+			},
+		}
+		testTime := time.Date(2026, 2, 7, 3, 30, 0, 0, berlinLoc)
+		assert.False(t, covering.IsRotationAllowed(testTime))
+		assert.Equal(t, time.Date(2026, 2, 8, 3, 0, 0, 0, berlinLoc), covering.NextRotationAllowed(testTime))
+	})
+
+	t.Run("blackout applies with maintenance windows disabled", func(t *testing.T) {
+		disabled := config
+		disabled.Enabled = false
+		testTime := time.Date(2026, 2, 7, 1, 0, 0, 0, berlinLoc)
+		assert.True(t, disabled.IsBlackedOut(testTime))
+		assert.False(t, disabled.IsRotationAllowed(testTime))
+		assert.Equal(t, time.Date(2026, 2, 7, 4, 0, 0, 0, berlinLoc), disabled.NextRotationAllowed(testTime))
+
+		// Outside the blackout, rotation is always allowed
+		testTime = time.Date(2026, 2, 9, 12, 0, 0, 0, berlinLoc)
+		assert.True(t, disabled.IsRotationAllowed(testTime))
+	})
+
+	t.Run("blackout covering every window occurrence never allows rotation", func(t *testing.T) {
+		permanent := config
+		permanent.BlackoutWindows = []MaintenanceWindow{
+			{
+				Days:      []string{"saturday", "sunday"},
+				StartTime: "00:00",
+				EndTime:   "12:00",
+				Timezone:  "Europe/Berlin",
+			},
+		}
+		testTime := time.Date(2026, 2, 9, 12, 0, 0, 0, berlinLoc)
+		assert.True(t, permanent.NextRotationAllowed(testTime).IsZero())
+	})
+}
+
+func TestMaintenanceWindowsConfigValidateBlackoutWindows(t *testing.T) {
+	valid := MaintenanceWindow{
+		Name:      "code-freeze",
+		Days:      []string{"friday"},
+		StartTime: "00:00",
+		EndTime:   "23:00",
+		Timezone:  "UTC",
+	}
+
+	config := MaintenanceWindowsConfig{BlackoutWindows: []MaintenanceWindow{valid}}
+	assert.NoError(t, config.ValidateBlackoutWindows())
+
+	invalid := valid
+	invalid.EndTime = "00:00"
+	config = MaintenanceWindowsConfig{BlackoutWindows: []MaintenanceWindow{invalid}}
+	err := config.ValidateBlackoutWindows()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "blackout window 'code-freeze'")
+
+	invalid.Name = ""
+	config = MaintenanceWindowsConfig{BlackoutWindows: []MaintenanceWindow{invalid}}
+	err = config.ValidateBlackoutWindows()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "blackoutWindows[0]")
+
+	// Validate covers blackout windows, too
+	config = MaintenanceWindowsConfig{
+		Enabled:         true,
+		Windows:         []MaintenanceWindow{valid},
+		BlackoutWindows: []MaintenanceWindow{invalid},
+	}
+	err = config.Validate()
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "blackoutWindows[0]")
+
+	// Blackout windows inherit the default timezone
+	noTimezone := valid
+	noTimezone.Timezone = ""
+	config = MaintenanceWindowsConfig{DefaultTimezone: "Europe/Berlin", BlackoutWindows: []MaintenanceWindow{noTimezone}}
+	config.ApplyDefaultTimezone()
+	assert.Equal(t, "Europe/Berlin", config.BlackoutWindows[0].Timezone)
+	assert.NoError(t, config.ValidateBlackoutWindows())
+}
@@ -0,0 +1,67 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+)
+
+// bcryptMaxPasswordLength is the number of bytes of a password bcrypt takes into account
+const bcryptMaxPasswordLength = 72
+
+// GenerateBcryptHash returns the bcrypt hash of password with the given cost, with a random salt.
+// Passwords longer than 72 bytes are rejected, as bcrypt would silently ignore the rest.
+func (g *SecretGenerator) GenerateBcryptHash(password string, cost int) (string, error) {
+	if cost < bcrypt.MinCost || cost > bcrypt.MaxCost {
+		return "", fmt.Errorf("bcrypt cost must be between %d and %d, got %d", bcrypt.MinCost, bcrypt.MaxCost, cost)
+	}
+	if len(password) > bcryptMaxPasswordLength {
+		return "", fmt.Errorf("bcrypt only supports passwords of up to %d bytes, got %d", bcryptMaxPasswordLength, len(password))
+	}
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), cost)
+	if err != nil {
+		return "", fmt.Errorf("failed to compute bcrypt hash: %w", err)
+	}
+	return string(hash), nil
+}
+
+// GenerateHtpasswd generates a random password for username and returns the htpasswd line
+// "username:bcrypthash" together with the plaintext password, so the password can be stored
+// for the clients. The password uses the default charset and length, the hash the default cost.
+func (g *SecretGenerator) GenerateHtpasswd(username string) (string, string, error) {
+	if username == "" {
+		return "", "", fmt.Errorf("htpasswd username must not be empty")
+	}
+	if strings.ContainsAny(username, ":\r\n") {
+		return "", "", fmt.Errorf("htpasswd username %q must not contain colons or line breaks", username)
+	}
+
+	password, err := g.GenerateString(config.DefaultLength)
+	if err != nil {
+		return "", "", err
+	}
+	hash, err := g.GenerateBcryptHash(password, config.DefaultBcryptCost)
+	if err != nil {
+		return "", "", err
+	}
+	return username + ":" + hash, password, nil
+}
@@ -0,0 +1,114 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+)
+
+func TestGenerateBcryptHash(t *testing.T) {
+	gen := NewSecretGenerator()
+
+	hash, err := gen.GenerateBcryptHash("s3cr3t-password", bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte("s3cr3t-password")); err != nil {
+		t.Errorf("expected hash to match the password: %v", err)
+	}
+	if cost, err := bcrypt.Cost([]byte(hash)); err != nil || cost != bcrypt.MinCost {
+		t.Errorf("expected cost %d, got %d (%v)", bcrypt.MinCost, cost, err)
+	}
+
+	// Every hash has its own salt
+	other, err := gen.GenerateBcryptHash("s3cr3t-password", bcrypt.MinCost)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if other == hash {
+		t.Error("expected hashes of the same password to differ")
+	}
+}
+
+func TestGenerateBcryptHashErrors(t *testing.T) {
+	gen := NewSecretGenerator()
+
+	tests := []struct {
+		name     string
+		password string
+		cost     int
+	}{
+		{name: "cost too low", password: "password", cost: bcrypt.MinCost - 1},
+		{name: "cost too high", password: "password", cost: bcrypt.MaxCost + 1},
+		{name: "password too long", password: strings.Repeat("a", 73), cost: bcrypt.MinCost},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := gen.GenerateBcryptHash(tt.password, tt.cost); err == nil {
+				t.Error("expected an error")
+			}
+		})
+	}
+}
+
+func TestGenerateHtpasswd(t *testing.T) {
+	gen := NewSecretGenerator()
+
+	line, plaintext, err := gen.GenerateHtpasswd("admin")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(plaintext) != 32 {
+		t.Errorf("expected a 32-character password, got %d characters", len(plaintext))
+	}
+
+	// htpasswd lines are "user:hash" with the bcrypt hash in its modular crypt format
+	user, hash, ok := strings.Cut(line, ":")
+	if !ok || user != "admin" {
+		t.Fatalf("expected an htpasswd line for user admin, got %q", line)
+	}
+	if strings.ContainsAny(line, "\r\n") {
+		t.Errorf("expected a single line, got %q", line)
+	}
+	if !strings.HasPrefix(hash, "$2a$10$") {
+		t.Errorf("expected a bcrypt hash with cost 10, got %q", hash)
+	}
+	if err := bcrypt.CompareHashAndPassword([]byte(hash), []byte(plaintext)); err != nil {
+		t.Errorf("expected hash to match the plaintext password: %v", err)
+	}
+
+	_, other, err := gen.GenerateHtpasswd("admin")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if other == plaintext {
+		t.Error("expected every call to generate a new password")
+	}
+}
+
+func TestGenerateHtpasswdErrors(t *testing.T) {
+	gen := NewSecretGenerator()
+
+	for _, username := range []string{"", "ad:min", "admin\n", "ad\rmin"} {
+		if _, _, err := gen.GenerateHtpasswd(username); err == nil {
+			t.Errorf("expected an error for username %q", username)
+		}
+	}
+}
@@ -0,0 +1,216 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+	"net"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+)
+
+// keyUsages maps the key usage names of the cert-key-usage annotation to x509 key usages
+var keyUsages = map[string]x509.KeyUsage{
+	"digital-signature":  x509.KeyUsageDigitalSignature,
+	"content-commitment": x509.KeyUsageContentCommitment,
+	"key-encipherment":   x509.KeyUsageKeyEncipherment,
+	"key-agreement":      x509.KeyUsageKeyAgreement,
+	"cert-sign":          x509.KeyUsageCertSign,
+	"crl-sign":           x509.KeyUsageCRLSign,
+}
+
+// extKeyUsages maps the extended key usage names of the cert-usage annotation to x509 extended key usages
+var extKeyUsages = map[string]x509.ExtKeyUsage{
+	"server":           x509.ExtKeyUsageServerAuth,
+	"client":           x509.ExtKeyUsageClientAuth,
+	"code-signing":     x509.ExtKeyUsageCodeSigning,
+	"email-protection": x509.ExtKeyUsageEmailProtection,
+	"ocsp-signing":     x509.ExtKeyUsageOCSPSigning,
+}
+
+// CertificateRequest holds the settings of a generated certificate
+type CertificateRequest struct {
+	CommonName string
+	// DNSNames, IPAddresses, and URIs are the subject alternative names of the certificate
+	DNSNames    []string
+	IPAddresses []net.IP
+	URIs        []*url.URL
+	// KeyUsage and ExtKeyUsage are the usages of the certificate. A certificate with
+	// x509.KeyUsageCertSign is a CA.
+	KeyUsage    x509.KeyUsage
+	ExtKeyUsage []x509.ExtKeyUsage
+	// NotBefore is the issue time; the certificate is valid from NotBefore until NotBefore + Validity
+	NotBefore time.Time
+	Validity  time.Duration
+	// CACertificatePEM and CAKeyPEM are the PEM certificate and private key of the CA signing the
+	// certificate. If CACertificatePEM is empty, the certificate is self-signed.
+	CACertificatePEM string
+	CAKeyPEM         string
+}
+
+// ParseKeyUsages parses key usage names (e.g. "digital-signature", "cert-sign") into x509 key usages
+func ParseKeyUsages(names []string) (x509.KeyUsage, error) {
+	var usage x509.KeyUsage
+	for _, name := range names {
+		u, ok := keyUsages[name]
+		if !ok {
+			return 0, fmt.Errorf("unknown key usage %q, must be one of %s", name, usageNames(keyUsages))
+		}
+		usage |= u
+	}
+	return usage, nil
+}
+
+// ParseExtKeyUsages parses extended key usage names (e.g. "server", "client") into x509 extended key usages
+func ParseExtKeyUsages(names []string) ([]x509.ExtKeyUsage, error) {
+	usages := make([]x509.ExtKeyUsage, 0, len(names))
+	for _, name := range names {
+		u, ok := extKeyUsages[name]
+		if !ok {
+			return nil, fmt.Errorf("unknown extended key usage %q, must be one of %s", name, usageNames(extKeyUsages))
+		}
+		usages = append(usages, u)
+	}
+	return usages, nil
+}
+
+// ParseIPAddresses parses IPv4 and IPv6 addresses (e.g. "10.0.0.1", "::1") for IP SANs
+func ParseIPAddresses(values []string) ([]net.IP, error) {
+	ips := make([]net.IP, 0, len(values))
+	for _, value := range values {
+		ip := net.ParseIP(value)
+		if ip == nil {
+			return nil, fmt.Errorf("invalid IP address %q", value)
+		}
+		ips = append(ips, ip)
+	}
+	return ips, nil
+}
+
+// ParseURIs parses absolute URIs (e.g. "spiffe://cluster.local/ns/default/sa/api") for URI SANs
+func ParseURIs(values []string) ([]*url.URL, error) {
+	uris := make([]*url.URL, 0, len(values))
+	for _, value := range values {
+		uri, err := url.Parse(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid URI %q: %w", value, err)
+		}
+		if !uri.IsAbs() {
+			return nil, fmt.Errorf("invalid URI %q: must be absolute with a scheme", value)
+		}
+		uris = append(uris, uri)
+	}
+	return uris, nil
+}
+
+// usageNames returns the sorted, comma-separated names of usages for error messages
+func usageNames[T any](usages map[string]T) string {
+	names := make([]string, 0, len(usages))
+	for name := range usages {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return strings.Join(names, ", ")
+}
+
+// GenerateCertificate generates an ECDSA P-256 key and an X.509 certificate for it with a random
+// serial number, signed by the CA of req or self-signed. Returns the certificate PEM and the
+// private key in PKCS#8 PEM format.
+func (g *SecretGenerator) GenerateCertificate(req CertificateRequest) (string, string, error) {
+	if req.Validity <= 0 {
+		return "", "", fmt.Errorf("certificate validity must be positive, got %s", req.Validity)
+	}
+
+	privateKey, err := ecdsa.GenerateKey(elliptic.P256(), g.reader())
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate certificate key: %w", err)
+	}
+
+	// RFC 5280 allows serial numbers of up to 20 octets
+	serial, err := rand.Int(g.reader(), new(big.Int).Lsh(big.NewInt(1), 159))
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate certificate serial number: %w", err)
+	}
+
+	template := &x509.Certificate{
+		SerialNumber:          serial,
+		Subject:               pkix.Name{CommonName: req.CommonName},
+		DNSNames:              req.DNSNames,
+		IPAddresses:           req.IPAddresses,
+		URIs:                  req.URIs,
+		NotBefore:             req.NotBefore,
+		NotAfter:              req.NotBefore.Add(req.Validity),
+		KeyUsage:              req.KeyUsage,
+		ExtKeyUsage:           req.ExtKeyUsage,
+		BasicConstraintsValid: true,
+		IsCA:                  req.KeyUsage&x509.KeyUsageCertSign != 0,
+	}
+
+	parent, signer := template, crypto.Signer(privateKey)
+	if req.CACertificatePEM != "" {
+		if parent, signer, err = parseCA(req.CACertificatePEM, req.CAKeyPEM); err != nil {
+			return "", "", err
+		}
+	}
+
+	der, err := x509.CreateCertificate(g.reader(), template, parent, &privateKey.PublicKey, signer)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to create certificate: %w", err)
+	}
+	pkcs8, err := x509.MarshalPKCS8PrivateKey(privateKey)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal certificate key: %w", err)
+	}
+
+	certificatePEM := pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der})
+	privateKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "PRIVATE KEY", Bytes: pkcs8})
+	return string(certificatePEM), string(privateKeyPEM), nil
+}
+
+// parseCA parses the PEM certificate and private key of a CA and checks that they belong together
+// and that the certificate may sign certificates
+func parseCA(certificatePEM, keyPEM string) (*x509.Certificate, crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(certificatePEM))
+	if block == nil || block.Type != "CERTIFICATE" {
+		return nil, nil, fmt.Errorf("CA certificate: no PEM certificate found")
+	}
+	ca, err := x509.ParseCertificate(block.Bytes)
+	if err != nil {
+		return nil, nil, fmt.Errorf("CA certificate: %w", err)
+	}
+	if !ca.IsCA || ca.KeyUsage&x509.KeyUsageCertSign == 0 {
+		return nil, nil, fmt.Errorf("CA certificate %q is not a CA with the cert-sign key usage", ca.Subject.CommonName)
+	}
+	key, err := parsePrivateKeyPEM(keyPEM)
+	if err != nil {
+		return nil, nil, fmt.Errorf("CA %w", err)
+	}
+	if publicKey, ok := key.Public().(interface{ Equal(crypto.PublicKey) bool }); !ok || !publicKey.Equal(ca.PublicKey) {
+		return nil, nil, fmt.Errorf("CA key doesn't belong to the CA certificate")
+	}
+	return ca, key, nil
+}
@@ -0,0 +1,238 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"crypto/ecdsa"
+	"crypto/x509"
+	"encoding/pem"
+	"net"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// parseCertificatePEM parses a PEM certificate and its PEM private key
+func parseCertificatePEM(t *testing.T, certificatePEM, privateKeyPEM string) (*x509.Certificate, any) {
+	t.Helper()
+
+	block, _ := pem.Decode([]byte(certificatePEM))
+	require.NotNil(t, block)
+	assert.Equal(t, "CERTIFICATE", block.Type)
+	cert, err := x509.ParseCertificate(block.Bytes)
+	require.NoError(t, err)
+
+	block, _ = pem.Decode([]byte(privateKeyPEM))
+	require.NotNil(t, block)
+	assert.Equal(t, "PRIVATE KEY", block.Type)
+	key, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	require.NoError(t, err)
+	return cert, key
+}
+
+func TestGenerateCertificate(t *testing.T) {
+	g := NewSecretGenerator()
+	notBefore := time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)
+
+	certificatePEM, privateKeyPEM, err := g.GenerateCertificate(CertificateRequest{
+		CommonName:  "api.example.com",
+		DNSNames:    []string{"api.example.com", "api"},
+		IPAddresses: []net.IP{net.ParseIP("10.0.0.1"), net.ParseIP("fd00::1")},
+		URIs:        []*url.URL{{Scheme: "spiffe", Host: "cluster.local", Path: "/ns/default/sa/api"}},
+		KeyUsage:    x509.KeyUsageDigitalSignature | x509.KeyUsageKeyEncipherment,
+		ExtKeyUsage: []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth},
+		NotBefore:   notBefore,
+		Validity:    24 * time.Hour,
+	})
+	require.NoError(t, err)
+	cert, key := parseCertificatePEM(t, certificatePEM, privateKeyPEM)
+
+	assert.Equal(t, "api.example.com", cert.Subject.CommonName)
+	assert.Equal(t, []string{"api.example.com", "api"}, cert.DNSNames)
+	require.Len(t, cert.IPAddresses, 2)
+	assert.True(t, cert.IPAddresses[0].Equal(net.ParseIP("10.0.0.1")))
+	assert.True(t, cert.IPAddresses[1].Equal(net.ParseIP("fd00::1")))
+	require.Len(t, cert.URIs, 1)
+	assert.Equal(t, "spiffe://cluster.local/ns/default/sa/api", cert.URIs[0].String())
+	assert.Equal(t, x509.KeyUsageDigitalSignature|x509.KeyUsageKeyEncipherment, cert.KeyUsage)
+	assert.Equal(t, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}, cert.ExtKeyUsage)
+	assert.True(t, cert.NotBefore.Equal(notBefore))
+	assert.True(t, cert.NotAfter.Equal(notBefore.Add(24*time.Hour)))
+	assert.False(t, cert.IsCA)
+	assert.Positive(t, cert.SerialNumber.Sign())
+
+	// The certificate is self-signed and belongs to the private key
+	assert.NoError(t, cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature))
+	privateKey, ok := key.(*ecdsa.PrivateKey)
+	require.True(t, ok, "expected an ECDSA private key, got %T", key)
+	assert.True(t, privateKey.PublicKey.Equal(cert.PublicKey))
+}
+
+func TestGenerateCertificateCA(t *testing.T) {
+	g := NewSecretGenerator()
+
+	certificatePEM, privateKeyPEM, err := g.GenerateCertificate(CertificateRequest{
+		CommonName: "Internal CA",
+		KeyUsage:   x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		NotBefore:  time.Now(),
+		Validity:   time.Hour,
+	})
+	require.NoError(t, err)
+	cert, _ := parseCertificatePEM(t, certificatePEM, privateKeyPEM)
+
+	assert.True(t, cert.IsCA, "expected a certificate with cert-sign usage to be a CA")
+	assert.True(t, cert.BasicConstraintsValid)
+	assert.Empty(t, cert.ExtKeyUsage)
+}
+
+func TestGenerateCertificateCASigned(t *testing.T) {
+	g := NewSecretGenerator()
+	notBefore := time.Now()
+
+	caPEM, caKeyPEM, err := g.GenerateCertificate(CertificateRequest{
+		CommonName: "Internal CA",
+		KeyUsage:   x509.KeyUsageCertSign,
+		NotBefore:  notBefore,
+		Validity:   24 * time.Hour,
+	})
+	require.NoError(t, err)
+	ca, _ := parseCertificatePEM(t, caPEM, caKeyPEM)
+
+	certificatePEM, privateKeyPEM, err := g.GenerateCertificate(CertificateRequest{
+		CommonName:       "api.example.com",
+		DNSNames:         []string{"api.example.com"},
+		KeyUsage:         x509.KeyUsageDigitalSignature,
+		ExtKeyUsage:      []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth},
+		NotBefore:        notBefore,
+		Validity:         time.Hour,
+		CACertificatePEM: caPEM,
+		CAKeyPEM:         caKeyPEM,
+	})
+	require.NoError(t, err)
+	cert, key := parseCertificatePEM(t, certificatePEM, privateKeyPEM)
+
+	// The certificate is signed by the CA, not by its own key
+	assert.Equal(t, "Internal CA", cert.Issuer.CommonName)
+	assert.NoError(t, cert.CheckSignatureFrom(ca))
+	assert.Error(t, cert.CheckSignature(cert.SignatureAlgorithm, cert.RawTBSCertificate, cert.Signature))
+	roots := x509.NewCertPool()
+	roots.AddCert(ca)
+	_, err = cert.Verify(x509.VerifyOptions{Roots: roots, DNSName: "api.example.com", CurrentTime: notBefore.Add(time.Minute)})
+	assert.NoError(t, err)
+	privateKey, ok := key.(*ecdsa.PrivateKey)
+	require.True(t, ok, "expected an ECDSA private key, got %T", key)
+	assert.True(t, privateKey.PublicKey.Equal(cert.PublicKey))
+}
+
+func TestGenerateCertificateInvalidCA(t *testing.T) {
+	g := NewSecretGenerator()
+	caPEM, caKeyPEM, err := g.GenerateCertificate(CertificateRequest{KeyUsage: x509.KeyUsageCertSign, NotBefore: time.Now(), Validity: time.Hour})
+	require.NoError(t, err)
+	leafPEM, leafKeyPEM, err := g.GenerateCertificate(CertificateRequest{NotBefore: time.Now(), Validity: time.Hour})
+	require.NoError(t, err)
+
+	tests := []struct {
+		name          string
+		caPEM, keyPEM string
+		wantErr       string
+	}{
+		{name: "no certificate", caPEM: caKeyPEM, keyPEM: caKeyPEM, wantErr: "no PEM certificate found"},
+		{name: "not a CA", caPEM: leafPEM, keyPEM: leafKeyPEM, wantErr: "is not a CA"},
+		{name: "key of another certificate", caPEM: caPEM, keyPEM: leafKeyPEM, wantErr: "CA key doesn't belong"},
+		{name: "invalid key", caPEM: caPEM, keyPEM: "invalid", wantErr: "no PEM block found"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := g.GenerateCertificate(CertificateRequest{
+				NotBefore:        time.Now(),
+				Validity:         time.Hour,
+				CACertificatePEM: tt.caPEM,
+				CAKeyPEM:         tt.keyPEM,
+			})
+			assert.ErrorContains(t, err, tt.wantErr)
+		})
+	}
+}
+
+func TestGenerateCertificateUniqueSerials(t *testing.T) {
+	g := NewSecretGenerator()
+	req := CertificateRequest{NotBefore: time.Now(), Validity: time.Hour}
+
+	first, firstKey, err := g.GenerateCertificate(req)
+	require.NoError(t, err)
+	second, secondKey, err := g.GenerateCertificate(req)
+	require.NoError(t, err)
+
+	firstCert, _ := parseCertificatePEM(t, first, firstKey)
+	secondCert, _ := parseCertificatePEM(t, second, secondKey)
+	assert.NotEqual(t, firstCert.SerialNumber, secondCert.SerialNumber)
+	assert.NotEqual(t, firstKey, secondKey)
+}
+
+func TestGenerateCertificateInvalidValidity(t *testing.T) {
+	g := NewSecretGenerator()
+
+	_, _, err := g.GenerateCertificate(CertificateRequest{NotBefore: time.Now()})
+	assert.ErrorContains(t, err, "validity must be positive")
+}
+
+func TestParseKeyUsages(t *testing.T) {
+	usage, err := ParseKeyUsages([]string{"digital-signature", "key-encipherment", "cert-sign"})
+	require.NoError(t, err)
+	assert.Equal(t, x509.KeyUsageDigitalSignature|x509.KeyUsageKeyEncipherment|x509.KeyUsageCertSign, usage)
+
+	_, err = ParseKeyUsages([]string{"digital-signature", "server"})
+	assert.ErrorContains(t, err, `unknown key usage "server"`)
+}
+
+func TestParseExtKeyUsages(t *testing.T) {
+	usages, err := ParseExtKeyUsages([]string{"server", "client"})
+	require.NoError(t, err)
+	assert.Equal(t, []x509.ExtKeyUsage{x509.ExtKeyUsageServerAuth, x509.ExtKeyUsageClientAuth}, usages)
+
+	_, err = ParseExtKeyUsages([]string{"cert-sign"})
+	assert.ErrorContains(t, err, `unknown extended key usage "cert-sign"`)
+}
+
+func TestParseIPAddresses(t *testing.T) {
+	ips, err := ParseIPAddresses([]string{"10.0.0.1", "::1"})
+	require.NoError(t, err)
+	require.Len(t, ips, 2)
+	assert.True(t, ips[0].Equal(net.ParseIP("10.0.0.1")))
+	assert.True(t, ips[1].Equal(net.IPv6loopback))
+
+	_, err = ParseIPAddresses([]string{"10.0.0.1", "api.example.com"})
+	assert.ErrorContains(t, err, `invalid IP address "api.example.com"`)
+}
+
+func TestParseURIs(t *testing.T) {
+	uris, err := ParseURIs([]string{"spiffe://cluster.local/ns/default/sa/api", "urn:uuid:6e8bc430-9c3a-11d9-9669-0800200c9a66"})
+	require.NoError(t, err)
+	require.Len(t, uris, 2)
+	assert.Equal(t, "spiffe", uris[0].Scheme)
+	assert.Equal(t, "cluster.local", uris[0].Host)
+	assert.Equal(t, "urn", uris[1].Scheme)
+
+	_, err = ParseURIs([]string{"/ns/default/sa/api"})
+	assert.ErrorContains(t, err, "must be absolute")
+
+	_, err = ParseURIs([]string{"spiffe://cluster local"})
+	assert.ErrorContains(t, err, `invalid URI "spiffe://cluster local"`)
+}
@@ -0,0 +1,140 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"crypto/rand"
+	"fmt"
+	"math/big"
+	"strings"
+)
+
+// ComplexityRequirements specifies the minimum number of characters per character class
+// for GenerateComplexString.
+type ComplexityRequirements struct {
+	MinUppercase int
+	MinLowercase int
+	MinDigits    int
+	MinSymbols   int
+	// Charset is the set of allowed characters. The character classes are taken from it,
+	// symbols being all characters that are not ASCII letters or digits.
+	// If empty, DefaultCharset is used.
+	Charset string
+}
+
+// IsZero returns true if no minimum is required for any character class
+func (r ComplexityRequirements) IsZero() bool {
+	return r.MinUppercase == 0 && r.MinLowercase == 0 && r.MinDigits == 0 && r.MinSymbols == 0
+}
+
+// characterClass is a named subset of a charset with a required minimum count
+type characterClass struct {
+	name  string
+	chars string
+	min   int
+}
+
+// splitCharset returns the character classes of charset with the minimums from req
+func splitCharset(charset string, req ComplexityRequirements) []characterClass {
+	var upper, lower, digits, symbols strings.Builder
+	for i := 0; i < len(charset); i++ {
+		c := charset[i]
+		switch {
+		case c >= 'A' && c <= 'Z':
+			upper.WriteByte(c)
+		case c >= 'a' && c <= 'z':
+			lower.WriteByte(c)
+		case c >= '0' && c <= '9':
+			digits.WriteByte(c)
+		default:
+			symbols.WriteByte(c)
+		}
+	}
+	return []characterClass{
+		{name: "uppercase", chars: upper.String(), min: req.MinUppercase},
+		{name: "lowercase", chars: lower.String(), min: req.MinLowercase},
+		{name: "digit", chars: digits.String(), min: req.MinDigits},
+		{name: "symbol", chars: symbols.String(), min: req.MinSymbols},
+	}
+}
+
+// GenerateComplexString generates a random string of the specified length that contains at
+// least the required number of characters of each class. The remaining characters are drawn
+// from the whole charset and the result is shuffled, so required characters don't appear at
+// predictable positions. It returns an error if the requirements cannot be met.
+func (g *SecretGenerator) GenerateComplexString(length int, req ComplexityRequirements) (string, error) {
+	if length <= 0 {
+		return "", fmt.Errorf("length must be positive, got %d", length)
+	}
+	charset := req.Charset
+	if charset == "" {
+		charset = DefaultCharset
+	}
+
+	classes := splitCharset(charset, req)
+	total := 0
+	for _, class := range classes {
+		if class.min < 0 {
+			return "", fmt.Errorf("minimum %s count must not be negative, got %d", class.name, class.min)
+		}
+		if class.min > 0 && class.chars == "" {
+			return "", fmt.Errorf("%d %s character(s) required, but charset contains none", class.min, class.name)
+		}
+		total += class.min
+	}
+	if total > length {
+		return "", fmt.Errorf("complexity requirements need %d characters, but length is %d", total, length)
+	}
+
+	result := make([]byte, 0, length)
+	for _, class := range classes {
+		for i := 0; i < class.min; i++ {
+			c, err := g.randomChar(class.chars)
+			if err != nil {
+				return "", err
+			}
+			result = append(result, c)
+		}
+	}
+	for len(result) < length {
+		c, err := g.randomChar(charset)
+		if err != nil {
+			return "", err
+		}
+		result = append(result, c)
+	}
+
+	// Fisher-Yates shuffle
+	for i := len(result) - 1; i > 0; i-- {
+		j, err := rand.Int(g.reader(), big.NewInt(int64(i+1)))
+		if err != nil {
+			return "", fmt.Errorf("failed to generate random index: %w", err)
+		}
+		result[i], result[j.Int64()] = result[j.Int64()], result[i]
+	}
+
+	return string(result), nil
+}
+
+// randomChar returns a uniformly chosen character of chars
+func (g *SecretGenerator) randomChar(chars string) (byte, error) {
+	n, err := rand.Int(g.reader(), big.NewInt(int64(len(chars))))
+	if err != nil {
+		return 0, fmt.Errorf("failed to generate random index: %w", err)
+	}
+	return chars[n.Int64()], nil
+}
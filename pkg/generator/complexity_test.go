@@ -0,0 +1,145 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// classCounts counts the characters of each class in s
+func classCounts(s string) (upper, lower, digits, symbols int) {
+	for i := 0; i < len(s); i++ {
+		c := s[i]
+		switch {
+		case c >= 'A' && c <= 'Z':
+			upper++
+		case c >= 'a' && c <= 'z':
+			lower++
+		case c >= '0' && c <= '9':
+			digits++
+		default:
+			symbols++
+		}
+	}
+	return
+}
+
+func TestGenerateComplexString(t *testing.T) {
+	gen := NewSecretGenerator()
+	req := ComplexityRequirements{MinUppercase: 1, MinLowercase: 1, MinDigits: 1, MinSymbols: 1}
+
+	for i := 0; i < 1000; i++ {
+		result, err := gen.GenerateComplexString(12, req)
+		require.NoError(t, err)
+		require.Len(t, result, 12)
+
+		upper, lower, digits, symbols := classCounts(result)
+		assert.GreaterOrEqual(t, upper, 1, "missing uppercase in %q", result)
+		assert.GreaterOrEqual(t, lower, 1, "missing lowercase in %q", result)
+		assert.GreaterOrEqual(t, digits, 1, "missing digit in %q", result)
+		assert.GreaterOrEqual(t, symbols, 1, "missing symbol in %q", result)
+
+		for j := 0; j < len(result); j++ {
+			assert.True(t, strings.IndexByte(DefaultCharset, result[j]) >= 0, "unexpected character %q", result[j])
+		}
+	}
+}
+
+func TestGenerateComplexStringHigherMinimums(t *testing.T) {
+	gen := NewSecretGenerator()
+	req := ComplexityRequirements{MinUppercase: 3, MinDigits: 4, MinSymbols: 2, Charset: "ABCDEFabcdef0123456789!?"}
+
+	for i := 0; i < 200; i++ {
+		result, err := gen.GenerateComplexString(10, req)
+		require.NoError(t, err)
+
+		upper, _, digits, symbols := classCounts(result)
+		assert.GreaterOrEqual(t, upper, 3)
+		assert.GreaterOrEqual(t, digits, 4)
+		assert.GreaterOrEqual(t, symbols, 2)
+		for j := 0; j < len(result); j++ {
+			assert.True(t, strings.IndexByte(req.Charset, result[j]) >= 0, "character %q not in charset", result[j])
+		}
+	}
+}
+
+func TestGenerateComplexStringShuffled(t *testing.T) {
+	gen := NewSecretGenerator()
+	// Only digits are required; with a letters+digits charset, a missing shuffle would
+	// always place the required digit at position 0
+	req := ComplexityRequirements{MinDigits: 1, Charset: AlphanumericCharset}
+
+	digitPositions := make(map[int]bool)
+	for i := 0; i < 1000; i++ {
+		result, err := gen.GenerateComplexString(8, req)
+		require.NoError(t, err)
+		for j := 0; j < len(result); j++ {
+			if result[j] >= '0' && result[j] <= '9' {
+				digitPositions[j] = true
+			}
+		}
+	}
+	assert.Len(t, digitPositions, 8, "expected digits to appear at every position")
+}
+
+func TestGenerateComplexStringImpossibleRequirements(t *testing.T) {
+	gen := NewSecretGenerator()
+
+	tests := []struct {
+		name     string
+		length   int
+		req      ComplexityRequirements
+		errorMsg string
+	}{
+		{
+			name:     "minimums exceed length",
+			length:   4,
+			req:      ComplexityRequirements{MinUppercase: 2, MinLowercase: 2, MinDigits: 1},
+			errorMsg: "need 5 characters, but length is 4",
+		},
+		{
+			name:     "class missing from charset",
+			length:   16,
+			req:      ComplexityRequirements{MinSymbols: 1, Charset: AlphanumericCharset},
+			errorMsg: "symbol character(s) required, but charset contains none",
+		},
+		{
+			name:     "negative minimum",
+			length:   16,
+			req:      ComplexityRequirements{MinDigits: -1},
+			errorMsg: "must not be negative",
+		},
+		{
+			name:     "zero length",
+			length:   0,
+			req:      ComplexityRequirements{MinDigits: 1},
+			errorMsg: "length must be positive",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := gen.GenerateComplexString(tt.length, tt.req)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorMsg)
+		})
+	}
+}
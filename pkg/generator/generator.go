@@ -25,9 +25,18 @@ import (
 	"crypto/rand"
 	"crypto/rsa"
 	"crypto/x509"
+	"encoding/base32"
+	"encoding/base64"
+	"encoding/hex"
 	"encoding/pem"
 	"fmt"
+	"io"
+	"math"
+	"net"
+	"sort"
+	"strings"
 
+	"filippo.io/age"
 	"github.com/cloudflare/circl/sign/mldsa/mldsa65"
 	"github.com/cloudflare/circl/sign/mldsa/mldsa87"
 	"github.com/cloudflare/circl/sign/slhdsa"
@@ -43,13 +52,28 @@ type Generator interface {
 	GenerateStringWithCharset(length int, charset string) (string, error)
 	// GenerateBytes generates random bytes of the specified length
 	GenerateBytes(length int) ([]byte, error)
+	// GenerateStrings generates count random strings of the specified length using the
+	// default charset, drawing the randomness for all of them at once
+	GenerateStrings(count, length int) ([]string, error)
+	// GenerateBytesBatch generates count random byte slices of the specified length,
+	// drawing the randomness for all of them at once
+	GenerateBytesBatch(count, length int) ([][]byte, error)
 	// GenerateRSAKeypair generates an RSA keypair with the given key size in bits.
 	// Returns (privateKeyPEM, publicKeyPEM, error).
 	GenerateRSAKeypair(bits int) (string, string, error)
+	// GenerateRSAKeypairPKCS8 generates an RSA keypair with the private key in PKCS#8 format.
+	// Returns (privateKeyPEM, publicKeyPEM, error).
+	GenerateRSAKeypairPKCS8(bits int) (string, string, error)
 	// GenerateECDSAKeypair generates an ECDSA keypair for the given curve name.
 	// Supported curves: P-256, P-384, P-521.
 	// Returns (privateKeyPEM, publicKeyPEM, error).
 	GenerateECDSAKeypair(curveName string) (string, string, error)
+	// GenerateEncryptedRSAKeypair generates an RSA keypair whose private key is encrypted with passphrase.
+	// Returns (encrypted PKCS#8 privateKeyPEM, publicKeyPEM, error).
+	GenerateEncryptedRSAKeypair(bits int, passphrase string) (string, string, error)
+	// GenerateEncryptedECDSAKeypair generates an ECDSA keypair whose private key is encrypted with passphrase.
+	// Returns (encrypted PKCS#8 privateKeyPEM, publicKeyPEM, error).
+	GenerateEncryptedECDSAKeypair(curveName, passphrase string) (string, string, error)
 	// GenerateEd25519Keypair generates an Ed25519 keypair.
 	// Returns (privateKeyPEM, publicKeyPEM, error).
 	GenerateEd25519Keypair() (string, string, error)
@@ -65,16 +89,70 @@ type Generator interface {
 	// Supported params: "128s", "128f", "192s", "192f", "256s", "256f".
 	// Returns (privateKey, publicKey, error) as raw bytes encoded to string.
 	GenerateSLHDSAKeypair(param string) (string, string, error)
+	// GenerateAgeKeypair generates an age X25519 keypair.
+	// Returns (identity, recipient, error) in the standard age string formats.
+	GenerateAgeKeypair() (string, string, error)
+	// GenerateSSHKeypair generates an SSH keypair ("rsa" with the given bits, or "ed25519").
+	// Returns (private key in OpenSSH PEM format, public key in authorized_keys format, error).
+	GenerateSSHKeypair(keyType string, bits int) (string, string, error)
+	// GenerateMAC generates a random locally-administered unicast MAC address
+	// formatted with colons (e.g. "02:1a:2b:3c:4d:5e").
+	GenerateMAC() (string, error)
+	// GenerateUUID generates a random RFC 4122 version 4 UUID
+	// (e.g. "f47ac10b-58cc-4372-a567-0e02b2c3d479").
+	GenerateUUID() (string, error)
+	// GenerateURLSafePassword generates a password that can be embedded in a URL userinfo
+	// component without escaping. The output is lengthened to match the entropy of a
+	// DefaultCharset string of the given length.
+	GenerateURLSafePassword(length int) (string, error)
+	// GenerateTOTPSecret generates a TOTP shared secret of the given number of random bytes,
+	// base32-encoded without padding (e.g. "JBSWY3DPEHPK3PXP").
+	GenerateTOTPSecret(bytes int) (string, error)
+	// GenerateNumeric generates exactly length random digits, leading zeros included (e.g. "007123").
+	GenerateNumeric(length int) (string, error)
+	// GeneratePassphrase generates a passphrase of the given number of random words from the
+	// embedded wordlist, joined by separator (e.g. "correct-horse-battery-staple").
+	GeneratePassphrase(words int, separator string) (string, error)
 	// Generate generates a value based on the specified type
 	Generate(genType string, length int) (string, error)
 	// GenerateWithCharset generates a value based on the specified type with a custom charset
 	GenerateWithCharset(genType string, length int, charset string) (string, error)
+	// GenerateWithPrefix generates a value like GenerateWithCharset and prepends prefix, which
+	// doesn't count towards length (e.g. "sk_live_" for API keys)
+	GenerateWithPrefix(prefix, genType string, length int, charset string) (string, error)
+	// GenerateComplexString generates a random string that contains at least the required
+	// number of characters of each character class.
+	GenerateComplexString(length int, req ComplexityRequirements) (string, error)
+	// GenerateJWT generates a JWT with the given claims, signed with the PEM-encoded
+	// RSA, ECDSA, or Ed25519 private key.
+	GenerateJWT(signingKeyPEM string, claims JWTClaims) (string, error)
+	// GenerateCertificate generates an ECDSA P-256 key and an X.509 certificate for it, signed by
+	// the CA of req or self-signed. Returns (certificatePEM, PKCS#8 privateKeyPEM, error).
+	GenerateCertificate(req CertificateRequest) (string, string, error)
+	// GenerateBcryptHash returns the bcrypt hash of password with the given cost
+	GenerateBcryptHash(password string, cost int) (string, error)
+	// GenerateHtpasswd generates a random password for username and returns the htpasswd line
+	// "username:bcrypthash" and the plaintext password
+	GenerateHtpasswd(username string) (line, plaintext string, err error)
+	// GenerateEncoded generates a value based on the specified type and applies the given
+	// output encoding ("raw", "hex", "base64"). Encodings other than "raw" are only supported
+	// for the bytes type, where length is the number of random bytes before encoding.
+	GenerateEncoded(genType string, length int, encoding string) (string, error)
+	// GenerateEncodedOutputLength generates random bytes encoded in the given output encoding,
+	// trimmed to exactly outputLength characters
+	GenerateEncodedOutputLength(outputLength int, encoding string) (string, error)
+	// GenerateBytesWithEncodings generates length random bytes and returns them together with
+	// their representation in each of the given output encodings, all derived from the same
+	// random draw. The map is keyed by encoding.
+	GenerateBytesWithEncodings(length int, encodings []string) ([]byte, map[string]string, error)
 }
 
 // SecretGenerator implements the Generator interface using crypto/rand
 type SecretGenerator struct {
 	// defaultCharset is the default character set used for string generation
 	defaultCharset string
+	// rand is the source of randomness, crypto/rand unless injected for tests
+	rand io.Reader
 }
 
 // DefaultCharset is the default character set for generating random strings
@@ -83,10 +161,99 @@ const DefaultCharset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123
 // AlphanumericCharset contains only alphanumeric characters
 const AlphanumericCharset = "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ0123456789"
 
+// NumericCharset contains the decimal digits
+const NumericCharset = "0123456789"
+
+// URLSafeCharset contains the RFC 3986 unreserved characters, which never need
+// percent-encoding inside a URL userinfo component
+const URLSafeCharset = AlphanumericCharset + "-._~"
+
+// AmbiguousChars contains characters that are easily confused when read or typed manually
+// (0/O/o, 1/l/I/|)
+const AmbiguousChars = "0Oo1lI|"
+
+// ExcludeAmbiguous returns charset without the characters in AmbiguousChars.
+// It returns an error if no characters remain.
+func ExcludeAmbiguous(charset string) (string, error) {
+	filtered := strings.Map(func(r rune) rune {
+		if strings.ContainsRune(AmbiguousChars, r) {
+			return -1
+		}
+		return r
+	}, charset)
+	if filtered == "" {
+		return "", fmt.Errorf("charset %q contains only ambiguous characters", charset)
+	}
+	return filtered, nil
+}
+
+// Contexts a generated value can be required to be safe for, see UnsafeChars
+const (
+	// SafeForShell requires values that can be used in POSIX shell commands without quoting issues
+	SafeForShell = "shell"
+	// SafeForURL requires values that can be embedded in a URL without percent-encoding
+	SafeForURL = "url"
+)
+
+// ShellUnsafeChars contains characters with special meaning to POSIX shells, inside double
+// quotes or when unquoted (expansion, quoting, globbing, word splitting, and command syntax)
+const ShellUnsafeChars = "`$\\\"'!&|;<>(){}[]*?# "
+
+// UnsafeChars returns the characters of charset that aren't safe in the given context
+// (SafeForShell or SafeForURL), in charset order and without duplicates.
+// It returns an error if the context is unknown.
+func UnsafeChars(charset, context string) (string, error) {
+	var isUnsafe func(r rune) bool
+	switch context {
+	case SafeForShell:
+		isUnsafe = func(r rune) bool { return strings.ContainsRune(ShellUnsafeChars, r) }
+	case SafeForURL:
+		isUnsafe = func(r rune) bool { return !strings.ContainsRune(URLSafeCharset, r) }
+	default:
+		return "", fmt.Errorf("unknown safety context %q, must be '%s' or '%s'", context, SafeForShell, SafeForURL)
+	}
+
+	var unsafe strings.Builder
+	for _, r := range charset {
+		if isUnsafe(r) && !strings.ContainsRune(unsafe.String(), r) {
+			unsafe.WriteRune(r)
+		}
+	}
+	return unsafe.String(), nil
+}
+
+// charsetPresets maps the names accepted by CharsetForPreset to their charsets
+var charsetPresets = map[string]string{
+	"alphanumeric": AlphanumericCharset,
+	"alpha":        "abcdefghijklmnopqrstuvwxyzABCDEFGHIJKLMNOPQRSTUVWXYZ",
+	"numeric":      NumericCharset,
+	"hex":          "0123456789abcdef",
+	"lowercase":    "abcdefghijklmnopqrstuvwxyz",
+	"base64url":    AlphanumericCharset + "-_",
+	// All printable ASCII characters except space
+	"ascii-symbols": AlphanumericCharset + "!\"#$%&'()*+,-./:;<=>?@[\\]^_`{|}~",
+}
+
+// CharsetForPreset returns the charset of a named preset.
+// It returns an error if the preset is unknown.
+func CharsetForPreset(name string) (string, error) {
+	charset, ok := charsetPresets[name]
+	if !ok {
+		presets := make([]string, 0, len(charsetPresets))
+		for preset := range charsetPresets {
+			presets = append(presets, preset)
+		}
+		sort.Strings(presets)
+		return "", fmt.Errorf("unknown charset preset %q (valid presets: %s)", name, strings.Join(presets, ", "))
+	}
+	return charset, nil
+}
+
 // NewSecretGenerator creates a new SecretGenerator with default settings
 func NewSecretGenerator() *SecretGenerator {
 	return &SecretGenerator{
 		defaultCharset: AlphanumericCharset,
+		rand:           rand.Reader,
 	}
 }
 
@@ -94,9 +261,30 @@ func NewSecretGenerator() *SecretGenerator {
 func NewSecretGeneratorWithCharset(charset string) *SecretGenerator {
 	return &SecretGenerator{
 		defaultCharset: charset,
+		rand:           rand.Reader,
 	}
 }
 
+// NewSecretGeneratorWithReader creates a new SecretGenerator that reads its randomness from r
+// instead of crypto/rand. It is meant for tests: a seeded reader makes strings, bytes,
+// passphrases, and Ed25519 keys reproducible. RSA and ECDSA key generation ignore r, as the
+// standard library always uses a secure source for them. Never use it outside of tests.
+func NewSecretGeneratorWithReader(r io.Reader) *SecretGenerator {
+	return &SecretGenerator{
+		defaultCharset: AlphanumericCharset,
+		rand:           r,
+	}
+}
+
+// reader returns the source of randomness, crypto/rand unless the generator was created
+// with NewSecretGeneratorWithReader
+func (g *SecretGenerator) reader() io.Reader {
+	if g.rand == nil {
+		return rand.Reader
+	}
+	return g.rand
+}
+
 // GenerateString generates a random string of the specified length using the default charset
 func (g *SecretGenerator) GenerateString(length int) (string, error) {
 	return g.GenerateStringWithCharset(length, g.defaultCharset)
@@ -111,18 +299,38 @@ func (g *SecretGenerator) GenerateStringWithCharset(length int, charset string)
 		return "", fmt.Errorf("charset must not be empty")
 	}
 
-	result := make([]byte, length)
+	result := make([]byte, 0, length)
 	charsetLen := len(charset)
 
-	// Generate random bytes
-	randomBytes := make([]byte, length)
-	if _, err := rand.Read(randomBytes); err != nil {
-		return "", fmt.Errorf("failed to generate random bytes: %w", err)
+	// Bytes can only address 256 characters, use uniform big.Int selection beyond that
+	if charsetLen > 256 {
+		for len(result) < length {
+			c, err := g.randomChar(charset)
+			if err != nil {
+				return "", err
+			}
+			result = append(result, c)
+		}
+		return string(result), nil
 	}
 
-	// Map random bytes to charset characters
-	for i := 0; i < length; i++ {
-		result[i] = charset[int(randomBytes[i])%charsetLen]
+	// Rejection sampling: discard random bytes >= limit, the largest multiple of charsetLen
+	// not above 256, so every character of the charset is equally likely (no modulo bias)
+	limit := 256 - 256%charsetLen
+	randomBytes := make([]byte, length)
+	for len(result) < length {
+		if _, err := io.ReadFull(g.reader(), randomBytes); err != nil {
+			return "", fmt.Errorf("failed to generate random bytes: %w", err)
+		}
+		for _, b := range randomBytes {
+			if int(b) >= limit {
+				continue
+			}
+			result = append(result, charset[int(b)%charsetLen])
+			if len(result) == length {
+				break
+			}
+		}
 	}
 
 	return string(result), nil
@@ -135,13 +343,134 @@ func (g *SecretGenerator) GenerateBytes(length int) ([]byte, error) {
 	}
 
 	randomBytes := make([]byte, length)
-	if _, err := rand.Read(randomBytes); err != nil {
+	if _, err := io.ReadFull(g.reader(), randomBytes); err != nil {
 		return nil, fmt.Errorf("failed to generate random bytes: %w", err)
 	}
 
 	return randomBytes, nil
 }
 
+// GenerateStrings generates count random strings of the specified length using the default charset.
+// The characters of all strings are sampled from a single buffer, which saves reads from the random
+// source when many values are needed; each character is still uniformly distributed.
+func (g *SecretGenerator) GenerateStrings(count, length int) ([]string, error) {
+	if err := validateBatch(count, length); err != nil {
+		return nil, err
+	}
+
+	combined, err := g.GenerateStringWithCharset(count*length, g.defaultCharset)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]string, count)
+	for i := range result {
+		result[i] = combined[i*length : (i+1)*length]
+	}
+	return result, nil
+}
+
+// GenerateBytesBatch generates count random byte slices of the specified length from a single read
+// of the random source
+func (g *SecretGenerator) GenerateBytesBatch(count, length int) ([][]byte, error) {
+	if err := validateBatch(count, length); err != nil {
+		return nil, err
+	}
+
+	combined, err := g.GenerateBytes(count * length)
+	if err != nil {
+		return nil, err
+	}
+
+	// Cap each slice so appending to one value can't overwrite the next
+	result := make([][]byte, count)
+	for i := range result {
+		result[i] = combined[i*length : (i+1)*length : (i+1)*length]
+	}
+	return result, nil
+}
+
+// validateBatch checks the count and length of a batch generation
+func validateBatch(count, length int) error {
+	if count <= 0 {
+		return fmt.Errorf("count must be positive, got %d", count)
+	}
+	if length <= 0 {
+		return fmt.Errorf("length must be positive, got %d", length)
+	}
+	if count > math.MaxInt/length {
+		return fmt.Errorf("batch of %d values of length %d is too large", count, length)
+	}
+	return nil
+}
+
+// GenerateMAC generates a random MAC address with the locally-administered bit set
+// and the multicast bit cleared, formatted as six colon-separated hex octets.
+func (g *SecretGenerator) GenerateMAC() (string, error) {
+	mac, err := g.GenerateBytes(6)
+	if err != nil {
+		return "", err
+	}
+	// Set the locally-administered bit (0x02) and clear the multicast bit (0x01)
+	mac[0] = (mac[0] | 0x02) &^ 0x01
+	return net.HardwareAddr(mac).String(), nil
+}
+
+// GenerateUUID generates a random RFC 4122 version 4 UUID in its canonical
+// lowercase 8-4-4-4-12 hex form.
+func (g *SecretGenerator) GenerateUUID() (string, error) {
+	uuid, err := g.GenerateBytes(16)
+	if err != nil {
+		return "", err
+	}
+	// Set the version (4) and the RFC 4122 variant (10xx)
+	uuid[6] = (uuid[6] & 0x0f) | 0x40
+	uuid[8] = (uuid[8] & 0x3f) | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", uuid[0:4], uuid[4:6], uuid[6:8], uuid[8:10], uuid[10:16]), nil
+}
+
+// GenerateNumeric generates a string of exactly length uniformly random digits. Leading zeros are
+// kept, so the value must be treated as a string, not a number.
+func (g *SecretGenerator) GenerateNumeric(length int) (string, error) {
+	return g.GenerateStringWithCharset(length, NumericCharset)
+}
+
+// GenerateTOTPSecret generates a TOTP shared secret of the given number of random bytes, encoded
+// with the standard base32 alphabet without padding, as expected by authenticator apps.
+func (g *SecretGenerator) GenerateTOTPSecret(bytes int) (string, error) {
+	secret, err := g.GenerateBytes(bytes)
+	if err != nil {
+		return "", err
+	}
+	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(secret), nil
+}
+
+// GenerateURLSafePassword generates a password from URLSafeCharset. Since URLSafeCharset is
+// smaller than DefaultCharset, the password is lengthened so its entropy is at least that of
+// a DefaultCharset string with the requested length.
+func (g *SecretGenerator) GenerateURLSafePassword(length int) (string, error) {
+	if length <= 0 {
+		return "", fmt.Errorf("length must be positive, got %d", length)
+	}
+	return g.GenerateStringWithCharset(URLSafePasswordLength(length), URLSafeCharset)
+}
+
+// URLSafePasswordLength returns the number of URLSafeCharset characters needed to reach
+// the entropy of a DefaultCharset string with the given length.
+func URLSafePasswordLength(length int) int {
+	targetBits := EstimateEntropyBits(len(DefaultCharset), length)
+	return int(math.Ceil(targetBits / math.Log2(float64(len(URLSafeCharset)))))
+}
+
+// EstimateEntropyBits returns the entropy in bits of a value of length characters drawn
+// uniformly from a charset of charsetLen characters. A single-character charset has none.
+func EstimateEntropyBits(charsetLen, length int) float64 {
+	if charsetLen <= 1 || length <= 0 {
+		return 0
+	}
+	return float64(length) * math.Log2(float64(charsetLen))
+}
+
 // Generate generates a value based on the specified type using the default charset
 func (g *SecretGenerator) Generate(genType string, length int) (string, error) {
 	return g.GenerateWithCharset(genType, length, g.defaultCharset)
@@ -158,30 +487,78 @@ func (g *SecretGenerator) GenerateWithCharset(genType string, length int, charse
 			return "", err
 		}
 		return string(bytes), nil
-	case config.TypeRSA, config.TypeECDSA, config.TypeEd25519, config.TypeMLKEM, config.TypeMLDSA, config.TypeSLHDSA:
+	case config.TypeMAC:
+		return g.GenerateMAC()
+	case config.TypeUUID:
+		return g.GenerateUUID()
+	case config.TypeURLSafePassword:
+		return g.GenerateURLSafePassword(length)
+	case config.TypeTOTP:
+		return g.GenerateTOTPSecret(length)
+	case config.TypeNumeric:
+		return g.GenerateNumeric(length)
+	case config.TypePassphrase:
+		return g.GeneratePassphrase(length, config.DefaultPassphraseSeparator)
+	case config.TypeRSA, config.TypeECDSA, config.TypeEd25519, config.TypeMLKEM, config.TypeMLDSA, config.TypeSLHDSA, config.TypeAge,
+		config.TypeSSHRSA, config.TypeSSHEd25519:
 		return "", fmt.Errorf("keypair types must be generated using dedicated keypair methods, not GenerateWithCharset")
+	case config.TypeJWT:
+		return "", fmt.Errorf("jwt type must be generated using GenerateJWT, not GenerateWithCharset")
+	case config.TypeBcrypt:
+		return "", fmt.Errorf("bcrypt type must be generated using GenerateBcryptHash, not GenerateWithCharset")
+	case config.TypeHtpasswd:
+		return "", fmt.Errorf("htpasswd type must be generated using GenerateHtpasswd, not GenerateWithCharset")
 	default:
 		return "", fmt.Errorf("unknown generation type: %s", genType)
 	}
 }
 
+// GenerateWithPrefix generates a value like GenerateWithCharset and prepends prefix. The prefix
+// doesn't count towards length, so the random portion keeps its full entropy.
+func (g *SecretGenerator) GenerateWithPrefix(prefix, genType string, length int, charset string) (string, error) {
+	value, err := g.GenerateWithCharset(genType, length, charset)
+	if err != nil {
+		return "", err
+	}
+	return prefix + value, nil
+}
+
 // GenerateRSAKeypair generates an RSA keypair with the given key size in bits.
 // Returns the private key and public key in PKCS#1 PEM format.
 func (g *SecretGenerator) GenerateRSAKeypair(bits int) (string, string, error) {
+	return g.generateRSAKeypair(bits, false)
+}
+
+// GenerateRSAKeypairPKCS8 generates an RSA keypair with the given key size in bits.
+// Returns the private key in PKCS#8 PEM format ("PRIVATE KEY") and the public key in PKCS#1 PEM format.
+func (g *SecretGenerator) GenerateRSAKeypairPKCS8(bits int) (string, string, error) {
+	return g.generateRSAKeypair(bits, true)
+}
+
+// generateRSAKeypair generates an RSA keypair, encoding the private key as PKCS#8 or PKCS#1
+func (g *SecretGenerator) generateRSAKeypair(bits int, pkcs8 bool) (string, string, error) {
 	if bits < 1024 {
 		return "", "", fmt.Errorf("RSA key size must be at least 1024 bits, got %d", bits)
 	}
 
-	privateKey, err := rsa.GenerateKey(rand.Reader, bits)
+	privateKey, err := rsa.GenerateKey(g.reader(), bits)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to generate RSA key: %w", err)
 	}
 
-	// Encode private key in PKCS#1 PEM format
-	privateKeyPEM := pem.EncodeToMemory(&pem.Block{
+	// Encode private key in PKCS#1 or PKCS#8 PEM format
+	privateKeyBlock := &pem.Block{
 		Type:  "RSA PRIVATE KEY",
 		Bytes: x509.MarshalPKCS1PrivateKey(privateKey),
-	})
+	}
+	if pkcs8 {
+		der, err := x509.MarshalPKCS8PrivateKey(privateKey)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to marshal RSA private key: %w", err)
+		}
+		privateKeyBlock = &pem.Block{Type: "PRIVATE KEY", Bytes: der}
+	}
+	privateKeyPEM := pem.EncodeToMemory(privateKeyBlock)
 
 	// Encode public key in PKCS#1 PEM format
 	publicKeyPEM := pem.EncodeToMemory(&pem.Block{
@@ -195,12 +572,15 @@ func (g *SecretGenerator) GenerateRSAKeypair(bits int) (string, string, error) {
 // GenerateECDSAKeypair generates an ECDSA keypair for the given curve name.
 // Returns the private key in EC PEM format and public key in PKIX PEM format.
 func (g *SecretGenerator) GenerateECDSAKeypair(curveName string) (string, string, error) {
+	if curveName == CurveSecp256k1 {
+		return g.generateSecp256k1Keypair("")
+	}
 	curve, err := parseCurve(curveName)
 	if err != nil {
 		return "", "", err
 	}
 
-	privateKey, err := ecdsa.GenerateKey(curve, rand.Reader)
+	privateKey, err := ecdsa.GenerateKey(curve, g.reader())
 	if err != nil {
 		return "", "", fmt.Errorf("failed to generate ECDSA key: %w", err)
 	}
@@ -231,7 +611,7 @@ func (g *SecretGenerator) GenerateECDSAKeypair(curveName string) (string, string
 // GenerateEd25519Keypair generates an Ed25519 keypair.
 // Returns the private key and public key in PKCS#8/PKIX PEM format.
 func (g *SecretGenerator) GenerateEd25519Keypair() (string, string, error) {
-	publicKey, privateKey, err := ed25519.GenerateKey(rand.Reader)
+	publicKey, privateKey, err := ed25519.GenerateKey(g.reader())
 	if err != nil {
 		return "", "", fmt.Errorf("failed to generate Ed25519 key: %w", err)
 	}
@@ -259,6 +639,113 @@ func (g *SecretGenerator) GenerateEd25519Keypair() (string, string, error) {
 	return string(privateKeyPEM), string(publicKeyPEM), nil
 }
 
+// GenerateEncoded generates a value based on the specified type and encodes it.
+// For the bytes type, length random bytes are generated and then encoded, so the
+// resulting string is longer than length for hex and base64.
+func (g *SecretGenerator) GenerateEncoded(genType string, length int, encoding string) (string, error) {
+	if encoding == "" || encoding == config.EncodingRaw {
+		return g.Generate(genType, length)
+	}
+	if genType != config.TypeBytes {
+		return "", fmt.Errorf("encoding %q is only supported for type %q, got %q", encoding, config.TypeBytes, genType)
+	}
+
+	randomBytes, err := g.GenerateBytes(length)
+	if err != nil {
+		return "", err
+	}
+	return EncodeBytes(randomBytes, encoding)
+}
+
+// GenerateEncodedOutputLength generates as many random bytes as are needed for outputLength
+// characters in the given output encoding ("raw", "hex", "base64") and trims the encoded value to
+// exactly outputLength characters, e.g. a 44-character base64 token from 33 random bytes.
+// Trimmed base64 values never contain padding, but may not decode to whole bytes.
+func (g *SecretGenerator) GenerateEncodedOutputLength(outputLength int, encoding string) (string, error) {
+	length, err := BytesForOutputLength(outputLength, encoding)
+	if err != nil {
+		return "", err
+	}
+	randomBytes, err := g.GenerateBytes(length)
+	if err != nil {
+		return "", err
+	}
+	encoded, err := EncodeBytes(randomBytes, encoding)
+	if err != nil {
+		return "", err
+	}
+	return encoded[:outputLength], nil
+}
+
+// BytesForOutputLength returns the number of random bytes needed to encode at least outputLength
+// characters in the given output encoding ("raw", "hex", "base64")
+func BytesForOutputLength(outputLength int, encoding string) (int, error) {
+	if outputLength <= 0 {
+		return 0, fmt.Errorf("output length must be positive, got %d", outputLength)
+	}
+	switch encoding {
+	case "", config.EncodingRaw:
+		return outputLength, nil
+	case config.EncodingHex:
+		// Two characters per byte
+		return (outputLength + 1) / 2, nil
+	case config.EncodingBase64:
+		// Four characters per three bytes, the last group may be partial
+		return (outputLength*3 + 3) / 4, nil
+	default:
+		return 0, fmt.Errorf("unsupported encoding %q, must be 'raw', 'hex', or 'base64'", encoding)
+	}
+}
+
+// GenerateBytesWithEncodings generates length random bytes once and encodes them in every
+// requested encoding, so e.g. a key and its base64 form for display share the same entropy.
+// Encodings are validated before any random bytes are drawn.
+func (g *SecretGenerator) GenerateBytesWithEncodings(length int, encodings []string) ([]byte, map[string]string, error) {
+	for _, encoding := range encodings {
+		if _, err := EncodeBytes(nil, encoding); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	randomBytes, err := g.GenerateBytes(length)
+	if err != nil {
+		return nil, nil, err
+	}
+	encoded := make(map[string]string, len(encodings))
+	for _, encoding := range encodings {
+		// Encodings were validated above
+		encoded[encoding], _ = EncodeBytes(randomBytes, encoding)
+	}
+	return randomBytes, encoded, nil
+}
+
+// EncodeBytes encodes raw in the given output encoding ("raw", "hex", "base64")
+func EncodeBytes(raw []byte, encoding string) (string, error) {
+	switch encoding {
+	case "", config.EncodingRaw:
+		return string(raw), nil
+	case config.EncodingHex:
+		return hex.EncodeToString(raw), nil
+	case config.EncodingBase64:
+		return base64.StdEncoding.EncodeToString(raw), nil
+	default:
+		return "", fmt.Errorf("unsupported encoding %q, must be 'raw', 'hex', or 'base64'", encoding)
+	}
+}
+
+// PEMToDER decodes a single PEM block and returns its DER bytes.
+// Used to convert the PEM output of the keypair methods into binary form.
+func PEMToDER(pemData string) ([]byte, error) {
+	block, rest := pem.Decode([]byte(pemData))
+	if block == nil {
+		return nil, fmt.Errorf("no PEM block found")
+	}
+	if len(rest) > 0 {
+		return nil, fmt.Errorf("unexpected data after PEM block")
+	}
+	return block.Bytes, nil
+}
+
 // parseCurve parses a curve name string into an elliptic.Curve
 func parseCurve(curveName string) (elliptic.Curve, error) {
 	switch curveName {
@@ -269,7 +756,7 @@ func parseCurve(curveName string) (elliptic.Curve, error) {
 	case "P-521":
 		return elliptic.P521(), nil
 	default:
-		return nil, fmt.Errorf("unsupported ECDSA curve: %s, must be 'P-256', 'P-384', or 'P-521'", curveName)
+		return nil, fmt.Errorf("unsupported ECDSA curve: %s, must be 'P-256', 'P-384', 'P-521', or 'secp256k1'", curveName)
 	}
 }
 
@@ -301,7 +788,7 @@ func (g *SecretGenerator) GenerateMLKEMKeypair(param string) (string, string, er
 func (g *SecretGenerator) GenerateMLDSAKeypair(param string) (string, string, error) {
 	switch param {
 	case "65":
-		pk, sk, err := mldsa65.GenerateKey(rand.Reader)
+		pk, sk, err := mldsa65.GenerateKey(g.reader())
 		if err != nil {
 			return "", "", fmt.Errorf("failed to generate ML-DSA-65 key: %w", err)
 		}
@@ -315,7 +802,7 @@ func (g *SecretGenerator) GenerateMLDSAKeypair(param string) (string, string, er
 		}
 		return string(skBytes), string(pkBytes), nil
 	case "87":
-		pk, sk, err := mldsa87.GenerateKey(rand.Reader)
+		pk, sk, err := mldsa87.GenerateKey(g.reader())
 		if err != nil {
 			return "", "", fmt.Errorf("failed to generate ML-DSA-87 key: %w", err)
 		}
@@ -355,7 +842,7 @@ func (g *SecretGenerator) GenerateSLHDSAKeypair(param string) (string, string, e
 		return "", "", fmt.Errorf("unsupported SLH-DSA parameter: %s, must be '128s', '128f', '192s', '192f', '256s', or '256f'", param)
 	}
 
-	pk, sk, err := slhdsa.GenerateKey(rand.Reader, id)
+	pk, sk, err := slhdsa.GenerateKey(g.reader(), id)
 	if err != nil {
 		return "", "", fmt.Errorf("failed to generate SLH-DSA-%s key: %w", param, err)
 	}
@@ -372,3 +859,15 @@ func (g *SecretGenerator) GenerateSLHDSAKeypair(param string) (string, string, e
 
 	return string(skBytes), string(pkBytes), nil
 }
+
+// GenerateAgeKeypair generates an age X25519 keypair.
+// Returns the identity ("AGE-SECRET-KEY-1...") and the recipient ("age1...")
+// in the formats used by age and SOPS.
+func (g *SecretGenerator) GenerateAgeKeypair() (string, string, error) {
+	identity, err := age.GenerateX25519Identity()
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate age key: %w", err)
+	}
+
+	return identity.String(), identity.Recipient().String(), nil
+}
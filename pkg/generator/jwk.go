@@ -0,0 +1,166 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"math/big"
+)
+
+// RSAPublicKeyToJWK converts a PEM-encoded RSA public key (PKCS#1 or PKIX) to a JWK (RFC 7517).
+// The kid is the RFC 7638 thumbprint of the key.
+func RSAPublicKeyToJWK(pubPEM string) (string, error) {
+	key, err := parsePublicKeyPEM(pubPEM)
+	if err != nil {
+		return "", err
+	}
+	rsaKey, ok := key.(*rsa.PublicKey)
+	if !ok {
+		return "", fmt.Errorf("expected an RSA public key, got %T", key)
+	}
+	return publicJWK(rsaKey)
+}
+
+// ECDSAPublicKeyToJWK converts a PEM-encoded ECDSA public key (PKIX) on P-256, P-384, or P-521
+// to a JWK (RFC 7517). The kid is the RFC 7638 thumbprint of the key.
+func ECDSAPublicKeyToJWK(pubPEM string) (string, error) {
+	key, err := parsePublicKeyPEM(pubPEM)
+	if err != nil {
+		return "", err
+	}
+	ecKey, ok := key.(*ecdsa.PublicKey)
+	if !ok {
+		return "", fmt.Errorf("expected an ECDSA public key, got %T", key)
+	}
+	return publicJWK(ecKey)
+}
+
+// Ed25519PublicKeyToJWK converts a PEM-encoded Ed25519 public key (PKIX) to an OKP JWK (RFC 8037).
+// The kid is the RFC 7638 thumbprint of the key.
+func Ed25519PublicKeyToJWK(pubPEM string) (string, error) {
+	key, err := parsePublicKeyPEM(pubPEM)
+	if err != nil {
+		return "", err
+	}
+	edKey, ok := key.(ed25519.PublicKey)
+	if !ok {
+		return "", fmt.Errorf("expected an Ed25519 public key, got %T", key)
+	}
+	return publicJWK(edKey)
+}
+
+// PublicKeyToJWK converts a PEM-encoded RSA, ECDSA, or Ed25519 public key to a JWK, like the
+// type-specific functions
+func PublicKeyToJWK(pubPEM string) (string, error) {
+	key, err := parsePublicKeyPEM(pubPEM)
+	if err != nil {
+		return "", err
+	}
+	return publicJWK(key)
+}
+
+// parsePublicKeyPEM parses a PEM-encoded PKCS#1 RSA or PKIX public key
+func parsePublicKeyPEM(pubPEM string) (any, error) {
+	block, _ := pem.Decode([]byte(pubPEM))
+	if block == nil {
+		return nil, fmt.Errorf("public key: no PEM block found")
+	}
+
+	var key any
+	var err error
+	switch block.Type {
+	case "RSA PUBLIC KEY":
+		key, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	case "PUBLIC KEY":
+		key, err = x509.ParsePKIXPublicKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("public key: unsupported PEM block type %q", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("public key: %w", err)
+	}
+	return key, nil
+}
+
+// publicJWK returns the JSON of the public JWK of key, with its thumbprint as kid
+func publicJWK(key any) (string, error) {
+	members, err := jwkMembers(key)
+	if err != nil {
+		return "", err
+	}
+
+	// The thumbprint input is the JSON of the required members in lexicographic order without
+	// whitespace, which is how encoding/json marshals a map
+	thumbprintInput, err := json.Marshal(members)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JWK: %w", err)
+	}
+	thumbprint := sha256.Sum256(thumbprintInput)
+	members["kid"] = base64.RawURLEncoding.EncodeToString(thumbprint[:])
+
+	jwk, err := json.Marshal(members)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JWK: %w", err)
+	}
+	return string(jwk), nil
+}
+
+// jwkMembers returns the required members of the public JWK of key
+func jwkMembers(key any) (map[string]string, error) {
+	switch k := key.(type) {
+	case *rsa.PublicKey:
+		return map[string]string{
+			"kty": "RSA",
+			"n":   base64.RawURLEncoding.EncodeToString(k.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(k.E)).Bytes()),
+		}, nil
+	case *ecdsa.PublicKey:
+		crv := k.Curve.Params().Name
+		if _, err := parseCurve(crv); err != nil {
+			return nil, fmt.Errorf("public key: %w", err)
+		}
+		ecdhKey, err := k.ECDH()
+		if err != nil {
+			return nil, fmt.Errorf("public key: %w", err)
+		}
+		// Uncompressed point: 0x04 || X || Y, both coordinates padded to the curve size
+		point := ecdhKey.Bytes()
+		size := (len(point) - 1) / 2
+		return map[string]string{
+			"kty": "EC",
+			"crv": crv,
+			"x":   base64.RawURLEncoding.EncodeToString(point[1 : 1+size]),
+			"y":   base64.RawURLEncoding.EncodeToString(point[1+size:]),
+		}, nil
+	case ed25519.PublicKey:
+		return map[string]string{
+			"kty": "OKP",
+			"crv": "Ed25519",
+			"x":   base64.RawURLEncoding.EncodeToString(k),
+		}, nil
+	default:
+		return nil, fmt.Errorf("public key: unsupported key type %T for JWK", key)
+	}
+}
@@ -0,0 +1,136 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// publicKeyFromJWK unmarshals a JWK and reconstructs its public key
+func publicKeyFromJWK(t *testing.T, jwk string) (map[string]string, any) {
+	t.Helper()
+	var members map[string]string
+	require.NoError(t, json.Unmarshal([]byte(jwk), &members))
+	decode := func(name string) []byte {
+		value, err := base64.RawURLEncoding.DecodeString(members[name])
+		require.NoError(t, err, "member %s", name)
+		return value
+	}
+
+	switch members["kty"] {
+	case "RSA":
+		return members, &rsa.PublicKey{N: new(big.Int).SetBytes(decode("n")), E: int(new(big.Int).SetBytes(decode("e")).Int64())}
+	case "EC":
+		curves := map[string]elliptic.Curve{"P-256": elliptic.P256(), "P-384": elliptic.P384(), "P-521": elliptic.P521()}
+		curve, ok := curves[members["crv"]]
+		require.True(t, ok, "unexpected curve %q", members["crv"])
+		key, err := ecdsa.ParseUncompressedPublicKey(curve, append([]byte{4}, append(decode("x"), decode("y")...)...))
+		require.NoError(t, err)
+		return members, key
+	case "OKP":
+		require.Equal(t, "Ed25519", members["crv"])
+		return members, ed25519.PublicKey(decode("x"))
+	default:
+		t.Fatalf("unexpected key type %q", members["kty"])
+		return nil, nil
+	}
+}
+
+func TestPublicKeyToJWK(t *testing.T) {
+	gen := NewSecretGenerator()
+
+	tests := []struct {
+		name     string
+		generate func() (string, string, error)
+		convert  func(string) (string, error)
+		kty      string
+	}{
+		{name: "rsa", generate: func() (string, string, error) { return gen.GenerateRSAKeypair(2048) }, convert: RSAPublicKeyToJWK, kty: "RSA"},
+		{name: "ecdsa P-256", generate: func() (string, string, error) { return gen.GenerateECDSAKeypair("P-256") }, convert: ECDSAPublicKeyToJWK, kty: "EC"},
+		{name: "ecdsa P-521", generate: func() (string, string, error) { return gen.GenerateECDSAKeypair("P-521") }, convert: ECDSAPublicKeyToJWK, kty: "EC"},
+		{name: "ed25519", generate: gen.GenerateEd25519Keypair, convert: Ed25519PublicKeyToJWK, kty: "OKP"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			privateKeyPEM, publicKeyPEM, err := tt.generate()
+			require.NoError(t, err)
+
+			jwk, err := tt.convert(publicKeyPEM)
+			require.NoError(t, err)
+			generic, err := PublicKeyToJWK(publicKeyPEM)
+			require.NoError(t, err)
+			assert.Equal(t, jwk, generic)
+
+			members, publicKey := publicKeyFromJWK(t, jwk)
+			assert.Equal(t, tt.kty, members["kty"])
+			assert.NotEmpty(t, members["kid"])
+			assert.NotContains(t, members, "d", "JWK must not contain private key material")
+
+			// The reconstructed key is the public key of the generated private key
+			signer, err := parsePrivateKeyPEM(privateKeyPEM)
+			require.NoError(t, err)
+			assert.True(t, signer.Public().(interface{ Equal(crypto.PublicKey) bool }).Equal(publicKey), "reconstructed key differs from the generated key")
+		})
+	}
+}
+
+func TestPublicKeyToJWKThumbprint(t *testing.T) {
+	// RFC 7638, section 3.1
+	n, err := base64.RawURLEncoding.DecodeString("0vx7agoebGcQSuuPiLJXZptN9nndrQmbXEps2aiAFbWhM78LhWx4cbbfAAtVT86zwu1RK7aPFFxuhDR1L6tSoc_BJECPebWKRXjBZCiFV4n3oknjhMstn64tZ_2W-5JsGY4Hc5n9yBXArwl93lqt7_RN5w6Cf0h4QyQ5v-65YGjQR0_FDW2QvzqY368QQMicAtaSqzs8KJZgnYb9c7d0zgdAZHzu6qMQvRL5hajrn1n91CbOpbISD08qNLyrdkt-bFTWhAI4vMQFh6WeZu0fM4lFd2NcRwr3XPksINHaQ-G_xBniIqbw0Ls1jF44-csFCur-kEgU8awapJzKnqDKgw")
+	require.NoError(t, err)
+	publicKeyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PUBLIC KEY",
+		Bytes: x509.MarshalPKCS1PublicKey(&rsa.PublicKey{N: new(big.Int).SetBytes(n), E: 65537}),
+	})
+
+	jwk, err := RSAPublicKeyToJWK(string(publicKeyPEM))
+	require.NoError(t, err)
+	members, _ := publicKeyFromJWK(t, jwk)
+	assert.Equal(t, "NzbLsXh8uDCcd-6MNwXF4W_7noWXFZAfHkxZsRGC9Xs", members["kid"])
+	assert.Equal(t, "AQAB", members["e"])
+}
+
+func TestPublicKeyToJWKErrors(t *testing.T) {
+	gen := NewSecretGenerator()
+	_, ed25519PEM, err := gen.GenerateEd25519Keypair()
+	require.NoError(t, err)
+	_, secp256k1PEM, err := gen.GenerateECDSAKeypair(CurveSecp256k1)
+	require.NoError(t, err)
+
+	_, err = RSAPublicKeyToJWK(ed25519PEM)
+	assert.Error(t, err, "Ed25519 key converted as RSA")
+	_, err = ECDSAPublicKeyToJWK(ed25519PEM)
+	assert.Error(t, err, "Ed25519 key converted as ECDSA")
+	_, err = PublicKeyToJWK(secp256k1PEM)
+	assert.Error(t, err, "secp256k1 keys are not supported")
+	_, err = PublicKeyToJWK("not a key")
+	assert.Error(t, err)
+}
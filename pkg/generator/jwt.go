@@ -0,0 +1,203 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/hex"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"hash"
+	"io"
+	"time"
+)
+
+// JWTClaims holds the claims of a generated JWT
+type JWTClaims struct {
+	Issuer   string
+	Subject  string
+	Audience []string
+	// IssuedAt is the issue time; the token is valid from IssuedAt until IssuedAt + TTL
+	IssuedAt time.Time
+	TTL      time.Duration
+}
+
+// jwtPayload is the JSON representation of JWTClaims
+type jwtPayload struct {
+	Issuer    string `json:"iss,omitempty"`
+	Subject   string `json:"sub,omitempty"`
+	Audience  any    `json:"aud,omitempty"`
+	IssuedAt  int64  `json:"iat"`
+	NotBefore int64  `json:"nbf"`
+	ExpiresAt int64  `json:"exp"`
+	ID        string `json:"jti"`
+}
+
+// GenerateJWT generates a signed JWT with the given claims and a random token ID (jti).
+// signingKeyPEM is a PEM-encoded RSA, ECDSA, or Ed25519 private key (PKCS#1, SEC 1, or PKCS#8).
+// The signing algorithm is derived from the key: RS256, ES256/ES384/ES512 (by curve), or EdDSA.
+func (g *SecretGenerator) GenerateJWT(signingKeyPEM string, claims JWTClaims) (string, error) {
+	if claims.TTL <= 0 {
+		return "", fmt.Errorf("JWT TTL must be positive, got %s", claims.TTL)
+	}
+
+	key, err := parsePrivateKeyPEM(signingKeyPEM)
+	if err != nil {
+		return "", err
+	}
+	alg, err := jwtAlgorithm(key)
+	if err != nil {
+		return "", err
+	}
+
+	jti := make([]byte, 16)
+	if _, err := io.ReadFull(g.reader(), jti); err != nil {
+		return "", fmt.Errorf("failed to generate JWT ID: %w", err)
+	}
+
+	payload := jwtPayload{
+		Issuer:    claims.Issuer,
+		Subject:   claims.Subject,
+		IssuedAt:  claims.IssuedAt.Unix(),
+		NotBefore: claims.IssuedAt.Unix(),
+		ExpiresAt: claims.IssuedAt.Add(claims.TTL).Unix(),
+		ID:        hex.EncodeToString(jti),
+	}
+	switch len(claims.Audience) {
+	case 0:
+	case 1:
+		payload.Audience = claims.Audience[0]
+	default:
+		payload.Audience = claims.Audience
+	}
+
+	headerJSON, err := json.Marshal(map[string]string{"alg": alg, "typ": "JWT"})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JWT header: %w", err)
+	}
+	payloadJSON, err := json.Marshal(payload)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal JWT claims: %w", err)
+	}
+
+	signingInput := base64.RawURLEncoding.EncodeToString(headerJSON) + "." + base64.RawURLEncoding.EncodeToString(payloadJSON)
+	signature, err := g.signJWT(key, []byte(signingInput))
+	if err != nil {
+		return "", err
+	}
+
+	return signingInput + "." + base64.RawURLEncoding.EncodeToString(signature), nil
+}
+
+// parsePrivateKeyPEM parses a PEM-encoded RSA, ECDSA, or Ed25519 private key
+func parsePrivateKeyPEM(keyPEM string) (crypto.Signer, error) {
+	block, _ := pem.Decode([]byte(keyPEM))
+	if block == nil {
+		return nil, fmt.Errorf("signing key: no PEM block found")
+	}
+
+	var key any
+	var err error
+	switch block.Type {
+	case "RSA PRIVATE KEY":
+		key, err = x509.ParsePKCS1PrivateKey(block.Bytes)
+	case "EC PRIVATE KEY":
+		key, err = x509.ParseECPrivateKey(block.Bytes)
+	case "PRIVATE KEY":
+		key, err = x509.ParsePKCS8PrivateKey(block.Bytes)
+	default:
+		return nil, fmt.Errorf("signing key: unsupported PEM block type %q", block.Type)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("signing key: %w", err)
+	}
+
+	signer, ok := key.(crypto.Signer)
+	if !ok {
+		return nil, fmt.Errorf("signing key: unsupported key type %T", key)
+	}
+	return signer, nil
+}
+
+// jwtAlgorithm returns the JWS algorithm name for the given key
+func jwtAlgorithm(key crypto.Signer) (string, error) {
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		return "RS256", nil
+	case ed25519.PrivateKey:
+		return "EdDSA", nil
+	case *ecdsa.PrivateKey:
+		switch k.Curve {
+		case elliptic.P256():
+			return "ES256", nil
+		case elliptic.P384():
+			return "ES384", nil
+		case elliptic.P521():
+			return "ES512", nil
+		}
+		return "", fmt.Errorf("signing key: unsupported ECDSA curve %s", k.Curve.Params().Name)
+	default:
+		return "", fmt.Errorf("signing key: unsupported key type %T", key)
+	}
+}
+
+// signJWT signs the JWS signing input with the given key
+func (g *SecretGenerator) signJWT(key crypto.Signer, signingInput []byte) ([]byte, error) {
+	switch k := key.(type) {
+	case ed25519.PrivateKey:
+		return ed25519.Sign(k, signingInput), nil
+	case *rsa.PrivateKey:
+		digest := sha256.Sum256(signingInput)
+		signature, err := rsa.SignPKCS1v15(g.reader(), k, crypto.SHA256, digest[:])
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign JWT: %w", err)
+		}
+		return signature, nil
+	case *ecdsa.PrivateKey:
+		var h hash.Hash
+		switch k.Curve {
+		case elliptic.P256():
+			h = sha256.New()
+		case elliptic.P384():
+			h = sha512.New384()
+		default:
+			h = sha512.New()
+		}
+		h.Write(signingInput)
+		r, s, err := ecdsa.Sign(g.reader(), k, h.Sum(nil))
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign JWT: %w", err)
+		}
+		// JWS uses the fixed-size R || S encoding instead of ASN.1
+		size := (k.Curve.Params().BitSize + 7) / 8
+		signature := make([]byte, 2*size)
+		r.FillBytes(signature[:size])
+		s.FillBytes(signature[size:])
+		return signature, nil
+	default:
+		return nil, fmt.Errorf("signing key: unsupported key type %T", key)
+	}
+}
@@ -0,0 +1,203 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// verifyJWT verifies the signature of token with the PEM public key and returns its header and claims
+func verifyJWT(t *testing.T, token, publicKeyPEM string) (map[string]any, map[string]any) {
+	t.Helper()
+
+	parts := strings.Split(token, ".")
+	require.Len(t, parts, 3, "JWT must have three parts")
+
+	headerJSON, err := base64.RawURLEncoding.DecodeString(parts[0])
+	require.NoError(t, err)
+	payloadJSON, err := base64.RawURLEncoding.DecodeString(parts[1])
+	require.NoError(t, err)
+	signature, err := base64.RawURLEncoding.DecodeString(parts[2])
+	require.NoError(t, err)
+
+	var header, claims map[string]any
+	require.NoError(t, json.Unmarshal(headerJSON, &header))
+	require.NoError(t, json.Unmarshal(payloadJSON, &claims))
+
+	block, _ := pem.Decode([]byte(publicKeyPEM))
+	require.NotNil(t, block)
+	var publicKey any
+	if block.Type == "RSA PUBLIC KEY" {
+		publicKey, err = x509.ParsePKCS1PublicKey(block.Bytes)
+	} else {
+		publicKey, err = x509.ParsePKIXPublicKey(block.Bytes)
+	}
+	require.NoError(t, err)
+
+	signingInput := []byte(parts[0] + "." + parts[1])
+	switch k := publicKey.(type) {
+	case ed25519.PublicKey:
+		assert.Equal(t, "EdDSA", header["alg"])
+		assert.True(t, ed25519.Verify(k, signingInput, signature), "invalid EdDSA signature")
+	case *rsa.PublicKey:
+		assert.Equal(t, "RS256", header["alg"])
+		digest := sha256.Sum256(signingInput)
+		assert.NoError(t, rsa.VerifyPKCS1v15(k, crypto.SHA256, digest[:], signature), "invalid RS256 signature")
+	case *ecdsa.PublicKey:
+		var digest []byte
+		switch header["alg"] {
+		case "ES256":
+			d := sha256.Sum256(signingInput)
+			digest = d[:]
+		case "ES384":
+			d := sha512.Sum384(signingInput)
+			digest = d[:]
+		case "ES512":
+			d := sha512.Sum512(signingInput)
+			digest = d[:]
+		default:
+			t.Fatalf("unexpected ECDSA algorithm %v", header["alg"])
+		}
+		size := len(signature) / 2
+		r := new(big.Int).SetBytes(signature[:size])
+		s := new(big.Int).SetBytes(signature[size:])
+		assert.True(t, ecdsa.Verify(k, digest, r, s), "invalid ECDSA signature")
+	default:
+		t.Fatalf("unexpected public key type %T", publicKey)
+	}
+
+	return header, claims
+}
+
+func TestGenerateJWT(t *testing.T) {
+	gen := NewSecretGenerator()
+	issuedAt := time.Date(2026, 2, 2, 10, 0, 0, 0, time.UTC)
+
+	keypairs := map[string]func() (string, string, error){
+		"ed25519": gen.GenerateEd25519Keypair,
+		"rsa":     func() (string, string, error) { return gen.GenerateRSAKeypair(2048) },
+		"P-256":   func() (string, string, error) { return gen.GenerateECDSAKeypair("P-256") },
+		"P-384":   func() (string, string, error) { return gen.GenerateECDSAKeypair("P-384") },
+		"P-521":   func() (string, string, error) { return gen.GenerateECDSAKeypair("P-521") },
+	}
+
+	for name, keypair := range keypairs {
+		t.Run(name, func(t *testing.T) {
+			privateKey, publicKey, err := keypair()
+			require.NoError(t, err)
+
+			token, err := gen.GenerateJWT(privateKey, JWTClaims{
+				Issuer:   "secrets-operator",
+				Subject:  "service-a",
+				Audience: []string{"service-b"},
+				IssuedAt: issuedAt,
+				TTL:      time.Hour,
+			})
+			require.NoError(t, err)
+
+			header, claims := verifyJWT(t, token, publicKey)
+			assert.Equal(t, "JWT", header["typ"])
+			assert.Equal(t, "secrets-operator", claims["iss"])
+			assert.Equal(t, "service-a", claims["sub"])
+			assert.Equal(t, "service-b", claims["aud"])
+			assert.Equal(t, float64(issuedAt.Unix()), claims["iat"])
+			assert.Equal(t, float64(issuedAt.Unix()), claims["nbf"])
+			assert.Equal(t, float64(issuedAt.Add(time.Hour).Unix()), claims["exp"])
+			assert.NotEmpty(t, claims["jti"])
+		})
+	}
+}
+
+func TestGenerateJWTMultipleAudiences(t *testing.T) {
+	gen := NewSecretGenerator()
+	privateKey, publicKey, err := gen.GenerateEd25519Keypair()
+	require.NoError(t, err)
+
+	token, err := gen.GenerateJWT(privateKey, JWTClaims{
+		Audience: []string{"service-b", "service-c"},
+		IssuedAt: time.Now(),
+		TTL:      time.Hour,
+	})
+	require.NoError(t, err)
+
+	_, claims := verifyJWT(t, token, publicKey)
+	assert.Equal(t, []any{"service-b", "service-c"}, claims["aud"])
+	assert.NotContains(t, claims, "iss")
+	assert.NotContains(t, claims, "sub")
+}
+
+func TestGenerateJWTUniqueID(t *testing.T) {
+	gen := NewSecretGenerator()
+	privateKey, publicKey, err := gen.GenerateEd25519Keypair()
+	require.NoError(t, err)
+
+	claims := JWTClaims{IssuedAt: time.Now(), TTL: time.Hour}
+	token1, err := gen.GenerateJWT(privateKey, claims)
+	require.NoError(t, err)
+	token2, err := gen.GenerateJWT(privateKey, claims)
+	require.NoError(t, err)
+
+	_, claims1 := verifyJWT(t, token1, publicKey)
+	_, claims2 := verifyJWT(t, token2, publicKey)
+	assert.NotEqual(t, claims1["jti"], claims2["jti"])
+}
+
+func TestGenerateJWTErrors(t *testing.T) {
+	gen := NewSecretGenerator()
+	privateKey, _, err := gen.GenerateEd25519Keypair()
+	require.NoError(t, err)
+
+	tests := []struct {
+		name     string
+		key      string
+		ttl      time.Duration
+		errorMsg string
+	}{
+		{name: "zero TTL", key: privateKey, ttl: 0, errorMsg: "TTL must be positive"},
+		{name: "no PEM block", key: "not a key", ttl: time.Hour, errorMsg: "no PEM block found"},
+		{
+			name:     "unsupported PEM type",
+			key:      string(pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: []byte("x")})),
+			ttl:      time.Hour,
+			errorMsg: "unsupported PEM block type",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := gen.GenerateJWT(tt.key, JWTClaims{IssuedAt: time.Now(), TTL: tt.ttl})
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorMsg)
+		})
+	}
+}
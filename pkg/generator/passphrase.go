@@ -0,0 +1,62 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"crypto/rand"
+	_ "embed"
+	"fmt"
+	"math"
+	"math/big"
+	"strings"
+)
+
+// wordlistText is the BIP-0039 English wordlist: 2048 common words of 3 to 8 lowercase letters,
+// unambiguous by their first four letters
+//
+//go:embed wordlist.txt
+var wordlistText string
+
+// passphraseWords are the words passphrases are drawn from
+var passphraseWords = strings.Fields(wordlistText)
+
+// PassphraseCharset contains the characters of the words passphrases are drawn from
+const PassphraseCharset = "abcdefghijklmnopqrstuvwxyz"
+
+// PassphraseWordBits returns the entropy of each passphrase word in bits
+func PassphraseWordBits() float64 {
+	return math.Log2(float64(len(passphraseWords)))
+}
+
+// GeneratePassphrase generates a passphrase of words uniformly random words from the embedded
+// wordlist, joined by separator. Words may repeat, as in diceware.
+func (g *SecretGenerator) GeneratePassphrase(words int, separator string) (string, error) {
+	if words <= 0 {
+		return "", fmt.Errorf("number of words must be positive, got %d", words)
+	}
+
+	count := big.NewInt(int64(len(passphraseWords)))
+	selected := make([]string, words)
+	for i := range selected {
+		n, err := rand.Int(g.reader(), count)
+		if err != nil {
+			return "", fmt.Errorf("failed to generate random index: %w", err)
+		}
+		selected[i] = passphraseWords[n.Int64()]
+	}
+	return strings.Join(selected, separator), nil
+}
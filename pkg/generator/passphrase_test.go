@@ -0,0 +1,114 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestPassphraseWordlist(t *testing.T) {
+	if len(passphraseWords) != 2048 {
+		t.Fatalf("expected 2048 words, got %d", len(passphraseWords))
+	}
+	if bits := PassphraseWordBits(); bits != 11 {
+		t.Errorf("expected 11 bits per word, got %f", bits)
+	}
+
+	seen := make(map[string]bool, len(passphraseWords))
+	for _, word := range passphraseWords {
+		if seen[word] {
+			t.Errorf("duplicate word %q", word)
+		}
+		seen[word] = true
+		if strings.Trim(word, PassphraseCharset) != "" {
+			t.Errorf("word %q contains characters outside PassphraseCharset", word)
+		}
+	}
+}
+
+func TestGeneratePassphrase(t *testing.T) {
+	gen := NewSecretGenerator()
+
+	inWordlist := make(map[string]bool, len(passphraseWords))
+	for _, word := range passphraseWords {
+		inWordlist[word] = true
+	}
+
+	tests := []struct {
+		words     int
+		separator string
+	}{
+		{words: 1, separator: "-"},
+		{words: 4, separator: "-"},
+		{words: 7, separator: " "},
+		{words: 6, separator: "_+_"},
+	}
+	for _, tt := range tests {
+		passphrase, err := gen.GeneratePassphrase(tt.words, tt.separator)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		words := strings.Split(passphrase, tt.separator)
+		if len(words) != tt.words {
+			t.Errorf("expected %d words separated by %q, got %q", tt.words, tt.separator, passphrase)
+		}
+		for _, word := range words {
+			if !inWordlist[word] {
+				t.Errorf("expected only words from the wordlist, got %q in %q", word, passphrase)
+			}
+		}
+	}
+
+	// Without separator the words are joined directly
+	passphrase, err := gen.GeneratePassphrase(3, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if strings.Trim(passphrase, PassphraseCharset) != "" || len(passphrase) < 9 {
+		t.Errorf("expected 3 joined words, got %q", passphrase)
+	}
+
+	if _, err := gen.GeneratePassphrase(0, "-"); err == nil {
+		t.Error("expected error for zero words")
+	}
+}
+
+func TestGeneratePassphraseUniqueness(t *testing.T) {
+	gen := NewSecretGenerator()
+
+	first, err := gen.GeneratePassphrase(7, "-")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	second, err := gen.GeneratePassphrase(7, "-")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if first == second {
+		t.Errorf("expected two passphrases to differ, got %q twice", first)
+	}
+
+	// The generic Generate method supports the passphrase type, with length as number of words
+	passphrase, err := gen.Generate("passphrase", 5)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if got := len(strings.Split(passphrase, "-")); got != 5 {
+		t.Errorf("expected 5 words, got %d (%q)", got, passphrase)
+	}
+}
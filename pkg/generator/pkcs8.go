@@ -0,0 +1,209 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"bytes"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"fmt"
+	"io"
+
+	"golang.org/x/crypto/scrypt"
+)
+
+// Object identifiers of the PKCS#8 encryption scheme (RFC 8018 PBES2 with the RFC 7914 scrypt KDF)
+var (
+	oidPBES2     = asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 5, 13}
+	oidScrypt    = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11591, 4, 11}
+	oidAES256CBC = asn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+)
+
+const (
+	// scryptCost is the scrypt CPU/memory cost parameter N. 2^14 matches the OpenSSL default,
+	// so the keys can be decrypted with the default OpenSSL memory limit.
+	scryptCost = 1 << 14
+	// scryptBlockSize is the scrypt block size parameter r
+	scryptBlockSize = 8
+	// scryptParallelization is the scrypt parallelization parameter p
+	scryptParallelization = 1
+	// scryptSaltSize is the size of the random scrypt salt in bytes
+	scryptSaltSize = 16
+	// aes256KeySize is the size of the derived AES-256 key in bytes
+	aes256KeySize = 32
+)
+
+// encryptedPrivateKeyInfo is the PKCS#8 EncryptedPrivateKeyInfo structure (RFC 5958)
+type encryptedPrivateKeyInfo struct {
+	Algorithm     pkix.AlgorithmIdentifier
+	EncryptedData []byte
+}
+
+// pbes2Params is the PBES2-params structure (RFC 8018)
+type pbes2Params struct {
+	KeyDerivationFunc pkix.AlgorithmIdentifier
+	EncryptionScheme  pkix.AlgorithmIdentifier
+}
+
+// scryptParams is the scrypt-params structure (RFC 7914)
+type scryptParams struct {
+	Salt                     []byte
+	CostParameter            int
+	BlockSize                int
+	ParallelizationParameter int
+	KeyLength                int `asn1:"optional"`
+}
+
+// GenerateEncryptedRSAKeypair generates an RSA keypair with the given key size in bits and encrypts
+// the private key with passphrase. Returns the private key in encrypted PKCS#8 PEM format
+// ("ENCRYPTED PRIVATE KEY") and the unencrypted public key in PKCS#1 PEM format.
+func (g *SecretGenerator) GenerateEncryptedRSAKeypair(bits int, passphrase string) (string, string, error) {
+	if bits < 1024 {
+		return "", "", fmt.Errorf("RSA key size must be at least 1024 bits, got %d", bits)
+	}
+	if passphrase == "" {
+		return "", "", fmt.Errorf("passphrase must not be empty")
+	}
+
+	privateKey, err := rsa.GenerateKey(g.reader(), bits)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate RSA key: %w", err)
+	}
+
+	privateKeyPEM, err := g.encryptPKCS8PrivateKey(privateKey, passphrase)
+	if err != nil {
+		return "", "", err
+	}
+
+	publicKeyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PUBLIC KEY",
+		Bytes: x509.MarshalPKCS1PublicKey(&privateKey.PublicKey),
+	})
+
+	return privateKeyPEM, string(publicKeyPEM), nil
+}
+
+// GenerateEncryptedECDSAKeypair generates an ECDSA keypair for the given curve name and encrypts
+// the private key with passphrase. Returns the private key in encrypted PKCS#8 PEM format
+// ("ENCRYPTED PRIVATE KEY") and the unencrypted public key in PKIX PEM format.
+func (g *SecretGenerator) GenerateEncryptedECDSAKeypair(curveName, passphrase string) (string, string, error) {
+	if passphrase == "" {
+		return "", "", fmt.Errorf("passphrase must not be empty")
+	}
+	if curveName == CurveSecp256k1 {
+		return g.generateSecp256k1Keypair(passphrase)
+	}
+	curve, err := parseCurve(curveName)
+	if err != nil {
+		return "", "", err
+	}
+
+	privateKey, err := ecdsa.GenerateKey(curve, g.reader())
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate ECDSA key: %w", err)
+	}
+
+	privateKeyPEM, err := g.encryptPKCS8PrivateKey(privateKey, passphrase)
+	if err != nil {
+		return "", "", err
+	}
+
+	publicKeyBytes, err := x509.MarshalPKIXPublicKey(&privateKey.PublicKey)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal ECDSA public key: %w", err)
+	}
+	publicKeyPEM := pem.EncodeToMemory(&pem.Block{
+		Type:  "PUBLIC KEY",
+		Bytes: publicKeyBytes,
+	})
+
+	return privateKeyPEM, string(publicKeyPEM), nil
+}
+
+// encryptPKCS8PrivateKey encodes key as PKCS#8 and encrypts it with PBES2, using scrypt to derive
+// an AES-256-CBC key from passphrase. The result is the PEM-encoded EncryptedPrivateKeyInfo,
+// which OpenSSL and most TLS libraries can decrypt.
+func (g *SecretGenerator) encryptPKCS8PrivateKey(key any, passphrase string) (string, error) {
+	plaintext, err := x509.MarshalPKCS8PrivateKey(key)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal private key: %w", err)
+	}
+	return g.encryptPKCS8(plaintext, passphrase)
+}
+
+// encryptPKCS8 encrypts the DER-encoded PKCS#8 PrivateKeyInfo plaintext like encryptPKCS8PrivateKey
+func (g *SecretGenerator) encryptPKCS8(plaintext []byte, passphrase string) (string, error) {
+	salt := make([]byte, scryptSaltSize)
+	if _, err := io.ReadFull(g.reader(), salt); err != nil {
+		return "", fmt.Errorf("failed to generate salt: %w", err)
+	}
+	iv := make([]byte, aes.BlockSize)
+	if _, err := io.ReadFull(g.reader(), iv); err != nil {
+		return "", fmt.Errorf("failed to generate IV: %w", err)
+	}
+
+	derivedKey, err := scrypt.Key([]byte(passphrase), salt, scryptCost, scryptBlockSize, scryptParallelization, aes256KeySize)
+	if err != nil {
+		return "", fmt.Errorf("failed to derive encryption key: %w", err)
+	}
+	block, err := aes.NewCipher(derivedKey)
+	if err != nil {
+		return "", fmt.Errorf("failed to create cipher: %w", err)
+	}
+
+	// PKCS#7 padding, a full block is added if the plaintext is already aligned
+	padding := aes.BlockSize - len(plaintext)%aes.BlockSize
+	ciphertext := append(plaintext, bytes.Repeat([]byte{byte(padding)}, padding)...)
+	cipher.NewCBCEncrypter(block, iv).CryptBlocks(ciphertext, ciphertext)
+
+	kdfParams, err := asn1.Marshal(scryptParams{
+		Salt:                     salt,
+		CostParameter:            scryptCost,
+		BlockSize:                scryptBlockSize,
+		ParallelizationParameter: scryptParallelization,
+		KeyLength:                aes256KeySize,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal scrypt parameters: %w", err)
+	}
+	ivParam, err := asn1.Marshal(iv)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal IV: %w", err)
+	}
+	schemeParams, err := asn1.Marshal(pbes2Params{
+		KeyDerivationFunc: pkix.AlgorithmIdentifier{Algorithm: oidScrypt, Parameters: asn1.RawValue{FullBytes: kdfParams}},
+		EncryptionScheme:  pkix.AlgorithmIdentifier{Algorithm: oidAES256CBC, Parameters: asn1.RawValue{FullBytes: ivParam}},
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal PBES2 parameters: %w", err)
+	}
+	der, err := asn1.Marshal(encryptedPrivateKeyInfo{
+		Algorithm:     pkix.AlgorithmIdentifier{Algorithm: oidPBES2, Parameters: asn1.RawValue{FullBytes: schemeParams}},
+		EncryptedData: ciphertext,
+	})
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal encrypted private key: %w", err)
+	}
+
+	return string(pem.EncodeToMemory(&pem.Block{Type: "ENCRYPTED PRIVATE KEY", Bytes: der})), nil
+}
@@ -0,0 +1,211 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/asn1"
+	"encoding/pem"
+	"testing"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"golang.org/x/crypto/scrypt"
+)
+
+// decryptPKCS8PrivateKey decrypts a PEM-encoded PBES2/scrypt/AES-256-CBC encrypted PKCS#8 private key
+func decryptPKCS8PrivateKey(t *testing.T, keyPEM, passphrase string) (any, error) {
+	t.Helper()
+
+	plaintext, err := decryptPKCS8(t, keyPEM, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	return x509.ParsePKCS8PrivateKey(plaintext)
+}
+
+// decryptPKCS8 decrypts an "ENCRYPTED PRIVATE KEY" PEM block to the DER-encoded PrivateKeyInfo
+func decryptPKCS8(t *testing.T, keyPEM, passphrase string) ([]byte, error) {
+	t.Helper()
+
+	block, _ := pem.Decode([]byte(keyPEM))
+	require.NotNil(t, block)
+	require.Equal(t, "ENCRYPTED PRIVATE KEY", block.Type)
+
+	var info encryptedPrivateKeyInfo
+	_, err := asn1.Unmarshal(block.Bytes, &info)
+	require.NoError(t, err)
+	require.True(t, info.Algorithm.Algorithm.Equal(oidPBES2))
+
+	var params pbes2Params
+	_, err = asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &params)
+	require.NoError(t, err)
+	require.True(t, params.KeyDerivationFunc.Algorithm.Equal(oidScrypt))
+	require.True(t, params.EncryptionScheme.Algorithm.Equal(oidAES256CBC))
+
+	var kdf scryptParams
+	_, err = asn1.Unmarshal(params.KeyDerivationFunc.Parameters.FullBytes, &kdf)
+	require.NoError(t, err)
+	var iv []byte
+	_, err = asn1.Unmarshal(params.EncryptionScheme.Parameters.FullBytes, &iv)
+	require.NoError(t, err)
+
+	derivedKey, err := scrypt.Key([]byte(passphrase), kdf.Salt, kdf.CostParameter, kdf.BlockSize, kdf.ParallelizationParameter, kdf.KeyLength)
+	require.NoError(t, err)
+	aesBlock, err := aes.NewCipher(derivedKey)
+	require.NoError(t, err)
+
+	plaintext := make([]byte, len(info.EncryptedData))
+	cipher.NewCBCDecrypter(aesBlock, iv).CryptBlocks(plaintext, info.EncryptedData)
+	padding := int(plaintext[len(plaintext)-1])
+	if padding == 0 || padding > aes.BlockSize {
+		// A wrong passphrase yields random padding
+		return nil, x509.IncorrectPasswordError
+	}
+	return plaintext[:len(plaintext)-padding], nil
+}
+
+func TestGenerateEncryptedRSAKeypair(t *testing.T) {
+	gen := NewSecretGenerator()
+
+	privateKeyPEM, publicKeyPEM, err := gen.GenerateEncryptedRSAKeypair(2048, "correct horse")
+	require.NoError(t, err)
+
+	key, err := decryptPKCS8PrivateKey(t, privateKeyPEM, "correct horse")
+	require.NoError(t, err)
+	privateKey, ok := key.(*rsa.PrivateKey)
+	require.True(t, ok, "expected RSA private key, got %T", key)
+	assert.Equal(t, 2048, privateKey.N.BitLen())
+
+	// The public key is not encrypted and matches the private key
+	block, _ := pem.Decode([]byte(publicKeyPEM))
+	require.NotNil(t, block)
+	assert.Equal(t, "RSA PUBLIC KEY", block.Type)
+	publicKey, err := x509.ParsePKCS1PublicKey(block.Bytes)
+	require.NoError(t, err)
+	assert.True(t, privateKey.PublicKey.Equal(publicKey), "public key doesn't match private key")
+}
+
+func TestGenerateEncryptedECDSAKeypair(t *testing.T) {
+	gen := NewSecretGenerator()
+
+	privateKeyPEM, publicKeyPEM, err := gen.GenerateEncryptedECDSAKeypair("P-384", "correct horse")
+	require.NoError(t, err)
+
+	key, err := decryptPKCS8PrivateKey(t, privateKeyPEM, "correct horse")
+	require.NoError(t, err)
+	privateKey, ok := key.(*ecdsa.PrivateKey)
+	require.True(t, ok, "expected ECDSA private key, got %T", key)
+	assert.Equal(t, elliptic.P384(), privateKey.Curve)
+
+	block, _ := pem.Decode([]byte(publicKeyPEM))
+	require.NotNil(t, block)
+	assert.Equal(t, "PUBLIC KEY", block.Type)
+	publicKey, err := x509.ParsePKIXPublicKey(block.Bytes)
+	require.NoError(t, err)
+	assert.True(t, privateKey.PublicKey.Equal(publicKey), "public key doesn't match private key")
+}
+
+func TestGenerateEncryptedSecp256k1Keypair(t *testing.T) {
+	gen := NewSecretGenerator()
+
+	privateKeyPEM, publicKeyPEM, err := gen.GenerateEncryptedECDSAKeypair("secp256k1", "correct horse")
+	require.NoError(t, err)
+
+	plaintext, err := decryptPKCS8(t, privateKeyPEM, "correct horse")
+	require.NoError(t, err)
+	var info pkcs8PrivateKeyInfo
+	_, err = asn1.Unmarshal(plaintext, &info)
+	require.NoError(t, err)
+	require.True(t, info.Algorithm.Algorithm.Equal(oidPublicKeyECDSA))
+	var curve asn1.ObjectIdentifier
+	_, err = asn1.Unmarshal(info.Algorithm.Parameters.FullBytes, &curve)
+	require.NoError(t, err)
+	assert.True(t, curve.Equal(oidNamedCurveK256), "expected secp256k1 curve, got %v", curve)
+
+	var ecKey ecPrivateKey
+	_, err = asn1.Unmarshal(info.PrivateKey, &ecKey)
+	require.NoError(t, err)
+	privateKey := secp256k1.PrivKeyFromBytes(ecKey.PrivateKey)
+	assert.Equal(t, parseSecp256k1PublicKeyPEM(t, publicKeyPEM).SerializeUncompressed(), privateKey.PubKey().SerializeUncompressed(),
+		"public key doesn't match private key")
+}
+
+func TestGenerateEncryptedKeypairWrongPassphrase(t *testing.T) {
+	gen := NewSecretGenerator()
+
+	privateKeyPEM, _, err := gen.GenerateEncryptedECDSAKeypair("P-256", "correct horse")
+	require.NoError(t, err)
+
+	_, err = decryptPKCS8PrivateKey(t, privateKeyPEM, "battery staple")
+	assert.Error(t, err)
+}
+
+func TestGenerateEncryptedKeypairUniqueSalt(t *testing.T) {
+	gen := NewSecretGenerator()
+
+	key1, _, err := gen.GenerateEncryptedECDSAKeypair("P-256", "correct horse")
+	require.NoError(t, err)
+	key2, _, err := gen.GenerateEncryptedECDSAKeypair("P-256", "correct horse")
+	require.NoError(t, err)
+	assert.NotEqual(t, key1, key2)
+}
+
+func TestGenerateEncryptedKeypairErrors(t *testing.T) {
+	gen := NewSecretGenerator()
+
+	tests := []struct {
+		name     string
+		generate func() (string, string, error)
+		errorMsg string
+	}{
+		{
+			name:     "empty RSA passphrase",
+			generate: func() (string, string, error) { return gen.GenerateEncryptedRSAKeypair(2048, "") },
+			errorMsg: "passphrase must not be empty",
+		},
+		{
+			name:     "RSA key too small",
+			generate: func() (string, string, error) { return gen.GenerateEncryptedRSAKeypair(512, "secret") },
+			errorMsg: "at least 1024 bits",
+		},
+		{
+			name:     "empty ECDSA passphrase",
+			generate: func() (string, string, error) { return gen.GenerateEncryptedECDSAKeypair("P-256", "") },
+			errorMsg: "passphrase must not be empty",
+		},
+		{
+			name:     "unsupported curve",
+			generate: func() (string, string, error) { return gen.GenerateEncryptedECDSAKeypair("P-224", "secret") },
+			errorMsg: "unsupported",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := tt.generate()
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.errorMsg)
+		})
+	}
+}
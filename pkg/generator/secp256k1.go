@@ -0,0 +1,143 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/pem"
+	"fmt"
+
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+)
+
+// CurveSecp256k1 is the name of the secp256k1 (SEC 2) Koblitz curve used by blockchain tooling.
+// The standard library doesn't support it, so its keys are encoded here instead of by crypto/x509.
+const CurveSecp256k1 = "secp256k1"
+
+// Object identifiers of EC public keys (RFC 5480) and the secp256k1 curve (SEC 2)
+var (
+	oidPublicKeyECDSA = asn1.ObjectIdentifier{1, 2, 840, 10045, 2, 1}
+	oidNamedCurveK256 = asn1.ObjectIdentifier{1, 3, 132, 0, 10}
+)
+
+// ecPrivateKey is the SEC 1 ECPrivateKey structure (RFC 5915)
+type ecPrivateKey struct {
+	Version       int
+	PrivateKey    []byte
+	NamedCurveOID asn1.ObjectIdentifier `asn1:"optional,explicit,tag:0"`
+	PublicKey     asn1.BitString        `asn1:"optional,explicit,tag:1"`
+}
+
+// pkcs8PrivateKeyInfo is the PKCS#8 PrivateKeyInfo structure (RFC 5208)
+type pkcs8PrivateKeyInfo struct {
+	Version    int
+	Algorithm  pkix.AlgorithmIdentifier
+	PrivateKey []byte
+}
+
+// subjectPublicKeyInfo is the PKIX SubjectPublicKeyInfo structure (RFC 5280)
+type subjectPublicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// marshalSecp256k1PrivateKey encodes key as SEC 1 ECPrivateKey. The curve is omitted when the key
+// is wrapped in PKCS#8, which names the curve in its algorithm identifier instead.
+func marshalSecp256k1PrivateKey(key *secp256k1.PrivateKey, withCurve bool) ([]byte, error) {
+	publicKey := key.PubKey().SerializeUncompressed()
+	ecKey := ecPrivateKey{
+		Version:    1,
+		PrivateKey: key.Serialize(),
+		PublicKey:  asn1.BitString{Bytes: publicKey, BitLength: 8 * len(publicKey)},
+	}
+	if withCurve {
+		ecKey.NamedCurveOID = oidNamedCurveK256
+	}
+	return asn1.Marshal(ecKey)
+}
+
+// secp256k1AlgorithmIdentifier returns the algorithm identifier of secp256k1 keys
+func secp256k1AlgorithmIdentifier() (pkix.AlgorithmIdentifier, error) {
+	curve, err := asn1.Marshal(oidNamedCurveK256)
+	if err != nil {
+		return pkix.AlgorithmIdentifier{}, err
+	}
+	return pkix.AlgorithmIdentifier{Algorithm: oidPublicKeyECDSA, Parameters: asn1.RawValue{FullBytes: curve}}, nil
+}
+
+// marshalSecp256k1PKCS8PrivateKey encodes key as PKCS#8 PrivateKeyInfo
+func marshalSecp256k1PKCS8PrivateKey(key *secp256k1.PrivateKey) ([]byte, error) {
+	ecKey, err := marshalSecp256k1PrivateKey(key, false)
+	if err != nil {
+		return nil, err
+	}
+	algorithm, err := secp256k1AlgorithmIdentifier()
+	if err != nil {
+		return nil, err
+	}
+	return asn1.Marshal(pkcs8PrivateKeyInfo{Algorithm: algorithm, PrivateKey: ecKey})
+}
+
+// marshalSecp256k1PublicKey encodes the public key of key as PKIX SubjectPublicKeyInfo with an
+// uncompressed point
+func marshalSecp256k1PublicKey(key *secp256k1.PrivateKey) ([]byte, error) {
+	algorithm, err := secp256k1AlgorithmIdentifier()
+	if err != nil {
+		return nil, err
+	}
+	publicKey := key.PubKey().SerializeUncompressed()
+	return asn1.Marshal(subjectPublicKeyInfo{
+		Algorithm: algorithm,
+		PublicKey: asn1.BitString{Bytes: publicKey, BitLength: 8 * len(publicKey)},
+	})
+}
+
+// generateSecp256k1Keypair generates a secp256k1 keypair. The private key is returned in EC PEM
+// format, or in encrypted PKCS#8 PEM format if passphrase isn't empty, and the public key in PKIX
+// PEM format, like the keys of the other ECDSA curves.
+func (g *SecretGenerator) generateSecp256k1Keypair(passphrase string) (string, string, error) {
+	privateKey, err := secp256k1.GeneratePrivateKeyFromRand(g.reader())
+	if err != nil {
+		return "", "", fmt.Errorf("failed to generate ECDSA key: %w", err)
+	}
+
+	var privateKeyPEM string
+	if passphrase != "" {
+		pkcs8Bytes, err := marshalSecp256k1PKCS8PrivateKey(privateKey)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to marshal ECDSA private key: %w", err)
+		}
+		if privateKeyPEM, err = g.encryptPKCS8(pkcs8Bytes, passphrase); err != nil {
+			return "", "", err
+		}
+	} else {
+		ecPrivateKeyBytes, err := marshalSecp256k1PrivateKey(privateKey, true)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to marshal ECDSA private key: %w", err)
+		}
+		privateKeyPEM = string(pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: ecPrivateKeyBytes}))
+	}
+
+	publicKeyBytes, err := marshalSecp256k1PublicKey(privateKey)
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal ECDSA public key: %w", err)
+	}
+	publicKeyPEM := pem.EncodeToMemory(&pem.Block{Type: "PUBLIC KEY", Bytes: publicKeyBytes})
+
+	return privateKeyPEM, string(publicKeyPEM), nil
+}
@@ -0,0 +1,75 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package generator
+
+import (
+	"crypto"
+	"crypto/ed25519"
+	"crypto/rsa"
+	"encoding/pem"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/ssh"
+)
+
+const (
+	// SSHKeyTypeRSA selects an RSA SSH keypair (ssh-rsa)
+	SSHKeyTypeRSA = "rsa"
+	// SSHKeyTypeEd25519 selects an Ed25519 SSH keypair (ssh-ed25519)
+	SSHKeyTypeEd25519 = "ed25519"
+)
+
+// GenerateSSHKeypair generates an SSH keypair of the given key type ("rsa" or "ed25519").
+// bits is the RSA key size and is ignored for Ed25519.
+// Returns the private key in OpenSSH PEM format ("OPENSSH PRIVATE KEY") and the public key
+// as a single authorized_keys line (e.g. "ssh-ed25519 AAAA...").
+func (g *SecretGenerator) GenerateSSHKeypair(keyType string, bits int) (string, string, error) {
+	var privateKey crypto.Signer
+	switch keyType {
+	case SSHKeyTypeRSA:
+		if bits < 1024 {
+			return "", "", fmt.Errorf("SSH RSA key size must be at least 1024 bits, got %d", bits)
+		}
+		key, err := rsa.GenerateKey(g.reader(), bits)
+		if err != nil {
+			return "", "", fmt.Errorf("failed to generate RSA key: %w", err)
+		}
+		privateKey = key
+	case SSHKeyTypeEd25519:
+		_, key, err := ed25519.GenerateKey(g.reader())
+		if err != nil {
+			return "", "", fmt.Errorf("failed to generate Ed25519 key: %w", err)
+		}
+		privateKey = key
+	default:
+		return "", "", fmt.Errorf("unsupported SSH key type %q, must be %q or %q", keyType, SSHKeyTypeRSA, SSHKeyTypeEd25519)
+	}
+
+	privateKeyBlock, err := ssh.MarshalPrivateKey(privateKey, "")
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal SSH private key: %w", err)
+	}
+
+	publicKey, err := ssh.NewPublicKey(privateKey.Public())
+	if err != nil {
+		return "", "", fmt.Errorf("failed to marshal SSH public key: %w", err)
+	}
+	authorizedKey := strings.TrimSuffix(string(ssh.MarshalAuthorizedKey(publicKey)), "\n")
+
+	return string(pem.EncodeToMemory(privateKeyBlock)), authorizedKey, nil
+}
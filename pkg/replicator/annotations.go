@@ -0,0 +1,70 @@
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package replicator
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// Annotations holds the replication annotation keys for an annotation prefix. Controllers build
+// them once from the configured prefix; the package-level functions use DefaultAnnotations.
+type Annotations struct {
+	ReplicatableFromNamespaces string
+	ReplicateFrom              string
+	ReplicateTo                string
+	ReplicatedFrom             string
+	LastReplicatedAt           string
+	// Autogenerate is the secret generator's annotation, which conflicts with ReplicateFrom
+	Autogenerate string
+}
+
+// DefaultAnnotations are the replication annotation keys with AnnotationPrefix
+var DefaultAnnotations = NewAnnotations(AnnotationPrefix)
+
+// NewAnnotations returns the replication annotation keys for prefix. An empty prefix stands
+// for AnnotationPrefix.
+func NewAnnotations(prefix string) *Annotations {
+	if prefix == "" {
+		prefix = AnnotationPrefix
+	}
+	key := func(builtIn string) string {
+		return prefix + strings.TrimPrefix(builtIn, AnnotationPrefix)
+	}
+	return &Annotations{
+		ReplicatableFromNamespaces: key(AnnotationReplicatableFromNamespaces),
+		ReplicateFrom:              key(AnnotationReplicateFrom),
+		ReplicateTo:                key(AnnotationReplicateTo),
+		ReplicatedFrom:             key(AnnotationReplicatedFrom),
+		LastReplicatedAt:           key(AnnotationLastReplicatedAt),
+		Autogenerate:               prefix + "autogenerate",
+	}
+}
+
+// setReplicationStatus adds the replication status annotations of a replica of source to target
+func (a *Annotations) setReplicationStatus(source, target metav1.Object) {
+	annotations := target.GetAnnotations()
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+	annotations[a.ReplicatedFrom] = fmt.Sprintf("%s/%s", source.GetNamespace(), source.GetName())
+	annotations[a.LastReplicatedAt] = time.Now().Format(time.RFC3339)
+	target.SetAnnotations(annotations)
+}
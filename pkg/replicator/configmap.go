@@ -17,15 +17,17 @@ limitations under the License.
 package replicator
 
 import (
-	"fmt"
-	"time"
-
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
 
 // ReplicateConfigMap copies data from source ConfigMap to target ConfigMap
 func ReplicateConfigMap(source, target *corev1.ConfigMap) {
+	DefaultAnnotations.ReplicateConfigMap(source, target)
+}
+
+// ReplicateConfigMap copies data from source ConfigMap to target ConfigMap
+func (a *Annotations) ReplicateConfigMap(source, target *corev1.ConfigMap) {
 	if target.Data == nil {
 		target.Data = make(map[string]string)
 	}
@@ -40,27 +42,25 @@ func ReplicateConfigMap(source, target *corev1.ConfigMap) {
 		target.BinaryData[key] = value
 	}
 
-	if target.Annotations == nil {
-		target.Annotations = make(map[string]string)
-	}
-	target.Annotations[AnnotationReplicatedFrom] = fmt.Sprintf("%s/%s", source.Namespace, source.Name)
-	target.Annotations[AnnotationLastReplicatedAt] = time.Now().Format(time.RFC3339)
+	a.setReplicationStatus(source, target)
 }
 
 // CreateReplicatedConfigMap creates a new ConfigMap for push-based replication
 func CreateReplicatedConfigMap(source *corev1.ConfigMap, targetNamespace string) *corev1.ConfigMap {
+	return DefaultAnnotations.CreateReplicatedConfigMap(source, targetNamespace)
+}
+
+// CreateReplicatedConfigMap creates a new ConfigMap for push-based replication
+func (a *Annotations) CreateReplicatedConfigMap(source *corev1.ConfigMap, targetNamespace string) *corev1.ConfigMap {
 	target := &corev1.ConfigMap{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      source.Name,
 			Namespace: targetNamespace,
 			Labels:    make(map[string]string),
-			Annotations: map[string]string{
-				AnnotationReplicatedFrom:   fmt.Sprintf("%s/%s", source.Namespace, source.Name),
-				AnnotationLastReplicatedAt: time.Now().Format(time.RFC3339),
-			},
 		},
 		Data: make(map[string]string),
 	}
+	a.setReplicationStatus(source, target)
 
 	// Copy labels from source (optional, can be customized)
 	for key, value := range source.Labels {
@@ -21,7 +21,6 @@ import (
 	"fmt"
 	"path/filepath"
 	"strings"
-	"time"
 
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
@@ -52,6 +51,11 @@ const (
 
 // ReplicateSecret copies data from source Secret to target Secret
 func ReplicateSecret(source, target *corev1.Secret) {
+	DefaultAnnotations.ReplicateSecret(source, target)
+}
+
+// ReplicateSecret copies data from source Secret to target Secret
+func (a *Annotations) ReplicateSecret(source, target *corev1.Secret) {
 	// Initialize target data if nil
 	if target.Data == nil {
 		target.Data = make(map[string][]byte)
@@ -63,11 +67,7 @@ func ReplicateSecret(source, target *corev1.Secret) {
 	}
 
 	// Add replication status annotations
-	if target.Annotations == nil {
-		target.Annotations = make(map[string]string)
-	}
-	target.Annotations[AnnotationReplicatedFrom] = fmt.Sprintf("%s/%s", source.Namespace, source.Name)
-	target.Annotations[AnnotationLastReplicatedAt] = time.Now().Format(time.RFC3339)
+	a.setReplicationStatus(source, target)
 }
 
 // ValidateReplication checks if replication is allowed (mutual consent)
@@ -178,7 +178,25 @@ func RemoveFinalizer(obj metav1.Object) {
 
 // IsOwnedByUs checks if an object was replicated by us (has our annotation)
 func IsOwnedByUs(obj metav1.Object, expectedSource string) bool {
-	return GetReplicatedFromAnnotation(obj) == expectedSource
+	return DefaultAnnotations.IsOwnedByUs(obj, expectedSource)
+}
+
+// IsOwnedByUs checks if an object was replicated by us (has our annotation)
+func (a *Annotations) IsOwnedByUs(obj metav1.Object, expectedSource string) bool {
+	return a.GetReplicatedFromAnnotation(obj) == expectedSource
+}
+
+// IsRetargeted checks if a replica of expectedSource was manually pointed at another source
+// with a replicate-from annotation. Such replicas are no longer managed by push-based replication.
+func IsRetargeted(obj metav1.Object, expectedSource string) bool {
+	return DefaultAnnotations.IsRetargeted(obj, expectedSource)
+}
+
+// IsRetargeted checks if a replica of expectedSource was manually pointed at another source
+// with a replicate-from annotation
+func (a *Annotations) IsRetargeted(obj metav1.Object, expectedSource string) bool {
+	replicateFrom := obj.GetAnnotations()[a.ReplicateFrom]
+	return replicateFrom != "" && replicateFrom != expectedSource
 }
 
 // IsBeingDeleted checks if an object is being deleted (has DeletionTimestamp)
@@ -188,38 +206,50 @@ func IsBeingDeleted(obj metav1.Object) bool {
 
 // GetReplicatedFromAnnotation returns the value of the replicated-from annotation
 func GetReplicatedFromAnnotation(obj metav1.Object) string {
+	return DefaultAnnotations.GetReplicatedFromAnnotation(obj)
+}
+
+// GetReplicatedFromAnnotation returns the value of the replicated-from annotation
+func (a *Annotations) GetReplicatedFromAnnotation(obj metav1.Object) string {
 	annotations := obj.GetAnnotations()
 	if annotations == nil {
 		return ""
 	}
-	return annotations[AnnotationReplicatedFrom]
+	return annotations[a.ReplicatedFrom]
 }
 
 // HasConflictingAnnotations checks if autogenerate and replicate-from are both present
 func HasConflictingAnnotations(secret *corev1.Secret) bool {
+	return DefaultAnnotations.HasConflictingAnnotations(secret)
+}
+
+// HasConflictingAnnotations checks if autogenerate and replicate-from are both present
+func (a *Annotations) HasConflictingAnnotations(secret *corev1.Secret) bool {
 	if secret.Annotations == nil {
 		return false
 	}
-	hasAutogenerate := secret.Annotations[AnnotationPrefix+"autogenerate"] != ""
-	hasReplicateFrom := secret.Annotations[AnnotationReplicateFrom] != ""
+	hasAutogenerate := secret.Annotations[a.Autogenerate] != ""
+	hasReplicateFrom := secret.Annotations[a.ReplicateFrom] != ""
 	return hasAutogenerate && hasReplicateFrom
 }
 
 // CreateReplicatedSecret creates a new Secret for replication
 func CreateReplicatedSecret(source *corev1.Secret, targetNamespace string) *corev1.Secret {
+	return DefaultAnnotations.CreateReplicatedSecret(source, targetNamespace)
+}
+
+// CreateReplicatedSecret creates a new Secret for replication
+func (a *Annotations) CreateReplicatedSecret(source *corev1.Secret, targetNamespace string) *corev1.Secret {
 	target := &corev1.Secret{
 		ObjectMeta: metav1.ObjectMeta{
 			Name:      source.Name,
 			Namespace: targetNamespace,
 			Labels:    make(map[string]string),
-			Annotations: map[string]string{
-				AnnotationReplicatedFrom:   fmt.Sprintf("%s/%s", source.Namespace, source.Name),
-				AnnotationLastReplicatedAt: time.Now().Format(time.RFC3339),
-			},
 		},
 		Type: source.Type,
 		Data: make(map[string][]byte),
 	}
+	a.setReplicationStatus(source, target)
 
 	// Copy labels from source (optional, can be customized)
 	for key, value := range source.Labels {
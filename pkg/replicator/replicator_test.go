@@ -635,6 +635,50 @@ func TestIsOwnedByUs(t *testing.T) {
 	}
 }
 
+func TestIsRetargeted(t *testing.T) {
+	tests := []struct {
+		name        string
+		annotations map[string]string
+		want        bool
+	}{
+		{
+			name:        "pushed replica",
+			annotations: map[string]string{AnnotationReplicatedFrom: "production/db-credentials"},
+			want:        false,
+		},
+		{
+			name: "pointed at the same source",
+			annotations: map[string]string{
+				AnnotationReplicatedFrom: "production/db-credentials",
+				AnnotationReplicateFrom:  "production/db-credentials",
+			},
+			want: false,
+		},
+		{
+			name: "pointed at another source",
+			annotations: map[string]string{
+				AnnotationReplicatedFrom: "production/db-credentials",
+				AnnotationReplicateFrom:  "shared/db-credentials",
+			},
+			want: true,
+		},
+		{
+			name:        "no annotations",
+			annotations: nil,
+			want:        false,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			secret := &corev1.Secret{ObjectMeta: metav1.ObjectMeta{Annotations: tt.annotations}}
+			if got := IsRetargeted(secret, "production/db-credentials"); got != tt.want {
+				t.Errorf("IsRetargeted() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
 func TestIsBeingDeleted(t *testing.T) {
 	now := metav1.Now()
 
@@ -1066,3 +1110,25 @@ func TestCharacterClassPatterns(t *testing.T) {
 		})
 	}
 }
+
+func TestCustomAnnotationPrefix(t *testing.T) {
+	keys := NewAnnotations("secrets.acme.internal/")
+	if keys.ReplicateTo != "secrets.acme.internal/replicate-to" {
+		t.Errorf("expected replicate-to with the custom prefix, got %q", keys.ReplicateTo)
+	}
+
+	source := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{Name: "source", Namespace: "ns-a"},
+		Data:       map[string][]byte{"key": []byte("value")},
+	}
+	target := keys.CreateReplicatedSecret(source, "ns-b")
+	if target.Annotations["secrets.acme.internal/replicated-from"] != "ns-a/source" {
+		t.Errorf("expected replicated-from with the custom prefix, got annotations %v", target.Annotations)
+	}
+	if _, ok := target.Annotations[AnnotationReplicatedFrom]; ok {
+		t.Errorf("expected no annotation with the built-in prefix, got annotations %v", target.Annotations)
+	}
+	if !keys.IsOwnedByUs(target, "ns-a/source") || DefaultAnnotations.IsOwnedByUs(target, "ns-a/source") {
+		t.Error("expected only the custom prefix keys to own the replica")
+	}
+}
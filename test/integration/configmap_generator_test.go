@@ -0,0 +1,188 @@
+//go:build integration
+// +build integration
+
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+
+	"github.com/guided-traffic/internal-secrets-operator/internal/controller"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/generator"
+)
+
+// setupTestManagerWithConfigMapGenerator creates a manager running only the ConfigMap generator controller
+func setupTestManagerWithConfigMapGenerator(t *testing.T, operatorConfig *config.Config) *testContext {
+	t.Helper()
+
+	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
+		Scheme: scheme.Scheme,
+		Metrics: metricsserver.Options{
+			BindAddress: "0",
+		},
+	})
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+
+	if operatorConfig == nil {
+		operatorConfig = config.NewDefaultConfig()
+	}
+
+	reconciler := &controller.ConfigMapGeneratorReconciler{
+		Client:        mgr.GetClient(),
+		Scheme:        mgr.GetScheme(),
+		Generator:     generator.NewSecretGenerator(),
+		Config:        operatorConfig,
+		EventRecorder: mgr.GetEventRecorder("configmap-generator"),
+	}
+
+	counter := atomic.AddInt64(&controllerCounter, 1)
+	controllerName := "configmap-generator-" + time.Now().Format("150405") + "-" + string(rune('a'+counter%26))
+
+	if err := reconciler.SetupWithManagerAndName(mgr, controllerName); err != nil {
+		t.Fatalf("failed to setup configmap generator controller: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	go func() {
+		if err := mgr.Start(ctx); err != nil {
+			t.Logf("manager stopped: %v", err)
+		}
+	}()
+
+	// Wait for manager and cache to be ready
+	time.Sleep(500 * time.Millisecond)
+
+	return &testContext{
+		client: mgr.GetClient(),
+		cancel: cancel,
+	}
+}
+
+// waitForConfigMapField waits until the ConfigMap has a value for the given field
+func waitForConfigMapField(ctx context.Context, c client.Client, key types.NamespacedName, field string) (*corev1.ConfigMap, error) {
+	var cm corev1.ConfigMap
+	deadline := time.Now().Add(timeout)
+
+	for time.Now().Before(deadline) {
+		if err := c.Get(ctx, key, &cm); err == nil && cm.Data[field] != "" {
+			return &cm, nil
+		}
+		time.Sleep(interval)
+	}
+
+	return &cm, context.DeadlineExceeded
+}
+
+// TestConfigMapGenerator tests value generation and rotation in ConfigMaps
+func TestConfigMapGenerator(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	cfg.Features.ConfigMapGenerator = true
+
+	tc := setupTestManagerWithConfigMapGenerator(t, cfg)
+	ns := createNamespace(t, tc.client)
+	defer tc.cleanup(t, ns)
+
+	ctx := context.Background()
+
+	t.Run("GeneratesField", func(t *testing.T) {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-configmap-generate",
+				Namespace: ns.Name,
+				Annotations: map[string]string{
+					AnnotationAutogenerate:                 "instance-id",
+					AnnotationLengthPrefix + "instance-id": "12",
+				},
+			},
+			Data: map[string]string{"region": "eu-west-1"},
+		}
+		if err := tc.client.Create(ctx, cm); err != nil {
+			t.Fatalf("failed to create configmap: %v", err)
+		}
+
+		updated, err := waitForConfigMapField(ctx, tc.client, types.NamespacedName{Name: cm.Name, Namespace: ns.Name}, "instance-id")
+		if err != nil {
+			t.Fatalf("failed to get configmap: %v", err)
+		}
+		if len(updated.Data["instance-id"]) != 12 {
+			t.Errorf("expected instance-id of length 12, got %q", updated.Data["instance-id"])
+		}
+		if updated.Data["region"] != "eu-west-1" {
+			t.Errorf("expected existing data to be kept, got %q", updated.Data["region"])
+		}
+		if updated.Annotations[AnnotationGeneratedAt] == "" {
+			t.Error("expected generated-at annotation to be set")
+		}
+	})
+
+	t.Run("RotatesField", func(t *testing.T) {
+		cm := &corev1.ConfigMap{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-configmap-rotate",
+				Namespace: ns.Name,
+				Annotations: map[string]string{
+					AnnotationAutogenerate: "instance-id",
+				},
+			},
+		}
+		if err := tc.client.Create(ctx, cm); err != nil {
+			t.Fatalf("failed to create configmap: %v", err)
+		}
+
+		key := types.NamespacedName{Name: cm.Name, Namespace: ns.Name}
+		generated, err := waitForConfigMapField(ctx, tc.client, key, "instance-id")
+		if err != nil {
+			t.Fatalf("failed to get configmap: %v", err)
+		}
+		original := generated.Data["instance-id"]
+
+		generated.Annotations[AnnotationRotateNow] = "true"
+		if err := tc.client.Update(ctx, generated); err != nil {
+			t.Fatalf("failed to request rotation: %v", err)
+		}
+
+		deadline := time.Now().Add(timeout)
+		var rotated corev1.ConfigMap
+		for time.Now().Before(deadline) {
+			if err := tc.client.Get(ctx, key, &rotated); err == nil && rotated.Data["instance-id"] != original {
+				break
+			}
+			time.Sleep(interval)
+		}
+		if rotated.Data["instance-id"] == original {
+			t.Error("expected instance-id to be rotated")
+		}
+		if _, ok := rotated.Annotations[AnnotationRotateNow]; ok {
+			t.Error("expected rotate-now annotation to be removed")
+		}
+	})
+}
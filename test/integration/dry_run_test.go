@@ -0,0 +1,113 @@
+//go:build integration
+// +build integration
+
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	eventsv1 "k8s.io/api/events/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+)
+
+// TestDryRun tests that a due Secret is reported but not changed in dry-run mode
+func TestDryRun(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	cfg.DryRun = true
+
+	tc := setupTestManager(t, cfg)
+	ns := createNamespace(t, tc.client)
+	defer tc.cleanup(t, ns)
+
+	ctx := context.Background()
+
+	generatedAt := time.Now().Add(-48 * time.Hour).UTC().Format(time.RFC3339)
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-dry-run",
+			Namespace: ns.Name,
+			Annotations: map[string]string{
+				AnnotationAutogenerate: "password",
+				AnnotationRotate:       "24h",
+				AnnotationGeneratedAt:  generatedAt,
+			},
+		},
+		Data: map[string][]byte{"password": []byte("old-password")},
+		Type: corev1.SecretTypeOpaque,
+	}
+	if err := tc.client.Create(ctx, secret); err != nil {
+		t.Fatalf("failed to create secret: %v", err)
+	}
+	key := types.NamespacedName{Name: secret.Name, Namespace: ns.Name}
+
+	// Trigger further reconciles by changing a label
+	for i := 0; i < 3; i++ {
+		time.Sleep(time.Second)
+		var current corev1.Secret
+		if err := tc.client.Get(ctx, key, &current); err != nil {
+			t.Fatalf("failed to get secret: %v", err)
+		}
+		if current.Labels == nil {
+			current.Labels = map[string]string{}
+		}
+		current.Labels["reconcile"] = "r" + string(rune('0'+i))
+		if err := tc.client.Update(ctx, &current); err != nil {
+			t.Fatalf("failed to update secret: %v", err)
+		}
+	}
+
+	// The rotation is reported as a DryRunAction event
+	deadline := time.Now().Add(timeout)
+	found := false
+	for time.Now().Before(deadline) && !found {
+		var events eventsv1.EventList
+		if err := tc.client.List(ctx, &events, client.InNamespace(ns.Name)); err == nil {
+			for _, event := range events.Items {
+				if event.Reason == "DryRunAction" && event.Regarding.Name == secret.Name {
+					found = true
+				}
+			}
+		}
+		time.Sleep(interval)
+	}
+	if !found {
+		t.Error("expected DryRunAction event for the due Secret")
+	}
+
+	var got corev1.Secret
+	if err := tc.client.Get(ctx, key, &got); err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+	if string(got.Data["password"]) != "old-password" {
+		t.Errorf("expected password to be unchanged in dry-run mode, got %q", got.Data["password"])
+	}
+	if got.Annotations[AnnotationGeneratedAt] != generatedAt {
+		t.Errorf("expected generated-at to be unchanged, got %q", got.Annotations[AnnotationGeneratedAt])
+	}
+	if _, ok := got.Annotations[AnnotationStatus]; ok {
+		t.Error("expected no status annotations in dry-run mode")
+	}
+}
@@ -27,8 +27,14 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/guided-traffic/internal-secrets-operator/internal/controller"
 	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/generator"
 )
 
 // TestMaintenanceWindowRotationDeferred tests that rotation is deferred outside maintenance windows
@@ -463,3 +469,90 @@ func TestMaintenanceWindowDifferentTimezones(t *testing.T) {
 		}
 	})
 }
+
+// TestMaintenanceWindowRequeueAtNextWindow tests that a due but deferred rotation is requeued
+// exactly at the start of the next maintenance window instead of busy-looping until it opens
+func TestMaintenanceWindowRequeueAtNextWindow(t *testing.T) {
+	// Monday 12:00 UTC - maintenance window is only on Saturday nights
+	mockTime := time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)
+	mockClock := &MockClock{currentTime: mockTime}
+
+	cfg := config.NewDefaultConfig()
+	cfg.Rotation.MaintenanceWindows = config.MaintenanceWindowsConfig{
+		Enabled: true,
+		Windows: []config.MaintenanceWindow{
+			{
+				Name:      "saturday-night",
+				Days:      []string{"saturday"},
+				StartTime: "03:00",
+				EndTime:   "05:00",
+				Timezone:  "UTC",
+			},
+		},
+	}
+
+	// The reconciler is called directly to see the result it returns
+	k8sClient, err := client.New(restConfig, client.Options{Scheme: scheme.Scheme})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	ns := createNamespace(t, k8sClient)
+	defer func() { _ = k8sClient.Delete(context.Background(), ns) }()
+
+	reconciler := &controller.SecretReconciler{
+		Client:        k8sClient,
+		Scheme:        scheme.Scheme,
+		Generator:     generator.NewSecretGenerator(),
+		Config:        cfg,
+		EventRecorder: events.NewFakeRecorder(10),
+		Clock:         mockClock,
+	}
+
+	ctx := context.Background()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-requeue-next-window",
+			Namespace: ns.Name,
+			Annotations: map[string]string{
+				AnnotationAutogenerate: "password",
+				AnnotationRotate:       "1h",
+				AnnotationGeneratedAt:  mockTime.Add(-2 * time.Hour).Format(time.RFC3339),
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			"password": []byte("old-password-value"),
+		},
+	}
+	if err := k8sClient.Create(ctx, secret); err != nil {
+		t.Fatalf("failed to create secret: %v", err)
+	}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: ns.Name}}
+	result, err := reconciler.Reconcile(ctx, req)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	// Monday 12:00 -> Saturday 03:00
+	want := cfg.Rotation.MaintenanceWindows.DurationUntilNextWindow(mockTime)
+	if want != 111*time.Hour {
+		t.Fatalf("expected the next window in 111h, got %v", want)
+	}
+	if result.RequeueAfter != want {
+		t.Errorf("expected RequeueAfter %v (start of the next window), got %v", want, result.RequeueAfter)
+	}
+
+	// At the requeue, the window is open and the rotation happens
+	mockClock.Advance(result.RequeueAfter)
+	if _, err := reconciler.Reconcile(ctx, req); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var updatedSecret corev1.Secret
+	if err := k8sClient.Get(ctx, req.NamespacedName, &updatedSecret); err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+	if string(updatedSecret.Data["password"]) == "old-password-value" {
+		t.Error("expected password to be rotated at the start of the window")
+	}
+}
@@ -0,0 +1,95 @@
+//go:build integration
+// +build integration
+
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+)
+
+// TestNamespaceFilter tests that Secrets in excluded namespaces are never modified
+func TestNamespaceFilter(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	cfg.Namespaces.Exclude = []string{"excluded-*"}
+
+	tc := setupTestManager(t, cfg)
+	ns := createNamespace(t, tc.client)
+	defer tc.cleanup(t, ns)
+
+	ctx := context.Background()
+
+	excludedNs := &corev1.Namespace{ObjectMeta: metav1.ObjectMeta{GenerateName: "excluded-"}}
+	if err := tc.client.Create(ctx, excludedNs); err != nil {
+		t.Fatalf("failed to create namespace: %v", err)
+	}
+	defer func() { _ = tc.client.Delete(context.Background(), excludedNs) }()
+
+	newSecret := func(namespace string) *corev1.Secret {
+		return &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-namespace-filter",
+				Namespace: namespace,
+				Annotations: map[string]string{
+					AnnotationAutogenerate: "password",
+				},
+			},
+			Type: corev1.SecretTypeOpaque,
+		}
+	}
+
+	excluded := newSecret(excludedNs.Name)
+	if err := tc.client.Create(ctx, excluded); err != nil {
+		t.Fatalf("failed to create secret: %v", err)
+	}
+	included := newSecret(ns.Name)
+	if err := tc.client.Create(ctx, included); err != nil {
+		t.Fatalf("failed to create secret: %v", err)
+	}
+
+	// The Secret in the included namespace is generated
+	updated, err := waitForSecretField(ctx, tc.client, types.NamespacedName{Name: included.Name, Namespace: ns.Name}, "password")
+	if err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+	if len(updated.Data["password"]) != 32 {
+		t.Errorf("expected password in included namespace to be generated, got %q", updated.Data["password"])
+	}
+
+	// Give the controller time to (not) reconcile the excluded Secret
+	time.Sleep(2 * time.Second)
+
+	var got corev1.Secret
+	if err := tc.client.Get(ctx, types.NamespacedName{Name: excluded.Name, Namespace: excludedNs.Name}, &got); err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+	if len(got.Data) != 0 {
+		t.Errorf("expected secret in excluded namespace not to be modified, got data %v", got.Data)
+	}
+	if got.ResourceVersion != excluded.ResourceVersion {
+		t.Errorf("expected secret in excluded namespace not to be updated, resource version %s -> %s", excluded.ResourceVersion, got.ResourceVersion)
+	}
+}
@@ -0,0 +1,126 @@
+//go:build integration
+// +build integration
+
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"bytes"
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+)
+
+const (
+	AnnotationPrune         = AnnotationPrefix + "prune"
+	AnnotationManagedFields = AnnotationPrefix + "managed-fields"
+)
+
+// TestPruneRemovedFields tests that dropping fields from autogenerate removes only the values the operator created
+func TestPruneRemovedFields(t *testing.T) {
+	tc := setupTestManager(t, config.NewDefaultConfig())
+	ns := createNamespace(t, tc.client)
+	defer tc.cleanup(t, ns)
+
+	ctx := context.Background()
+
+	// "provided" is listed but has a value, so it is kept instead of generated; "unlisted" isn't listed at all
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-prune",
+			Namespace: ns.Name,
+			Annotations: map[string]string{
+				AnnotationAutogenerate: "password,token,provided",
+				AnnotationPrune:        "true",
+			},
+		},
+		Data: map[string][]byte{
+			"provided": []byte("user-value"),
+			"unlisted": []byte("user-config"),
+		},
+		Type: corev1.SecretTypeOpaque,
+	}
+	if err := tc.client.Create(ctx, secret); err != nil {
+		t.Fatalf("failed to create secret: %v", err)
+	}
+	key := types.NamespacedName{Name: secret.Name, Namespace: ns.Name}
+
+	generated, err := waitForSecretField(ctx, tc.client, key, "token")
+	if err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+	if len(generated.Data["password"]) == 0 || len(generated.Data["token"]) == 0 {
+		t.Fatalf("expected password and token to be generated, got %v", generated.Data)
+	}
+	if got := generated.Annotations[AnnotationManagedFields]; got != "password,token" {
+		t.Errorf("expected managed fields %q, got %q", "password,token", got)
+	}
+
+	// Drop token and provided from autogenerate, retrying on conflicts with the operator's status updates
+	deadline := time.Now().Add(timeout)
+	for {
+		var current corev1.Secret
+		if err := tc.client.Get(ctx, key, &current); err != nil {
+			t.Fatalf("failed to get secret: %v", err)
+		}
+		current.Annotations[AnnotationAutogenerate] = "password"
+		err := tc.client.Update(ctx, &current)
+		if err == nil {
+			break
+		}
+		if !apierrors.IsConflict(err) || time.Now().After(deadline) {
+			t.Fatalf("failed to update secret: %v", err)
+		}
+		time.Sleep(interval)
+	}
+
+	// The generated token is pruned
+	var got corev1.Secret
+	for time.Now().Before(deadline) {
+		if err := tc.client.Get(ctx, key, &got); err == nil {
+			if _, ok := got.Data["token"]; !ok {
+				break
+			}
+		}
+		time.Sleep(interval)
+	}
+	if _, ok := got.Data["token"]; ok {
+		t.Fatal("expected token to be pruned")
+	}
+
+	// Values provided by the user are never pruned, and the remaining field keeps its value
+	if string(got.Data["provided"]) != "user-value" {
+		t.Errorf("expected provided to be kept, got %q", got.Data["provided"])
+	}
+	if string(got.Data["unlisted"]) != "user-config" {
+		t.Errorf("expected unlisted to be kept, got %q", got.Data["unlisted"])
+	}
+	if !bytes.Equal(got.Data["password"], generated.Data["password"]) {
+		t.Error("expected password to keep its value")
+	}
+	if got.Annotations[AnnotationManagedFields] != "password" {
+		t.Errorf("expected managed fields %q, got %q", "password", got.Annotations[AnnotationManagedFields])
+	}
+}
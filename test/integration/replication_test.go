@@ -22,6 +22,7 @@ package integration
 import (
 	"context"
 	"fmt"
+	"strings"
 	"testing"
 	"time"
 
@@ -776,6 +777,86 @@ func TestSecretReplication(t *testing.T) {
 			t.Errorf("pushed secret was not cleaned up: %v", err)
 		}
 	})
+
+	t.Run("PushBasedReplication_CleanupKeepsRetargetedReplicas", func(t *testing.T) {
+		sourceNS := &corev1.Namespace{
+			ObjectMeta: metav1.ObjectMeta{
+				GenerateName: "retarget-source-",
+			},
+		}
+		if err := tc.client.Create(ctx, sourceNS); err != nil {
+			t.Fatalf("failed to create source namespace: %v", err)
+		}
+		defer tc.client.Delete(ctx, sourceNS)
+
+		var targetNamespaces []string
+		for _, prefix := range []string{"retarget-pushed-", "retarget-kept-"} {
+			ns := &corev1.Namespace{
+				ObjectMeta: metav1.ObjectMeta{
+					GenerateName: prefix,
+				},
+			}
+			if err := tc.client.Create(ctx, ns); err != nil {
+				t.Fatalf("failed to create target namespace: %v", err)
+			}
+			defer tc.client.Delete(ctx, ns)
+			targetNamespaces = append(targetNamespaces, ns.Name)
+		}
+
+		sourceSecret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "retarget-secret",
+				Namespace: sourceNS.Name,
+				Annotations: map[string]string{
+					replicator.AnnotationReplicateTo: strings.Join(targetNamespaces, ","),
+				},
+			},
+			Data: map[string][]byte{
+				"data": []byte("test"),
+			},
+		}
+		if err := tc.client.Create(ctx, sourceSecret); err != nil {
+			t.Fatalf("failed to create source secret: %v", err)
+		}
+
+		var replicas []*corev1.Secret
+		for _, ns := range targetNamespaces {
+			replica, err := waitForSecretReplication(ctx, tc.client, types.NamespacedName{
+				Namespace: ns,
+				Name:      "retarget-secret",
+			}, map[string]string{"data": "test"})
+			if err != nil {
+				t.Fatalf("push to %s failed: %v", ns, err)
+			}
+			replicas = append(replicas, replica)
+		}
+		defer tc.client.Delete(ctx, replicas[1])
+
+		// Point the second replica at another source
+		replicas[1].Annotations[replicator.AnnotationReplicateFrom] = "elsewhere/retarget-secret"
+		if err := tc.client.Update(ctx, replicas[1]); err != nil {
+			t.Fatalf("failed to retarget replica: %v", err)
+		}
+
+		if err := tc.client.Delete(ctx, sourceSecret); err != nil {
+			t.Fatalf("failed to delete source secret: %v", err)
+		}
+
+		if err := waitForSecretDeletion(ctx, tc.client, types.NamespacedName{
+			Namespace: targetNamespaces[0],
+			Name:      "retarget-secret",
+		}); err != nil {
+			t.Errorf("pushed secret was not cleaned up: %v", err)
+		}
+
+		kept := &corev1.Secret{}
+		if err := tc.client.Get(ctx, types.NamespacedName{
+			Namespace: targetNamespaces[1],
+			Name:      "retarget-secret",
+		}, kept); err != nil {
+			t.Errorf("retargeted secret was deleted: %v", err)
+		}
+	})
 }
 
 // TestFeatureInteractions tests the interaction between secret generation and replication features
@@ -1259,3 +1340,87 @@ func TestPushReplication_RetryAfterConflictingSecretDeleted(t *testing.T) {
 	// Cleanup
 	defer tc.client.Delete(ctx, replicatedSecret)
 }
+
+func TestPushReplication_NamespaceCreatedAfterSource(t *testing.T) {
+	cfg := config.NewDefaultConfig()
+	cfg.Features.SecretReplicator = true
+	tc := setupTestManagerWithReplicator(t, cfg)
+	defer tc.cancel()
+
+	ctx := context.Background()
+
+	// Create source namespace
+	sourceNS := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			GenerateName: "late-source-",
+		},
+	}
+	if err := tc.client.Create(ctx, sourceNS); err != nil {
+		t.Fatalf("failed to create source namespace: %v", err)
+	}
+	defer tc.client.Delete(ctx, sourceNS)
+
+	// Step 1: Create a source Secret that pushes to a namespace that doesn't exist yet
+	targetNSName := sourceNS.Name + "-target"
+	sourceSecret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "late-secret",
+			Namespace: sourceNS.Name,
+			Annotations: map[string]string{
+				replicator.AnnotationReplicateTo: targetNSName,
+			},
+		},
+		Data: map[string][]byte{
+			"replicated-key": []byte("replicated-value"),
+		},
+	}
+	if err := tc.client.Create(ctx, sourceSecret); err != nil {
+		t.Fatalf("failed to create source secret: %v", err)
+	}
+	defer tc.client.Delete(ctx, sourceSecret)
+
+	// Wait until the source has been reconciled (finalizer added), so the push to the
+	// missing namespace has been attempted and failed
+	deadline := time.Now().Add(replicationTimeout)
+	for {
+		var current corev1.Secret
+		if err := tc.client.Get(ctx, client.ObjectKeyFromObject(sourceSecret), &current); err == nil && replicator.HasFinalizer(&current) {
+			sourceSecret = &current
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatal("source secret was not reconciled")
+		}
+		time.Sleep(replicationInterval)
+	}
+	sourceResourceVersion := sourceSecret.ResourceVersion
+
+	// Step 2: Create the target namespace, without touching the source
+	targetNS := &corev1.Namespace{
+		ObjectMeta: metav1.ObjectMeta{
+			Name: targetNSName,
+		},
+	}
+	if err := tc.client.Create(ctx, targetNS); err != nil {
+		t.Fatalf("failed to create target namespace: %v", err)
+	}
+	defer tc.client.Delete(ctx, targetNS)
+
+	// Step 3: The replica should be created promptly in the new namespace
+	replicatedSecret, err := waitForSecretReplication(ctx, tc.client, types.NamespacedName{
+		Namespace: targetNSName,
+		Name:      "late-secret",
+	}, map[string]string{"replicated-key": "replicated-value"})
+	if err != nil {
+		t.Fatalf("push replication did not run after target namespace was created: %v", err)
+	}
+	defer tc.client.Delete(ctx, replicatedSecret)
+
+	var currentSource corev1.Secret
+	if err := tc.client.Get(ctx, client.ObjectKeyFromObject(sourceSecret), &currentSource); err != nil {
+		t.Fatalf("failed to get source secret: %v", err)
+	}
+	if currentSource.ResourceVersion != sourceResourceVersion {
+		t.Error("source secret should not have been modified")
+	}
+}
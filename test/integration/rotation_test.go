@@ -21,20 +21,36 @@ package integration
 
 import (
 	"context"
+	"encoding/json"
+	"strconv"
 	"testing"
 	"time"
 
 	corev1 "k8s.io/api/core/v1"
+	apierrors "k8s.io/apimachinery/pkg/api/errors"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/tools/events"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
 
+	"github.com/guided-traffic/internal-secrets-operator/internal/controller"
 	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/generator"
 )
 
 const (
 	// Rotation annotation constants
-	AnnotationRotate       = AnnotationPrefix + "rotate"
-	AnnotationRotatePrefix = AnnotationPrefix + "rotate."
+	AnnotationRotate          = AnnotationPrefix + "rotate"
+	AnnotationRotatePrefix    = AnnotationPrefix + "rotate."
+	AnnotationRotateNow       = AnnotationPrefix + "rotate-now"
+	AnnotationRotationHistory = AnnotationPrefix + "rotation-history"
+
+	AnnotationVersionFieldPrefix = AnnotationPrefix + "version-field."
+
+	AnnotationGracePeriodPrefix          = AnnotationPrefix + "grace-period."
+	AnnotationPreviousValueExpiresPrefix = AnnotationPrefix + "previous-value-expires."
 )
 
 // TestRotationBasic tests basic secret rotation functionality
@@ -582,3 +598,322 @@ func TestRotationPreservesOtherData(t *testing.T) {
 		}
 	})
 }
+
+// TestRotateNow tests that the rotate-now annotation rotates all fields once and is removed afterwards
+func TestRotateNow(t *testing.T) {
+	tc := setupTestManager(t, nil)
+	ns := createNamespace(t, tc.client)
+	defer tc.cleanup(t, ns)
+
+	ctx := context.Background()
+
+	t.Run("RotateNowRotatesAllFields", func(t *testing.T) {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-rotate-now",
+				Namespace: ns.Name,
+				Annotations: map[string]string{
+					AnnotationAutogenerate: "password,token",
+				},
+			},
+			Type: corev1.SecretTypeOpaque,
+		}
+
+		if err := tc.client.Create(ctx, secret); err != nil {
+			t.Fatalf("failed to create secret: %v", err)
+		}
+
+		key := types.NamespacedName{Name: secret.Name, Namespace: ns.Name}
+		generated, err := waitForSecretField(ctx, tc.client, key, "token")
+		if err != nil {
+			t.Fatalf("failed to get secret: %v", err)
+		}
+		oldPassword := string(generated.Data["password"])
+		oldToken := string(generated.Data["token"])
+
+		// Request a rotation, retrying on conflicts with the controller
+		deadline := time.Now().Add(timeout)
+		for {
+			var current corev1.Secret
+			if err := tc.client.Get(ctx, key, &current); err != nil {
+				t.Fatalf("failed to get secret: %v", err)
+			}
+			current.Annotations[AnnotationRotateNow] = "true"
+			err := tc.client.Update(ctx, &current)
+			if err == nil {
+				break
+			}
+			if !apierrors.IsConflict(err) || time.Now().After(deadline) {
+				t.Fatalf("failed to set rotate-now annotation: %v", err)
+			}
+			time.Sleep(interval)
+		}
+
+		// Poll for the rotated values
+		var rotated corev1.Secret
+		deadline = time.Now().Add(timeout)
+		for time.Now().Before(deadline) {
+			if err := tc.client.Get(ctx, key, &rotated); err == nil &&
+				string(rotated.Data["password"]) != oldPassword && string(rotated.Data["token"]) != oldToken {
+				break
+			}
+			time.Sleep(interval)
+		}
+
+		if string(rotated.Data["password"]) == oldPassword {
+			t.Error("expected password to be rotated")
+		}
+		if string(rotated.Data["token"]) == oldToken {
+			t.Error("expected token to be rotated")
+		}
+		if _, ok := rotated.Annotations[AnnotationRotateNow]; ok {
+			t.Error("expected rotate-now annotation to be removed")
+		}
+	})
+}
+
+// TestRotationGracePeriod tests that the previous value is kept after rotation until its grace period is over
+func TestRotationGracePeriod(t *testing.T) {
+	mockTime := time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)
+	mockClock := &MockClock{currentTime: mockTime}
+
+	tc := setupTestManagerWithClock(t, nil, mockClock)
+	ns := createNamespace(t, tc.client)
+	defer tc.cleanup(t, ns)
+
+	ctx := context.Background()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-grace-period",
+			Namespace: ns.Name,
+			Annotations: map[string]string{
+				AnnotationAutogenerate:                   "password",
+				AnnotationRotate:                         "1h",
+				AnnotationGracePeriodPrefix + "password": "30m",
+				AnnotationGeneratedAt:                    mockTime.Add(-2 * time.Hour).Format(time.RFC3339),
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+		Data: map[string][]byte{
+			"password": []byte("old-password"),
+		},
+	}
+
+	if err := tc.client.Create(ctx, secret); err != nil {
+		t.Fatalf("failed to create secret: %v", err)
+	}
+
+	// The overdue rotation moves the old password to password-previous
+	key := types.NamespacedName{Name: secret.Name, Namespace: ns.Name}
+	updatedSecret, err := waitForSecretField(ctx, tc.client, key, "password-previous")
+	if err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+	if got := string(updatedSecret.Data["password-previous"]); got != "old-password" {
+		t.Fatalf("expected previous password %q, got %q", "old-password", got)
+	}
+	if string(updatedSecret.Data["password"]) == "old-password" {
+		t.Error("expected password to be rotated")
+	}
+	if got := updatedSecret.Annotations[AnnotationPreviousValueExpiresPrefix+"password"]; got != "2026-02-02T12:30:00Z" {
+		t.Errorf("expected previous value to expire at 2026-02-02T12:30:00Z, got %q", got)
+	}
+	newPassword := string(updatedSecret.Data["password"])
+
+	// Once the grace period is over, the next reconcile removes the previous value
+	mockClock.Advance(31 * time.Minute)
+	if updatedSecret.Labels == nil {
+		updatedSecret.Labels = make(map[string]string)
+	}
+	updatedSecret.Labels["reconcile"] = "now"
+	if err := tc.client.Update(ctx, updatedSecret); err != nil {
+		t.Fatalf("failed to update secret: %v", err)
+	}
+
+	deadline := time.Now().Add(timeout)
+	for time.Now().Before(deadline) {
+		if err := tc.client.Get(ctx, key, updatedSecret); err == nil {
+			if _, ok := updatedSecret.Data["password-previous"]; !ok {
+				break
+			}
+		}
+		time.Sleep(interval)
+	}
+	if _, ok := updatedSecret.Data["password-previous"]; ok {
+		t.Fatal("expected previous password to be removed after the grace period")
+	}
+	if _, ok := updatedSecret.Annotations[AnnotationPreviousValueExpiresPrefix+"password"]; ok {
+		t.Error("expected expiry annotation to be removed")
+	}
+	if got := string(updatedSecret.Data["password"]); got != newPassword {
+		t.Errorf("expected current password to be unchanged, got %q", got)
+	}
+}
+
+// TestRotationHistory tests that every rotation appends its time to the rotation-history
+// annotation, which keeps only the configured number of most recent rotations
+func TestRotationHistory(t *testing.T) {
+	mockTime := time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)
+	mockClock := &MockClock{currentTime: mockTime}
+
+	cfg := config.NewDefaultConfig()
+	cfg.Rotation.HistorySize = 3
+
+	// The reconciler is called directly to rotate at the mocked times
+	k8sClient, err := client.New(restConfig, client.Options{Scheme: scheme.Scheme})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	ns := createNamespace(t, k8sClient)
+	defer func() { _ = k8sClient.Delete(context.Background(), ns) }()
+
+	reconciler := &controller.SecretReconciler{
+		Client:        k8sClient,
+		Scheme:        scheme.Scheme,
+		Generator:     generator.NewSecretGenerator(),
+		Config:        cfg,
+		EventRecorder: events.NewFakeRecorder(10),
+		Clock:         mockClock,
+	}
+
+	ctx := context.Background()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-rotation-history",
+			Namespace: ns.Name,
+			Annotations: map[string]string{
+				AnnotationAutogenerate: "password",
+				AnnotationRotate:       "1h",
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+	}
+	if err := k8sClient.Create(ctx, secret); err != nil {
+		t.Fatalf("failed to create secret: %v", err)
+	}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: ns.Name}}
+	reconcile := func() *corev1.Secret {
+		t.Helper()
+		if _, err := reconciler.Reconcile(ctx, req); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var updatedSecret corev1.Secret
+		if err := k8sClient.Get(ctx, req.NamespacedName, &updatedSecret); err != nil {
+			t.Fatalf("failed to get secret: %v", err)
+		}
+		return &updatedSecret
+	}
+
+	// The initial generation is not a rotation
+	updatedSecret := reconcile()
+	if _, ok := updatedSecret.Annotations[AnnotationRotationHistory]; ok {
+		t.Errorf("expected no rotation history before the first rotation, got %q", updatedSecret.Annotations[AnnotationRotationHistory])
+	}
+
+	var want []string
+	for i := 0; i < 5; i++ {
+		mockClock.Advance(61 * time.Minute)
+		want = append(want, mockClock.Now().Format(time.RFC3339))
+		updatedSecret = reconcile()
+	}
+
+	var history []string
+	if err := json.Unmarshal([]byte(updatedSecret.Annotations[AnnotationRotationHistory]), &history); err != nil {
+		t.Fatalf("expected rotation history to be a JSON array, got %q: %v", updatedSecret.Annotations[AnnotationRotationHistory], err)
+	}
+	want = want[len(want)-3:]
+	if len(history) != len(want) {
+		t.Fatalf("expected %d history entries, got %v", len(want), history)
+	}
+	for i := range want {
+		if history[i] != want[i] {
+			t.Errorf("expected history entry %d to be %s, got %s", i, want[i], history[i])
+		}
+	}
+
+	// A reconcile without a due rotation leaves the Secret alone
+	resourceVersion := updatedSecret.ResourceVersion
+	updatedSecret = reconcile()
+	if updatedSecret.ResourceVersion != resourceVersion {
+		t.Errorf("expected no update without a rotation, resource version changed from %s to %s", resourceVersion, updatedSecret.ResourceVersion)
+	}
+}
+
+// TestRotationVersionField tests that the version field is incremented by one with each rotation
+// and written together with the rotated value
+func TestRotationVersionField(t *testing.T) {
+	mockClock := &MockClock{currentTime: time.Date(2026, 2, 2, 12, 0, 0, 0, time.UTC)}
+
+	// The reconciler is called directly to rotate at the mocked times
+	k8sClient, err := client.New(restConfig, client.Options{Scheme: scheme.Scheme})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	ns := createNamespace(t, k8sClient)
+	defer func() { _ = k8sClient.Delete(context.Background(), ns) }()
+
+	reconciler := &controller.SecretReconciler{
+		Client:        k8sClient,
+		Scheme:        scheme.Scheme,
+		Generator:     generator.NewSecretGenerator(),
+		Config:        config.NewDefaultConfig(),
+		EventRecorder: events.NewFakeRecorder(10),
+		Clock:         mockClock,
+	}
+
+	ctx := context.Background()
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-version-field",
+			Namespace: ns.Name,
+			Annotations: map[string]string{
+				AnnotationAutogenerate:                 "token",
+				AnnotationRotate:                       "1h",
+				AnnotationVersionFieldPrefix + "token": "token-version",
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+	}
+	if err := k8sClient.Create(ctx, secret); err != nil {
+		t.Fatalf("failed to create secret: %v", err)
+	}
+
+	req := ctrl.Request{NamespacedName: types.NamespacedName{Name: secret.Name, Namespace: ns.Name}}
+	reconcile := func() *corev1.Secret {
+		t.Helper()
+		if _, err := reconciler.Reconcile(ctx, req); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var updatedSecret corev1.Secret
+		if err := k8sClient.Get(ctx, req.NamespacedName, &updatedSecret); err != nil {
+			t.Fatalf("failed to get secret: %v", err)
+		}
+		return &updatedSecret
+	}
+
+	updatedSecret := reconcile()
+	if got := string(updatedSecret.Data["token-version"]); got != "1" {
+		t.Fatalf("expected version 1 after the initial generation, got %q", got)
+	}
+
+	for want := 2; want <= 5; want++ {
+		previousToken := string(updatedSecret.Data["token"])
+		mockClock.Advance(61 * time.Minute)
+		updatedSecret = reconcile()
+
+		if string(updatedSecret.Data["token"]) == previousToken {
+			t.Fatalf("expected token to be rotated in rotation %d", want-1)
+		}
+		if got := string(updatedSecret.Data["token-version"]); got != strconv.Itoa(want) {
+			t.Errorf("expected version %d after rotation %d, got %q", want, want-1, got)
+		}
+	}
+
+	// A reconcile without a due rotation keeps the version
+	updatedSecret = reconcile()
+	if got := string(updatedSecret.Data["token-version"]); got != "5" {
+		t.Errorf("expected version 5 without a rotation, got %q", got)
+	}
+}
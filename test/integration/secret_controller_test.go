@@ -25,6 +25,7 @@ import (
 	"testing"
 	"time"
 
+	"golang.org/x/crypto/bcrypt"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
@@ -47,6 +48,10 @@ const (
 	AnnotationParamPrefix  = AnnotationPrefix + "param."
 	AnnotationGeneratedAt  = AnnotationPrefix + "generated-at"
 
+	AnnotationTemplatePrefix     = AnnotationPrefix + "template."
+	AnnotationBcryptSourcePrefix = AnnotationPrefix + "bcrypt-source."
+	AnnotationBcryptCostPrefix   = AnnotationPrefix + "bcrypt-cost."
+
 	AnnotationStringUppercase           = AnnotationPrefix + "string.uppercase"
 	AnnotationStringLowercase           = AnnotationPrefix + "string.lowercase"
 	AnnotationStringNumbers             = AnnotationPrefix + "string.numbers"
@@ -153,6 +158,116 @@ func TestSecretController(t *testing.T) {
 		}
 	})
 
+	t.Run("StringData", func(t *testing.T) {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-string-data",
+				Namespace: ns.Name,
+				Annotations: map[string]string{
+					AnnotationAutogenerate: "password,api-key,token",
+				},
+			},
+			Data: map[string][]byte{
+				"token": []byte("from-data"),
+			},
+			StringData: map[string]string{
+				"username": "admin",
+				"api-key":  "",
+				"token":    "from-string-data",
+			},
+			Type: corev1.SecretTypeOpaque,
+		}
+
+		if err := tc.client.Create(ctx, secret); err != nil {
+			t.Fatalf("failed to create secret: %v", err)
+		}
+
+		// The API server turns stringData into data, so the empty api-key arrives as an empty data value
+		key := types.NamespacedName{Name: secret.Name, Namespace: ns.Name}
+		updatedSecret, err := waitForAnnotation(ctx, tc.client, key, AnnotationGeneratedAt)
+		if err != nil {
+			t.Fatalf("failed to get secret: %v", err)
+		}
+
+		if len(updatedSecret.Data["password"]) != 32 {
+			t.Errorf("expected missing password to be generated with length 32, got %q", updatedSecret.Data["password"])
+		}
+		if len(updatedSecret.Data["api-key"]) != 32 {
+			t.Errorf("expected empty api-key from stringData to be generated with length 32, got %q", updatedSecret.Data["api-key"])
+		}
+		if string(updatedSecret.Data["token"]) != "from-string-data" {
+			t.Errorf("expected token from stringData to take precedence over data, got %q", updatedSecret.Data["token"])
+		}
+		if string(updatedSecret.Data["username"]) != "admin" {
+			t.Errorf("expected username from stringData to be kept, got %q", updatedSecret.Data["username"])
+		}
+	})
+
+	t.Run("TemplatedConnectionString", func(t *testing.T) {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-templated-dsn",
+				Namespace: ns.Name,
+				Annotations: map[string]string{
+					AnnotationAutogenerate:              "username,password",
+					AnnotationLengthPrefix + "username": "12",
+					AnnotationTemplatePrefix + "dsn":    "postgres://{{ .username }}:{{ .password }}@postgres:5432/app",
+				},
+			},
+			Type: corev1.SecretTypeOpaque,
+		}
+
+		if err := tc.client.Create(ctx, secret); err != nil {
+			t.Fatalf("failed to create secret: %v", err)
+		}
+
+		key := types.NamespacedName{Name: secret.Name, Namespace: ns.Name}
+		updatedSecret, err := waitForSecretField(ctx, tc.client, key, "dsn")
+		if err != nil {
+			t.Fatalf("failed to get secret: %v", err)
+		}
+
+		username := string(updatedSecret.Data["username"])
+		password := string(updatedSecret.Data["password"])
+		if len(username) != 12 || len(password) != 32 {
+			t.Fatalf("expected generated username and password, got %q and %q", username, password)
+		}
+		expected := "postgres://" + username + ":" + password + "@postgres:5432/app"
+		if got := string(updatedSecret.Data["dsn"]); got != expected {
+			t.Errorf("expected dsn %q, got %q", expected, got)
+		}
+	})
+
+	t.Run("BcryptHash", func(t *testing.T) {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-bcrypt-hash",
+				Namespace: ns.Name,
+				Annotations: map[string]string{
+					AnnotationAutogenerate:                         "password,password-hash",
+					AnnotationTypePrefix + "password-hash":         "bcrypt",
+					AnnotationBcryptSourcePrefix + "password-hash": "password",
+					AnnotationBcryptCostPrefix + "password-hash":   "4",
+				},
+			},
+			Type: corev1.SecretTypeOpaque,
+		}
+
+		if err := tc.client.Create(ctx, secret); err != nil {
+			t.Fatalf("failed to create secret: %v", err)
+		}
+
+		key := types.NamespacedName{Name: secret.Name, Namespace: ns.Name}
+		updatedSecret, err := waitForSecretField(ctx, tc.client, key, "password-hash")
+		if err != nil {
+			t.Fatalf("failed to get secret: %v", err)
+		}
+
+		if err := bcrypt.CompareHashAndPassword(updatedSecret.Data["password-hash"], updatedSecret.Data["password"]); err != nil {
+			t.Errorf("expected password-hash to match the generated password: %v", err)
+		}
+	})
+
 	t.Run("MultipleFieldGeneration", func(t *testing.T) {
 		secret := &corev1.Secret{
 			ObjectMeta: metav1.ObjectMeta{
@@ -0,0 +1,160 @@
+//go:build integration
+// +build integration
+
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes/scheme"
+	ctrl "sigs.k8s.io/controller-runtime"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+	metricsserver "sigs.k8s.io/controller-runtime/pkg/metrics/server"
+
+	"github.com/guided-traffic/internal-secrets-operator/internal/controller"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/config"
+	"github.com/guided-traffic/internal-secrets-operator/pkg/generator"
+)
+
+// blockingUpdateClient holds the first Update of a Secret in namespace until release is closed
+type blockingUpdateClient struct {
+	client.Client
+	namespace string
+	updating  chan struct{}
+	release   chan struct{}
+	once      sync.Once
+}
+
+func (c *blockingUpdateClient) Update(ctx context.Context, obj client.Object, opts ...client.UpdateOption) error {
+	if obj.GetNamespace() == c.namespace {
+		c.once.Do(func() {
+			close(c.updating)
+			<-c.release
+		})
+	}
+	return c.Client.Update(ctx, obj, opts...)
+}
+
+// TestShutdownDrainsInFlightReconcile tests that a reconcile in progress when the manager shuts
+// down completes its update instead of leaving the Secret without its values
+func TestShutdownDrainsInFlightReconcile(t *testing.T) {
+	k8sClient, err := client.New(restConfig, client.Options{Scheme: scheme.Scheme})
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	ns := createNamespace(t, k8sClient)
+	defer func() { _ = k8sClient.Delete(context.Background(), ns) }()
+
+	cfg := config.NewDefaultConfig()
+	gracePeriod := cfg.ShutdownGracePeriod.Duration()
+	mgr, err := ctrl.NewManager(restConfig, ctrl.Options{
+		Scheme: scheme.Scheme,
+		Metrics: metricsserver.Options{
+			BindAddress: "0",
+		},
+		GracefulShutdownTimeout: &gracePeriod,
+	})
+	if err != nil {
+		t.Fatalf("failed to create manager: %v", err)
+	}
+
+	drain := controller.NewReconcileDrain(gracePeriod)
+	if err := mgr.Add(drain); err != nil {
+		t.Fatalf("failed to add reconcile drain: %v", err)
+	}
+	blocking := &blockingUpdateClient{
+		Client:    mgr.GetClient(),
+		namespace: ns.Name,
+		updating:  make(chan struct{}),
+		release:   make(chan struct{}),
+	}
+	reconciler := &controller.SecretReconciler{
+		Client:        blocking,
+		Scheme:        mgr.GetScheme(),
+		Generator:     generator.NewSecretGenerator(),
+		Config:        cfg,
+		EventRecorder: mgr.GetEventRecorder("secret-operator"),
+		Drain:         drain,
+	}
+	counter := atomic.AddInt64(&controllerCounter, 1)
+	controllerName := "secret-controller-shutdown-" + time.Now().Format("150405") + "-" + string(rune('a'+counter%26))
+	if err := ctrl.NewControllerManagedBy(mgr).Named(controllerName).For(&corev1.Secret{}).Complete(reconciler); err != nil {
+		t.Fatalf("failed to setup controller: %v", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stopped := make(chan error, 1)
+	go func() {
+		stopped <- mgr.Start(ctx)
+	}()
+
+	secret := &corev1.Secret{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      "test-shutdown-drain",
+			Namespace: ns.Name,
+			Annotations: map[string]string{
+				AnnotationAutogenerate: "password",
+			},
+		},
+		Type: corev1.SecretTypeOpaque,
+	}
+	if err := k8sClient.Create(context.Background(), secret); err != nil {
+		t.Fatalf("failed to create secret: %v", err)
+	}
+
+	// Shut down while the reconcile is about to update the Secret
+	select {
+	case <-blocking.updating:
+	case <-time.After(timeout):
+		t.Fatal("timed out waiting for the reconcile to update the secret")
+	}
+	cancel()
+	time.Sleep(200 * time.Millisecond)
+	select {
+	case err := <-stopped:
+		t.Fatalf("expected the manager to wait for the in-flight reconcile, it stopped with %v", err)
+	default:
+	}
+	close(blocking.release)
+
+	select {
+	case err := <-stopped:
+		if err != nil {
+			t.Fatalf("expected a clean shutdown, got %v", err)
+		}
+	case <-time.After(gracePeriod):
+		t.Fatal("timed out waiting for the manager to stop")
+	}
+
+	var updatedSecret corev1.Secret
+	if err := k8sClient.Get(context.Background(), types.NamespacedName{Name: secret.Name, Namespace: ns.Name}, &updatedSecret); err != nil {
+		t.Fatalf("failed to get secret: %v", err)
+	}
+	if len(updatedSecret.Data["password"]) == 0 {
+		t.Error("expected the in-flight reconcile to complete its update during shutdown")
+	}
+}
@@ -0,0 +1,166 @@
+//go:build integration
+// +build integration
+
+/*
+Copyright 2025 Guided Traffic.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+package integration
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"sigs.k8s.io/controller-runtime/pkg/client"
+)
+
+const (
+	// Status annotation constants
+	AnnotationStatus           = AnnotationPrefix + "status"
+	AnnotationLastError        = AnnotationPrefix + "last-error"
+	AnnotationLastRotationTime = AnnotationPrefix + "last-rotation-time"
+	AnnotationNextRotationTime = AnnotationPrefix + "next-rotation-time"
+)
+
+// waitForAnnotationValue waits for an annotation to have the given value
+func waitForAnnotationValue(ctx context.Context, c client.Client, key types.NamespacedName, annotation, value string) (*corev1.Secret, error) {
+	var secret corev1.Secret
+	deadline := time.Now().Add(timeout)
+
+	for time.Now().Before(deadline) {
+		if err := c.Get(ctx, key, &secret); err == nil && secret.Annotations[annotation] == value {
+			return &secret, nil
+		}
+		time.Sleep(interval)
+	}
+
+	// Return whatever we have
+	if err := c.Get(ctx, key, &secret); err != nil {
+		return nil, err
+	}
+	return &secret, nil
+}
+
+// TestStatusAnnotations tests the status annotations written on reconcile
+func TestStatusAnnotations(t *testing.T) {
+	tc := setupTestManager(t, nil)
+	ns := createNamespace(t, tc.client)
+	defer tc.cleanup(t, ns)
+
+	ctx := context.Background()
+
+	t.Run("ReadyWithNextRotation", func(t *testing.T) {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-status-ready",
+				Namespace: ns.Name,
+				Annotations: map[string]string{
+					AnnotationAutogenerate: "password",
+					AnnotationRotate:       "24h",
+				},
+			},
+			Type: corev1.SecretTypeOpaque,
+		}
+		if err := tc.client.Create(ctx, secret); err != nil {
+			t.Fatalf("failed to create secret: %v", err)
+		}
+
+		key := types.NamespacedName{Name: secret.Name, Namespace: ns.Name}
+		updatedSecret, err := waitForAnnotationValue(ctx, tc.client, key, AnnotationStatus, "Ready")
+		if err != nil {
+			t.Fatalf("failed to get secret: %v", err)
+		}
+		if got := updatedSecret.Annotations[AnnotationStatus]; got != "Ready" {
+			t.Fatalf("expected status Ready, got %q", got)
+		}
+
+		nextRotation, err := time.Parse(time.RFC3339, updatedSecret.Annotations[AnnotationNextRotationTime])
+		if err != nil {
+			t.Fatalf("expected a valid next rotation time, got %q", updatedSecret.Annotations[AnnotationNextRotationTime])
+		}
+		generatedAt, err := time.Parse(time.RFC3339, updatedSecret.Annotations[AnnotationGeneratedAt])
+		if err != nil {
+			t.Fatalf("expected a valid generated-at time, got %q", updatedSecret.Annotations[AnnotationGeneratedAt])
+		}
+		if diff := nextRotation.Sub(generatedAt) - 24*time.Hour; diff < -time.Second || diff > time.Second {
+			t.Errorf("expected next rotation 24h after generation, got %s (generated at %s)", nextRotation, generatedAt)
+		}
+
+		// A manual rotation records the rotation time
+		updatedSecret.Annotations[AnnotationRotateNow] = "true"
+		if err := tc.client.Update(ctx, updatedSecret); err != nil {
+			t.Fatalf("failed to update secret: %v", err)
+		}
+		rotatedSecret, err := waitForAnnotation(ctx, tc.client, key, AnnotationLastRotationTime)
+		if err != nil {
+			t.Fatalf("failed to get secret: %v", err)
+		}
+		if _, err := time.Parse(time.RFC3339, rotatedSecret.Annotations[AnnotationLastRotationTime]); err != nil {
+			t.Errorf("expected a valid last rotation time, got %q", rotatedSecret.Annotations[AnnotationLastRotationTime])
+		}
+	})
+
+	t.Run("ErrorAndRecovery", func(t *testing.T) {
+		secret := &corev1.Secret{
+			ObjectMeta: metav1.ObjectMeta{
+				Name:      "test-status-error",
+				Namespace: ns.Name,
+				Annotations: map[string]string{
+					AnnotationAutogenerate: "password",
+					AnnotationType:         "unknown",
+				},
+			},
+			Type: corev1.SecretTypeOpaque,
+		}
+		if err := tc.client.Create(ctx, secret); err != nil {
+			t.Fatalf("failed to create secret: %v", err)
+		}
+
+		key := types.NamespacedName{Name: secret.Name, Namespace: ns.Name}
+		updatedSecret, err := waitForAnnotationValue(ctx, tc.client, key, AnnotationStatus, "Error")
+		if err != nil {
+			t.Fatalf("failed to get secret: %v", err)
+		}
+		if got := updatedSecret.Annotations[AnnotationStatus]; got != "Error" {
+			t.Fatalf("expected status Error, got %q", got)
+		}
+		if updatedSecret.Annotations[AnnotationLastError] == "" {
+			t.Error("expected last-error to be set")
+		}
+
+		// Fixing the type clears the error
+		delete(updatedSecret.Annotations, AnnotationType)
+		if err := tc.client.Update(ctx, updatedSecret); err != nil {
+			t.Fatalf("failed to update secret: %v", err)
+		}
+		updatedSecret, err = waitForAnnotationValue(ctx, tc.client, key, AnnotationStatus, "Ready")
+		if err != nil {
+			t.Fatalf("failed to get secret: %v", err)
+		}
+		if got := updatedSecret.Annotations[AnnotationStatus]; got != "Ready" {
+			t.Fatalf("expected status Ready, got %q", got)
+		}
+		if _, ok := updatedSecret.Annotations[AnnotationLastError]; ok {
+			t.Error("expected last-error to be removed")
+		}
+		if _, ok := updatedSecret.Data["password"]; !ok {
+			t.Error("expected password to be generated")
+		}
+	})
+}